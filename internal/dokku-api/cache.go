@@ -4,6 +4,8 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"log/slog"
+	"slices"
+	"strings"
 	"time"
 )
 
@@ -40,24 +42,24 @@ func NewCommandCacheManager(config *CacheConfig, logger *slog.Logger) *CommandCa
 }
 
 // Get retrieves a cached result if available and not expired
-func (cm *CommandCacheManager) Get(command string, args []string) ([]byte, error, bool) {
+func (cm *CommandCacheManager) Get(command string, args []string) ([]byte, bool) {
 	if cm == nil {
-		return nil, nil, false
+		return nil, false
 	}
 
-	key := cm.generateCacheKey(command, args)
+	key := cm.generateCacheKey(command, cacheAppName(args), args)
 
 	cm.cache.mutex.RLock()
 	defer cm.cache.mutex.RUnlock()
 
 	entry, exists := cm.cache.entries[key]
 	if !exists {
-		return nil, nil, false
+		return nil, false
 	}
 
 	// Check if expired
 	if time.Now().After(entry.expiresAt) {
-		return nil, nil, false
+		return nil, false
 	}
 
 	cm.logger.Debug("Cache hit",
@@ -65,24 +67,25 @@ func (cm *CommandCacheManager) Get(command string, args []string) ([]byte, error
 		"args", args,
 		"key", key)
 
-	return entry.result, entry.error, true
+	return entry.result, true
 }
 
 // Set stores a command result in the cache with appropriate TTL
-func (cm *CommandCacheManager) Set(command string, args []string, result []byte, err error) {
+func (cm *CommandCacheManager) Set(command string, args []string, result []byte) {
 	if cm == nil {
 		return
 	}
 
-	key := cm.generateCacheKey(command, args)
+	app := cacheAppName(args)
+	key := cm.generateCacheKey(command, app, args)
 	ttl := cm.config.GetTTLForCommand(command)
 
 	cm.cache.mutex.Lock()
 	defer cm.cache.mutex.Unlock()
 
 	cm.cache.entries[key] = &cacheEntry{
+		app:       app,
 		result:    result,
-		error:     err,
 		expiresAt: time.Now().Add(ttl),
 	}
 
@@ -92,8 +95,26 @@ func (cm *CommandCacheManager) Set(command string, args []string, result []byte,
 		"ttl", ttl)
 }
 
-// Invalidate clears all cached entries
-func (cm *CommandCacheManager) Invalidate() {
+// Invalidate drops the cached entries of an app, along with the entries not tied
+// to any app (global reports and lists) which may include it
+func (cm *CommandCacheManager) Invalidate(app string) {
+	if cm == nil {
+		return
+	}
+
+	cm.cache.mutex.Lock()
+	defer cm.cache.mutex.Unlock()
+
+	for key, entry := range cm.cache.entries {
+		if entry.app == app || entry.app == "" {
+			delete(cm.cache.entries, key)
+		}
+	}
+	cm.logger.Debug("Cache invalidated", "app", app)
+}
+
+// InvalidateAll clears all cached entries
+func (cm *CommandCacheManager) InvalidateAll() {
 	if cm == nil {
 		return
 	}
@@ -105,6 +126,21 @@ func (cm *CommandCacheManager) Invalidate() {
 	cm.logger.Debug("Cache invalidated")
 }
 
+// InvalidateAfter drops the entries a mutating command may have made stale.
+// Global changes and app lifecycle commands can affect any app, so they clear everything.
+func (cm *CommandCacheManager) InvalidateAfter(command string, args []string) {
+	if IsReadOnlyCommand(command) {
+		return
+	}
+
+	app := cacheAppName(args)
+	if app == "" || strings.HasPrefix(command, "apps:") || slices.Contains(args, "--global") {
+		cm.InvalidateAll()
+		return
+	}
+	cm.Invalidate(app)
+}
+
 // Stop stops the background cleanup process
 func (cm *CommandCacheManager) Stop() {
 	if cm != nil && cm.cleanup != nil {
@@ -114,11 +150,14 @@ func (cm *CommandCacheManager) Stop() {
 
 // Internal methods
 
-// generateCacheKey creates a unique key for command + args combination
-func (cm *CommandCacheManager) generateCacheKey(command string, args []string) string {
+// generateCacheKey creates a unique key for command + app + args combination
+func (cm *CommandCacheManager) generateCacheKey(command, app string, args []string) string {
 	hasher := sha256.New()
 	hasher.Write([]byte(command))
+	hasher.Write([]byte{0})
+	hasher.Write([]byte(app))
 	for _, arg := range args {
+		hasher.Write([]byte{0})
 		hasher.Write([]byte(arg))
 	}
 	return hex.EncodeToString(hasher.Sum(nil))[:16] // First 16 chars
@@ -1,6 +1,7 @@
 package dokkuApi
 
 import (
+	"strings"
 	"sync"
 	"time"
 )
@@ -12,29 +13,54 @@ type CacheConfig struct {
 	Policies   map[string]time.Duration `yaml:"policies,omitempty"`
 }
 
-// DefaultCacheConfig returns sensible caching defaults
+// DefaultCacheConfig returns sensible caching defaults. Only read-only commands are
+// cached and mutating commands invalidate them, so the default TTL is kept short.
 func DefaultCacheConfig() *CacheConfig {
 	return &CacheConfig{
 		Enabled:    true,
-		DefaultTTL: 5 * time.Minute,
+		DefaultTTL: 5 * time.Second,
 		Policies: map[string]time.Duration{
-			// Fast-changing data - short cache
-			"logs":        30 * time.Second,
-			"ps:scale":    1 * time.Minute,
-			"apps:exists": 2 * time.Minute,
-
-			// Semi-stable data - medium cache
-			"config:show":    5 * time.Minute,
-			"domains:report": 5 * time.Minute,
-
 			// Stable data - longer cache
-			"plugin:list":   15 * time.Minute,
-			"version":       30 * time.Minute,
-			"ssh-keys:list": 10 * time.Minute,
+			"plugin:list":   1 * time.Minute,
+			"version":       5 * time.Minute,
+			"ssh-keys:list": 1 * time.Minute,
 		},
 	}
 }
 
+// readOnlyCommands do not change anything but are not cached, their output being
+// either live (logs) or holding secrets (config values)
+var readOnlyCommands = map[string]bool{
+	"logs":          true,
+	"logs:failed":   true,
+	"config:show":   true,
+	"config:get":    true,
+	"config:keys":   true,
+	"config:export": true,
+	"apps:exists":   true,
+}
+
+// IsCacheableCommand returns true for the read-only report and list commands whose output is cached
+func IsCacheableCommand(command string) bool {
+	if IsSensitiveCommand(command) {
+		return false
+	}
+	return command == "version" || strings.HasSuffix(command, ":report") || strings.HasSuffix(command, ":list")
+}
+
+// IsReadOnlyCommand returns true for commands that leave the cached state untouched
+func IsReadOnlyCommand(command string) bool {
+	return IsCacheableCommand(command) || readOnlyCommands[command]
+}
+
+// cacheAppName returns the app a command targets, which is its first argument unless it is a flag
+func cacheAppName(args []string) string {
+	if len(args) == 0 || strings.HasPrefix(args[0], "-") {
+		return ""
+	}
+	return args[0]
+}
+
 // GetTTLForCommand returns the appropriate TTL for a command
 func (c *CacheConfig) GetTTLForCommand(command string) time.Duration {
 	if ttl, exists := c.Policies[command]; exists {
@@ -45,8 +71,8 @@ func (c *CacheConfig) GetTTLForCommand(command string) time.Duration {
 
 // cacheEntry stores cached command results with TTL (internal to cache manager)
 type cacheEntry struct {
+	app       string
 	result    []byte
-	error     error
 	expiresAt time.Time
 }
 
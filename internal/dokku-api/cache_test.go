@@ -0,0 +1,126 @@
+package dokkuApi_test
+
+import (
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	dokkuApi "github.com/dokku-mcp/dokku-mcp/internal/dokku-api"
+)
+
+var _ = Describe("CommandCacheManager", func() {
+	var cache *dokkuApi.CommandCacheManager
+
+	BeforeEach(func() {
+		cache = dokkuApi.NewCommandCacheManager(dokkuApi.DefaultCacheConfig(), slog.Default())
+		DeferCleanup(cache.Stop)
+	})
+
+	It("should default to a short TTL", func() {
+		Expect(dokkuApi.DefaultCacheConfig().DefaultTTL).To(Equal(5 * time.Second))
+	})
+
+	DescribeTable("only caches read-only commands",
+		func(command string, cacheable, readOnly bool) {
+			Expect(dokkuApi.IsCacheableCommand(command)).To(Equal(cacheable))
+			Expect(dokkuApi.IsReadOnlyCommand(command)).To(Equal(readOnly))
+		},
+		Entry("proxy:report", "proxy:report", true, true),
+		Entry("plugin:list", "plugin:list", true, true),
+		Entry("version", "version", true, true),
+		Entry("logs", "logs", false, true),
+		Entry("config:show", "config:show", false, true),
+		Entry("proxy:set", "proxy:set", false, false),
+		Entry("registry:login", "registry:login", false, false),
+	)
+
+	It("should key entries by command, app and arguments", func() {
+		cache.Set("proxy:report", []string{"my-app"}, []byte("nginx"))
+
+		result, found := cache.Get("proxy:report", []string{"my-app"})
+		Expect(found).To(BeTrue())
+		Expect(string(result)).To(Equal("nginx"))
+
+		_, found = cache.Get("proxy:report", []string{"other-app"})
+		Expect(found).To(BeFalse())
+		_, found = cache.Get("proxy:report", []string{"my-app", "--proxy-type"})
+		Expect(found).To(BeFalse())
+	})
+
+	It("should expire entries after their TTL", func() {
+		shortLived := dokkuApi.NewCommandCacheManager(&dokkuApi.CacheConfig{
+			Enabled:    true,
+			DefaultTTL: 20 * time.Millisecond,
+		}, slog.Default())
+		DeferCleanup(shortLived.Stop)
+
+		shortLived.Set("git:report", []string{"my-app"}, []byte("main"))
+		Eventually(func() bool {
+			_, found := shortLived.Get("git:report", []string{"my-app"})
+			return found
+		}).Should(BeFalse())
+	})
+
+	It("should invalidate the proxy report of an app after proxy:set on it", func() {
+		cache.Set("proxy:report", []string{"my-app"}, []byte("nginx"))
+		cache.Set("proxy:report", []string{"other-app"}, []byte("nginx"))
+		cache.Set("proxy:report", []string{"--global"}, []byte("nginx"))
+
+		cache.InvalidateAfter("proxy:set", []string{"my-app", "caddy"})
+
+		_, found := cache.Get("proxy:report", []string{"my-app"})
+		Expect(found).To(BeFalse())
+		_, found = cache.Get("proxy:report", []string{"--global"})
+		Expect(found).To(BeFalse())
+		_, found = cache.Get("proxy:report", []string{"other-app"})
+		Expect(found).To(BeTrue())
+	})
+
+	It("should clear everything after a global change", func() {
+		cache.Set("proxy:report", []string{"my-app"}, []byte("nginx"))
+
+		cache.InvalidateAfter("proxy:set", []string{"--global", "caddy"})
+
+		_, found := cache.Get("proxy:report", []string{"my-app"})
+		Expect(found).To(BeFalse())
+	})
+
+	It("should keep entries after a read-only command", func() {
+		cache.Set("proxy:report", []string{"my-app"}, []byte("nginx"))
+
+		cache.InvalidateAfter("logs", []string{"my-app", "--num", "10"})
+		cache.InvalidateAfter("domains:report", []string{"my-app"})
+
+		_, found := cache.Get("proxy:report", []string{"my-app"})
+		Expect(found).To(BeTrue())
+	})
+
+	It("should be safe for concurrent use", func() {
+		var wg sync.WaitGroup
+		for i := range 20 {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				app := fmt.Sprintf("app-%d", i%4)
+				cache.Set("ps:report", []string{app}, []byte("running"))
+				cache.Get("ps:report", []string{app})
+				cache.InvalidateAfter("ps:scale", []string{app, "web=2"})
+			}(i)
+		}
+		wg.Wait()
+	})
+
+	It("should be a no-op when disabled", func() {
+		disabled := dokkuApi.NewCommandCacheManager(&dokkuApi.CacheConfig{Enabled: false}, slog.Default())
+		Expect(disabled).To(BeNil())
+
+		disabled.Set("version", nil, []byte("0.35.0"))
+		_, found := disabled.Get("version", nil)
+		Expect(found).To(BeFalse())
+		disabled.InvalidateAfter("proxy:set", []string{"my-app", "caddy"})
+	})
+})
@@ -1,16 +1,58 @@
 package dokkuApi
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"log/slog"
 	"os/exec"
+	"slices"
 	"strings"
 	"syscall"
 	"time"
 )
 
+// SensitiveCommands take credentials as arguments, which must never reach logs, errors or the cache
+var SensitiveCommands = []string{
+	"registry:login",
+}
+
+// IsSensitiveCommand returns true if the arguments of the command carry credentials
+func IsSensitiveCommand(command string) bool {
+	return slices.Contains(SensitiveCommands, command)
+}
+
+// cacheBypassKey marks a context whose commands skip the cache lookup
+type cacheBypassKey struct{}
+
+// WithoutCache returns a context whose report commands always hit Dokku. The fresh
+// result still refreshes the cache.
+func WithoutCache(ctx context.Context) context.Context {
+	return context.WithValue(ctx, cacheBypassKey{}, true)
+}
+
+func isCacheBypassed(ctx context.Context) bool {
+	bypassed, _ := ctx.Value(cacheBypassKey{}).(bool)
+	return bypassed
+}
+
+// redactedArg replaces the arguments of sensitive commands wherever they would be exposed
+const redactedArg = "[REDACTED]"
+
+// redactArgs hides the arguments of sensitive commands
+func redactArgs(commandName string, args []string) []string {
+	if !IsSensitiveCommand(commandName) {
+		return args
+	}
+	redacted := make([]string, len(args))
+	for i := range redacted {
+		redacted[i] = redactedArg
+	}
+	return redacted
+}
+
 // isAppScopedCommand returns true for commands that target a specific app
 func isAppScopedCommand(commandName string) bool {
 	return strings.HasPrefix(commandName, "apps:") || strings.HasPrefix(commandName, "ps:") || commandName == "logs"
@@ -42,7 +84,7 @@ func (c *client) ValidateCommand(commandName string, args []string) error {
 	for i, arg := range args {
 		for _, char := range dangerousChars {
 			if strings.Contains(arg, char) {
-				return fmt.Errorf("argument %d contains dangerous character '%s': %s", i, char, arg)
+				return fmt.Errorf("argument %d contains dangerous character '%s': %s", i, char, redactArgs(commandName, args)[i])
 			}
 		}
 	}
@@ -89,6 +131,7 @@ func NewDokkuClient(config *ClientConfig, logger *slog.Logger) DokkuClient {
 		logger:         logger,
 		sshConnManager: sshConnManager,
 		capabilities:   NewDokkuCapabilities(),
+		tracer:         newTracer(config.TracerProvider),
 	}
 
 	// Initialize cache manager if caching is enabled
@@ -111,28 +154,55 @@ func (c *client) GetSSHConnectionManager() *SSHConnectionManager {
 	return c.sshConnManager
 }
 
-func (c *client) ExecuteCommand(ctx context.Context, commandName string, args []string) ([]byte, error) {
+func (c *client) ExecuteCommand(ctx context.Context, commandName string, args []string) (output []byte, err error) {
+	ctx, span := c.startCommandSpan(ctx, commandName, args)
+	outcome := outcomeSuccess
+	defer func() { endCommandSpan(span, outcome, err) }()
+
 	if err := c.ValidateCommand(commandName, args); err != nil {
 		return nil, fmt.Errorf("invalid command: %w", err)
 	}
 
-	// Check cache first if caching is enabled
-	if result, err, found := c.cacheManager.Get(commandName, args); found {
+	// Retries only apply to contexts that opted in, see WithRetry
+	execute := c.executeCommandDirect
+	if policy, ok := retryPolicyFrom(ctx); ok {
+		execute = func(ctx context.Context, commandName string, args []string) ([]byte, error) {
+			return retry(ctx, policy, c.logger, commandName, func(ctx context.Context) ([]byte, error) {
+				return c.executeCommandDirect(ctx, commandName, args)
+			})
+		}
+	}
+
+	// Mutating commands run directly and drop the entries they may have made stale
+	if !IsCacheableCommand(commandName) {
+		result, err := execute(ctx, commandName, args)
+		c.cacheManager.InvalidateAfter(commandName, args)
 		return result, err
 	}
 
+	// Check cache first if caching is enabled
+	if !isCacheBypassed(ctx) {
+		if result, found := c.cacheManager.Get(commandName, args); found {
+			outcome = outcomeCacheHit
+			return result, nil
+		}
+	}
+
 	// Execute command
-	result, err := c.executeCommandDirect(ctx, commandName, args)
+	result, err := execute(ctx, commandName, args)
+	if err != nil {
+		return result, err
+	}
 
-	// Cache the result if caching is enabled
-	c.cacheManager.Set(commandName, args, result, err)
+	// Cache the result if caching is enabled, failures are always retried
+	c.cacheManager.Set(commandName, args, result)
 
-	return result, err
+	return result, nil
 }
 
 // executeCommandDirect performs the actual command execution without caching
 func (c *client) executeCommandDirect(ctx context.Context, commandName string, args []string) ([]byte, error) {
-	cmdCtx, cancel := c.commandContext(ctx)
+	cmdCtx, cancel := c.commandContext(ctx, commandName)
 	defer cancel()
 
 	dokkuCommand := buildDokkuCommand(commandName, args)
@@ -147,11 +217,22 @@ func (c *client) executeCommandDirect(ctx context.Context, commandName string, a
 		return nil, fmt.Errorf("failed to prepare SSH command: %w", err)
 	}
 
-	c.logCommandExecutionStart(cmdCtx, commandName, args, dokkuCommand, sshArgs, env)
+	// Only redacted values are handed to the logging paths
+	logArgs := redactArgs(commandName, args)
+	logCommand := buildDokkuCommand(commandName, logArgs)
+	logSSHArgs := sshArgs
+	if logCommand != dokkuCommand {
+		logSSHArgs = make([]string, len(sshArgs))
+		for i, arg := range sshArgs {
+			logSSHArgs[i] = strings.ReplaceAll(arg, dokkuCommand, logCommand)
+		}
+	}
+
+	c.logCommandExecutionStart(cmdCtx, commandName, logArgs, logCommand, logSSHArgs, env)
 
 	output, execErr := cmd.CombinedOutput()
 	if execErr != nil {
-		return c.handleCommandError(cmdCtx, commandName, args, dokkuCommand, sshArgs, env, output, execErr)
+		return c.handleCommandError(cmdCtx, commandName, logArgs, logCommand, logSSHArgs, env, output, execErr)
 	}
 
 	c.logger.Debug("Dokku command executed successfully",
@@ -161,12 +242,62 @@ func (c *client) executeCommandDirect(ctx context.Context, commandName string, a
 	return output, nil
 }
 
-func (c *client) commandContext(ctx context.Context) (context.Context, context.CancelFunc) {
+// StreamCommand runs a command without buffering its data: stdin, when not nil, is sent
+// to the command and its standard output is copied to stdout as it comes. Standard error
+// is kept apart, it only shows in the error of a failed command. Streamed commands are
+// never cached nor retried, the data may already be partly transferred.
+func (c *client) StreamCommand(ctx context.Context, commandName string, args []string, stdin io.Reader, stdout io.Writer) (err error) {
+	ctx, span := c.startCommandSpan(ctx, commandName, args)
+	defer func() { endCommandSpan(span, outcomeSuccess, err) }()
+
+	if err := c.ValidateCommand(commandName, args); err != nil {
+		return fmt.Errorf("invalid command: %w", err)
+	}
+
+	cmdCtx, cancel := c.commandContext(ctx, commandName)
+	defer cancel()
+
+	sshArgs, env, err := c.sshConnManager.PrepareSSHCommand(buildDokkuCommand(commandName, args))
+	if err != nil {
+		return fmt.Errorf("failed to prepare SSH command: %w", err)
+	}
+
+	cmd, err := prepareSSHExecCommand(cmdCtx, sshArgs, env)
+	if err != nil {
+		return fmt.Errorf("failed to prepare SSH command: %w", err)
+	}
+	var stderr bytes.Buffer
+	cmd.Stdin = stdin
+	cmd.Stdout = stdout
+	cmd.Stderr = &stderr
+
+	logArgs := redactArgs(commandName, args)
+	c.logger.Debug("Streaming Dokku command via SSH",
+		"command", commandName,
+		"args", logArgs,
+		"ssh_target", c.sshConnManager.Config().ConnectionString())
+
+	execErr := cmd.Run()
+	c.cacheManager.InvalidateAfter(commandName, args)
+	if execErr != nil {
+		c.logger.Error("Failed to stream Dokku command",
+			"error", execErr,
+			"command", commandName,
+			"args", logArgs,
+			"stderr", stderr.String())
+		return fmt.Errorf("failed to execute Dokku command %s: %w: %s", commandName, execErr, strings.TrimSpace(stderr.String()))
+	}
+
+	c.logger.Debug("Dokku command streamed successfully", "command", commandName)
+	return nil
+}
+
+func (c *client) commandContext(ctx context.Context, commandName string) (context.Context, context.CancelFunc) {
 	if _, hasDeadline := ctx.Deadline(); hasDeadline {
 		return ctx, func() {}
 	}
-	if c.config.CommandTimeout > 0 {
-		return context.WithTimeout(ctx, c.config.CommandTimeout)
+	if timeout := c.timeoutFor(ctx, commandName); timeout > 0 {
+		return context.WithTimeout(ctx, timeout)
 	}
 	return ctx, func() {}
 }
@@ -199,7 +330,7 @@ func (c *client) logCommandExecutionStart(ctx context.Context, commandName strin
 		"ssh_target", c.sshConnManager.Config().ConnectionString(),
 		"ssh_args", sshArgs,
 		"env", env,
-		"timeout", c.config.CommandTimeout,
+		"timeout", c.timeoutFor(ctx, commandName),
 		"context_deadline_ok", ctx.Err() == nil,
 		"connection_info", c.sshConnManager.GetConnectionInfo())
 }
@@ -226,6 +357,10 @@ func (c *client) handleCommandError(ctx context.Context, commandName string, arg
 		return nil, fmt.Errorf("failed to execute Dokku command %s: %w", commandName, &NotFoundError{Command: commandName, Err: ErrAppNotFound})
 	}
 
+	if reason, ok := transientFailure(output); ok {
+		return nil, fmt.Errorf("failed to execute Dokku command %s: %w", commandName, &TransientError{Command: commandName, Reason: reason, Err: execErr})
+	}
+
 	return nil, fmt.Errorf("failed to execute Dokku command %s: %w", commandName, execErr)
 }
 
@@ -293,6 +428,29 @@ func shouldWrapNotFound(commandName string, output []byte) bool {
 	return isNotFoundOutput(lower)
 }
 
+// transientOutputs are the output fragments of failures that may succeed when run
+// again, with the reason reported for each
+var transientOutputs = []struct{ fragment, reason string }{
+	{"connection reset by peer", "connection reset"},
+	{"broken pipe", "connection reset"},
+	{"connection closed by remote host", "connection reset"},
+	{"another deployment is in progress", "deployment in progress"},
+	{"is currently being deployed", "deployment in progress"},
+	{"deploy lock exists", "deployment in progress"},
+}
+
+// transientFailure reports whether the output of a failed command describes a transient
+// failure, and which one
+func transientFailure(output []byte) (string, bool) {
+	lower := strings.ToLower(string(output))
+	for _, transient := range transientOutputs {
+		if strings.Contains(lower, transient.fragment) {
+			return transient.reason, true
+		}
+	}
+	return "", false
+}
+
 func isNotFoundOutput(lowerOutput string) bool {
 	if strings.Contains(lowerOutput, "does not exist") || strings.Contains(lowerOutput, "has not been deployed") {
 		return true
@@ -302,7 +460,7 @@ func isNotFoundOutput(lowerOutput string) bool {
 
 // InvalidateCache clears all cached entries (delegates to cache manager)
 func (c *client) InvalidateCache() {
-	c.cacheManager.Invalidate()
+	c.cacheManager.InvalidateAll()
 }
 
 // SetBlacklist sets the blacklisted commands for runtime security configuration
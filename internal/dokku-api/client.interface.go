@@ -1,12 +1,21 @@
 package dokkuApi
 
-import "context"
+import (
+	"context"
+	"io"
+)
 
 // CommandExecutor defines the core command execution capability
 type CommandExecutor interface {
 	ExecuteCommand(ctx context.Context, command string, args []string) ([]byte, error)
 }
 
+// CommandStreamer defines the execution of commands whose data is too large to be
+// buffered, e.g. database dumps
+type CommandStreamer interface {
+	StreamCommand(ctx context.Context, command string, args []string, stdin io.Reader, stdout io.Writer) error
+}
+
 // CommandParser defines parsing capabilities for different output formats
 type CommandParser interface {
 	GetKeyValueOutput(ctx context.Context, command string, args []string, separator string) (map[string]string, error)
@@ -41,6 +50,7 @@ type CommandFilter interface {
 // This is the "convenience interface" that most consumers will use
 type DokkuClient interface {
 	CommandExecutor
+	CommandStreamer
 	CommandParser
 	StructuredExecutor
 	CapabilityManager
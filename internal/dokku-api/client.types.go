@@ -4,6 +4,8 @@ import (
 	"encoding/json"
 	"log/slog"
 	"time"
+
+	"go.opentelemetry.io/otel/trace"
 )
 
 // OutputFormat represents different output parsing strategies
@@ -44,10 +46,15 @@ type ClientConfig struct {
 	DokkuPath      string        `yaml:"dokku_path"`
 	SSHKeyPath     string        `yaml:"ssh_key_path"`
 	CommandTimeout time.Duration `yaml:"command_timeout"`
-	Cache          *CacheConfig  `yaml:"cache"`
+	// TimeoutPolicy overrides CommandTimeout by kind of command, nil applying it to all
+	TimeoutPolicy *TimeoutPolicy `yaml:"timeout_policy"`
+	Cache         *CacheConfig   `yaml:"cache"`
+	// TracerProvider receives a span per command, nil disabling tracing
+	TracerProvider trace.TracerProvider `yaml:"-"`
 }
 
 func DefaultClientConfig() *ClientConfig {
+	policy := DefaultTimeoutPolicy()
 	return &ClientConfig{
 		DokkuHost:      "pro.dokku.com",
 		DokkuPort:      22,
@@ -55,6 +62,7 @@ func DefaultClientConfig() *ClientConfig {
 		DokkuPath:      "/usr/bin/dokku",
 		SSHKeyPath:     "",
 		CommandTimeout: 30 * time.Second,
+		TimeoutPolicy:  &policy,
 		Cache:          DefaultCacheConfig(),
 	}
 }
@@ -70,4 +78,6 @@ type client struct {
 
 	// Capabilities tracking
 	capabilities *DokkuCapabilities
+
+	tracer trace.Tracer
 }
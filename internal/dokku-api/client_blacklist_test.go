@@ -113,4 +113,13 @@ var _ = Describe("DokkuClient", func() {
 			})
 		})
 	})
+
+	Describe("Sensitive commands", func() {
+		It("should not expose registry credentials in validation errors", func() {
+			err := client.ValidateCommand("registry:login", []string{"registry.example.com", "deploy", "p4ss;word"})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("[REDACTED]"))
+			Expect(err.Error()).NotTo(ContainSubstring("p4ss"))
+		})
+	})
 })
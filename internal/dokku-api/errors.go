@@ -3,6 +3,7 @@ package dokkuApi
 import (
 	"errors"
 	"fmt"
+	"syscall"
 )
 
 // ErrAppNotFound is the sentinel error for missing Dokku applications.
@@ -37,3 +38,36 @@ func IsNotFoundError(err error) bool {
 	}
 	return errors.Is(err, ErrAppNotFound)
 }
+
+// TransientError indicates a command failure that may succeed when run again, such
+// as a dropped SSH connection or a deployment already holding the app lock.
+type TransientError struct {
+	Command string
+	Reason  string
+	Err     error
+}
+
+func (e *TransientError) Error() string {
+	if e == nil {
+		return ""
+	}
+	if e.Command != "" {
+		return fmt.Sprintf("%s: %s: %v", e.Command, e.Reason, e.Err)
+	}
+	return fmt.Sprintf("%s: %v", e.Reason, e.Err)
+}
+
+func (e *TransientError) Unwrap() error { return e.Err }
+
+// IsTransientError returns true when err is worth retrying. Not-found errors never are,
+// whatever they wrap.
+func IsTransientError(err error) bool {
+	if err == nil || IsNotFoundError(err) {
+		return false
+	}
+	var transient *TransientError
+	if errors.As(err, &transient) {
+		return true
+	}
+	return errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.EPIPE)
+}
@@ -4,6 +4,7 @@ import (
 	"log/slog"
 
 	"github.com/dokku-mcp/dokku-mcp/pkg/config"
+	"go.opentelemetry.io/otel"
 )
 
 // NewDokkuClientFromConfig creates a DokkuClient from the server configuration.
@@ -20,7 +21,15 @@ func NewDokkuClientFromConfig(cfg *config.ServerConfig, logger *slog.Logger) Dok
 		DokkuPath:      cfg.DokkuPath,
 		SSHKeyPath:     sshKeyPath,
 		CommandTimeout: cfg.Timeout,
-		Cache:          createCacheConfig(cfg),
+		TimeoutPolicy: &TimeoutPolicy{
+			Report:   cfg.CommandTimeouts.Report,
+			Mutation: cfg.CommandTimeouts.Mutation,
+			Deploy:   cfg.CommandTimeouts.Deploy,
+			Plugin:   cfg.CommandTimeouts.Plugin,
+		},
+		Cache: createCacheConfig(cfg),
+		// Spans go to the global provider, a no-op until the embedding program installs one
+		TracerProvider: otel.GetTracerProvider(),
 	}
 
 	client := NewDokkuClient(dokkuConfig, logger)
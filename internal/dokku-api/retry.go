@@ -0,0 +1,88 @@
+package dokkuApi
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// RetryPolicy bounds the retries of a command failing transiently. The backoff doubles
+// after each failed attempt, up to MaxBackoff.
+type RetryPolicy struct {
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+}
+
+// DefaultRetryPolicy returns the policy used when a command opts in without tuning
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: 500 * time.Millisecond,
+		MaxBackoff:     5 * time.Second,
+	}
+}
+
+// backoff returns the wait before the given retry, the first one being 1
+func (p RetryPolicy) backoff(retry int) time.Duration {
+	wait := p.InitialBackoff
+	for i := 1; i < retry; i++ {
+		wait *= 2
+		if p.MaxBackoff > 0 && wait >= p.MaxBackoff {
+			return p.MaxBackoff
+		}
+	}
+	if p.MaxBackoff > 0 {
+		return min(wait, p.MaxBackoff)
+	}
+	return wait
+}
+
+// retryPolicyKey marks a context whose commands retry transient failures
+type retryPolicyKey struct{}
+
+// WithRetry returns a context whose commands are retried under the policy when they
+// fail transiently, see IsTransientError. Commands run once by default: each caller
+// opts in for the commands that are safe to run again.
+func WithRetry(ctx context.Context, policy RetryPolicy) context.Context {
+	return context.WithValue(ctx, retryPolicyKey{}, policy)
+}
+
+func retryPolicyFrom(ctx context.Context) (RetryPolicy, bool) {
+	policy, ok := ctx.Value(retryPolicyKey{}).(RetryPolicy)
+	return policy, ok
+}
+
+// ExecuteWithRetry runs a command through the executor, retrying it under the policy
+// while it fails transiently
+func ExecuteWithRetry(ctx context.Context, executor CommandExecutor, policy RetryPolicy, commandName string, args []string) ([]byte, error) {
+	return retry(ctx, policy, slog.Default(), commandName, func(ctx context.Context) ([]byte, error) {
+		return executor.ExecuteCommand(ctx, commandName, args)
+	})
+}
+
+func retry(ctx context.Context, policy RetryPolicy, logger *slog.Logger, commandName string, run func(ctx context.Context) ([]byte, error)) ([]byte, error) {
+	attempts := max(policy.MaxAttempts, 1)
+	for attempt := 1; ; attempt++ {
+		output, err := run(ctx)
+		if err == nil || attempt >= attempts || !IsTransientError(err) {
+			return output, err
+		}
+
+		wait := policy.backoff(attempt)
+		logger.Warn("Transient Dokku command failure, retrying",
+			"command", commandName,
+			"attempt", attempt,
+			"max_attempts", attempts,
+			"backoff", wait,
+			"error", err)
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package dokkuApi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"syscall"
+	"testing"
+	"time"
+)
+
+// failingExecutor fails with its errors in order, then succeeds
+type failingExecutor struct {
+	errs  []error
+	calls int
+}
+
+func (e *failingExecutor) ExecuteCommand(_ context.Context, _ string, _ []string) ([]byte, error) {
+	e.calls++
+	if e.calls <= len(e.errs) {
+		return nil, e.errs[e.calls-1]
+	}
+	return []byte("ok"), nil
+}
+
+var testRetryPolicy = RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: 2 * time.Millisecond}
+
+func TestExecuteWithRetrySucceedsAfterTransientFailures(t *testing.T) {
+	executor := &failingExecutor{errs: []error{
+		fmt.Errorf("ssh: %w", syscall.ECONNRESET),
+		&TransientError{Command: "ps:rebuild", Reason: "deployment in progress", Err: errors.New("exit status 1")},
+	}}
+
+	output, err := ExecuteWithRetry(context.Background(), executor, testRetryPolicy, "ps:rebuild", []string{"my-app"})
+	if err != nil {
+		t.Fatalf("expected success after retries, got %v", err)
+	}
+	if string(output) != "ok" || executor.calls != 3 {
+		t.Fatalf("expected ok after 3 calls, got %q after %d", output, executor.calls)
+	}
+}
+
+func TestExecuteWithRetryStopsAtMaxAttempts(t *testing.T) {
+	executor := &failingExecutor{errs: []error{syscall.EPIPE, syscall.EPIPE, syscall.EPIPE}}
+
+	if _, err := ExecuteWithRetry(context.Background(), executor, testRetryPolicy, "apps:list", nil); err == nil {
+		t.Fatalf("expected the last failure")
+	}
+	if executor.calls != 3 {
+		t.Fatalf("expected 3 calls, got %d", executor.calls)
+	}
+}
+
+func TestExecuteWithRetryDoesNotRetryPermanentFailures(t *testing.T) {
+	for _, err := range []error{
+		&NotFoundError{Command: "apps:report", Err: ErrAppNotFound},
+		&NotFoundError{Command: "apps:report", Err: syscall.ECONNRESET},
+		fmt.Errorf("invalid command: %w", errors.New("command is blacklisted")),
+	} {
+		executor := &failingExecutor{errs: []error{err}}
+		if _, got := ExecuteWithRetry(context.Background(), executor, testRetryPolicy, "apps:report", nil); got == nil {
+			t.Fatalf("expected %v to be returned", err)
+		}
+		if executor.calls != 1 {
+			t.Fatalf("expected %v not to be retried, got %d calls", err, executor.calls)
+		}
+	}
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	policy := RetryPolicy{InitialBackoff: 100 * time.Millisecond, MaxBackoff: 300 * time.Millisecond}
+	expected := []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 300 * time.Millisecond, 300 * time.Millisecond}
+	for i, want := range expected {
+		if got := policy.backoff(i + 1); got != want {
+			t.Fatalf("retry %d: expected %v, got %v", i+1, want, got)
+		}
+	}
+}
+
+func TestTransientFailure(t *testing.T) {
+	if reason, ok := transientFailure([]byte("!     App my-app is currently being deployed")); !ok || reason != "deployment in progress" {
+		t.Fatalf("expected a deployment in progress, got %q", reason)
+	}
+	if _, ok := transientFailure([]byte("!     App my-app does not exist")); ok {
+		t.Fatalf("not found output should not be transient")
+	}
+}
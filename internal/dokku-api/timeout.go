@@ -0,0 +1,103 @@
+package dokkuApi
+
+import (
+	"context"
+	"strings"
+	"time"
+)
+
+// CommandKind groups commands by how long they may legitimately run
+type CommandKind string
+
+const (
+	CommandKindReport   CommandKind = "report"
+	CommandKindMutation CommandKind = "mutation"
+	CommandKindDeploy   CommandKind = "deploy"
+	CommandKindPlugin   CommandKind = "plugin"
+)
+
+// deployCommands build or release an application, which takes minutes
+var deployCommands = map[string]bool{
+	"git:sync":         true,
+	"git:from-image":   true,
+	"git:from-archive": true,
+	"git:load-image":   true,
+	"ps:rebuild":       true,
+	"ps:rebuildall":    true,
+}
+
+// CommandKindOf returns the kind of a command: plugin management, deploys, read-only
+// reports, and every other command as a mutation
+func CommandKindOf(commandName string) CommandKind {
+	switch {
+	case strings.HasPrefix(commandName, "plugin:"):
+		return CommandKindPlugin
+	case deployCommands[commandName]:
+		return CommandKindDeploy
+	case IsReadOnlyCommand(commandName):
+		return CommandKindReport
+	default:
+		return CommandKindMutation
+	}
+}
+
+// TimeoutPolicy sets the timeout of the commands of each kind. A zero timeout falls
+// back to the client CommandTimeout.
+type TimeoutPolicy struct {
+	Report   time.Duration `yaml:"report"`
+	Mutation time.Duration `yaml:"mutation"`
+	Deploy   time.Duration `yaml:"deploy"`
+	Plugin   time.Duration `yaml:"plugin"`
+}
+
+// DefaultTimeoutPolicy returns tight timeouts for reports and generous ones for
+// deploys and plugin installs, mutations using the client CommandTimeout
+func DefaultTimeoutPolicy() TimeoutPolicy {
+	return TimeoutPolicy{
+		Report: 10 * time.Second,
+		Deploy: 15 * time.Minute,
+		Plugin: 10 * time.Minute,
+	}
+}
+
+// For returns the timeout of the commands of the kind, zero if the policy sets none
+func (p TimeoutPolicy) For(kind CommandKind) time.Duration {
+	switch kind {
+	case CommandKindReport:
+		return p.Report
+	case CommandKindDeploy:
+		return p.Deploy
+	case CommandKindPlugin:
+		return p.Plugin
+	default:
+		return p.Mutation
+	}
+}
+
+// commandTimeoutKey marks a context whose commands use a given timeout
+type commandTimeoutKey struct{}
+
+// WithCommandTimeout returns a context whose commands time out after the given
+// duration, whatever their kind. A deadline already set on the context still wins.
+func WithCommandTimeout(ctx context.Context, timeout time.Duration) context.Context {
+	return context.WithValue(ctx, commandTimeoutKey{}, timeout)
+}
+
+func commandTimeoutFrom(ctx context.Context) (time.Duration, bool) {
+	timeout, ok := ctx.Value(commandTimeoutKey{}).(time.Duration)
+	return timeout, ok
+}
+
+// timeoutFor returns the timeout of a command run with the context: the per-call
+// override, then the policy, then the client CommandTimeout. Zero means no timeout.
+func (c *client) timeoutFor(ctx context.Context, commandName string) time.Duration {
+	if timeout, ok := commandTimeoutFrom(ctx); ok {
+		return timeout
+	}
+	if c.config.TimeoutPolicy != nil {
+		if timeout := c.config.TimeoutPolicy.For(CommandKindOf(commandName)); timeout > 0 {
+			return timeout
+		}
+	}
+	return c.config.CommandTimeout
+}
@@ -0,0 +1,94 @@
+package dokkuApi
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestCommandKindOf(t *testing.T) {
+	for command, expected := range map[string]CommandKind{
+		"apps:report":    CommandKindReport,
+		"domains:list":   CommandKindReport,
+		"config:show":    CommandKindReport,
+		"config:set":     CommandKindMutation,
+		"ps:scale":       CommandKindMutation,
+		"git:sync":       CommandKindDeploy,
+		"ps:rebuild":     CommandKindDeploy,
+		"plugin:install": CommandKindPlugin,
+		"plugin:list":    CommandKindPlugin,
+	} {
+		if kind := CommandKindOf(command); kind != expected {
+			t.Errorf("expected %s to be a %s command, got %s", command, expected, kind)
+		}
+	}
+}
+
+// remaining returns the time left before the deadline of the command context
+func remaining(t *testing.T, c *client, ctx context.Context, commandName string) time.Duration {
+	t.Helper()
+	cmdCtx, cancel := c.commandContext(ctx, commandName)
+	defer cancel()
+	deadline, ok := cmdCtx.Deadline()
+	if !ok {
+		t.Fatalf("expected %s to have a deadline", commandName)
+	}
+	return time.Until(deadline)
+}
+
+func TestCommandContextUsesTheTimeoutOfTheCommandKind(t *testing.T) {
+	policy := DefaultTimeoutPolicy()
+	c := &client{config: &ClientConfig{CommandTimeout: 30 * time.Second, TimeoutPolicy: &policy}}
+
+	if left := remaining(t, c, context.Background(), "apps:report"); left > 10*time.Second {
+		t.Fatalf("expected a report to time out within 10s, got %s", left)
+	}
+	if left := remaining(t, c, context.Background(), "git:sync"); left < 14*time.Minute {
+		t.Fatalf("expected a deploy to get about 15m, got %s", left)
+	}
+	if left := remaining(t, c, context.Background(), "config:set"); left < 29*time.Second || left > 30*time.Second {
+		t.Fatalf("expected a mutation to fall back to the command timeout, got %s", left)
+	}
+}
+
+func TestCommandContextTimesOutReportsFirst(t *testing.T) {
+	c := &client{config: &ClientConfig{TimeoutPolicy: &TimeoutPolicy{Report: 10 * time.Millisecond, Deploy: time.Minute}}}
+
+	reportCtx, cancelReport := c.commandContext(context.Background(), "ps:report")
+	defer cancelReport()
+	deployCtx, cancelDeploy := c.commandContext(context.Background(), "ps:rebuild")
+	defer cancelDeploy()
+
+	select {
+	case <-reportCtx.Done():
+	case <-time.After(time.Second):
+		t.Fatalf("expected the report to time out")
+	}
+	if err := deployCtx.Err(); err != nil {
+		t.Fatalf("expected the deploy to still run, got %v", err)
+	}
+}
+
+func TestCommandContextHonorsPerCallOverride(t *testing.T) {
+	policy := DefaultTimeoutPolicy()
+	c := &client{config: &ClientConfig{CommandTimeout: 30 * time.Second, TimeoutPolicy: &policy}}
+
+	ctx := WithCommandTimeout(context.Background(), time.Hour)
+	if left := remaining(t, c, ctx, "apps:report"); left < 59*time.Minute {
+		t.Fatalf("expected the override to win over the policy, got %s", left)
+	}
+
+	deadlineCtx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if left := remaining(t, c, WithCommandTimeout(deadlineCtx, time.Hour), "git:sync"); left > time.Second {
+		t.Fatalf("expected the context deadline to win, got %s", left)
+	}
+}
+
+func TestCommandContextWithoutPolicyUsesTheCommandTimeout(t *testing.T) {
+	c := &client{config: &ClientConfig{CommandTimeout: 30 * time.Second}}
+
+	if left := remaining(t, c, context.Background(), "git:sync"); left > 30*time.Second {
+		t.Fatalf("expected the command timeout, got %s", left)
+	}
+}
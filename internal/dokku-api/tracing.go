@@ -0,0 +1,84 @@
+package dokkuApi
+
+import (
+	"context"
+	"regexp"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+// tracerName is the instrumentation scope of the command spans
+const tracerName = "github.com/dokku-mcp/dokku-mcp/internal/dokku-api"
+
+// Attributes of the command spans. Arguments are never recorded, they may hold secrets.
+const (
+	attrCommand     = attribute.Key("dokku.command")
+	attrCommandKind = attribute.Key("dokku.command.kind")
+	attrApp         = attribute.Key("dokku.app")
+	attrArgsCount   = attribute.Key("dokku.args.count")
+	attrOutcome     = attribute.Key("dokku.outcome")
+)
+
+// Outcomes of a traced command
+const (
+	outcomeSuccess  = "success"
+	outcomeCacheHit = "cache_hit"
+	outcomeError    = "error"
+)
+
+// traceableAppName matches the arguments that are safe to record as the app of a span
+var traceableAppName = regexp.MustCompile(`^[a-z0-9][a-z0-9-]*$`)
+
+// newTracer returns the tracer of the client, a no-op one without a provider
+func newTracer(provider trace.TracerProvider) trace.Tracer {
+	if provider == nil {
+		provider = noop.NewTracerProvider()
+	}
+	return provider.Tracer(tracerName)
+}
+
+// startCommandSpan starts the span of a command, covering the cache lookup and retries
+func (c *client) startCommandSpan(ctx context.Context, commandName string, args []string) (context.Context, trace.Span) {
+	attrs := []attribute.KeyValue{
+		attrCommand.String(commandName),
+		attrCommandKind.String(string(CommandKindOf(commandName))),
+		attrArgsCount.Int(len(args)),
+	}
+	if app := tracedAppName(commandName, args); app != "" {
+		attrs = append(attrs, attrApp.String(app))
+	}
+	tracer := c.tracer
+	if tracer == nil {
+		tracer = newTracer(nil)
+	}
+	return tracer.Start(ctx, "dokku "+commandName,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attrs...))
+}
+
+// endCommandSpan records the outcome of the command and ends its span. The error
+// message is left out, it may quote the command output.
+func endCommandSpan(span trace.Span, outcome string, err error) {
+	if err != nil {
+		outcome = outcomeError
+		span.SetStatus(codes.Error, "dokku command failed")
+	}
+	span.SetAttributes(attrOutcome.String(outcome))
+	span.End()
+}
+
+// tracedAppName returns the app a command targets when it can be recorded: the first
+// argument shaped like an app name, never one of a sensitive or plugin command
+func tracedAppName(commandName string, args []string) string {
+	if IsSensitiveCommand(commandName) || CommandKindOf(commandName) == CommandKindPlugin {
+		return ""
+	}
+	app := cacheAppName(args)
+	if !traceableAppName.MatchString(app) {
+		return ""
+	}
+	return app
+}
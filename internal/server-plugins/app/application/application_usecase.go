@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"time"
 
 	domain "github.com/dokku-mcp/dokku-mcp/internal/server-plugins/app/domain"
 	"github.com/dokku-mcp/dokku-mcp/internal/shared"
@@ -12,23 +13,47 @@ import (
 
 // ApplicationUseCase orchestrates application operations
 type ApplicationUseCase struct {
-	applicationRepo   domain.ApplicationRepository
-	deploymentSvc     shared.DeploymentService
-	validationService *domain.ValidationService
-	logger            *slog.Logger
+	applicationRepo    domain.ApplicationRepository
+	describer          domain.ApplicationDescriber
+	serviceLinks       domain.ServiceLinksLoader
+	deploymentSvc      shared.DeploymentService
+	validationService  *domain.ValidationService
+	blastRadiusService *domain.BlastRadiusService
+	logger             *slog.Logger
+
+	// deploymentPollInterval and deploymentTimeout pace the wait for the final status of
+	// the builds the deployment service runs in the background
+	deploymentPollInterval time.Duration
+	deploymentTimeout      time.Duration
 }
 
-// NewApplicationUseCase creates a new application use case
+const (
+	defaultDeploymentPollInterval = 10 * time.Second
+	// defaultDeploymentTimeout matches the time the deployment poller waits for a build
+	defaultDeploymentTimeout = 30 * time.Minute
+)
+
+// NewApplicationUseCase creates a new application use case. The describer gives the
+// application snapshots, and the service links the blast radius of a destroy; without
+// a loader only the domains are considered.
 func NewApplicationUseCase(
 	applicationRepo domain.ApplicationRepository,
+	describer domain.ApplicationDescriber,
+	serviceLinks domain.ServiceLinksLoader,
 	deploymentSvc shared.DeploymentService,
 	logger *slog.Logger,
 ) *ApplicationUseCase {
 	return &ApplicationUseCase{
-		applicationRepo:   applicationRepo,
-		deploymentSvc:     deploymentSvc,
-		validationService: domain.NewValidationService(),
-		logger:            logger,
+		applicationRepo:    applicationRepo,
+		describer:          describer,
+		serviceLinks:       serviceLinks,
+		deploymentSvc:      deploymentSvc,
+		validationService:  domain.NewValidationService(),
+		blastRadiusService: domain.NewBlastRadiusService(),
+		logger:             logger,
+
+		deploymentPollInterval: defaultDeploymentPollInterval,
+		deploymentTimeout:      defaultDeploymentTimeout,
 	}
 }
 
@@ -85,6 +110,68 @@ func (uc *ApplicationUseCase) CreateApplication(ctx context.Context, cmd CreateA
 	return nil
 }
 
+// DestroyApplicationCommand represents the data for destroying an application.
+// Confirm must repeat the name, as Dokku prompts for it.
+type DestroyApplicationCommand struct {
+	Name    string
+	Confirm string
+}
+
+// DestroyApplication orchestrates application destruction, refused unless confirmed.
+// It returns the blast radius of the destroy, computed beforehand, also when the
+// destroy is refused so the caller can show what confirming it would affect.
+func (uc *ApplicationUseCase) DestroyApplication(ctx context.Context, cmd DestroyApplicationCommand) (*domain.BlastRadius, error) {
+	uc.logger.Info("Destroying application", "app_name", cmd.Name)
+
+	appName, err := domain.NewApplicationName(cmd.Name)
+	if err != nil {
+		return nil, fmt.Errorf("invalid application name: %w", err)
+	}
+
+	app, err := uc.applicationRepo.GetByName(ctx, appName)
+	if err != nil {
+		return nil, fmt.Errorf("application not found: %w", err)
+	}
+
+	radius, err := uc.blastRadius(ctx, app)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute the blast radius: %w", err)
+	}
+
+	if err := app.Destroy(cmd.Confirm); err != nil {
+		return radius, err
+	}
+
+	// Saving a destroyed application runs the destroy
+	if err := uc.applicationRepo.Save(ctx, app); err != nil {
+		return radius, fmt.Errorf("failed to destroy: %w", err)
+	}
+
+	uc.logger.Info("Application destroyed successfully",
+		"app_name", cmd.Name,
+		"blast_radius", radius.Summary())
+	return radius, nil
+}
+
+// blastRadius computes what destroying the application affects, from the other
+// applications and the service links
+func (uc *ApplicationUseCase) blastRadius(ctx context.Context, target *domain.Application) (*domain.BlastRadius, error) {
+	apps, err := uc.applicationRepo.GetAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve applications: %w", err)
+	}
+
+	var links []*domain.ServiceLink
+	if uc.serviceLinks != nil {
+		links, err = uc.serviceLinks.LoadServiceLinks(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load service links: %w", err)
+		}
+	}
+
+	return uc.blastRadiusService.Compute(target, apps, links)
+}
+
 // DeployApplicationCommand represents the data for deploying an application
 type DeployApplicationCommand struct {
 	Name       string
@@ -164,38 +251,97 @@ func (uc *ApplicationUseCase) DeployApplication(ctx context.Context, cmd DeployA
 		RunImage:   runImage,
 	}
 
+	// Record the deployment start, waiting for a slot unless the context is done
+	if err := app.DeployWithContext(ctx, gitRef, &domain.DeploymentOptions{
+		BuildImage: buildImage,
+		RunImage:   runImage,
+	}); err != nil {
+		return fmt.Errorf("failed to update application state: %w", err)
+	}
+
 	// Perform deployment via shared service interface
-	deploymentResult, err := uc.deploymentSvc.Deploy(ctx, cmd.Name, deployOptions)
+	deploymentResult, err := uc.deploymentSvc.Deploy(ctx, appName.Value(), deployOptions)
 	if err != nil {
 		uc.logger.Error("Deployment service failed", "app_name", cmd.Name, "error", err)
-		// Rollback app state
-		if failErr := app.FailDeployment(err.Error()); failErr != nil {
+		// Rollback app state, recording a cancellation as such
+		var failErr error
+		if ctx.Err() != nil {
+			failErr = app.FailDeployment(domain.DeploymentCancelledReason)
+		} else {
+			failErr = app.FailDeploymentChecks(err.Error(), domain.ParseCheckResults(err.Error()))
+		}
+		if failErr != nil {
 			uc.logger.Error("failed to mark deployment as failed", "error", failErr)
 		}
-		if saveErr := uc.applicationRepo.Save(ctx, app); saveErr != nil {
+		// The failure is saved even when the deployment context is done
+		if saveErr := uc.applicationRepo.Save(context.WithoutCancel(ctx), app); saveErr != nil {
 			uc.logger.Error("failed to save app state after deployment failure", "error", saveErr)
 		}
 		return fmt.Errorf("deployment failed: %w", err)
 	}
 
-	// Update domain entity
-	if err := app.Deploy(gitRef, &domain.DeploymentOptions{
-		BuildImage: buildImage,
-		RunImage:   runImage,
-	}); err != nil {
-		return fmt.Errorf("failed to update application state: %w", err)
+	// The build goes on in the background, the deployment keeps its slot until it ends
+	uc.logger.Info("Deployment started",
+		"app_name", cmd.Name,
+		"deployment_id", deploymentResult.ID)
+	go uc.awaitDeployment(context.WithoutCancel(ctx), app, deploymentResult.ID)
+	return nil
+}
+
+// awaitDeployment waits for the final status of a deployment, then completes or fails
+// it and saves the application, which releases its deployment slot
+func (uc *ApplicationUseCase) awaitDeployment(ctx context.Context, app *domain.Application, deploymentID string) {
+	result, err := uc.waitForFinalStatus(ctx, deploymentID)
+	var endErr error
+	switch {
+	case err != nil:
+		uc.logger.Error("Deployment ended without a final status", "deployment_id", deploymentID, "error", err)
+		endErr = app.FailDeployment(err.Error())
+	case result.Status == shared.DeploymentStatusSucceeded:
+		endErr = app.CompleteDeployment()
+	default:
+		endErr = app.FailDeploymentChecks(result.ErrorMsg, domain.ParseCheckResults(result.ErrorMsg))
+	}
+	if endErr != nil {
+		uc.logger.Error("Failed to end deployment", "deployment_id", deploymentID, "error", endErr)
 	}
 
-	// Save changes
 	if err := uc.applicationRepo.Save(ctx, app); err != nil {
 		uc.logger.Warn("Failed to save after deployment",
 			"error", err)
 	}
 
-	uc.logger.Info("Deployment completed successfully",
-		"app_name", cmd.Name,
-		"deployment_id", deploymentResult.ID)
-	return nil
+	uc.logger.Info("Deployment ended",
+		"app_name", app.Name().Value(),
+		"deployment_id", deploymentID,
+		"state", app.State().Value())
+}
+
+// waitForFinalStatus polls the deployment service until the deployment succeeded,
+// failed or was rolled back, for at most the deployment timeout
+func (uc *ApplicationUseCase) waitForFinalStatus(ctx context.Context, deploymentID string) (*shared.DeploymentResult, error) {
+	ctx, cancel := context.WithTimeout(ctx, uc.deploymentTimeout)
+	defer cancel()
+
+	ticker := time.NewTicker(uc.deploymentPollInterval)
+	defer ticker.Stop()
+
+	for {
+		result, err := uc.deploymentSvc.GetStatus(ctx, deploymentID)
+		if err != nil {
+			return nil, fmt.Errorf("deployment status unavailable: %w", err)
+		}
+		switch result.Status {
+		case shared.DeploymentStatusSucceeded, shared.DeploymentStatusFailed, shared.DeploymentStatusRolledBack:
+			return result, nil
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return nil, fmt.Errorf("deployment timed out after %v", uc.deploymentTimeout)
+		}
+	}
 }
 
 // ScaleApplicationCommand represents the data for scaling an application
@@ -254,10 +400,9 @@ func (uc *ApplicationUseCase) ScaleApplication(ctx context.Context, cmd ScaleApp
 		return fmt.Errorf("scaling failed: %w", err)
 	}
 
-	// Save changes
+	// Save changes, which runs ps:scale
 	if err := uc.applicationRepo.Save(ctx, app); err != nil {
-		uc.logger.Warn("Failed to save after scaling",
-			"error", err)
+		return fmt.Errorf("failed to save after scaling: %w", err)
 	}
 
 	uc.logger.Info("Scaling completed successfully",
@@ -290,11 +435,9 @@ func (uc *ApplicationUseCase) SetApplicationConfig(ctx context.Context, cmd SetC
 		return fmt.Errorf("application not found: %w", err)
 	}
 
-	// Apply configuration
-	for key, value := range cmd.Config {
-		if err := app.SetEnvironmentVariable(key, value); err != nil {
-			return fmt.Errorf("unable to set variable %s: %w", key, err)
-		}
+	// Apply configuration, all variables or none
+	if err := app.SetEnvironmentVariables(cmd.Config); err != nil {
+		return fmt.Errorf("unable to set variables: %w", err)
 	}
 
 	// Save changes
@@ -340,3 +483,26 @@ func (uc *ApplicationUseCase) GetApplicationByName(ctx context.Context, name str
 		"app_name", name)
 	return app, nil
 }
+
+// DescribeApplication returns a snapshot of everything Dokku reports about an
+// application, for diagnostics. The sections whose report failed are listed as
+// unavailable in the snapshot.
+func (uc *ApplicationUseCase) DescribeApplication(ctx context.Context, name string) (*domain.ApplicationSnapshot, error) {
+	uc.logger.Debug("Describing application",
+		"app_name", name)
+
+	appName, err := domain.NewApplicationName(name)
+	if err != nil {
+		return nil, fmt.Errorf("invalid application name: %w", err)
+	}
+
+	snapshot, err := uc.describer.DescribeApplication(ctx, appName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe application: %w", err)
+	}
+
+	uc.logger.Debug("Application described successfully",
+		"app_name", name,
+		"unavailable_sections", len(snapshot.Unavailable))
+	return snapshot, nil
+}
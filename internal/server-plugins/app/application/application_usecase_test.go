@@ -0,0 +1,148 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+
+	domain "github.com/dokku-mcp/dokku-mcp/internal/server-plugins/app/domain"
+	"github.com/dokku-mcp/dokku-mcp/internal/shared"
+)
+
+// memoryRepository keeps the applications in memory and records the state each was
+// last saved in
+type memoryRepository struct {
+	domain.ApplicationRepository
+
+	mu     sync.Mutex
+	apps   map[string]*domain.Application
+	states map[string]domain.StateValue
+}
+
+func (r *memoryRepository) GetByName(ctx context.Context, name *domain.ApplicationName) (*domain.Application, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	app, exists := r.apps[name.Value()]
+	if !exists {
+		return nil, domain.ErrApplicationNotFound
+	}
+	return app, nil
+}
+
+func (r *memoryRepository) Save(ctx context.Context, app *domain.Application) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.states[app.Name().Value()] = app.State().Value()
+	return nil
+}
+
+func (r *memoryRepository) savedState(appName string) domain.StateValue {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.states[appName]
+}
+
+// asyncDeploymentService starts builds that keep running until finish is called
+type asyncDeploymentService struct {
+	shared.DeploymentService
+
+	mu       sync.Mutex
+	statuses map[string]shared.DeploymentStatus
+}
+
+func (s *asyncDeploymentService) Deploy(ctx context.Context, appName string, options shared.DeployOptions) (*shared.DeploymentResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.statuses[appName] = shared.DeploymentStatusRunning
+	return &shared.DeploymentResult{ID: appName, AppName: appName, Status: shared.DeploymentStatusRunning}, nil
+}
+
+func (s *asyncDeploymentService) GetStatus(ctx context.Context, deploymentID string) (*shared.DeploymentResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return &shared.DeploymentResult{ID: deploymentID, Status: s.statuses[deploymentID]}, nil
+}
+
+func (s *asyncDeploymentService) finish(deploymentID string, status shared.DeploymentStatus) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.statuses[deploymentID] = status
+}
+
+func newDeployUseCase(t *testing.T, names ...string) (*ApplicationUseCase, *memoryRepository, *asyncDeploymentService) {
+	t.Helper()
+	repo := &memoryRepository{apps: map[string]*domain.Application{}, states: map[string]domain.StateValue{}}
+	for _, name := range names {
+		app, err := domain.NewApplication(name)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		repo.apps[name] = app
+	}
+	deploymentSvc := &asyncDeploymentService{statuses: map[string]shared.DeploymentStatus{}}
+
+	uc := NewApplicationUseCase(repo, nil, nil, deploymentSvc, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	uc.deploymentPollInterval = time.Millisecond
+	return uc, repo, deploymentSvc
+}
+
+func waitFor(t *testing.T, condition func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !condition() {
+		if time.Now().After(deadline) {
+			t.Fatal("condition not met in time")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestDeployApplicationHoldsTheSlotUntilTheBuildEnds(t *testing.T) {
+	scheduler, err := domain.NewDeploymentScheduler(1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	domain.SetDeploymentScheduler(scheduler)
+	t.Cleanup(func() { domain.SetDeploymentScheduler(nil) })
+
+	uc, repo, deploymentSvc := newDeployUseCase(t, "first-app", "second-app")
+
+	if err := uc.DeployApplication(context.Background(), DeployApplicationCommand{Name: "first-app", GitRef: "main"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if scheduler.Running() != 1 {
+		t.Fatalf("expected the running build to hold its slot, got %d running", scheduler.Running())
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	err = uc.DeployApplication(ctx, DeployApplicationCommand{Name: "second-app", GitRef: "main"})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected the second deployment to wait for the build, got %v", err)
+	}
+
+	deploymentSvc.finish("first-app", shared.DeploymentStatusSucceeded)
+	waitFor(t, func() bool { return scheduler.Running() == 0 })
+	waitFor(t, func() bool { return repo.savedState("first-app") == domain.StateRunning })
+
+	if err := uc.DeployApplication(context.Background(), DeployApplicationCommand{Name: "second-app", GitRef: "main"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	deploymentSvc.finish("second-app", shared.DeploymentStatusFailed)
+	waitFor(t, func() bool { return scheduler.Running() == 0 })
+	waitFor(t, func() bool { return repo.savedState("second-app") == domain.StateError })
+}
+
+func TestDeployApplicationFailsABuildWithoutFinalStatus(t *testing.T) {
+	uc, repo, _ := newDeployUseCase(t, "my-app")
+	uc.deploymentTimeout = 10 * time.Millisecond
+
+	if err := uc.DeployApplication(context.Background(), DeployApplicationCommand{Name: "my-app", GitRef: "main"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	waitFor(t, func() bool { return repo.savedState("my-app") == domain.StateError })
+}
@@ -0,0 +1,187 @@
+package app
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/dokku-mcp/dokku-mcp/internal/shared/process"
+)
+
+// appJSONKeys are the top-level keys of the app.json schema. Dokku only reads some of
+// them, the others are kept for Heroku compatibility and ignored without warning.
+var appJSONKeys = []string{
+	"name", "description", "keywords", "website", "repository", "logo", "success_url",
+	"scripts", "env", "formation", "image", "addons", "buildpacks", "environments",
+	"stack", "healthchecks", "cron",
+}
+
+// AppFormation is the process formation entry of app.json
+type AppFormation struct {
+	Quantity    int `json:"quantity"`
+	MaxParallel int `json:"max_parallel,omitempty"`
+}
+
+// AppHealthCheck is a healthchecks entry of app.json, timings in seconds
+type AppHealthCheck struct {
+	Type     string `json:"type,omitempty"`
+	Name     string `json:"name,omitempty"`
+	Path     string `json:"path,omitempty"`
+	Attempts int    `json:"attempts,omitempty"`
+	Wait     int    `json:"wait,omitempty"`
+	Timeout  int    `json:"timeout,omitempty"`
+}
+
+// AppCronEntry is a cron entry of app.json
+type AppCronEntry struct {
+	Command  string `json:"command"`
+	Schedule string `json:"schedule"`
+}
+
+// AppScripts are the deployment scripts of app.json
+type AppScripts struct {
+	Dokku struct {
+		Predeploy  string `json:"predeploy,omitempty"`
+		Postdeploy string `json:"postdeploy,omitempty"`
+	} `json:"dokku"`
+	Postdeploy string `json:"postdeploy,omitempty"`
+}
+
+// AppManifest is the part of a repository app.json Dokku reads
+type AppManifest struct {
+	Name         string                      `json:"name,omitempty"`
+	Scripts      AppScripts                  `json:"scripts"`
+	Formation    map[string]AppFormation     `json:"formation,omitempty"`
+	Healthchecks map[string][]AppHealthCheck `json:"healthchecks,omitempty"`
+	Cron         []AppCronEntry              `json:"cron,omitempty"`
+	// Warnings lists what the parser ignored, such as unknown top-level keys
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// ParseAppJSON parses an app.json document. Malformed JSON and values of the wrong type
+// are errors, unknown top-level keys are reported as warnings.
+func ParseAppJSON(data []byte) (*AppManifest, error) {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, describeAppJSONError(err)
+	}
+
+	manifest := &AppManifest{}
+	if err := json.Unmarshal(data, manifest); err != nil {
+		return nil, describeAppJSONError(err)
+	}
+	// Warnings are the parser's own, never read from the document
+	manifest.Warnings = nil
+
+	for _, key := range slices.Sorted(maps.Keys(raw)) {
+		if !slices.Contains(appJSONKeys, key) {
+			manifest.Warnings = append(manifest.Warnings, fmt.Sprintf("unknown top-level key '%s' ignored", key))
+		}
+	}
+
+	return manifest, nil
+}
+
+func describeAppJSONError(err error) error {
+	var syntaxErr *json.SyntaxError
+	if errors.As(err, &syntaxErr) {
+		return fmt.Errorf("malformed app.json at offset %d: %w", syntaxErr.Offset, err)
+	}
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) {
+		return fmt.Errorf("invalid app.json: %s must be %s, got %s", typeErr.Field, typeErr.Type, typeErr.Value)
+	}
+	return fmt.Errorf("invalid app.json: %w", err)
+}
+
+// webHealthCheck returns the checks settings of the first startup check of the web
+// process, the one Dokku's zero-downtime checks rely on, defaults filling the unset timings
+func (m *AppManifest) webHealthCheck() (*HealthCheck, bool, error) {
+	for _, check := range m.Healthchecks[string(process.ProcessTypeWeb)] {
+		if check.Type != "" && check.Type != "startup" {
+			continue
+		}
+
+		wait, timeout, attempts := DefaultChecksWait, DefaultChecksTimeout, DefaultChecksAttempts
+		if check.Wait > 0 {
+			wait = time.Duration(check.Wait) * time.Second
+		}
+		if check.Timeout > 0 {
+			timeout = time.Duration(check.Timeout) * time.Second
+		}
+		if check.Attempts > 0 {
+			attempts = check.Attempts
+		}
+		healthCheck, err := NewHealthCheck(wait, timeout, attempts)
+		return healthCheck, true, err
+	}
+	return nil, false, nil
+}
+
+// ApplyManifest makes the application reflect an app.json: the formation scales the
+// declared processes, the web startup check sets the health checks and the cron
+// section replaces the scheduled tasks. Each change emits its usual event. On any
+// invalid entry nothing is applied.
+func (a *Application) ApplyManifest(m *AppManifest) error {
+	if m == nil {
+		return fmt.Errorf("manifest cannot be null")
+	}
+
+	snapshot := a.takeSnapshot()
+	if err := a.applyManifest(m); err != nil {
+		a.restoreSnapshot(snapshot)
+		return err
+	}
+	return nil
+}
+
+func (a *Application) applyManifest(m *AppManifest) error {
+	for _, name := range slices.Sorted(maps.Keys(m.Formation)) {
+		quantity := m.Formation[name].Quantity
+		if proc, exists := a.findProcess(process.ProcessType(name)); exists && proc.Scale() == quantity {
+			continue
+		}
+		if err := a.Scale(process.ProcessType(name), quantity); err != nil {
+			return fmt.Errorf("invalid formation of process %s: %w", name, err)
+		}
+	}
+
+	healthCheck, found, err := m.webHealthCheck()
+	if err != nil {
+		return fmt.Errorf("invalid web health check: %w", err)
+	}
+	if found {
+		a.changeHealthChecks(healthCheck)
+	}
+
+	if m.Cron == nil {
+		return nil
+	}
+	declared := make([]*ScheduledTask, 0, len(m.Cron))
+	for _, entry := range m.Cron {
+		task, err := NewScheduledTask(entry.Schedule, entry.Command)
+		if err != nil {
+			return fmt.Errorf("invalid cron entry: %w", err)
+		}
+		declared = append(declared, task)
+	}
+	for _, task := range a.GetScheduledTasks() {
+		if !slices.ContainsFunc(declared, task.Equal) {
+			if err := a.RemoveScheduledTask(task.Schedule(), task.Command()); err != nil {
+				return err
+			}
+		}
+	}
+	for _, task := range declared {
+		if slices.ContainsFunc(a.configuration.scheduledTasks, task.Equal) {
+			continue
+		}
+		if err := a.AddScheduledTask(task.Schedule(), task.Command()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
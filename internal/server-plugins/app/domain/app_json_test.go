@@ -0,0 +1,106 @@
+package app_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	app "github.com/dokku-mcp/dokku-mcp/internal/server-plugins/app/domain"
+	"github.com/dokku-mcp/dokku-mcp/internal/shared/process"
+)
+
+const sampleAppJSON = `{
+  "name": "my-app",
+  "scripts": {"dokku": {"predeploy": "npm run migrate"}},
+  "formation": {"web": {"quantity": 2}, "worker": {"quantity": 1}},
+  "healthchecks": {
+    "web": [{"type": "startup", "name": "web check", "path": "/health", "attempts": 3, "wait": 2}]
+  },
+  "cron": [{"command": "npm run cleanup", "schedule": "0 3 * * *"}],
+  "custom": true
+}`
+
+var _ = Describe("ParseAppJSON", func() {
+	It("should parse the sections Dokku reads", func() {
+		manifest, err := app.ParseAppJSON([]byte(sampleAppJSON))
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(manifest.Name).To(Equal("my-app"))
+		Expect(manifest.Scripts.Dokku.Predeploy).To(Equal("npm run migrate"))
+		Expect(manifest.Formation).To(HaveKeyWithValue("web", app.AppFormation{Quantity: 2}))
+		Expect(manifest.Healthchecks["web"]).To(HaveLen(1))
+		Expect(manifest.Cron).To(Equal([]app.AppCronEntry{{Command: "npm run cleanup", Schedule: "0 3 * * *"}}))
+	})
+
+	It("should warn about unknown top-level keys", func() {
+		manifest, err := app.ParseAppJSON([]byte(sampleAppJSON))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(manifest.Warnings).To(Equal([]string{"unknown top-level key 'custom' ignored"}))
+	})
+
+	It("should locate malformed JSON", func() {
+		_, err := app.ParseAppJSON([]byte(`{"formation": {"web": }}`))
+		Expect(err).To(MatchError(ContainSubstring("malformed app.json at offset")))
+	})
+
+	It("should name a value of the wrong type", func() {
+		_, err := app.ParseAppJSON([]byte(`{"formation": {"web": {"quantity": "two"}}}`))
+		Expect(err).To(MatchError(ContainSubstring("formation.web.quantity must be int")))
+	})
+})
+
+var _ = Describe("Application ApplyManifest", func() {
+	var application *app.Application
+
+	BeforeEach(func() {
+		var err error
+		application, err = app.NewApplication("my-app")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(application.AddScheduledTask("@hourly", "npm run legacy")).To(Succeed())
+		application.ClearEvents()
+	})
+
+	It("should set the formation, health checks and scheduled tasks", func() {
+		manifest, err := app.ParseAppJSON([]byte(sampleAppJSON))
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(application.ApplyManifest(manifest)).To(Succeed())
+
+		Expect(application.GetProcessScale(process.ProcessTypeWeb)).To(Equal(2))
+		Expect(application.GetProcessScale(process.ProcessTypeWorker)).To(Equal(1))
+		checks := application.GetHealthChecks()
+		Expect(checks.Attempts()).To(Equal(3))
+		Expect(checks.Wait()).To(Equal(2 * time.Second))
+		Expect(checks.Timeout()).To(Equal(app.DefaultChecksTimeout))
+		tasks := application.GetScheduledTasks()
+		Expect(tasks).To(HaveLen(1))
+		Expect(tasks[0].Command()).To(Equal("npm run cleanup"))
+
+		Expect(application.GetEvents()).To(ContainElement(BeAssignableToTypeOf(&app.ApplicationScaledEvent{})))
+		Expect(application.GetEvents()).To(ContainElement(BeAssignableToTypeOf(&app.HealthChecksChangedEvent{})))
+		Expect(application.GetEvents()).To(ContainElement(BeAssignableToTypeOf(&app.ScheduledTaskRemovedEvent{})))
+		Expect(application.GetEvents()).To(ContainElement(BeAssignableToTypeOf(&app.ScheduledTaskAddedEvent{})))
+	})
+
+	It("should apply nothing when an entry is invalid", func() {
+		manifest, err := app.ParseAppJSON([]byte(`{
+  "formation": {"web": {"quantity": 2}},
+  "cron": [{"command": "npm run cleanup", "schedule": "every night"}]
+}`))
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(application.ApplyManifest(manifest)).NotTo(Succeed())
+		Expect(application.GetProcessScale(process.ProcessTypeWeb)).To(Equal(0))
+		Expect(application.GetScheduledTasks()).To(HaveLen(1))
+		Expect(application.GetEvents()).To(BeEmpty())
+	})
+
+	It("should keep the scheduled tasks without a cron section", func() {
+		manifest, err := app.ParseAppJSON([]byte(`{"formation": {"web": {"quantity": 1}}}`))
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(application.ApplyManifest(manifest)).To(Succeed())
+		Expect(application.GetScheduledTasks()).To(HaveLen(1))
+	})
+})
@@ -19,23 +19,40 @@ func NewValidationService() *ValidationService {
 
 // ValidationResult is the result of a validation
 type ValidationResult struct {
-	IsValid  bool
-	Errors   []ValidationError
-	Warnings []ValidationWarning
+	IsValid  bool                `json:"is_valid"`
+	Errors   []ValidationError   `json:"errors"`
+	Warnings []ValidationWarning `json:"warnings"`
 }
 
-// ValidationError is a validation error
+// ValidationError is a validation error. Field is the path of the invalid input,
+// e.g. name, domains[2] or env.FOO.
 type ValidationError struct {
-	Field   string
-	Message string
-	Code    string
+	Field   string `json:"field"`
+	Message string `json:"message"`
+	Code    string `json:"code"`
+}
+
+func (e ValidationError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Field, e.Message)
+}
+
+// ValidationErrors collects every invalid field of an input, for the client to fix
+// them all at once
+type ValidationErrors []ValidationError
+
+func (e ValidationErrors) Error() string {
+	messages := make([]string, len(e))
+	for i, err := range e {
+		messages[i] = err.Error()
+	}
+	return strings.Join(messages, "; ")
 }
 
 // ValidationWarning is a validation warning
 type ValidationWarning struct {
-	Field   string
-	Message string
-	Code    string
+	Field   string `json:"field"`
+	Message string `json:"message"`
+	Code    string `json:"code"`
 }
 
 // ValidateApplication validates a complete application
@@ -67,7 +84,7 @@ func (s *ValidationService) ValidateApplicationName(ctx context.Context, nameStr
 	}
 
 	// Use Value Object for primitive validation
-	_, err := NewApplicationName(nameStr)
+	name, err := NewApplicationName(nameStr)
 	if err != nil {
 		result.IsValid = false
 		result.Errors = append(result.Errors, ValidationError{
@@ -78,8 +95,16 @@ func (s *ValidationService) ValidateApplicationName(ctx context.Context, nameStr
 		return result
 	}
 
+	if name.WasNormalized() {
+		result.Warnings = append(result.Warnings, ValidationWarning{
+			Field:   "name",
+			Message: fmt.Sprintf("Application name normalized to '%s'", name.Value()),
+			Code:    "NAME_NORMALIZED",
+		})
+	}
+
 	// Add business warnings (not covered by VO)
-	s.addApplicationNameWarnings(nameStr, result)
+	s.addApplicationNameWarnings(name.Value(), result)
 
 	return result
 }
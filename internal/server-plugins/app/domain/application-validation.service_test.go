@@ -54,6 +54,7 @@ var _ = Describe("ValidationService", func() {
 			Entry("invalid characters - VO validation should catch this", "my_app_with_underscores", false, 1, 0, []string{"INVALID_APPLICATION_NAME"}, []string{}),
 			Entry("reserved name - should pass VO validation but add warning", "dokku", false, 1, 0, []string{"INVALID_APPLICATION_NAME"}, []string{}),
 			Entry("long name without hyphens - should add format suggestion warning", "verylongapplicationnamewithouthyphens", true, 0, 1, []string{}, []string{"NAME_FORMAT_SUGGESTION"}),
+			Entry("mixed case name with trailing space - should warn about normalization", "MyApp ", true, 0, 1, []string{}, []string{"NAME_NORMALIZED"}),
 		)
 	})
 
@@ -0,0 +1,134 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ActivityCategory groups activity entries, stable across releases so clients may filter on it
+type ActivityCategory string
+
+const (
+	ActivityCategoryLifecycle ActivityCategory = "lifecycle"
+	ActivityCategoryDeploy    ActivityCategory = "deploy"
+	ActivityCategoryScale     ActivityCategory = "scale"
+	ActivityCategoryDomain    ActivityCategory = "domain"
+	ActivityCategoryConfig    ActivityCategory = "config"
+)
+
+// ActivityEntry is a one-line, human readable description of an application event
+type ActivityEntry struct {
+	Sequence    uint64           `json:"sequence"`
+	OccurredAt  time.Time        `json:"occurred_at"`
+	Category    ActivityCategory `json:"category"`
+	EventType   string           `json:"event_type"`
+	Description string           `json:"description"`
+}
+
+// ActivityLog describes the pending events of the application, oldest first
+func (a *Application) ActivityLog() []ActivityEntry {
+	entries := make([]ActivityEntry, 0, len(a.events))
+	for _, event := range a.events {
+		category, description := describeEvent(event)
+		entries = append(entries, ActivityEntry{
+			Sequence:    event.Sequence(),
+			OccurredAt:  event.OccurredAt(),
+			Category:    category,
+			EventType:   event.EventType(),
+			Description: description,
+		})
+	}
+	return entries
+}
+
+func describeEvent(event DomainEvent) (ActivityCategory, string) {
+	switch e := event.(type) {
+	case *ApplicationCreatedEvent:
+		return ActivityCategoryLifecycle, "created"
+	case *ApplicationDestroyedEvent:
+		return ActivityCategoryLifecycle, "destroyed"
+	case *ApplicationRenamedEvent:
+		return ActivityCategoryLifecycle, fmt.Sprintf("renamed from %s to %s", e.OldName(), e.NewName())
+	case *ApplicationStateChangedEvent:
+		return ActivityCategoryLifecycle, fmt.Sprintf("state changed from %s to %s", e.OldState(), e.NewState())
+	case *ApplicationDeployedEvent:
+		return ActivityCategoryDeploy, fmt.Sprintf("deployed %s", e.GitRef())
+	case *ApplicationDeployedFromImageEvent:
+		return ActivityCategoryDeploy, fmt.Sprintf("deployed image %s", e.Image())
+	case *ApplicationRebuiltEvent:
+		return ActivityCategoryDeploy, fmt.Sprintf("rebuilt %s", e.GitRef())
+	case *ApplicationDeploymentCompletedEvent:
+		return ActivityCategoryDeploy, fmt.Sprintf("deployment completed in %s", e.Duration())
+	case *ApplicationDeploymentFailedEvent:
+		if checks := e.CheckResults(); len(checks) > 0 {
+			return ActivityCategoryDeploy, fmt.Sprintf("deployment failed after %s: %s", e.Duration(), checks[len(checks)-1])
+		}
+		return ActivityCategoryDeploy, fmt.Sprintf("deployment failed after %s: %s", e.Duration(), e.Reason())
+	case *ApplicationUndeployedEvent:
+		if e.Reason() == "" {
+			return ActivityCategoryDeploy, "undeployed"
+		}
+		return ActivityCategoryDeploy, fmt.Sprintf("undeployed: %s", e.Reason())
+	case *ApplicationScaledEvent:
+		return ActivityCategoryScale, fmt.Sprintf("scaled %s from %d to %d", e.ProcessType(), e.OldScale(), e.NewScale())
+	case *ProcessLimitsChangedEvent:
+		return ActivityCategoryScale, fmt.Sprintf("set %s limits to memory=%s cpu=%s storage=%s",
+			e.ProcessType(), orNone(e.Memory()), orNone(e.CPU()), orNone(e.Storage()))
+	case *RestartPolicyChangedEvent:
+		return ActivityCategoryScale, fmt.Sprintf("set %s restart policy to %s", e.ProcessType(), e.Policy())
+	case *DomainAddedEvent:
+		return ActivityCategoryDomain, fmt.Sprintf("added domain %s", e.Domain())
+	case *DomainRemovedEvent:
+		return ActivityCategoryDomain, fmt.Sprintf("removed domain %s", e.Domain())
+	case *BuildpackChangedEvent:
+		return ActivityCategoryConfig, fmt.Sprintf("set buildpack to %s", e.Buildpack())
+	case *BuildpackAddedEvent:
+		return ActivityCategoryConfig, fmt.Sprintf("added buildpack %s at position %d", e.Buildpack(), e.Index())
+	case *BuildpackRemovedEvent:
+		return ActivityCategoryConfig, fmt.Sprintf("removed buildpack %s", e.Buildpack())
+	case *HealthChecksChangedEvent:
+		if e.Skipped() {
+			return ActivityCategoryConfig, "skipped health checks"
+		}
+		return ActivityCategoryConfig, fmt.Sprintf("set health checks to wait=%s timeout=%s attempts=%d",
+			e.Wait(), e.Timeout(), e.Attempts())
+	case *RunAsUserChangedEvent:
+		return ActivityCategoryConfig, fmt.Sprintf("set run-as user to %s", orNone(e.User()))
+	case *GitConfigChangedEvent:
+		return ActivityCategoryConfig, fmt.Sprintf("set deploy branch to %s", orNone(e.DeployBranch()))
+	case *MaintenanceEnabledEvent:
+		return ActivityCategoryConfig, "enabled maintenance mode"
+	case *MaintenanceDisabledEvent:
+		return ActivityCategoryConfig, "disabled maintenance mode"
+	case *PortMappingsChangedEvent:
+		if len(e.Mappings()) == 0 {
+			return ActivityCategoryConfig, "cleared port mappings"
+		}
+		return ActivityCategoryConfig, fmt.Sprintf("set port mappings to %s", strings.Join(e.Mappings(), " "))
+	case *ScheduledTaskAddedEvent:
+		return ActivityCategoryConfig, fmt.Sprintf("scheduled %s at %s", e.Command(), e.Schedule())
+	case *ScheduledTaskRemovedEvent:
+		return ActivityCategoryConfig, fmt.Sprintf("unscheduled %s at %s", e.Command(), e.Schedule())
+	case *DockerOptionAddedEvent:
+		return ActivityCategoryConfig, fmt.Sprintf("added %s docker option %s", e.Phase(), e.Option())
+	case *DockerOptionRemovedEvent:
+		return ActivityCategoryConfig, fmt.Sprintf("removed %s docker option %s", e.Phase(), e.Option())
+	case *LabelChangedEvent:
+		if e.Removed() {
+			return ActivityCategoryConfig, fmt.Sprintf("removed label %s", e.Key())
+		}
+		return ActivityCategoryConfig, fmt.Sprintf("set label %s=%s", e.Key(), e.Value())
+	case *ConfigurationReconciledEvent:
+		return ActivityCategoryConfig, "reconciled with the live configuration: " + e.Summary().String()
+	default:
+		return ActivityCategoryConfig, event.EventType()
+	}
+}
+
+func orNone(value string) string {
+	if value == "" {
+		return "none"
+	}
+	return value
+}
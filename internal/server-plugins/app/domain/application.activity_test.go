@@ -0,0 +1,47 @@
+package app_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	app "github.com/dokku-mcp/dokku-mcp/internal/server-plugins/app/domain"
+	"github.com/dokku-mcp/dokku-mcp/internal/shared"
+	"github.com/dokku-mcp/dokku-mcp/internal/shared/process"
+)
+
+var _ = Describe("Application activity log", func() {
+	It("should describe each event on one line with a stable category", func() {
+		application, err := app.NewApplication("activity-app")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(application.Scale(process.ProcessTypeWeb, 1)).To(Succeed())
+		application.ClearEvents()
+
+		Expect(application.Scale(process.ProcessTypeWeb, 3)).To(Succeed())
+		Expect(application.AddDomain("example.com")).To(Succeed())
+		Expect(application.SetBuildpack("heroku/nodejs")).To(Succeed())
+
+		log := application.ActivityLog()
+		Expect(log).To(HaveLen(3))
+		Expect(log[0].Category).To(Equal(app.ActivityCategoryScale))
+		Expect(log[0].Description).To(Equal("scaled web from 1 to 3"))
+		Expect(log[1].Category).To(Equal(app.ActivityCategoryDomain))
+		Expect(log[1].Description).To(Equal("added domain example.com"))
+		Expect(log[2].Category).To(Equal(app.ActivityCategoryConfig))
+		Expect(log[2].Description).To(Equal("set buildpack to heroku/nodejs"))
+	})
+
+	It("should carry the sequence and timestamp of the events", func() {
+		application, _ := app.NewApplication("activity-app")
+		Expect(application.Deploy(shared.MustNewGitRef("v1.0.0"), nil)).To(Succeed())
+
+		events := application.GetEvents()
+		log := application.ActivityLog()
+		Expect(log).To(HaveLen(len(events)))
+		for i, entry := range log {
+			Expect(entry.Sequence).To(Equal(events[i].Sequence()))
+			Expect(entry.OccurredAt).To(Equal(events[i].OccurredAt()))
+			Expect(entry.EventType).To(Equal(events[i].EventType()))
+		}
+		Expect(log).To(ContainElement(HaveField("Description", "deployed v1.0.0")))
+	})
+})
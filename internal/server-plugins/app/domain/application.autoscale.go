@@ -0,0 +1,74 @@
+package app
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/dokku-mcp/dokku-mcp/internal/shared/process"
+)
+
+// SetReplicaTarget declares the replica bounds for a process type
+func (a *Application) SetReplicaTarget(processType process.ProcessType, minReplicas, maxReplicas int) error {
+	processType, err := process.NormalizeProcessType(processType)
+	if err != nil {
+		return fmt.Errorf("invalid process type: %w", err)
+	}
+
+	target, err := NewReplicaTarget(minReplicas, maxReplicas)
+	if err != nil {
+		return fmt.Errorf("invalid replica target for %s: %w", processType, err)
+	}
+
+	a.configuration.replicaTargets[processType] = target
+	a.updatedAt = a.clock.Now()
+
+	return nil
+}
+
+// RemoveReplicaTarget drops the replica bounds of a process type
+func (a *Application) RemoveReplicaTarget(processType process.ProcessType) {
+	processType, err := process.NormalizeProcessType(processType)
+	if err != nil {
+		return
+	}
+	if _, exists := a.configuration.replicaTargets[processType]; !exists {
+		return
+	}
+	delete(a.configuration.replicaTargets, processType)
+	a.updatedAt = a.clock.Now()
+}
+
+// GetReplicaTarget returns the replica bounds of a process type, if declared
+func (a *Application) GetReplicaTarget(processType process.ProcessType) (*ReplicaTarget, bool) {
+	normalized, err := process.NormalizeProcessType(processType)
+	if err != nil {
+		return nil, false
+	}
+	target, exists := a.configuration.replicaTargets[normalized]
+	return target, exists
+}
+
+// ReconcileReplicas scales a process toward the supplied load signal within its
+// declared bounds. The load signal is provided by the caller until metrics are
+// collected; a scale event is only emitted when the replica count changes.
+func (a *Application) ReconcileReplicas(processType process.ProcessType, load float64) (int, error) {
+	target, exists := a.GetReplicaTarget(processType)
+	if !exists {
+		return 0, fmt.Errorf("no replica target declared for process %s", processType)
+	}
+	if load < 0 || math.IsNaN(load) || math.IsInf(load, 0) {
+		return 0, fmt.Errorf("invalid load signal for process %s: %v", processType, load)
+	}
+
+	current := a.GetProcessScale(processType)
+	desired := target.Desired(current, load)
+	if desired == current {
+		return current, nil
+	}
+
+	if err := a.Scale(processType, desired); err != nil {
+		return current, fmt.Errorf("unable to reconcile replicas for %s: %w", processType, err)
+	}
+
+	return desired, nil
+}
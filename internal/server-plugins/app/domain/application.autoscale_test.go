@@ -0,0 +1,88 @@
+package app_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	app "github.com/dokku-mcp/dokku-mcp/internal/server-plugins/app/domain"
+	"github.com/dokku-mcp/dokku-mcp/internal/shared/process"
+)
+
+var _ = Describe("Application autoscale", func() {
+	var application *app.Application
+
+	BeforeEach(func() {
+		var err error
+		application, err = app.NewApplication("autoscale-app")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(application.Scale(process.ProcessTypeWeb, 2)).To(Succeed())
+		application.ClearEvents()
+	})
+
+	Describe("NewReplicaTarget", func() {
+		DescribeTable("bounds validation",
+			func(minReplicas, maxReplicas int, expectError bool) {
+				_, err := app.NewReplicaTarget(minReplicas, maxReplicas)
+				if expectError {
+					Expect(err).To(HaveOccurred())
+				} else {
+					Expect(err).NotTo(HaveOccurred())
+				}
+			},
+			Entry("valid range", 1, 5, false),
+			Entry("fixed size", 3, 3, false),
+			Entry("zero minimum", 0, 2, false),
+			Entry("min greater than max", 4, 2, true),
+			Entry("negative minimum", -1, 2, true),
+			Entry("negative maximum", 0, -2, true),
+		)
+	})
+
+	Describe("ReconcileReplicas", func() {
+		BeforeEach(func() {
+			Expect(application.SetReplicaTarget(process.ProcessTypeWeb, 1, 4)).To(Succeed())
+		})
+
+		DescribeTable("respects the declared bounds",
+			func(load float64, expected int) {
+				scale, err := application.ReconcileReplicas(process.ProcessTypeWeb, load)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(scale).To(Equal(expected))
+				Expect(application.GetProcessScale(process.ProcessTypeWeb)).To(Equal(expected))
+			},
+			Entry("steady load keeps the current scale", 1.0, 2),
+			Entry("moderate load scales up", 1.5, 3),
+			Entry("heavy load is capped at max", 10.0, 4),
+			Entry("low load scales down", 0.5, 1),
+			Entry("no load is floored at min", 0.0, 1),
+		)
+
+		It("should emit a scale event when the replica count changes", func() {
+			_, err := application.ReconcileReplicas(process.ProcessTypeWeb, 2.0)
+			Expect(err).NotTo(HaveOccurred())
+
+			events := application.GetEvents()
+			Expect(events).To(HaveLen(1))
+			scaled, ok := events[0].(*app.ApplicationScaledEvent)
+			Expect(ok).To(BeTrue())
+			Expect(scaled.OldScale()).To(Equal(2))
+			Expect(scaled.NewScale()).To(Equal(4))
+		})
+
+		It("should not emit an event when the scale is unchanged", func() {
+			_, err := application.ReconcileReplicas(process.ProcessTypeWeb, 1.0)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(application.GetEvents()).To(BeEmpty())
+		})
+
+		It("should reject a negative load signal", func() {
+			_, err := application.ReconcileReplicas(process.ProcessTypeWeb, -1)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should fail for a process without a target", func() {
+			_, err := application.ReconcileReplicas(process.ProcessTypeWorker, 1.0)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
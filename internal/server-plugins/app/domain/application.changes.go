@@ -0,0 +1,170 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/dokku-mcp/dokku-mcp/internal/shared/process"
+)
+
+// ConfigChangeKind is the kind of mutation a ConfigChange applies
+type ConfigChangeKind string
+
+const (
+	ConfigChangeAddDomain    ConfigChangeKind = "add_domain"
+	ConfigChangeRemoveDomain ConfigChangeKind = "remove_domain"
+	ConfigChangeSetEnv       ConfigChangeKind = "set_env"
+	ConfigChangeUnsetEnv     ConfigChangeKind = "unset_env"
+	ConfigChangeScale        ConfigChangeKind = "scale"
+	ConfigChangeSetBuildpack ConfigChangeKind = "set_buildpack"
+)
+
+// ConfigChange is a single step of a configuration plan. Key holds the domain, variable
+// key, process type or buildpack; Value the variable value and Scale the instance count.
+type ConfigChange struct {
+	Kind  ConfigChangeKind `json:"kind"`
+	Key   string           `json:"key"`
+	Value string           `json:"value,omitempty"`
+	Scale int              `json:"scale,omitempty"`
+}
+
+// String describes the change without the variable value, which may be a secret
+func (c ConfigChange) String() string {
+	if c.Kind == ConfigChangeScale {
+		return fmt.Sprintf("%s %s=%d", c.Kind, c.Key, c.Scale)
+	}
+	return fmt.Sprintf("%s %s", c.Kind, c.Key)
+}
+
+// ChangeOutcome is the outcome of one change of a batch
+type ChangeOutcome struct {
+	Change  ConfigChange `json:"change"`
+	Applied bool         `json:"applied"`
+	Error   string       `json:"error,omitempty"`
+}
+
+// ChangeResult reports what a batch of changes did, in the order of the changes
+type ChangeResult struct {
+	Outcomes []ChangeOutcome `json:"outcomes"`
+	// RolledBack is set when an atomic batch failed and its applied changes were undone
+	RolledBack bool `json:"rolled_back"`
+}
+
+// AppliedCount returns how many changes took effect
+func (r ChangeResult) AppliedCount() int {
+	count := 0
+	for _, outcome := range r.Outcomes {
+		if outcome.Applied {
+			count++
+		}
+	}
+	return count
+}
+
+// Failed returns the outcomes of the changes that failed
+func (r ChangeResult) Failed() []ChangeOutcome {
+	failed := make([]ChangeOutcome, 0)
+	for _, outcome := range r.Outcomes {
+		if outcome.Error != "" {
+			failed = append(failed, outcome)
+		}
+	}
+	return failed
+}
+
+// ApplyChanges attempts every change and reports the outcome of each, the returned error
+// joining the failures. By default a failed change does not stop the next ones. When
+// atomic, the batch stops at the first failure and the changes already applied are rolled
+// back along with their events, leaving the application as it was.
+func (a *Application) ApplyChanges(changes []ConfigChange, atomic bool) (ChangeResult, error) {
+	result := ChangeResult{Outcomes: make([]ChangeOutcome, 0, len(changes))}
+	if len(changes) == 0 {
+		return result, nil
+	}
+
+	snapshot := a.takeSnapshot()
+	var errs []error
+	for _, change := range changes {
+		outcome := ChangeOutcome{Change: change}
+		if err := a.applyChange(change); err != nil {
+			outcome.Error = err.Error()
+			errs = append(errs, fmt.Errorf("%s: %w", change, err))
+		} else {
+			outcome.Applied = true
+		}
+		result.Outcomes = append(result.Outcomes, outcome)
+
+		if atomic && len(errs) > 0 {
+			break
+		}
+	}
+
+	if atomic && len(errs) > 0 {
+		a.restoreSnapshot(snapshot)
+		for i := range result.Outcomes {
+			result.Outcomes[i].Applied = false
+		}
+		result.RolledBack = true
+	}
+
+	return result, errors.Join(errs...)
+}
+
+func (a *Application) applyChange(change ConfigChange) error {
+	switch change.Kind {
+	case ConfigChangeAddDomain:
+		return a.AddDomain(change.Key)
+	case ConfigChangeRemoveDomain:
+		return a.RemoveDomain(change.Key)
+	case ConfigChangeSetEnv:
+		return a.SetEnvironmentVariable(change.Key, change.Value)
+	case ConfigChangeUnsetEnv:
+		return a.UnsetEnvironmentVariable(change.Key)
+	case ConfigChangeScale:
+		return a.Scale(process.ProcessType(change.Key), change.Scale)
+	case ConfigChangeSetBuildpack:
+		return a.SetBuildpack(change.Key)
+	default:
+		return fmt.Errorf("unknown configuration change kind '%s'", change.Kind)
+	}
+}
+
+// applicationSnapshot is the in-memory state a failed atomic batch restores. Processes
+// are shared with the copied configuration and scaled in place, so their scales are
+// kept apart.
+type applicationSnapshot struct {
+	configuration *ApplicationConfiguration
+	scales        map[process.ProcessType]int
+	lastSequence  uint64
+	updatedAt     time.Time
+}
+
+func (a *Application) takeSnapshot() applicationSnapshot {
+	scales := make(map[process.ProcessType]int, len(a.configuration.processes))
+	for processType, proc := range a.configuration.processes {
+		scales[processType] = proc.Scale()
+	}
+	return applicationSnapshot{
+		configuration: a.copyConfiguration(),
+		scales:        scales,
+		lastSequence:  a.lastSequence,
+		updatedAt:     a.updatedAt,
+	}
+}
+
+func (a *Application) restoreSnapshot(snapshot applicationSnapshot) {
+	for processType, proc := range snapshot.configuration.processes {
+		// The scale was valid when taken, it cannot fail to be restored
+		_ = proc.SetScale(snapshot.scales[processType])
+	}
+	a.configuration = snapshot.configuration
+	// Events past the retention cap may have been dropped meanwhile, so the events of
+	// the undone changes are told apart by their sequence
+	a.events = slices.DeleteFunc(a.events, func(event DomainEvent) bool {
+		return event.Sequence() > snapshot.lastSequence
+	})
+	a.lastSequence = snapshot.lastSequence
+	a.updatedAt = snapshot.updatedAt
+}
@@ -0,0 +1,75 @@
+package app_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	app "github.com/dokku-mcp/dokku-mcp/internal/server-plugins/app/domain"
+	"github.com/dokku-mcp/dokku-mcp/internal/shared/process"
+)
+
+var _ = Describe("Application batch changes", func() {
+	var application *app.Application
+
+	BeforeEach(func() {
+		var err error
+		application, err = app.NewApplication("batch-app")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(application.AddDomain("existing.example.com")).To(Succeed())
+		Expect(application.Scale(process.ProcessTypeWeb, 1)).To(Succeed())
+		application.ClearEvents()
+	})
+
+	plan := []app.ConfigChange{
+		{Kind: app.ConfigChangeAddDomain, Key: "new.example.com"},
+		{Kind: app.ConfigChangeRemoveDomain, Key: "missing.example.com"},
+		{Kind: app.ConfigChangeScale, Key: "web", Scale: 3},
+		{Kind: app.ConfigChangeSetEnv, Key: "NODE_ENV", Value: "production"},
+	}
+
+	It("should report the outcome of each change and go on after a failure", func() {
+		result, err := application.ApplyChanges(plan, false)
+		Expect(err).To(MatchError(app.ErrDomainNotFound))
+
+		Expect(result.Outcomes).To(HaveLen(4))
+		Expect(result.AppliedCount()).To(Equal(3))
+		Expect(result.Failed()).To(HaveLen(1))
+		Expect(result.Failed()[0].Change.Key).To(Equal("missing.example.com"))
+		Expect(result.RolledBack).To(BeFalse())
+
+		Expect(application.GetDomains()).To(ContainElement("new.example.com"))
+		Expect(application.GetProcessScale(process.ProcessTypeWeb)).To(Equal(3))
+	})
+
+	It("should roll every applied change back when atomic", func() {
+		sequence := application.LastEventSequence()
+
+		result, err := application.ApplyChanges(plan, true)
+		Expect(err).To(HaveOccurred())
+
+		Expect(result.Outcomes).To(HaveLen(2))
+		Expect(result.AppliedCount()).To(BeZero())
+		Expect(result.RolledBack).To(BeTrue())
+
+		Expect(application.GetDomains()).To(Equal([]string{"existing.example.com"}))
+		Expect(application.GetProcessScale(process.ProcessTypeWeb)).To(Equal(1))
+		Expect(application.GetEvents()).To(BeEmpty())
+		Expect(application.LastEventSequence()).To(Equal(sequence))
+	})
+
+	It("should apply an atomic batch without failures entirely", func() {
+		result, err := application.ApplyChanges([]app.ConfigChange{
+			{Kind: app.ConfigChangeAddDomain, Key: "new.example.com"},
+			{Kind: app.ConfigChangeScale, Key: "worker", Scale: 2},
+		}, true)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.AppliedCount()).To(Equal(2))
+		Expect(application.GetProcessScale("worker")).To(Equal(2))
+	})
+
+	It("should reject an unknown change kind", func() {
+		result, err := application.ApplyChanges([]app.ConfigChange{{Kind: "drop_database", Key: "db"}}, false)
+		Expect(err).To(HaveOccurred())
+		Expect(result.Failed()).To(HaveLen(1))
+	})
+})
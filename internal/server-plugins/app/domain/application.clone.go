@@ -0,0 +1,156 @@
+package app
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/dokku-mcp/dokku-mcp/internal/shared"
+	"github.com/dokku-mcp/dokku-mcp/internal/shared/process"
+)
+
+// CloneOptions controls what CloneConfigurationTo copies. Buildpacks are always copied.
+type CloneOptions struct {
+	// CopyEnv copies the environment variables, except the ones holding secrets
+	CopyEnv bool
+	// IncludeSecrets also copies the environment variables whose key or value looks like a secret
+	IncludeSecrets bool
+	// CopyDomains copies the domains, prefixed with DomainPrefix when set (e.g. "staging-")
+	CopyDomains  bool
+	DomainPrefix string
+	// CopyScales copies the process scales
+	CopyScales bool
+	// Overwrite replaces the buildpacks, variables and scales the target already has with
+	// different values. Without it, any such conflict aborts the clone.
+	Overwrite bool
+}
+
+// CloneConfigurationTo copies the configuration of the application onto a target,
+// e.g. to provision a staging copy of a production app. The source is never mutated.
+// Every conflict is checked before the target is touched, so a failed clone leaves
+// it unchanged. The target emits the events of each change it goes through.
+func (a *Application) CloneConfigurationTo(target *Application, opts CloneOptions) error {
+	if target == nil {
+		return fmt.Errorf("clone target cannot be null")
+	}
+	if target == a || target.name.Equal(a.name) {
+		return fmt.Errorf("cannot clone %s onto itself", a.name.Value())
+	}
+
+	buildpacks := a.GetBuildpacks()
+	env := a.cloneableEnv(opts)
+	domains, err := a.cloneableDomains(opts)
+	if err != nil {
+		return err
+	}
+	scales := make(map[process.ProcessType]int)
+	if opts.CopyScales {
+		for processType, proc := range a.configuration.processes {
+			scales[processType] = proc.Scale()
+		}
+	}
+
+	if !opts.Overwrite {
+		if err := target.checkCloneConflicts(buildpacks, env, scales); err != nil {
+			return err
+		}
+	}
+
+	if len(buildpacks) > 0 && !slices.Equal(target.GetBuildpacks(), buildpacks) {
+		if err := target.SetBuildpack(buildpacks[0]); err != nil {
+			return err
+		}
+		for _, buildpack := range buildpacks[1:] {
+			if err := target.AddBuildpack(buildpack, 0); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, key := range slices.Sorted(maps.Keys(env)) {
+		if err := target.SetEnvironmentVariable(key, env[key]); err != nil {
+			return err
+		}
+	}
+
+	for _, domain := range domains {
+		if target.HasDomain(domain) {
+			continue
+		}
+		if err := target.AddDomain(domain); err != nil {
+			return err
+		}
+	}
+
+	for _, processType := range slices.Sorted(maps.Keys(scales)) {
+		if proc, exists := target.configuration.processes[processType]; exists && proc.Scale() == scales[processType] {
+			continue
+		}
+		if err := target.Scale(processType, scales[processType]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// cloneableEnv returns the environment variables to copy, leaving out secrets unless asked
+func (a *Application) cloneableEnv(opts CloneOptions) map[string]string {
+	env := make(map[string]string)
+	if !opts.CopyEnv {
+		return env
+	}
+
+	for key, value := range a.configuration.environmentVars {
+		// Dokku sets its own variables on the target
+		if key.IsInternal() {
+			continue
+		}
+		if !opts.IncludeSecrets {
+			if key.LooksLikeSecret() || value.LooksLikeSecret() {
+				continue
+			}
+		}
+		env[key.Value()] = value.Value()
+	}
+	return env
+}
+
+// cloneableDomains returns the domains to copy, rewritten with the prefix
+func (a *Application) cloneableDomains(opts CloneOptions) ([]string, error) {
+	if !opts.CopyDomains {
+		return nil, nil
+	}
+
+	domains := make([]string, 0, len(a.configuration.domains))
+	for _, domain := range a.configuration.domains {
+		rewritten, err := shared.NewDomainName(opts.DomainPrefix + domain.Value())
+		if err != nil {
+			return nil, fmt.Errorf("invalid cloned domain: %w", err)
+		}
+		domains = append(domains, rewritten.Value())
+	}
+	return domains, nil
+}
+
+// checkCloneConflicts rejects a clone that would replace configuration the target already has
+func (a *Application) checkCloneConflicts(buildpacks []string, env map[string]string, scales map[process.ProcessType]int) error {
+	existing := a.GetBuildpacks()
+	if len(buildpacks) > 0 && len(existing) > 0 && !slices.Equal(existing, buildpacks) {
+		return fmt.Errorf("%s already uses the buildpacks %v", a.name.Value(), existing)
+	}
+
+	for key, value := range a.configuration.environmentVars {
+		if cloned, exists := env[key.Value()]; exists && cloned != value.Value() {
+			return fmt.Errorf("%s already sets %s to a different value", a.name.Value(), key.Value())
+		}
+	}
+
+	for processType, proc := range a.configuration.processes {
+		if scale, exists := scales[processType]; exists && scale != proc.Scale() {
+			return fmt.Errorf("%s already scales %s to %d", a.name.Value(), processType, proc.Scale())
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,127 @@
+package app_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	app "github.com/dokku-mcp/dokku-mcp/internal/server-plugins/app/domain"
+)
+
+var _ = Describe("Application configuration cloning", func() {
+	var production, staging *app.Application
+
+	BeforeEach(func() {
+		var err error
+		production, err = app.NewApplication("api")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(production.SetBuildpack("heroku/nodejs")).To(Succeed())
+		Expect(production.AddBuildpack("heroku/python", 0)).To(Succeed())
+		Expect(production.AddDomain("api.example.com")).To(Succeed())
+		Expect(production.SetEnvironmentVariable("NODE_ENV", "production")).To(Succeed())
+		Expect(production.SetEnvironmentVariable("STRIPE_SECRET", "not-so-obvious")).To(Succeed())
+		Expect(production.SetEnvironmentVariable("DATABASE_URL", "postgres://api:hunter2@db/api")).To(Succeed())
+		Expect(production.Scale("web", 3)).To(Succeed())
+		production.ClearEvents()
+
+		staging, err = app.NewApplication("api-staging")
+		Expect(err).NotTo(HaveOccurred())
+		staging.ClearEvents()
+	})
+
+	It("should copy the configuration and emit the target events", func() {
+		Expect(production.CloneConfigurationTo(staging, app.CloneOptions{
+			CopyEnv:      true,
+			CopyDomains:  true,
+			DomainPrefix: "staging-",
+			CopyScales:   true,
+		})).To(Succeed())
+
+		Expect(staging.GetBuildpacks()).To(Equal([]string{"heroku/nodejs", "heroku/python"}))
+		Expect(staging.GetDomains()).To(Equal([]string{"staging-api.example.com"}))
+		Expect(staging.GetProcessScale("web")).To(Equal(3))
+
+		env := staging.ConfigData().Env
+		Expect(env).To(HaveKey("NODE_ENV"))
+		Expect(env).NotTo(HaveKey("STRIPE_SECRET"))
+		Expect(env).NotTo(HaveKey("DATABASE_URL"))
+
+		eventTypes := make([]string, 0)
+		for _, event := range staging.GetEvents() {
+			Expect(event.AggregateID()).To(Equal("api-staging"))
+			eventTypes = append(eventTypes, event.EventType())
+		}
+		Expect(eventTypes).To(Equal([]string{
+			"application.buildpack.changed",
+			"application.buildpack.added",
+			"application.domain.added",
+			"application.scaled",
+		}))
+	})
+
+	It("should never mutate the source", func() {
+		before := production.ConfigData()
+
+		Expect(production.CloneConfigurationTo(staging, app.CloneOptions{CopyEnv: true, CopyDomains: true, CopyScales: true})).To(Succeed())
+
+		Expect(production.ConfigData()).To(Equal(before))
+		Expect(production.GetEvents()).To(BeEmpty())
+	})
+
+	It("should copy secrets only when asked", func() {
+		Expect(production.CloneConfigurationTo(staging, app.CloneOptions{CopyEnv: true, IncludeSecrets: true})).To(Succeed())
+
+		Expect(staging.ConfigData().Env).To(HaveKey("STRIPE_SECRET"))
+		Expect(staging.ConfigData().Env).To(HaveKey("DATABASE_URL"))
+	})
+
+	It("should only copy buildpacks by default", func() {
+		Expect(production.CloneConfigurationTo(staging, app.CloneOptions{})).To(Succeed())
+
+		Expect(staging.GetBuildpacks()).To(HaveLen(2))
+		Expect(staging.GetDomains()).To(BeEmpty())
+		Expect(staging.ConfigData().Env).To(BeEmpty())
+		Expect(staging.GetProcessScale("web")).To(Equal(0))
+	})
+
+	Context("when the target is already configured", func() {
+		BeforeEach(func() {
+			Expect(staging.SetEnvironmentVariable("NODE_ENV", "staging")).To(Succeed())
+			Expect(staging.SetEnvironmentVariable("DEBUG", "true")).To(Succeed())
+			Expect(staging.Scale("web", 1)).To(Succeed())
+			staging.ClearEvents()
+		})
+
+		It("should refuse conflicting values without touching the target", func() {
+			err := production.CloneConfigurationTo(staging, app.CloneOptions{CopyEnv: true, CopyScales: true})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("NODE_ENV"))
+
+			Expect(staging.GetBuildpacks()).To(BeEmpty())
+			Expect(staging.ConfigData().Env["NODE_ENV"].Value()).To(Equal("staging"))
+			Expect(staging.GetEvents()).To(BeEmpty())
+		})
+
+		It("should merge when overwriting", func() {
+			Expect(production.CloneConfigurationTo(staging, app.CloneOptions{CopyEnv: true, CopyScales: true, Overwrite: true})).To(Succeed())
+
+			Expect(staging.ConfigData().Env["NODE_ENV"].Value()).To(Equal("production"))
+			Expect(staging.ConfigData().Env).To(HaveKey("DEBUG"))
+			Expect(staging.GetProcessScale("web")).To(Equal(3))
+		})
+
+		It("should merge non-conflicting values without overwriting", func() {
+			Expect(production.CloneConfigurationTo(staging, app.CloneOptions{CopyDomains: true, DomainPrefix: "staging-"})).To(Succeed())
+
+			Expect(staging.GetDomains()).To(Equal([]string{"staging-api.example.com"}))
+			Expect(staging.ConfigData().Env).To(HaveKey("DEBUG"))
+		})
+	})
+
+	It("should reject cloning onto itself", func() {
+		Expect(production.CloneConfigurationTo(production, app.CloneOptions{})).NotTo(Succeed())
+	})
+
+	It("should reject a null target", func() {
+		Expect(production.CloneConfigurationTo(nil, app.CloneOptions{})).NotTo(Succeed())
+	})
+})
@@ -11,15 +11,33 @@ const (
 	CommandAppsDestroy ApplicationCommand = "apps:destroy"
 	CommandAppsExists  ApplicationCommand = "apps:exists"
 	CommandAppsReport  ApplicationCommand = "apps:report"
+	CommandAppsRename  ApplicationCommand = "apps:rename"
 
 	// Configuration commands
 	CommandConfigShow ApplicationCommand = "config:show"
 	CommandConfigSet  ApplicationCommand = "config:set"
+	CommandConfigKeys ApplicationCommand = "config:keys"
+
+	// Domain commands
+	CommandDomainsReport ApplicationCommand = "domains:report"
+	CommandCertsReport   ApplicationCommand = "certs:report"
 
 	// Process management commands
 	CommandPsScale  ApplicationCommand = "ps:scale"
 	CommandPsReport ApplicationCommand = "ps:report"
 
+	// Report commands read by the application snapshot
+	CommandBuildpacksReport ApplicationCommand = "buildpacks:report"
+	CommandGitReport        ApplicationCommand = "git:report"
+	CommandProxyReport      ApplicationCommand = "proxy:report"
+	CommandSchedulerReport  ApplicationCommand = "scheduler:report"
+
+	// CommandDockerOptionsReport lists the --link options of the linked services
+	CommandDockerOptionsReport ApplicationCommand = "docker-options:report"
+	// Docker options changed on the application, such as its --user
+	CommandDockerOptionsAdd    ApplicationCommand = "docker-options:add"
+	CommandDockerOptionsRemove ApplicationCommand = "docker-options:remove"
+
 	// Logging commands
 	CommandLogs ApplicationCommand = "logs"
 )
@@ -28,8 +46,10 @@ const (
 func (c ApplicationCommand) IsValid() bool {
 	switch c {
 	case CommandAppsList, CommandAppsInfo, CommandAppsCreate, CommandAppsDestroy,
-		CommandAppsExists, CommandAppsReport, CommandConfigShow, CommandConfigSet,
-		CommandPsScale, CommandPsReport, CommandLogs:
+		CommandAppsExists, CommandAppsReport, CommandAppsRename, CommandConfigShow, CommandConfigSet,
+		CommandConfigKeys, CommandDomainsReport, CommandCertsReport, CommandPsScale, CommandPsReport,
+		CommandBuildpacksReport, CommandGitReport, CommandProxyReport, CommandSchedulerReport,
+		CommandDockerOptionsReport, CommandDockerOptionsAdd, CommandDockerOptionsRemove, CommandLogs:
 		return true
 	default:
 		return false
@@ -50,10 +70,21 @@ func GetAllowedCommands() []ApplicationCommand {
 		CommandAppsDestroy,
 		CommandAppsExists,
 		CommandAppsReport,
+		CommandAppsRename,
 		CommandConfigShow,
 		CommandConfigSet,
+		CommandConfigKeys,
+		CommandDomainsReport,
+		CommandCertsReport,
 		CommandPsScale,
 		CommandPsReport,
+		CommandBuildpacksReport,
+		CommandGitReport,
+		CommandProxyReport,
+		CommandSchedulerReport,
+		CommandDockerOptionsReport,
+		CommandDockerOptionsAdd,
+		CommandDockerOptionsRemove,
 		CommandLogs,
 	}
 }
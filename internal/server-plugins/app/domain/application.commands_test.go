@@ -18,10 +18,21 @@ var _ = Describe("ApplicationCommand", func() {
 					app.CommandAppsDestroy,
 					app.CommandAppsExists,
 					app.CommandAppsReport,
+					app.CommandAppsRename,
 					app.CommandConfigShow,
 					app.CommandConfigSet,
+					app.CommandConfigKeys,
+					app.CommandDomainsReport,
+					app.CommandCertsReport,
 					app.CommandPsScale,
 					app.CommandPsReport,
+					app.CommandBuildpacksReport,
+					app.CommandGitReport,
+					app.CommandProxyReport,
+					app.CommandSchedulerReport,
+					app.CommandDockerOptionsReport,
+					app.CommandDockerOptionsAdd,
+					app.CommandDockerOptionsRemove,
 					app.CommandLogs,
 				}
 
@@ -58,7 +69,7 @@ var _ = Describe("ApplicationCommand", func() {
 	Describe("GetAllowedCommands", func() {
 		It("should return all allowed commands", func() {
 			commands := app.GetAllowedCommands()
-			Expect(commands).To(HaveLen(11))
+			Expect(commands).To(HaveLen(22))
 			Expect(commands).To(ContainElements(
 				app.CommandAppsList,
 				app.CommandAppsInfo,
@@ -66,10 +77,21 @@ var _ = Describe("ApplicationCommand", func() {
 				app.CommandAppsDestroy,
 				app.CommandAppsExists,
 				app.CommandAppsReport,
+				app.CommandAppsRename,
 				app.CommandConfigShow,
 				app.CommandConfigSet,
+				app.CommandConfigKeys,
+				app.CommandDomainsReport,
+				app.CommandCertsReport,
 				app.CommandPsScale,
 				app.CommandPsReport,
+				app.CommandBuildpacksReport,
+				app.CommandGitReport,
+				app.CommandProxyReport,
+				app.CommandSchedulerReport,
+				app.CommandDockerOptionsReport,
+				app.CommandDockerOptionsAdd,
+				app.CommandDockerOptionsRemove,
 				app.CommandLogs,
 			))
 		})
@@ -0,0 +1,44 @@
+package app
+
+import (
+	"slices"
+)
+
+// GetScheduledTasks returns the scheduled tasks of the application, in declaration order
+func (a *Application) GetScheduledTasks() []*ScheduledTask {
+	return slices.Clone(a.configuration.scheduledTasks)
+}
+
+// AddScheduledTask schedules a command, e.g. "0 3 * * *" and "npm run cleanup".
+// The same command cannot be scheduled twice on the same schedule.
+func (a *Application) AddScheduledTask(schedule, command string) error {
+	task, err := NewScheduledTask(schedule, command)
+	if err != nil {
+		return err
+	}
+	if slices.ContainsFunc(a.configuration.scheduledTasks, task.Equal) {
+		return newOperationError(ErrScheduledTaskExists, "the task %s is already scheduled", task)
+	}
+
+	a.configuration.scheduledTasks = append(a.configuration.scheduledTasks, task)
+	a.updatedAt = a.clock.Now()
+	a.addEvent(NewScheduledTaskAddedEvent(a.name.Value(), task.Schedule(), task.Command(), a.clock.Now()))
+	return nil
+}
+
+// RemoveScheduledTask unschedules the command run on the given schedule
+func (a *Application) RemoveScheduledTask(schedule, command string) error {
+	task, err := NewScheduledTask(schedule, command)
+	if err != nil {
+		return err
+	}
+	index := slices.IndexFunc(a.configuration.scheduledTasks, task.Equal)
+	if index < 0 {
+		return newOperationError(ErrScheduledTaskNotFound, "the task %s is not scheduled", task)
+	}
+
+	a.configuration.scheduledTasks = slices.Delete(a.configuration.scheduledTasks, index, index+1)
+	a.updatedAt = a.clock.Now()
+	a.addEvent(NewScheduledTaskRemovedEvent(a.name.Value(), task.Schedule(), task.Command(), a.clock.Now()))
+	return nil
+}
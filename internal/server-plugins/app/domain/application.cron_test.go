@@ -0,0 +1,71 @@
+package app_test
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	app "github.com/dokku-mcp/dokku-mcp/internal/server-plugins/app/domain"
+)
+
+var _ = Describe("Application scheduled tasks", func() {
+	var application *app.Application
+
+	BeforeEach(func() {
+		var err error
+		application, err = app.NewApplication("cron-app")
+		Expect(err).NotTo(HaveOccurred())
+		application.ClearEvents()
+	})
+
+	It("should add a task and emit an event", func() {
+		Expect(application.AddScheduledTask("0 3 * * *", "npm run cleanup")).To(Succeed())
+
+		tasks := application.GetScheduledTasks()
+		Expect(tasks).To(HaveLen(1))
+		Expect(tasks[0].String()).To(Equal("0 3 * * * npm run cleanup"))
+		added, ok := application.GetEvents()[0].(*app.ScheduledTaskAddedEvent)
+		Expect(ok).To(BeTrue())
+		Expect(added.Schedule()).To(Equal("0 3 * * *"))
+		Expect(added.Command()).To(Equal("npm run cleanup"))
+	})
+
+	It("should reject the same task scheduled twice", func() {
+		Expect(application.AddScheduledTask("0 3 * * *", "npm run cleanup")).To(Succeed())
+
+		err := application.AddScheduledTask("0  3 * * *", "npm run cleanup")
+		Expect(errors.Is(err, app.ErrScheduledTaskExists)).To(BeTrue())
+		Expect(application.AddScheduledTask("0 4 * * *", "npm run cleanup")).To(Succeed())
+		Expect(application.GetScheduledTasks()).To(HaveLen(2))
+	})
+
+	It("should reject an invalid schedule", func() {
+		Expect(application.AddScheduledTask("every night", "npm run cleanup")).NotTo(Succeed())
+		Expect(application.GetEvents()).To(BeEmpty())
+	})
+
+	It("should remove a task and emit an event", func() {
+		Expect(application.AddScheduledTask("@daily", "npm run report")).To(Succeed())
+		application.ClearEvents()
+
+		Expect(application.RemoveScheduledTask("@daily", "npm run report")).To(Succeed())
+		Expect(application.GetScheduledTasks()).To(BeEmpty())
+		_, ok := application.GetEvents()[0].(*app.ScheduledTaskRemovedEvent)
+		Expect(ok).To(BeTrue())
+
+		err := application.RemoveScheduledTask("@daily", "npm run report")
+		Expect(errors.Is(err, app.ErrScheduledTaskNotFound)).To(BeTrue())
+	})
+
+	It("should restore the tasks on replay", func() {
+		Expect(application.AddScheduledTask("0 3 * * *", "npm run cleanup")).To(Succeed())
+		Expect(application.AddScheduledTask("@daily", "npm run report")).To(Succeed())
+		Expect(application.RemoveScheduledTask("0 3 * * *", "npm run cleanup")).To(Succeed())
+
+		replayed, err := app.ReplayApplication("cron-app", application.GetEvents())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(replayed.GetScheduledTasks()).To(HaveLen(1))
+		Expect(replayed.GetScheduledTasks()[0].Command()).To(Equal("npm run report"))
+	})
+})
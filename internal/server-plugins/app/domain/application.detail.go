@@ -0,0 +1,77 @@
+package app
+
+import (
+	"maps"
+	"slices"
+	"time"
+)
+
+// ProcessScaleData is the configured scale of a process for JSON serialization
+type ProcessScaleData struct {
+	ProcessType string `json:"process_type"`
+	Scale       int    `json:"scale"`
+}
+
+// HealthResultData is the outcome of the last health probe for JSON serialization
+type HealthResultData struct {
+	Passed     bool      `json:"passed"`
+	ObservedAt time.Time `json:"observed_at"`
+}
+
+// ApplicationDetail gathers what a dashboard shows of an application in one
+// resource: its status, with the domains and the last deployment, the configured
+// scale of each process and the last health probe
+type ApplicationDetail struct {
+	ApplicationStatus
+	// Processes are sorted by type, empty when no process is known
+	Processes      []ProcessScaleData `json:"processes"`
+	TotalInstances int                `json:"total_instances"`
+	// Health is omitted until a probe is recorded
+	Health *HealthResultData `json:"health,omitempty"`
+}
+
+// Status returns the status of the application, the deployment being omitted
+// for an application never deployed
+func (a *Application) Status() ApplicationStatus {
+	status := ApplicationStatus{
+		Name:                a.name.Value(),
+		State:               a.state.Value(),
+		CreatedAt:           a.createdAt,
+		UpdatedAt:           a.updatedAt,
+		IsRunning:           a.IsRunning(),
+		IsDeployed:          a.IsDeployed(),
+		Domains:             a.GetDomains(),
+		HealthChecksSkipped: a.LastDeploymentSkippedChecks(),
+	}
+	if deployment := a.DeploymentSummary(); deployment.DeploymentCount > 0 {
+		status.Deployment = &deployment
+	}
+	if reason, failedAt := a.LastFailure(); failedAt != nil {
+		status.LastFailure = &DeploymentFailureData{Reason: reason, FailedAt: *failedAt}
+		if failedChecks := a.LastCheckResults(); len(failedChecks) > 0 {
+			status.LastFailure.FailedChecks = failedChecks
+		}
+	}
+	return status
+}
+
+// Detail assembles the detail of the application from its current state
+func (a *Application) Detail() ApplicationDetail {
+	detail := ApplicationDetail{
+		ApplicationStatus: a.Status(),
+		Processes:         make([]ProcessScaleData, 0, len(a.configuration.processes)),
+	}
+
+	for _, processType := range slices.Sorted(maps.Keys(a.configuration.processes)) {
+		scale := a.configuration.processes[processType].Scale()
+		detail.Processes = append(detail.Processes, ProcessScaleData{ProcessType: processType.String(), Scale: scale})
+		if !processType.IsOneOff() {
+			detail.TotalInstances += scale
+		}
+	}
+
+	if a.lastHealth != nil {
+		detail.Health = &HealthResultData{Passed: a.lastHealth.Passed(), ObservedAt: a.lastHealth.ObservedAt()}
+	}
+	return detail
+}
@@ -0,0 +1,61 @@
+package app_test
+
+import (
+	"encoding/json"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	app "github.com/dokku-mcp/dokku-mcp/internal/server-plugins/app/domain"
+	"github.com/dokku-mcp/dokku-mcp/internal/shared"
+	"github.com/dokku-mcp/dokku-mcp/internal/shared/process"
+)
+
+var _ = Describe("Application Detail", func() {
+	It("should serialize a new application with empty sections", func() {
+		application, err := app.NewApplication("my-app")
+		Expect(err).NotTo(HaveOccurred())
+
+		detail := application.Detail()
+		Expect(detail.Name).To(Equal("my-app"))
+		Expect(detail.Processes).To(BeEmpty())
+		Expect(detail.Deployment).To(BeNil())
+		Expect(detail.Health).To(BeNil())
+
+		data, err := json.Marshal(detail)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).To(ContainSubstring(`"name":"my-app"`))
+		Expect(string(data)).To(ContainSubstring(`"domains":[]`))
+		Expect(string(data)).To(ContainSubstring(`"processes":[]`))
+		Expect(string(data)).NotTo(ContainSubstring(`"deployment"`))
+		Expect(string(data)).NotTo(ContainSubstring(`"health"`))
+	})
+
+	It("should gather the status, processes, deployment and health", func() {
+		application, err := app.NewApplication("my-app")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(application.AddDomain("my-app.example.com")).To(Succeed())
+		Expect(application.ScaleAll(map[process.ProcessType]int{
+			process.ProcessTypeWorker:  2,
+			process.ProcessTypeWeb:     3,
+			process.ProcessTypeRelease: 1,
+		})).To(Succeed())
+		Expect(application.Deploy(shared.MustNewGitRef("main"), nil)).To(Succeed())
+		Expect(application.CompleteDeployment()).To(Succeed())
+		observedAt := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+		application.RecordHealthResult(true, observedAt)
+
+		detail := application.Detail()
+		Expect(detail.IsRunning).To(BeTrue())
+		Expect(detail.Domains).To(Equal([]string{"my-app.example.com"}))
+		Expect(detail.Processes).To(Equal([]app.ProcessScaleData{
+			{ProcessType: "release", Scale: 1},
+			{ProcessType: "web", Scale: 3},
+			{ProcessType: "worker", Scale: 2},
+		}))
+		Expect(detail.TotalInstances).To(Equal(5))
+		Expect(detail.Deployment.GitRef).To(Equal("main"))
+		Expect(detail.Health).To(Equal(&app.HealthResultData{Passed: true, ObservedAt: observedAt}))
+	})
+})
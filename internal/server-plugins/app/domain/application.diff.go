@@ -0,0 +1,234 @@
+package app
+
+import (
+	"slices"
+	"sort"
+
+	"github.com/dokku-mcp/dokku-mcp/internal/shared"
+	"github.com/dokku-mcp/dokku-mcp/internal/shared/process"
+)
+
+// BuildpackChange is a change of the buildpack list between two configurations
+type BuildpackChange struct {
+	From []string `json:"from"`
+	To   []string `json:"to"`
+}
+
+// ProcessScaleDelta is a change of the scale of a process between two configurations.
+// A process missing from one side counts as scaled to 0.
+type ProcessScaleDelta struct {
+	ProcessType string `json:"process_type"`
+	From        int    `json:"from"`
+	To          int    `json:"to"`
+}
+
+// Delta returns the number of instances added, negative when scaling down
+func (d ProcessScaleDelta) Delta() int {
+	return d.To - d.From
+}
+
+// ConfigurationDiff lists what differs between two configurations.
+// Environment variables are reported by key only, their values never leave the aggregate.
+type ConfigurationDiff struct {
+	AddedDomains   []string            `json:"added_domains"`
+	RemovedDomains []string            `json:"removed_domains"`
+	AddedEnvKeys   []string            `json:"added_env_keys"`
+	ChangedEnvKeys []string            `json:"changed_env_keys"`
+	RemovedEnvKeys []string            `json:"removed_env_keys"`
+	Buildpacks     *BuildpackChange    `json:"buildpacks,omitempty"`
+	Processes      []ProcessScaleDelta `json:"processes"`
+}
+
+// IsEmpty returns true if both configurations are the same
+func (d ConfigurationDiff) IsEmpty() bool {
+	return len(d.AddedDomains) == 0 && len(d.RemovedDomains) == 0 &&
+		len(d.AddedEnvKeys) == 0 && len(d.ChangedEnvKeys) == 0 && len(d.RemovedEnvKeys) == 0 &&
+		d.Buildpacks == nil && len(d.Processes) == 0
+}
+
+// DiffConfiguration compares the current configuration with another one, typically the
+// desired or live state. Additions are what the other configuration has and this one lacks.
+func (a *Application) DiffConfiguration(other *ApplicationConfiguration) ConfigurationDiff {
+	current := a.configuration
+	diff := ConfigurationDiff{
+		AddedDomains:   make([]string, 0),
+		RemovedDomains: make([]string, 0),
+		AddedEnvKeys:   make([]string, 0),
+		ChangedEnvKeys: make([]string, 0),
+		RemovedEnvKeys: make([]string, 0),
+		Processes:      make([]ProcessScaleDelta, 0),
+	}
+	if other == nil {
+		other = &ApplicationConfiguration{}
+	}
+
+	for _, domain := range other.domains {
+		if !containsDomain(current.domains, domain) {
+			diff.AddedDomains = append(diff.AddedDomains, domain.Value())
+		}
+	}
+	for _, domain := range current.domains {
+		if !containsDomain(other.domains, domain) {
+			diff.RemovedDomains = append(diff.RemovedDomains, domain.Value())
+		}
+	}
+
+	for key, value := range other.environmentVars {
+		existing, exists := current.environmentVars[key]
+		switch {
+		case !exists:
+			diff.AddedEnvKeys = append(diff.AddedEnvKeys, key.Value())
+		case !existing.Equal(value):
+			diff.ChangedEnvKeys = append(diff.ChangedEnvKeys, key.Value())
+		}
+	}
+	for key := range current.environmentVars {
+		if _, exists := other.environmentVars[key]; !exists {
+			diff.RemovedEnvKeys = append(diff.RemovedEnvKeys, key.Value())
+		}
+	}
+	sort.Strings(diff.AddedEnvKeys)
+	sort.Strings(diff.ChangedEnvKeys)
+	sort.Strings(diff.RemovedEnvKeys)
+
+	from := buildpackValues(current)
+	to := buildpackValues(other)
+	if !slices.Equal(from, to) {
+		diff.Buildpacks = &BuildpackChange{From: from, To: to}
+	}
+
+	scales := make(map[string]ProcessScaleDelta)
+	for processType, proc := range current.processes {
+		scales[string(processType)] = ProcessScaleDelta{ProcessType: string(processType), From: proc.Scale()}
+	}
+	for processType, proc := range other.processes {
+		delta := scales[string(processType)]
+		delta.ProcessType = string(processType)
+		delta.To = proc.Scale()
+		scales[string(processType)] = delta
+	}
+	for _, processType := range sortedKeys(scales) {
+		if delta := scales[processType]; delta.Delta() != 0 {
+			diff.Processes = append(diff.Processes, delta)
+		}
+	}
+
+	return diff
+}
+
+func buildpackValues(configuration *ApplicationConfiguration) []string {
+	values := make([]string, len(configuration.buildpacks))
+	for i, buildpack := range configuration.buildpacks {
+		values[i] = buildpack.Value()
+	}
+	return values
+}
+
+// Equal reports whether two configurations hold the same settings, so that applying
+// one over the other is a no-op. Environment variables compare by key and value:
+// a changed secret is a change. Domains, port mappings and scheduled tasks compare
+// regardless of order, buildpacks in order since Dokku runs them in sequence. Like
+// DiffConfiguration, a missing process counts as one scaled to 0 without a command.
+func (c *ApplicationConfiguration) Equal(other *ApplicationConfiguration) bool {
+	if c == nil || other == nil {
+		return c == other
+	}
+
+	if !slices.Equal(buildpackValues(c), buildpackValues(other)) {
+		return false
+	}
+	if !sameElements(c.domains, other.domains, (*shared.DomainName).Equal) {
+		return false
+	}
+
+	if len(c.environmentVars) != len(other.environmentVars) {
+		return false
+	}
+	for key, value := range c.environmentVars {
+		otherValue, exists := other.environmentVars[key]
+		if !exists || !value.Equal(otherValue) {
+			return false
+		}
+	}
+
+	for processType := range mergedKeys(c.processes, other.processes) {
+		if !equalProcesses(c.processes[processType], other.processes[processType]) {
+			return false
+		}
+	}
+	for processType := range mergedKeys(c.replicaTargets, other.replicaTargets) {
+		if !equalOptional(c.replicaTargets[processType], other.replicaTargets[processType], (*ReplicaTarget).Equal) {
+			return false
+		}
+	}
+
+	return equalOptional(c.healthChecks, other.healthChecks, (*HealthCheck).Equal) &&
+		equalOptional(c.runAsUser, other.runAsUser, (*ContainerUser).Equal) &&
+		equalOptional(c.git, other.git, (*GitConfiguration).Equal) &&
+		sameElements(c.portMappings, other.portMappings, func(a, b shared.PortMapping) bool { return a == b }) &&
+		sameElements(c.scheduledTasks, other.scheduledTasks, (*ScheduledTask).Equal) &&
+		equalDockerOptions(c.dockerOptions, other.dockerOptions) &&
+		c.maintenanceEnabled == other.maintenanceEnabled
+}
+
+// equalProcesses compares the Dokku-visible settings of two processes, a nil process
+// being one scaled to 0 without a command
+func equalProcesses(a, b *process.Process) bool {
+	if a == nil || b == nil {
+		present := a
+		if present == nil {
+			present = b
+		}
+		return present == nil || (present.Scale() == 0 && !present.HasCommand() &&
+			present.ResourceLimits() == nil && present.RestartPolicy() == nil)
+	}
+
+	return a.Scale() == b.Scale() &&
+		equalOptional(a.Command(), b.Command(), (*process.ProcessCommand).Equal) &&
+		equalOptional(a.ResourceLimits(), b.ResourceLimits(), (*process.ResourceLimits).Equal) &&
+		a.RestartPolicy().Equal(b.RestartPolicy())
+}
+
+// equalDockerOptions compares the options of each phase in order, Docker applying the
+// last of conflicting flags
+func equalDockerOptions(a, b map[shared.DockerOptionPhase][]string) bool {
+	for phase := range mergedKeys(a, b) {
+		if !slices.Equal(a[phase], b[phase]) {
+			return false
+		}
+	}
+	return true
+}
+
+// equalOptional compares two optional values, two missing values being equal
+func equalOptional[T any](a, b *T, equal func(a, b *T) bool) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return equal(a, b)
+}
+
+// sameElements reports whether two lists of distinct elements hold the same elements
+func sameElements[T any](a, b []T, equal func(a, b T) bool) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for _, element := range a {
+		if !slices.ContainsFunc(b, func(other T) bool { return equal(element, other) }) {
+			return false
+		}
+	}
+	return true
+}
+
+// mergedKeys returns the keys present in either map
+func mergedKeys[K comparable, V any](a, b map[K]V) map[K]struct{} {
+	keys := make(map[K]struct{}, len(a)+len(b))
+	for key := range a {
+		keys[key] = struct{}{}
+	}
+	for key := range b {
+		keys[key] = struct{}{}
+	}
+	return keys
+}
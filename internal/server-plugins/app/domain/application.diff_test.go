@@ -0,0 +1,138 @@
+package app_test
+
+import (
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	app "github.com/dokku-mcp/dokku-mcp/internal/server-plugins/app/domain"
+	"github.com/dokku-mcp/dokku-mcp/internal/shared/process"
+)
+
+var _ = Describe("Application DiffConfiguration", func() {
+	var current, desired *app.Application
+
+	BeforeEach(func() {
+		var err error
+		current, err = app.NewApplication("my-app")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(current.AddDomain("old.example.com")).To(Succeed())
+		Expect(current.AddDomain("kept.example.com")).To(Succeed())
+		Expect(current.SetEnvironmentVariable("DATABASE_URL", "postgres://old-secret@db")).To(Succeed())
+		Expect(current.SetEnvironmentVariable("LOG_LEVEL", "info")).To(Succeed())
+		Expect(current.SetEnvironmentVariable("LEGACY", "1")).To(Succeed())
+		Expect(current.SetBuildpack("heroku/nodejs")).To(Succeed())
+		Expect(current.AddProcessForScaling(process.ProcessTypeWeb, 1)).To(Succeed())
+		Expect(current.AddProcessForScaling(process.ProcessTypeWorker, 2)).To(Succeed())
+
+		desired, err = app.NewApplication("my-app")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should report no difference for the same configuration", func() {
+		diff := current.DiffConfiguration(current.Configuration())
+		Expect(diff.IsEmpty()).To(BeTrue())
+	})
+
+	It("should list every difference", func() {
+		Expect(desired.AddDomain("kept.example.com")).To(Succeed())
+		Expect(desired.AddDomain("new.example.com")).To(Succeed())
+		Expect(desired.SetEnvironmentVariable("DATABASE_URL", "postgres://new-secret@db")).To(Succeed())
+		Expect(desired.SetEnvironmentVariable("LOG_LEVEL", "info")).To(Succeed())
+		Expect(desired.SetEnvironmentVariable("SENTRY_DSN", "https://key@sentry.io/1")).To(Succeed())
+		Expect(desired.AddBuildpack("heroku/apt", 0)).To(Succeed())
+		Expect(desired.AddBuildpack("heroku/nodejs", 0)).To(Succeed())
+		Expect(desired.AddProcessForScaling(process.ProcessTypeWeb, 3)).To(Succeed())
+		Expect(desired.AddProcessForScaling(process.ProcessTypeWorker, 2)).To(Succeed())
+		Expect(desired.AddProcessForScaling(process.ProcessTypeCron, 1)).To(Succeed())
+
+		diff := current.DiffConfiguration(desired.Configuration())
+
+		Expect(diff.IsEmpty()).To(BeFalse())
+		Expect(diff.AddedDomains).To(Equal([]string{"new.example.com"}))
+		Expect(diff.RemovedDomains).To(Equal([]string{"old.example.com"}))
+		Expect(diff.AddedEnvKeys).To(Equal([]string{"SENTRY_DSN"}))
+		Expect(diff.ChangedEnvKeys).To(Equal([]string{"DATABASE_URL"}))
+		Expect(diff.RemovedEnvKeys).To(Equal([]string{"LEGACY"}))
+		Expect(diff.Buildpacks).To(Equal(&app.BuildpackChange{
+			From: []string{"heroku/nodejs"},
+			To:   []string{"heroku/apt", "heroku/nodejs"},
+		}))
+		Expect(diff.Processes).To(Equal([]app.ProcessScaleDelta{
+			{ProcessType: "cron", From: 0, To: 1},
+			{ProcessType: "web", From: 1, To: 3},
+		}))
+		Expect(diff.Processes[1].Delta()).To(Equal(2))
+	})
+
+	It("should count missing processes as scaled to zero", func() {
+		diff := current.DiffConfiguration(desired.Configuration())
+		Expect(diff.Processes).To(Equal([]app.ProcessScaleDelta{
+			{ProcessType: "web", From: 1, To: 0},
+			{ProcessType: "worker", From: 2, To: 0},
+		}))
+		Expect(diff.Processes[1].Delta()).To(Equal(-2))
+	})
+
+	It("should never expose environment values", func() {
+		Expect(desired.SetEnvironmentVariable("DATABASE_URL", "postgres://new-secret@db")).To(Succeed())
+
+		data, err := json.Marshal(current.DiffConfiguration(desired.Configuration()))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).To(ContainSubstring("DATABASE_URL"))
+		Expect(string(data)).NotTo(ContainSubstring("secret"))
+	})
+})
+
+var _ = Describe("ApplicationConfiguration Equal", func() {
+	var first, second *app.Application
+
+	configure := func(application *app.Application, domains ...string) {
+		for _, domain := range domains {
+			Expect(application.AddDomain(domain)).To(Succeed())
+		}
+		Expect(application.SetEnvironmentVariable("LOG_LEVEL", "info")).To(Succeed())
+		Expect(application.SetBuildpack("heroku/nodejs")).To(Succeed())
+		Expect(application.AddProcessForScaling(process.ProcessTypeWeb, 2)).To(Succeed())
+	}
+
+	BeforeEach(func() {
+		var err error
+		first, err = app.NewApplication("my-app")
+		Expect(err).NotTo(HaveOccurred())
+		second, err = app.NewApplication("my-app")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should ignore the order of the domains", func() {
+		configure(first, "a.example.com", "b.example.com")
+		configure(second, "b.example.com", "a.example.com")
+
+		Expect(first.Configuration().Equal(second.Configuration())).To(BeTrue())
+		Expect(first.Configuration().Equal(first.Configuration())).To(BeTrue())
+	})
+
+	It("should tell a scale difference", func() {
+		configure(first, "a.example.com")
+		configure(second, "a.example.com")
+		Expect(second.Scale(process.ProcessTypeWeb, 3)).To(Succeed())
+
+		Expect(first.Configuration().Equal(second.Configuration())).To(BeFalse())
+	})
+
+	It("should compare the environment variable values", func() {
+		configure(first, "a.example.com")
+		configure(second, "a.example.com")
+		Expect(second.SetEnvironmentVariable("LOG_LEVEL", "debug")).To(Succeed())
+
+		Expect(first.Configuration().Equal(second.Configuration())).To(BeFalse())
+	})
+
+	It("should count a missing process as scaled to zero", func() {
+		Expect(second.AddProcessForScaling(process.ProcessTypeWorker, 0)).To(Succeed())
+
+		Expect(first.Configuration().Equal(second.Configuration())).To(BeTrue())
+		Expect(first.Configuration().Equal(nil)).To(BeFalse())
+	})
+})
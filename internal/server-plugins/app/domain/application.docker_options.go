@@ -0,0 +1,58 @@
+package app
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/dokku-mcp/dokku-mcp/internal/shared"
+)
+
+// GetDockerOptions returns the docker-options flags of a phase, in the order they were added
+func (a *Application) GetDockerOptions(phase shared.DockerOptionPhase) []string {
+	return slices.Clone(a.configuration.dockerOptions[phase])
+}
+
+// AddDockerOption passes a flag to the containers of a phase, e.g. "--add-host db:10.0.0.2"
+// on deploy. An option is added once per phase.
+func (a *Application) AddDockerOption(phase shared.DockerOptionPhase, option string) error {
+	phase, option, err := validateDockerOption(phase, option)
+	if err != nil {
+		return err
+	}
+	if slices.Contains(a.configuration.dockerOptions[phase], option) {
+		return newOperationError(ErrDockerOptionExists, "the %s docker option %s is already set", phase, option)
+	}
+
+	a.configuration.dockerOptions[phase] = append(a.configuration.dockerOptions[phase], option)
+	a.updatedAt = a.clock.Now()
+	a.addEvent(NewDockerOptionAddedEvent(a.name.Value(), string(phase), option, a.clock.Now()))
+	return nil
+}
+
+// RemoveDockerOption stops passing a flag to the containers of a phase
+func (a *Application) RemoveDockerOption(phase shared.DockerOptionPhase, option string) error {
+	phase, option, err := validateDockerOption(phase, option)
+	if err != nil {
+		return err
+	}
+	index := slices.Index(a.configuration.dockerOptions[phase], option)
+	if index < 0 {
+		return newOperationError(ErrDockerOptionNotFound, "the %s docker option %s is not set", phase, option)
+	}
+
+	a.configuration.dockerOptions[phase] = slices.Delete(a.configuration.dockerOptions[phase], index, index+1)
+	a.updatedAt = a.clock.Now()
+	a.addEvent(NewDockerOptionRemovedEvent(a.name.Value(), string(phase), option, a.clock.Now()))
+	return nil
+}
+
+func validateDockerOption(phase shared.DockerOptionPhase, option string) (shared.DockerOptionPhase, string, error) {
+	phase, err := shared.NewDockerOptionPhase(string(phase))
+	if err != nil {
+		return "", "", err
+	}
+	if err := shared.ValidateDockerOption(option); err != nil {
+		return "", "", err
+	}
+	return phase, strings.TrimSpace(option), nil
+}
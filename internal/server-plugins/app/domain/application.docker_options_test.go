@@ -0,0 +1,61 @@
+package app_test
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	app "github.com/dokku-mcp/dokku-mcp/internal/server-plugins/app/domain"
+	"github.com/dokku-mcp/dokku-mcp/internal/shared"
+)
+
+var _ = Describe("Application docker options", func() {
+	var application *app.Application
+
+	BeforeEach(func() {
+		var err error
+		application, err = app.NewApplication("options-app")
+		Expect(err).NotTo(HaveOccurred())
+		application.ClearEvents()
+	})
+
+	It("should add an option to a phase and emit an event", func() {
+		Expect(application.AddDockerOption(shared.DockerOptionPhaseDeploy, " --add-host db:10.0.0.2 ")).To(Succeed())
+
+		Expect(application.GetDockerOptions(shared.DockerOptionPhaseDeploy)).To(Equal([]string{"--add-host db:10.0.0.2"}))
+		Expect(application.GetDockerOptions(shared.DockerOptionPhaseRun)).To(BeEmpty())
+		added, ok := application.GetEvents()[0].(*app.DockerOptionAddedEvent)
+		Expect(ok).To(BeTrue())
+		Expect(added.Phase()).To(Equal("deploy"))
+		Expect(added.Option()).To(Equal("--add-host db:10.0.0.2"))
+	})
+
+	It("should reject an option set twice in the same phase", func() {
+		Expect(application.AddDockerOption(shared.DockerOptionPhaseBuild, "--no-cache")).To(Succeed())
+
+		err := application.AddDockerOption(shared.DockerOptionPhaseBuild, "--no-cache")
+		Expect(errors.Is(err, app.ErrDockerOptionExists)).To(BeTrue())
+		Expect(application.AddDockerOption(shared.DockerOptionPhaseRun, "--no-cache")).To(Succeed())
+	})
+
+	It("should reject an invalid phase or option", func() {
+		Expect(application.AddDockerOption("release", "--privileged")).NotTo(Succeed())
+		Expect(application.AddDockerOption(shared.DockerOptionPhaseDeploy, "db:10.0.0.2")).NotTo(Succeed())
+		Expect(application.GetEvents()).To(BeEmpty())
+	})
+
+	It("should remove an option and restore the options on replay", func() {
+		Expect(application.AddDockerOption(shared.DockerOptionPhaseDeploy, "--add-host db:10.0.0.2")).To(Succeed())
+		Expect(application.AddDockerOption(shared.DockerOptionPhaseDeploy, "-v /var/data:/data")).To(Succeed())
+		Expect(application.RemoveDockerOption(shared.DockerOptionPhaseDeploy, "--add-host db:10.0.0.2")).To(Succeed())
+
+		err := application.RemoveDockerOption(shared.DockerOptionPhaseDeploy, "--add-host db:10.0.0.2")
+		Expect(errors.Is(err, app.ErrDockerOptionNotFound)).To(BeTrue())
+
+		replayed, err := app.ReplayApplication("options-app", application.GetEvents())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(replayed.GetDockerOptions(shared.DockerOptionPhaseDeploy)).To(Equal([]string{"-v /var/data:/data"}))
+		Expect(replayed.Configuration().Equal(application.Configuration())).To(BeTrue())
+	})
+})
@@ -0,0 +1,97 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// PlannedCommand is a Dokku command an application change would run. Planned
+// commands are never executed by the domain, they are rendered for approval.
+type PlannedCommand struct {
+	Command  string   `json:"command"`
+	Args     []string `json:"args"`
+	Executed bool     `json:"executed"`
+}
+
+// Argv returns the command followed by its arguments
+func (c PlannedCommand) Argv() []string {
+	return append([]string{c.Command}, c.Args...)
+}
+
+// String returns the command line with the variable values masked, as they may be secrets
+func (c PlannedCommand) String() string {
+	args := make([]string, len(c.Args))
+	for i, arg := range c.Args {
+		if key, _, ok := strings.Cut(arg, "="); ok && c.Command == "config:set" {
+			arg = key + "=***"
+		}
+		args[i] = arg
+	}
+	return strings.Join(append([]string{c.Command}, args...), " ")
+}
+
+// Command returns the Dokku command applying the change to the named application
+func (c ConfigChange) Command(appName string) (PlannedCommand, error) {
+	var command string
+	var args []string
+	switch c.Kind {
+	case ConfigChangeAddDomain:
+		command, args = "domains:add", []string{c.Key}
+	case ConfigChangeRemoveDomain:
+		command, args = "domains:remove", []string{c.Key}
+	case ConfigChangeSetEnv:
+		command, args = "config:set", []string{c.Key + "=" + c.Value}
+	case ConfigChangeUnsetEnv:
+		command, args = "config:unset", []string{c.Key}
+	case ConfigChangeScale:
+		command, args = "ps:scale", []string{fmt.Sprintf("%s=%d", c.Key, c.Scale)}
+	case ConfigChangeSetBuildpack:
+		command, args = "buildpacks:set", []string{c.Key}
+	default:
+		return PlannedCommand{}, fmt.Errorf("unknown configuration change kind '%s'", c.Kind)
+	}
+	return PlannedCommand{Command: command, Args: append([]string{appName}, args...)}, nil
+}
+
+// DryRunChanges checks the changes against the application like ApplyChanges, then
+// undoes them, and returns the Dokku commands that would apply them. The application
+// and its events are left as they were. The returned error joins the failed changes.
+func (a *Application) DryRunChanges(changes []ConfigChange) ([]PlannedCommand, error) {
+	snapshot := a.takeSnapshot()
+	defer a.restoreSnapshot(snapshot)
+
+	commands := make([]PlannedCommand, 0, len(changes))
+	var errs []error
+	for _, change := range changes {
+		if err := a.applyChange(change); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", change, err))
+			continue
+		}
+		command, err := change.Command(a.name.Value())
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		commands = append(commands, command)
+	}
+	return commands, errors.Join(errs...)
+}
+
+// Commands renders the plan as the Dokku commands aligning the live system, in order.
+// A plan aligning the entity changes nothing live and has no commands to render.
+func (p ReconcilePlan) Commands(appName string) ([]PlannedCommand, error) {
+	if p.Direction == ReconcileFromLive {
+		return nil, fmt.Errorf("a plan aligning the application with its live state runs no Dokku command")
+	}
+
+	commands := make([]PlannedCommand, 0, len(p.Changes))
+	for _, change := range p.Changes {
+		command, err := change.Command(appName)
+		if err != nil {
+			return nil, err
+		}
+		commands = append(commands, command)
+	}
+	return commands, nil
+}
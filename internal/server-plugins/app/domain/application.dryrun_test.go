@@ -0,0 +1,73 @@
+package app_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	app "github.com/dokku-mcp/dokku-mcp/internal/server-plugins/app/domain"
+	"github.com/dokku-mcp/dokku-mcp/internal/shared/process"
+)
+
+var _ = Describe("Application dry run", func() {
+	var application *app.Application
+
+	BeforeEach(func() {
+		var err error
+		application, err = app.NewApplication("previewed-app")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(application.AddDomain("api.example.com")).To(Succeed())
+		application.ClearEvents()
+	})
+
+	It("should render the commands without applying the changes", func() {
+		before := application.ConfigData()
+
+		commands, err := application.DryRunChanges([]app.ConfigChange{
+			{Kind: app.ConfigChangeAddDomain, Key: "www.example.com"},
+			{Kind: app.ConfigChangeScale, Key: "web", Scale: 2},
+			{Kind: app.ConfigChangeSetEnv, Key: "API_TOKEN", Value: "s3cr3t"},
+		})
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(commands).To(HaveLen(3))
+		Expect(commands[0].Argv()).To(Equal([]string{"domains:add", "previewed-app", "www.example.com"}))
+		Expect(commands[1].Argv()).To(Equal([]string{"ps:scale", "previewed-app", "web=2"}))
+		Expect(commands[2].Argv()).To(Equal([]string{"config:set", "previewed-app", "API_TOKEN=s3cr3t"}))
+		Expect(commands[2].String()).To(Equal("config:set previewed-app API_TOKEN=***"))
+		for _, command := range commands {
+			Expect(command.Executed).To(BeFalse())
+		}
+
+		Expect(application.ConfigData()).To(Equal(before))
+		Expect(application.GetProcessScale(process.ProcessTypeWeb)).To(Equal(0))
+		Expect(application.GetEvents()).To(BeEmpty())
+	})
+
+	It("should report the changes the application would refuse", func() {
+		commands, err := application.DryRunChanges([]app.ConfigChange{
+			{Kind: app.ConfigChangeAddDomain, Key: "api.example.com"},
+			{Kind: app.ConfigChangeSetEnv, Key: "NODE_ENV", Value: "production"},
+		})
+
+		Expect(err).To(HaveOccurred())
+		Expect(commands).To(HaveLen(1))
+		Expect(commands[0].String()).To(Equal("config:set previewed-app NODE_ENV=***"))
+	})
+
+	It("should render a plan aligning the live system", func() {
+		plan := application.Reconcile(&app.LiveAppState{Domains: []string{"old.example.com"}}, app.ReconcileToLive)
+
+		commands, err := plan.Commands("previewed-app")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(commands).To(HaveLen(2))
+		Expect(commands[0].String()).To(Equal("domains:add previewed-app api.example.com"))
+		Expect(commands[1].String()).To(Equal("domains:remove previewed-app old.example.com"))
+	})
+
+	It("should not render a plan aligning the entity", func() {
+		plan := application.Reconcile(&app.LiveAppState{}, app.ReconcileFromLive)
+
+		_, err := plan.Commands("previewed-app")
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -1,7 +1,11 @@
 package app
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"slices"
+	"sync"
 	"time"
 
 	"github.com/dokku-mcp/dokku-mcp/internal/shared"
@@ -11,6 +15,9 @@ import (
 type Application struct {
 	name *ApplicationName
 
+	// clock timestamps every change and event
+	clock Clock
+
 	state     *ApplicationState
 	createdAt time.Time
 	updatedAt time.Time
@@ -19,14 +26,55 @@ type Application struct {
 
 	deploymentInfo *DeploymentInfo
 
+	// labels tag the application for the clients, Dokku knows nothing about them
+	labels map[string]string
+
+	// lastHealth is the outcome of the last probe, nil until one is recorded
+	// or after a new deployment
+	lastHealth *HealthResult
+
+	// globalDomains are the host global domains, each giving the app an
+	// auto-generated <app>.<global domain> vhost
+	globalDomains []*shared.DomainName
+
+	// deployMu guards the deploying flag and the deployment lifecycle mutations
+	deployMu  sync.Mutex
+	deploying bool
+	// releaseSlot gives back the deployment scheduler slot of the current deployment, if any
+	releaseSlot func()
+
+	// destroyed is set once the destruction is confirmed, saving the application destroys it
+	destroyed bool
+
 	events []DomainEvent
+	// lastSequence is the sequence number of the last recorded event
+	lastSequence uint64
+	// eventRetention caps the pending events, 0 meaning no cap
+	eventRetention int
+	// droppedEventCount counts the events dropped past the cap since the last ClearEvents
+	droppedEventCount int
+
+	// memoryBudget caps the memory the formation may request in bytes, 0 meaning no cap
+	memoryBudget int64
 }
 
 type ApplicationConfiguration struct {
-	buildpack       *shared.BuildpackName
+	buildpacks      []*shared.BuildpackName
 	domains         []*shared.DomainName
 	environmentVars map[shared.EnvVarKey]*shared.EnvVarValue
 	processes       map[process.ProcessType]*process.Process
+	replicaTargets  map[process.ProcessType]*ReplicaTarget
+	healthChecks    *HealthCheck
+	runAsUser       *ContainerUser
+	git             *GitConfiguration
+	// portMappings override the mappings Dokku detects, none meaning the detected ones apply
+	portMappings []shared.PortMapping
+	// scheduledTasks are the cron tasks of app.json, in declaration order
+	scheduledTasks []*ScheduledTask
+	// dockerOptions are the docker-options flags of each phase, in the order they were added
+	dockerOptions map[shared.DockerOptionPhase][]string
+	// maintenanceEnabled is true while the proxy serves the maintenance page
+	maintenanceEnabled bool
 }
 
 type DeploymentInfo struct {
@@ -35,22 +83,32 @@ type DeploymentInfo struct {
 	buildImage      *shared.DockerImage
 	runImage        *shared.DockerImage
 	deploymentCount int
+	checksSkipped   bool
+	rebuildRequired bool
+	// lastDeploymentDuration is the time from Deploy to its completion or failure
+	lastDeploymentDuration time.Duration
+	// lastFailureReason and lastFailureAt record the last failed deployment until one completes
+	lastFailureReason string
+	lastFailureAt     *time.Time
+	// lastCheckResults are the failed checks of the last failed deployment
+	lastCheckResults []CheckResult
 }
 
 type DomainEvent interface {
 	OccurredAt() time.Time
 	EventType() string
 	AggregateID() string
+	Sequence() uint64
 }
 
-func NewApplication(name string) (*Application, error) {
+func NewApplication(name string, opts ...ApplicationOption) (*Application, error) {
 	// Default to "exists" state for new applications
-	return NewApplicationWithState(name, StateExists)
+	return NewApplicationWithState(name, StateExists, opts...)
 }
 
 // NewApplicationWithState creates an application with a specific state
 // This is useful for repositories to create entities that reflect actual system state
-func NewApplicationWithState(name string, state StateValue) (*Application, error) {
+func NewApplicationWithState(name string, state StateValue, opts ...ApplicationOption) (*Application, error) {
 	appName, err := NewApplicationName(name)
 	if err != nil {
 		return nil, fmt.Errorf("unable to create application: %w", err)
@@ -62,23 +120,34 @@ func NewApplicationWithState(name string, state StateValue) (*Application, error
 	}
 
 	app := &Application{
-		name:      appName,
-		state:     applicationState,
-		createdAt: time.Now(),
-		updatedAt: time.Now(),
+		name:  appName,
+		clock: DefaultClock,
+		state: applicationState,
 		configuration: &ApplicationConfiguration{
+			buildpacks:      make([]*shared.BuildpackName, 0),
 			domains:         make([]*shared.DomainName, 0),
 			environmentVars: make(map[shared.EnvVarKey]*shared.EnvVarValue),
 			processes:       make(map[process.ProcessType]*process.Process),
+			replicaTargets:  make(map[process.ProcessType]*ReplicaTarget),
+			dockerOptions:   make(map[shared.DockerOptionPhase][]string),
+			healthChecks:    DefaultHealthCheck(),
+			git:             DefaultGitConfiguration(),
 		},
 		deploymentInfo: &DeploymentInfo{
 			deploymentCount: 0,
 		},
-		events: make([]DomainEvent, 0),
+		labels:         make(map[string]string),
+		events:         make([]DomainEvent, 0),
+		eventRetention: DefaultEventRetention,
+	}
+	for _, opt := range opts {
+		opt(app)
 	}
+	app.createdAt = app.clock.Now()
+	app.updatedAt = app.clock.Now()
 
 	// Publish creation event
-	app.addEvent(NewApplicationCreatedEvent(appName.Value(), time.Now()))
+	app.addEvent(NewApplicationCreatedEvent(appName.Value(), app.clock.Now()))
 
 	return app, nil
 }
@@ -95,39 +164,326 @@ func (a *Application) Configuration() *ApplicationConfiguration {
 	return a.copyConfiguration()
 }
 
+// DeploymentCancelledReason is the failure reason recorded when a deployment context is done
+const DeploymentCancelledReason = "cancelled"
+
+// Deploy records a new deployment and holds the deployment lock until
+// CompleteDeployment or FailDeployment is called
 func (a *Application) Deploy(gitRef *shared.GitRef, buildOpts *DeploymentOptions) error {
+	return a.DeployWithContext(context.Background(), gitRef, buildOpts)
+}
+
+// DeployWithContext records a new deployment like Deploy. A context already done
+// starts nothing. When a deployment scheduler is installed, it first waits for a
+// slot, which is held until the deployment ends. A context done later does not end
+// the deployment: the caller fails it with DeploymentCancelledReason, on its own
+// goroutine like every other change of the aggregate.
+func (a *Application) DeployWithContext(ctx context.Context, gitRef *shared.GitRef, buildOpts *DeploymentOptions) error {
 	if gitRef == nil {
 		return fmt.Errorf("git reference cannot be null")
 	}
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("deployment %s: %w", DeploymentCancelledReason, err)
+	}
 
-	a.deploymentInfo.currentGitRef = gitRef
-	now := time.Now()
-	a.deploymentInfo.lastDeployedAt = &now
-	a.deploymentInfo.deploymentCount++
+	release, err := acquireDeploymentSlot(ctx, a.name.Value())
+	if err != nil {
+		return err
+	}
 
+	a.deployMu.Lock()
+	defer a.deployMu.Unlock()
+
+	if err := a.beginDeployment(); err != nil {
+		release()
+		return err
+	}
+	a.releaseSlot = release
+
+	a.deploymentInfo.currentGitRef = gitRef
 	if buildOpts != nil {
 		a.deploymentInfo.buildImage = buildOpts.BuildImage
 		a.deploymentInfo.runImage = buildOpts.RunImage
 	}
 
-	a.updatedAt = time.Now()
-	a.addEvent(NewApplicationDeployedEvent(a.name.Value(), gitRef.Value(), time.Now()))
+	a.updatedAt = a.clock.Now()
+	a.addEvent(NewApplicationDeployedEvent(a.name.Value(), gitRef.Value(), a.clock.Now()))
 
 	return nil
 }
 
-// CompleteDeployment just sets state to running
+// DeployFromImage records a deployment of a prebuilt image, without any git reference.
+// Like Deploy, it holds the deployment lock until the deployment completes or fails.
+func (a *Application) DeployFromImage(image *shared.DockerImage, buildOpts *DeploymentOptions) error {
+	return a.DeployFromImageWithContext(context.Background(), image, buildOpts)
+}
+
+// DeployFromImageWithContext records a deployment of a prebuilt image like
+// DeployFromImage, waiting for a scheduler slot like DeployWithContext
+func (a *Application) DeployFromImageWithContext(ctx context.Context, image *shared.DockerImage, buildOpts *DeploymentOptions) error {
+	if image == nil {
+		return fmt.Errorf("docker image cannot be null")
+	}
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("deployment %s: %w", DeploymentCancelledReason, err)
+	}
+
+	release, err := acquireDeploymentSlot(ctx, a.name.Value())
+	if err != nil {
+		return err
+	}
+
+	a.deployMu.Lock()
+	defer a.deployMu.Unlock()
+
+	if err := a.beginDeployment(); err != nil {
+		release()
+		return err
+	}
+	a.releaseSlot = release
+
+	a.deploymentInfo.currentGitRef = nil
+	a.deploymentInfo.runImage = image
+	a.deploymentInfo.buildImage = nil
+	if buildOpts != nil {
+		a.deploymentInfo.buildImage = buildOpts.BuildImage
+	}
+
+	a.updatedAt = a.clock.Now()
+	a.addEvent(NewApplicationDeployedFromImageEvent(a.name.Value(), image.Value(), a.clock.Now()))
+
+	return nil
+}
+
+// Rebuild records a rebuild of the current git ref, as ps:rebuild does, without a new
+// ref to deploy. Like Deploy, it holds the deployment lock until the rebuild completes
+// or fails. An application never deployed from git cannot be rebuilt.
+func (a *Application) Rebuild(buildOpts *DeploymentOptions) error {
+	return a.RebuildWithContext(context.Background(), buildOpts)
+}
+
+// RebuildWithContext records a rebuild like Rebuild, waiting for a scheduler slot
+// like DeployWithContext
+func (a *Application) RebuildWithContext(ctx context.Context, buildOpts *DeploymentOptions) error {
+	a.deployMu.Lock()
+	gitRef := a.deploymentInfo.currentGitRef
+	a.deployMu.Unlock()
+	if gitRef == nil {
+		return newOperationError(ErrApplicationNotDeployed, "%s has no git ref to rebuild", a.name.Value())
+	}
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("deployment %s: %w", DeploymentCancelledReason, err)
+	}
+
+	release, err := acquireDeploymentSlot(ctx, a.name.Value())
+	if err != nil {
+		return err
+	}
+
+	a.deployMu.Lock()
+	defer a.deployMu.Unlock()
+
+	if err := a.beginDeployment(); err != nil {
+		release()
+		return err
+	}
+	a.releaseSlot = release
+
+	var noCache, forceClean bool
+	if buildOpts != nil {
+		noCache, forceClean = buildOpts.NoCache, buildOpts.ForceClean
+		if buildOpts.BuildImage != nil {
+			a.deploymentInfo.buildImage = buildOpts.BuildImage
+		}
+		if buildOpts.RunImage != nil {
+			a.deploymentInfo.runImage = buildOpts.RunImage
+		}
+	}
+
+	a.updatedAt = a.clock.Now()
+	a.addEvent(NewApplicationRebuiltEvent(a.name.Value(), gitRef.Value(), noCache, forceClean, a.clock.Now()))
+
+	return nil
+}
+
+// beginDeployment takes the deployment lock and records the deployment start.
+// The caller must hold deployMu.
+func (a *Application) beginDeployment() error {
+	if a.deploying {
+		return ErrDeploymentInProgress
+	}
+	a.deploying = true
+
+	// A redeploy clears a previous error
+	if a.state.IsError() {
+		if err := a.setState(StateExists); err != nil {
+			return err
+		}
+	}
+
+	now := a.clock.Now()
+	a.deploymentInfo.lastDeployedAt = &now
+	a.deploymentInfo.deploymentCount++
+	a.deploymentInfo.checksSkipped = a.configuration.healthChecks.IsSkipped()
+	a.deploymentInfo.rebuildRequired = false
+	a.deploymentInfo.lastDeploymentDuration = 0
+	// Probes of the previous release say nothing about the new one
+	a.lastHealth = nil
+
+	return nil
+}
+
+// CompleteDeployment sets state to running and releases the deployment lock. Nothing
+// changes unless a deployment is in progress and the application may run.
 func (a *Application) CompleteDeployment() error {
+	a.deployMu.Lock()
+	defer a.deployMu.Unlock()
+
+	if !a.deploying {
+		return newOperationError(ErrNoDeploymentInProgress, "%s has no deployment to complete", a.name.Value())
+	}
+	if !CanTransition(a.state.Value(), StateRunning) {
+		return newOperationError(ErrInvalidState, "cannot complete the deployment of %s from %s", a.name.Value(), a.state.Value())
+	}
+
+	duration := a.endDeployment()
+	a.deploymentInfo.lastFailureReason = ""
+	a.deploymentInfo.lastFailureAt = nil
+	a.deploymentInfo.lastCheckResults = nil
+	a.addEvent(NewApplicationDeploymentCompletedEvent(a.name.Value(), duration, a.clock.Now()))
 	return a.setState(StateRunning)
 }
 
-// FailDeployment sets state to error
+// FailDeployment sets state to error and releases the deployment lock
 func (a *Application) FailDeployment(reason string) error {
-	a.addEvent(NewApplicationDeploymentFailedEvent(a.name.Value(), reason, time.Now()))
+	a.deployMu.Lock()
+	defer a.deployMu.Unlock()
+
+	return a.failDeployment(reason)
+}
+
+// FailDeploymentChecks fails the deployment like FailDeployment, keeping the failed
+// attempts among the check results, e.g. parsed from the deploy output with
+// ParseCheckResults
+func (a *Application) FailDeploymentChecks(reason string, results []CheckResult) error {
+	a.deployMu.Lock()
+	defer a.deployMu.Unlock()
+
+	return a.failDeployment(reason, FailedChecks(results)...)
+}
+
+// failDeployment records the failure. The caller must hold deployMu.
+func (a *Application) failDeployment(reason string, failedChecks ...CheckResult) error {
+	duration := a.endDeployment()
+	failedAt := a.clock.Now()
+	a.deploymentInfo.lastFailureReason = reason
+	a.deploymentInfo.lastFailureAt = &failedAt
+	a.deploymentInfo.lastCheckResults = failedChecks
+	a.addEvent(NewApplicationDeploymentFailedEvent(a.name.Value(), reason, duration, failedAt, failedChecks...))
 	return a.setState(StateError)
 }
 
+// MarkUndeployed records that the application no longer has an active release, after
+// its deployment was torn down outside of a destroy. The git ref and images are cleared
+// and the state goes back to exists from any state, the deployment count staying as
+// history. Undeploying an application with nothing deployed is a no-op.
+func (a *Application) MarkUndeployed(reason string) error {
+	a.deployMu.Lock()
+	defer a.deployMu.Unlock()
+
+	if a.deploying {
+		return ErrDeploymentInProgress
+	}
+	info := a.deploymentInfo
+	if a.state.Value() == StateExists && info.currentGitRef == nil && info.buildImage == nil && info.runImage == nil {
+		return nil
+	}
+
+	if err := a.setState(StateExists); err != nil {
+		return err
+	}
+	a.clearRelease()
+	a.addEvent(NewApplicationUndeployedEvent(a.name.Value(), reason, a.clock.Now()))
+	return nil
+}
+
+// Destroy records the destruction of the application, carried out when it is saved.
+// Like the Dokku prompt, the confirmation must repeat the application name, so a
+// destroy aimed at the wrong application is refused. A deploying application cannot
+// be destroyed, and destroying twice is a no-op.
+func (a *Application) Destroy(confirmation string) error {
+	a.deployMu.Lock()
+	defer a.deployMu.Unlock()
+
+	if confirmation != a.name.Value() {
+		return newOperationError(ErrDestroyNotConfirmed, "destroying %s requires confirming its name, got %q", a.name.Value(), confirmation)
+	}
+	if a.deploying {
+		return ErrDeploymentInProgress
+	}
+	if a.destroyed {
+		return nil
+	}
+
+	a.destroyed = true
+	a.updatedAt = a.clock.Now()
+	a.addEvent(NewApplicationDestroyedEvent(a.name.Value(), a.clock.Now()))
+	return nil
+}
+
+// IsDestroyed returns true once the destruction of the application is recorded
+func (a *Application) IsDestroyed() bool {
+	return a.destroyed
+}
+
+// clearRelease forgets what the last deployment released
+func (a *Application) clearRelease() {
+	a.deploymentInfo.currentGitRef = nil
+	a.deploymentInfo.buildImage = nil
+	a.deploymentInfo.runImage = nil
+	a.deploymentInfo.rebuildRequired = false
+	a.lastHealth = nil
+}
+
+// endDeployment releases the deployment lock and the scheduler slot and returns how
+// long the deployment took. The caller must hold deployMu.
+func (a *Application) endDeployment() time.Duration {
+	a.deploying = false
+	if a.releaseSlot != nil {
+		a.releaseSlot()
+		a.releaseSlot = nil
+	}
+
+	var duration time.Duration
+	if a.deploymentInfo.lastDeployedAt != nil {
+		duration = max(a.clock.Now().Sub(*a.deploymentInfo.lastDeployedAt), 0)
+	}
+	a.deploymentInfo.lastDeploymentDuration = duration
+	return duration
+}
+
+// Stop sets state to stopped
+func (a *Application) Stop() error {
+	return a.setState(StateStopped)
+}
+
+// Start sets a stopped application back to running
+func (a *Application) Start() error {
+	return a.setState(StateRunning)
+}
+
 func (a *Application) Scale(processType process.ProcessType, instances int) error {
+	processType, err := process.NormalizeProcessType(processType)
+	if err != nil {
+		return fmt.Errorf("invalid process type: %w", err)
+	}
+	if err := processType.ValidateScale(instances); err != nil {
+		return err
+	}
+	if err := a.checkMemoryBudget(map[process.ProcessType]int{processType: instances}, nil); err != nil {
+		return err
+	}
+
 	proc, exists := a.configuration.processes[processType]
 	if !exists {
 		// Create a process for scaling (command will be determined from Procfile later)
@@ -136,94 +492,498 @@ func (a *Application) Scale(processType process.ProcessType, instances int) erro
 			return fmt.Errorf("unable to create process: %w", err)
 		}
 		a.configuration.processes[processType] = proc
-		a.updatedAt = time.Now()
-		a.addEvent(NewApplicationScaledEvent(a.name.Value(), string(processType), 0, instances, time.Now()))
+		a.updatedAt = a.clock.Now()
+		a.addEvent(NewApplicationScaledEvent(a.name.Value(), string(processType), 0, instances, a.clock.Now()))
 		return nil
 	}
 
 	oldScale := proc.Scale()
-	err := proc.SetScale(instances)
+	err = proc.SetScale(instances)
 	if err != nil {
 		return err
 	}
-	a.updatedAt = time.Now()
-	a.addEvent(NewApplicationScaledEvent(a.name.Value(), string(processType), oldScale, instances, time.Now()))
+	a.updatedAt = a.clock.Now()
+	a.addEvent(NewApplicationScaledEvent(a.name.Value(), string(processType), oldScale, instances, a.clock.Now()))
 
 	return nil
 }
 
+// ScaleAll scales several processes in one operation, like `ps:scale app web=3 worker=2`.
+// Every count is validated before any process is touched, so a single invalid
+// count, or a formation over the host memory budget, aborts the whole batch.
+func (a *Application) ScaleAll(scales map[process.ProcessType]int) error {
+	if len(scales) == 0 {
+		return fmt.Errorf("at least one process scale is required")
+	}
+
+	normalized := make(map[process.ProcessType]int, len(scales))
+	for processType, instances := range scales {
+		normalizedType, err := process.NormalizeProcessType(processType)
+		if err != nil {
+			return fmt.Errorf("invalid process type: %w", err)
+		}
+		if _, duplicate := normalized[normalizedType]; duplicate {
+			return fmt.Errorf("process %s is scaled more than once", normalizedType)
+		}
+		if _, err := process.NewProcessScale(instances); err != nil {
+			return fmt.Errorf("invalid scale for process %s: %w", processType, err)
+		}
+		if err := normalizedType.ValidateScale(instances); err != nil {
+			return err
+		}
+		normalized[normalizedType] = instances
+	}
+	if err := a.checkMemoryBudget(normalized, nil); err != nil {
+		return err
+	}
+
+	processTypes := make([]process.ProcessType, 0, len(normalized))
+	for processType := range normalized {
+		processTypes = append(processTypes, processType)
+	}
+	slices.Sort(processTypes)
+
+	now := a.clock.Now()
+	for _, processType := range processTypes {
+		instances := normalized[processType]
+		oldScale := 0
+		if proc, exists := a.configuration.processes[processType]; exists {
+			oldScale = proc.Scale()
+			if err := proc.SetScale(instances); err != nil {
+				return err
+			}
+		} else {
+			proc, err := process.NewProcessForScaling(processType, instances)
+			if err != nil {
+				return fmt.Errorf("unable to create process: %w", err)
+			}
+			a.configuration.processes[processType] = proc
+		}
+		a.addEvent(NewApplicationScaledEvent(a.name.Value(), string(processType), oldScale, instances, now))
+	}
+	a.updatedAt = now
+
+	return nil
+}
+
+// AddDomain adds a custom domain. Adding the vhost Dokku already generates from
+// a global domain is a no-op, so that it is not configured twice.
 func (a *Application) AddDomain(domainName string) error {
 	domainVO, err := shared.NewDomainName(domainName)
 	if err != nil {
-		return fmt.Errorf("invalid domain: %w", err)
+		return newOperationError(ErrInvalidDomain, "invalid domain: %w", err)
+	}
+
+	if a.isGlobalVhost(domainVO) {
+		return nil
 	}
 
 	for _, existingDomain := range a.configuration.domains {
 		if existingDomain.Equal(domainVO) {
-			return fmt.Errorf("the domain %s already exists", domainName)
+			return newOperationError(ErrDomainAlreadyExists, "the domain %s already exists", domainName)
 		}
 	}
 
 	a.configuration.domains = append(a.configuration.domains, domainVO)
-	a.updatedAt = time.Now()
-	a.addEvent(NewDomainAddedEvent(a.name.Value(), domainName, time.Now()))
+	a.updatedAt = a.clock.Now()
+	a.addEvent(NewDomainAddedEvent(a.name.Value(), domainName, a.clock.Now()))
+
+	return nil
+}
 
+// SetGlobalDomains records the host global domains, as listed by domains:report --global
+func (a *Application) SetGlobalDomains(domains []string) error {
+	globalDomains := make([]*shared.DomainName, 0, len(domains))
+	for _, domain := range domains {
+		domainVO, err := shared.NewDomainName(domain)
+		if err != nil {
+			return fmt.Errorf("invalid global domain: %w", err)
+		}
+		globalDomains = append(globalDomains, domainVO)
+	}
+
+	a.globalDomains = globalDomains
 	return nil
 }
 
+// GlobalVhosts returns the vhosts Dokku generates for the app from the global domains
+func (a *Application) GlobalVhosts() []string {
+	vhosts := make([]string, len(a.globalDomains))
+	for i, globalDomain := range a.globalDomains {
+		vhosts[i] = a.name.Value() + "." + globalDomain.Value()
+	}
+	return vhosts
+}
+
+func (a *Application) isGlobalVhost(domain *shared.DomainName) bool {
+	return slices.Contains(a.GlobalVhosts(), domain.Value())
+}
+
 func (a *Application) RemoveDomain(domainName string) error {
 	domainVO, err := shared.NewDomainName(domainName)
 	if err != nil {
-		return fmt.Errorf("invalid domain: %w", err)
+		return newOperationError(ErrInvalidDomain, "invalid domain: %w", err)
 	}
 
 	for i, existingDomain := range a.configuration.domains {
 		if existingDomain.Equal(domainVO) {
 			// Delete the domain
 			a.configuration.domains = append(a.configuration.domains[:i], a.configuration.domains[i+1:]...)
-			a.updatedAt = time.Now()
-			a.addEvent(NewDomainRemovedEvent(a.name.Value(), domainName, time.Now()))
+			a.updatedAt = a.clock.Now()
+			a.addEvent(NewDomainRemovedEvent(a.name.Value(), domainName, a.clock.Now()))
 			return nil
 		}
 	}
 
-	return fmt.Errorf("the domain %s doesn't exist", domainName)
+	return newOperationError(ErrDomainNotFound, "the domain %s doesn't exist", domainName)
+}
+
+// EnsureDomain adds the domain unless it is already configured, reporting whether it was added.
+// Unlike AddDomain, an existing domain is not an error, which suits declarative reconciliation.
+func (a *Application) EnsureDomain(domainName string) (bool, error) {
+	count := len(a.configuration.domains)
+	if err := a.AddDomain(domainName); err != nil {
+		if errors.Is(err, ErrDomainAlreadyExists) {
+			return false, nil
+		}
+		return false, err
+	}
+	// A global vhost is accepted without being configured
+	return len(a.configuration.domains) > count, nil
 }
 
+// EnsureDomainAbsent removes the domain if it is configured, reporting whether it was removed
+func (a *Application) EnsureDomainAbsent(domainName string) (bool, error) {
+	if err := a.RemoveDomain(domainName); err != nil {
+		if errors.Is(err, ErrDomainNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// SetBuildpack replaces every buildpack with a single one. Setting the only current
+// buildpack again is a no-op, recording no event, though the name is still validated.
 func (a *Application) SetBuildpack(buildpackName string) error {
 	buildpackVO, err := shared.NewBuildpackName(buildpackName)
 	if err != nil {
-		return fmt.Errorf("invalid buildpack: %w", err)
+		return newOperationError(ErrInvalidBuildpack, "invalid buildpack: %w", err)
+	}
+	if current := a.configuration.buildpacks; len(current) == 1 && current[0].Equal(buildpackVO) {
+		return nil
+	}
+
+	a.configuration.buildpacks = []*shared.BuildpackName{buildpackVO}
+	a.updatedAt = a.clock.Now()
+	a.addEvent(NewBuildpackChangedEvent(a.name.Value(), buildpackName, a.clock.Now()))
+
+	return nil
+}
+
+// AddBuildpack inserts a buildpack at a 1-based index, as buildpacks:add --index does.
+// An index of 0 appends the buildpack after the existing ones.
+func (a *Application) AddBuildpack(buildpackName string, index int) error {
+	buildpackVO, err := shared.NewBuildpackName(buildpackName)
+	if err != nil {
+		return newOperationError(ErrInvalidBuildpack, "invalid buildpack: %w", err)
 	}
 
-	a.configuration.buildpack = buildpackVO
-	a.updatedAt = time.Now()
-	a.addEvent(NewBuildpackChangedEvent(a.name.Value(), buildpackName, time.Now()))
+	count := len(a.configuration.buildpacks)
+	if index < 0 || index > count+1 {
+		return fmt.Errorf("invalid buildpack index %d: must be between 1 and %d, or 0 to append", index, count+1)
+	}
+	for _, existing := range a.configuration.buildpacks {
+		if existing.Equal(buildpackVO) {
+			return newOperationError(ErrBuildpackAlreadyExists, "the buildpack %s already exists", buildpackName)
+		}
+	}
+
+	if index == 0 {
+		index = count + 1
+	}
+	a.configuration.buildpacks = slices.Insert(a.configuration.buildpacks, index-1, buildpackVO)
+	a.updatedAt = a.clock.Now()
+	a.addEvent(NewBuildpackAddedEvent(a.name.Value(), buildpackName, index, a.clock.Now()))
 
 	return nil
 }
 
+// RemoveBuildpack removes a buildpack, keeping the order of the others
+func (a *Application) RemoveBuildpack(buildpackName string) error {
+	buildpackVO, err := shared.NewBuildpackName(buildpackName)
+	if err != nil {
+		return newOperationError(ErrInvalidBuildpack, "invalid buildpack: %w", err)
+	}
+
+	for i, existing := range a.configuration.buildpacks {
+		if existing.Equal(buildpackVO) {
+			a.configuration.buildpacks = slices.Delete(a.configuration.buildpacks, i, i+1)
+			a.updatedAt = a.clock.Now()
+			a.addEvent(NewBuildpackRemovedEvent(a.name.Value(), buildpackName, a.clock.Now()))
+			return nil
+		}
+	}
+
+	return newOperationError(ErrBuildpackNotFound, "the buildpack %s doesn't exist", buildpackName)
+}
+
+// GetBuildpacks returns the buildpacks in the order they run
+func (a *Application) GetBuildpacks() []string {
+	buildpacks := make([]string, len(a.configuration.buildpacks))
+	for i, buildpackVO := range a.configuration.buildpacks {
+		buildpacks[i] = buildpackVO.Value()
+	}
+	return buildpacks
+}
+
+// SetEnvironmentVariable sets a variable. The variables Dokku manages are refused.
 func (a *Application) SetEnvironmentVariable(key, value string) error {
 	envKey, err := shared.NewEnvVarKey(key)
 	if err != nil {
 		return err
 	}
-	envValue := shared.NewEnvVarValue(value)
+	if envKey.IsInternal() {
+		return newOperationError(ErrInternalEnvVar, "the environment variable %s is managed by Dokku and cannot be set", key)
+	}
+
+	a.configuration.environmentVars[*envKey] = shared.NewEnvVarValue(value)
+	a.updatedAt = a.clock.Now()
+
+	return nil
+}
+
+// LoadEnvironmentVariable records a variable read from Dokku, internal ones included
+func (a *Application) LoadEnvironmentVariable(key, value string) error {
+	envKey, err := shared.NewEnvVarKey(key)
+	if err != nil {
+		return err
+	}
+
+	a.configuration.environmentVars[*envKey] = shared.NewEnvVarValue(value)
+	return nil
+}
+
+// GetEnvironmentVariables returns the variables by key, leaving out the ones Dokku
+// manages unless includeInternal is set
+func (a *Application) GetEnvironmentVariables(includeInternal bool) map[string]string {
+	env := make(map[string]string, len(a.configuration.environmentVars))
+	for key, value := range a.configuration.environmentVars {
+		if !includeInternal && key.IsInternal() {
+			continue
+		}
+		env[key.Value()] = value.Value()
+	}
+	return env
+}
+
+// UnsetEnvironmentVariable removes an environment variable. The variables Dokku manages are refused.
+func (a *Application) UnsetEnvironmentVariable(key string) error {
+	envKey, err := shared.NewEnvVarKey(key)
+	if err != nil {
+		return err
+	}
+	if envKey.IsInternal() {
+		return newOperationError(ErrInternalEnvVar, "the environment variable %s is managed by Dokku and cannot be unset", key)
+	}
+	if _, exists := a.configuration.environmentVars[*envKey]; !exists {
+		return fmt.Errorf("the environment variable %s is not set", key)
+	}
 
-	a.configuration.environmentVars[*envKey] = envValue
-	a.updatedAt = time.Now()
+	delete(a.configuration.environmentVars, *envKey)
+	a.updatedAt = a.clock.Now()
 
 	return nil
 }
 
+// SetHealthChecks enables zero-downtime checks with the given settings
+func (a *Application) SetHealthChecks(wait, timeout time.Duration, attempts int) error {
+	healthCheck, err := NewHealthCheck(wait, timeout, attempts)
+	if err != nil {
+		return fmt.Errorf("invalid health checks: %w", err)
+	}
+
+	a.changeHealthChecks(healthCheck)
+	return nil
+}
+
+// SkipHealthChecks disables zero-downtime checks, so deploys go straight to running
+func (a *Application) SkipHealthChecks() {
+	a.changeHealthChecks(SkippedHealthCheck())
+}
+
+// GetHealthChecks returns the zero-downtime checks configuration
+func (a *Application) GetHealthChecks() *HealthCheck {
+	return a.configuration.healthChecks
+}
+
+// DeploymentInProgress returns true between Deploy and CompleteDeployment or FailDeployment
+func (a *Application) DeploymentInProgress() bool {
+	a.deployMu.Lock()
+	defer a.deployMu.Unlock()
+
+	return a.deploying
+}
+
+// LastDeploymentSkippedChecks reports whether checks were skipped for the last deployment
+func (a *Application) LastDeploymentSkippedChecks() bool {
+	return a.deploymentInfo.checksSkipped
+}
+
+// LastDeploymentDuration returns how long the last finished deployment took, zero
+// while a deployment is in progress or if none finished
+func (a *Application) LastDeploymentDuration() time.Duration {
+	return a.deploymentInfo.lastDeploymentDuration
+}
+
+// SetRunAsUser makes containers run as the given uid or username.
+// The change only applies to new containers, so a rebuild is required.
+func (a *Application) SetRunAsUser(user string) error {
+	containerUser, err := NewContainerUser(user)
+	if err != nil {
+		return err
+	}
+	if containerUser.Equal(a.configuration.runAsUser) {
+		return nil
+	}
+
+	previousUser := a.runAsUserValue()
+	a.configuration.runAsUser = containerUser
+	a.deploymentInfo.rebuildRequired = true
+	a.updatedAt = a.clock.Now()
+	a.addEvent(NewRunAsUserChangedEvent(a.name.Value(), previousUser, containerUser.Value(), a.clock.Now()))
+
+	return nil
+}
+
+// ClearRunAsUser restores the image default user
+func (a *Application) ClearRunAsUser() {
+	if a.configuration.runAsUser == nil {
+		return
+	}
+
+	previousUser := a.runAsUserValue()
+	a.configuration.runAsUser = nil
+	a.deploymentInfo.rebuildRequired = true
+	a.updatedAt = a.clock.Now()
+	a.addEvent(NewRunAsUserChangedEvent(a.name.Value(), previousUser, "", a.clock.Now()))
+}
+
+func (a *Application) runAsUserValue() string {
+	if a.configuration.runAsUser == nil {
+		return ""
+	}
+	return a.configuration.runAsUser.Value()
+}
+
+// GitConfiguration returns the git settings of the application
+func (a *Application) GitConfiguration() *GitConfiguration {
+	return a.configuration.git
+}
+
+// SetDeployBranch sets the branch a push deploys, as git:set deploy-branch does. The
+// name must be a valid Git branch, it is refused before any command is built otherwise.
+func (a *Application) SetDeployBranch(branch string) error {
+	return a.changeGitConfiguration(branch, a.configuration.git.revEnvVar, a.configuration.git.keepGitDir)
+}
+
+// SetRevEnvVar sets the variable the deployed revision is exposed in, or disables it when empty
+func (a *Application) SetRevEnvVar(name string) error {
+	return a.changeGitConfiguration(a.configuration.git.deployBranch, name, a.configuration.git.keepGitDir)
+}
+
+// SetKeepGitDir sets whether the .git directory is kept in the build context
+func (a *Application) SetKeepGitDir(keep bool) error {
+	return a.changeGitConfiguration(a.configuration.git.deployBranch, a.configuration.git.revEnvVar, keep)
+}
+
+// MaintenanceEnabled returns true if the app is in maintenance mode
+func (a *Application) MaintenanceEnabled() bool {
+	return a.configuration.maintenanceEnabled
+}
+
+// EnableMaintenance puts a deployed app in maintenance mode, as maintenance:enable does.
+// Enabling it again is a no-op.
+func (a *Application) EnableMaintenance() error {
+	if !a.IsDeployed() {
+		return fmt.Errorf("cannot enable maintenance: the application %s is not deployed", a.name.Value())
+	}
+	if a.configuration.maintenanceEnabled {
+		return nil
+	}
+
+	a.configuration.maintenanceEnabled = true
+	a.updatedAt = a.clock.Now()
+	a.addEvent(NewMaintenanceEnabledEvent(a.name.Value(), a.clock.Now()))
+	return nil
+}
+
+// DisableMaintenance takes the app out of maintenance mode, as maintenance:disable does.
+// Disabling it when not in maintenance is a no-op.
+func (a *Application) DisableMaintenance() error {
+	if !a.configuration.maintenanceEnabled {
+		return nil
+	}
+
+	a.configuration.maintenanceEnabled = false
+	a.updatedAt = a.clock.Now()
+	a.addEvent(NewMaintenanceDisabledEvent(a.name.Value(), a.clock.Now()))
+	return nil
+}
+
+func (a *Application) changeGitConfiguration(deployBranch, revEnvVar string, keepGitDir bool) error {
+	git, err := NewGitConfiguration(deployBranch, revEnvVar, keepGitDir)
+	if err != nil {
+		return err
+	}
+	if git.Equal(a.configuration.git) {
+		return nil
+	}
+
+	a.configuration.git = git
+	a.updatedAt = a.clock.Now()
+	a.addEvent(NewGitConfigChangedEvent(a.name.Value(), git.DeployBranch(), git.RevEnvVar(), git.KeepGitDir(), a.clock.Now()))
+
+	return nil
+}
+
+// Rename changes the application name, as apps:rename does. The emitted event is keyed
+// by the old name, see ApplicationRenamedEvent.
+func (a *Application) Rename(newName string) error {
+	name, err := NewApplicationName(newName)
+	if err != nil {
+		return err
+	}
+	if name.Equal(a.name) {
+		return fmt.Errorf("application is already named %s", name.Value())
+	}
+
+	oldName := a.name.Value()
+	a.name = name
+	a.updatedAt = a.clock.Now()
+	a.addEvent(NewApplicationRenamedEvent(oldName, name.Value(), a.clock.Now()))
+
+	return nil
+}
+
+// RunAsUser returns the user containers run as, or nil for the image default
+func (a *Application) RunAsUser() *ContainerUser {
+	return a.configuration.runAsUser
+}
+
+// RebuildRequired reports whether a configuration change only takes effect after a rebuild
+func (a *Application) RebuildRequired() bool {
+	return a.deploymentInfo.rebuildRequired
+}
+
 func (a *Application) AddProcess(processType process.ProcessType, command string, scale int) error {
 	proc, err := process.NewProcess(processType, command, scale)
 	if err != nil {
 		return fmt.Errorf("unable to add process: %w", err)
 	}
 
-	a.configuration.processes[processType] = proc
-	a.updatedAt = time.Now()
+	a.configuration.processes[proc.Type()] = proc
+	a.updatedAt = a.clock.Now()
 
 	return nil
 }
@@ -236,16 +996,136 @@ func (a *Application) AddProcessForScaling(processType process.ProcessType, scal
 		return fmt.Errorf("unable to add process for scaling: %w", err)
 	}
 
-	a.configuration.processes[processType] = proc
-	a.updatedAt = time.Now()
+	a.configuration.processes[proc.Type()] = proc
+	a.updatedAt = a.clock.Now()
 
 	return nil
 }
 
+// SetProcessResourceLimits sets the memory, CPU and ephemeral storage limits of an existing
+// process. A memory limit taking the formation over the host memory budget is refused.
+func (a *Application) SetProcessResourceLimits(processType process.ProcessType, memory, cpu, storage string) error {
+	proc, exists := a.findProcess(processType)
+	if !exists {
+		return newOperationError(ErrProcessNotFound, "the process %s doesn't exist", processType)
+	}
+	if limits, err := process.NewResourceLimits(memory, cpu, storage); err == nil {
+		if err := a.checkMemoryBudget(nil, map[process.ProcessType]string{proc.Type(): limits.Memory()}); err != nil {
+			return err
+		}
+	}
+
+	if err := proc.SetResourceLimits(memory, cpu, storage); err != nil {
+		return fmt.Errorf("invalid resource limits for process %s: %w", processType, err)
+	}
+
+	var newMemory, newCPU, newStorage string
+	if limits := proc.ResourceLimits(); limits != nil {
+		newMemory, newCPU, newStorage = limits.Memory(), limits.CPU(), limits.Storage()
+	}
+
+	a.updatedAt = a.clock.Now()
+	a.addEvent(NewProcessLimitsChangedEvent(a.name.Value(), string(proc.Type()), newMemory, newCPU, newStorage, a.clock.Now()))
+
+	return nil
+}
+
+// SetRestartPolicy sets the restart policy of an existing process, as ps:set restart-policy.
+// Setting the current policy again is a no-op.
+func (a *Application) SetRestartPolicy(processType process.ProcessType, policy *process.RestartPolicy) error {
+	if policy == nil {
+		return fmt.Errorf("restart policy cannot be null")
+	}
+	proc, exists := a.findProcess(processType)
+	if !exists {
+		return newOperationError(ErrProcessNotFound, "the process %s doesn't exist", processType)
+	}
+	if proc.RestartPolicy().Equal(policy) {
+		return nil
+	}
+
+	proc.SetRestartPolicy(policy)
+	a.updatedAt = a.clock.Now()
+	a.addEvent(NewRestartPolicyChangedEvent(a.name.Value(), string(proc.Type()), policy.String(), a.clock.Now()))
+
+	return nil
+}
+
+// DeploymentSummary returns what was last deployed and when. An application never
+// deployed returns a zero summary.
+func (a *Application) DeploymentSummary() DeploymentSummaryData {
+	info := a.deploymentInfo
+	if info == nil {
+		return DeploymentSummaryData{}
+	}
+
+	summary := DeploymentSummaryData{DeploymentCount: info.deploymentCount}
+	if info.currentGitRef != nil {
+		summary.GitRef = info.currentGitRef.Value()
+	}
+	if info.lastDeployedAt != nil {
+		lastDeployedAt := *info.lastDeployedAt
+		summary.LastDeployedAt = &lastDeployedAt
+	}
+	if info.buildImage != nil {
+		summary.BuildImage = info.buildImage.Value()
+	}
+	if info.runImage != nil {
+		summary.RunImage = info.runImage.Value()
+	}
+	summary.DurationSeconds = info.lastDeploymentDuration.Seconds()
+	return summary
+}
+
+// LastFailure returns the reason and time of the last failed deployment, a nil time
+// meaning no deployment failed since the last one completed. An application in error
+// leaves that state by deploying again.
+func (a *Application) LastFailure() (string, *time.Time) {
+	info := a.deploymentInfo
+	if info == nil || info.lastFailureAt == nil {
+		return "", nil
+	}
+	failedAt := *info.lastFailureAt
+	return info.lastFailureReason, &failedAt
+}
+
+// LastCheckResults returns the checks the last failed deployment failed, empty if it
+// did not fail on its checks or if a deployment completed since
+func (a *Application) LastCheckResults() []CheckResult {
+	if a.deploymentInfo == nil {
+		return []CheckResult{}
+	}
+	return append([]CheckResult{}, a.deploymentInfo.lastCheckResults...)
+}
+
 func (a *Application) IsRunning() bool {
 	return a.state.Value() == StateRunning
 }
 
+// IsHealthy returns true if the application is running and passed its last probe.
+// A running application that was never probed is not known to be healthy.
+func (a *Application) IsHealthy() bool {
+	return a.IsRunning() && a.lastHealth != nil && a.lastHealth.Passed()
+}
+
+// RecordHealthResult stores the outcome of a probe, unless a more recent one is stored
+func (a *Application) RecordHealthResult(passed bool, at time.Time) {
+	if a.lastHealth != nil && at.Before(a.lastHealth.ObservedAt()) {
+		return
+	}
+	a.lastHealth = &HealthResult{passed: passed, observedAt: at}
+}
+
+// LastHealthResult returns the outcome of the last probe, or nil if none is recorded
+func (a *Application) LastHealthResult() *HealthResult {
+	return a.lastHealth
+}
+
+// StaleHealth returns true if no probe is recorded or the last one is older than maxAge
+func (a *Application) StaleHealth(maxAge time.Duration) bool {
+	return a.lastHealth == nil || a.clock.Now().Sub(a.lastHealth.ObservedAt()) > maxAge
+}
+
 func (a *Application) IsDeployed() bool {
 	return a.state.IsDeployed()
 }
@@ -265,12 +1145,36 @@ func (a *Application) HasDomain(domainName string) bool {
 }
 
 func (a *Application) GetProcessScale(processType process.ProcessType) int {
-	if proc, exists := a.configuration.processes[processType]; exists {
+	if proc, exists := a.findProcess(processType); exists {
 		return proc.Scale()
 	}
 	return 0
 }
 
+// GetProcessCommand returns the command of a process, empty if it has none yet
+func (a *Application) GetProcessCommand(processType process.ProcessType) string {
+	if proc, exists := a.findProcess(processType); exists && proc.HasCommand() {
+		return proc.Command().Value()
+	}
+	return ""
+}
+
+// GetProcessResourceLimits returns the resource limits of a process, or nil if none are set
+func (a *Application) GetProcessResourceLimits(processType process.ProcessType) *process.ResourceLimits {
+	if proc, exists := a.findProcess(processType); exists {
+		return proc.ResourceLimits()
+	}
+	return nil
+}
+
+// GetProcessRestartPolicy returns the restart policy of a process, or nil if none is set
+func (a *Application) GetProcessRestartPolicy(processType process.ProcessType) *process.RestartPolicy {
+	if proc, exists := a.findProcess(processType); exists {
+		return proc.RestartPolicy()
+	}
+	return nil
+}
+
 func (a *Application) GetDomains() []string {
 	domains := make([]string, len(a.configuration.domains))
 	for i, domainVO := range a.configuration.domains {
@@ -279,30 +1183,116 @@ func (a *Application) GetDomains() []string {
 	return domains
 }
 
+// GetEvents returns the pending events, at most the retention cap, oldest first
 func (a *Application) GetEvents() []DomainEvent {
 	return a.events
 }
 
+// GetEventsSince returns the pending events recorded after the given sequence number, in order
+func (a *Application) GetEventsSince(sequence uint64) []DomainEvent {
+	events := make([]DomainEvent, 0)
+	for _, event := range a.events {
+		if event.Sequence() > sequence {
+			events = append(events, event)
+		}
+	}
+	return events
+}
+
+// LastEventSequence returns the sequence number of the last recorded event, or 0 if none
+func (a *Application) LastEventSequence() uint64 {
+	return a.lastSequence
+}
+
+// ClearEvents drops the pending events. The sequence keeps increasing, so that
+// a position taken before clearing remains valid for GetEventsSince.
 func (a *Application) ClearEvents() {
 	a.events = make([]DomainEvent, 0)
+	a.droppedEventCount = 0
+}
+
+// DroppedEventCount returns how many of the oldest pending events were dropped past the
+// retention cap since the last ClearEvents. Persistence should drain the events with
+// GetEvents and ClearEvents often enough to keep it at zero.
+func (a *Application) DroppedEventCount() int {
+	return a.droppedEventCount
 }
 
 // Private methods
 
-// setState replaces the complex changeState logic with simple state setting
+// setState moves the application to a new state. A transition CanTransition refuses
+// fails with an OperationError wrapping ErrInvalidState, leaving the state unchanged.
+// An actual change records an ApplicationStateChangedEvent.
 func (a *Application) setState(newState StateValue) error {
 	newStateObj, err := NewApplicationState(newState)
 	if err != nil {
-		return fmt.Errorf("invalid state transition to %s: %w", newState, err)
+		return newOperationError(ErrInvalidState, "invalid state transition to %s: %w", newState, err)
 	}
 
+	if !CanTransition(a.state.Value(), newState) {
+		return newOperationError(ErrInvalidState, "cannot transition from %s to %s", a.state.Value(), newState)
+	}
+
+	oldState := a.state
 	a.state = newStateObj
-	a.updatedAt = time.Now()
+	a.updatedAt = a.clock.Now()
+	if !oldState.Equal(newStateObj) {
+		a.addEvent(NewApplicationStateChangedEvent(a.name.Value(), oldState.String(), newStateObj.String(), a.clock.Now()))
+	}
 	return nil
 }
 
+func (a *Application) changeHealthChecks(healthCheck *HealthCheck) {
+	if healthCheck.Equal(a.configuration.healthChecks) {
+		return
+	}
+
+	a.configuration.healthChecks = healthCheck
+	a.updatedAt = a.clock.Now()
+	a.addEvent(NewHealthChecksChangedEvent(
+		a.name.Value(),
+		healthCheck.Wait(),
+		healthCheck.Timeout(),
+		healthCheck.Attempts(),
+		healthCheck.IsSkipped(),
+		a.clock.Now(),
+	))
+}
+
+// findProcess looks a process up by its normalized type
+func (a *Application) findProcess(processType process.ProcessType) (*process.Process, bool) {
+	normalized, err := process.NormalizeProcessType(processType)
+	if err != nil {
+		return nil, false
+	}
+	proc, exists := a.configuration.processes[normalized]
+	return proc, exists
+}
+
+// DefaultEventRetention is the number of pending events an application keeps by default
+const DefaultEventRetention = 1000
+
+// WithEventRetention caps the pending events of the application, the oldest being
+// dropped past the cap. Zero or less keeps every event.
+func WithEventRetention(limit int) ApplicationOption {
+	return func(a *Application) {
+		a.eventRetention = max(limit, 0)
+	}
+}
+
 func (a *Application) addEvent(event DomainEvent) {
+	if recorded, ok := event.(interface{ setSequence(uint64) }); ok {
+		a.lastSequence++
+		recorded.setSequence(a.lastSequence)
+	}
 	a.events = append(a.events, event)
+
+	if excess := len(a.events) - a.eventRetention; a.eventRetention > 0 && excess > 0 {
+		kept := copy(a.events, a.events[excess:])
+		clear(a.events[kept:])
+		a.events = a.events[:kept]
+		a.droppedEventCount += excess
+	}
 }
 
 func (a *Application) copyConfiguration() *ApplicationConfiguration {
@@ -319,11 +1309,29 @@ func (a *Application) copyConfiguration() *ApplicationConfiguration {
 		processes[k] = v // This is a shallow copy, but Process is now an entity-like object
 	}
 
+	replicaTargets := make(map[process.ProcessType]*ReplicaTarget)
+	for k, v := range a.configuration.replicaTargets {
+		replicaTargets[k] = v
+	}
+
+	dockerOptions := make(map[shared.DockerOptionPhase][]string, len(a.configuration.dockerOptions))
+	for phase, options := range a.configuration.dockerOptions {
+		dockerOptions[phase] = slices.Clone(options)
+	}
+
 	return &ApplicationConfiguration{
-		buildpack:       a.configuration.buildpack,
-		domains:         domains,
-		environmentVars: envVars,
-		processes:       processes,
+		buildpacks:         slices.Clone(a.configuration.buildpacks),
+		domains:            domains,
+		environmentVars:    envVars,
+		processes:          processes,
+		replicaTargets:     replicaTargets,
+		healthChecks:       a.configuration.healthChecks,
+		runAsUser:          a.configuration.runAsUser,
+		git:                a.configuration.git,
+		portMappings:       slices.Clone(a.configuration.portMappings),
+		scheduledTasks:     slices.Clone(a.configuration.scheduledTasks),
+		dockerOptions:      dockerOptions,
+		maintenanceEnabled: a.configuration.maintenanceEnabled,
 	}
 }
 
@@ -336,23 +1344,49 @@ type DeploymentOptions struct {
 
 // ApplicationInfo represents application info for JSON serialization
 type ApplicationInfo struct {
-	Name       string    `json:"name"`
-	State      string    `json:"state"`
-	IsRunning  bool      `json:"is_running"`
-	IsDeployed bool      `json:"is_deployed"`
-	CreatedAt  time.Time `json:"created_at"`
-	UpdatedAt  time.Time `json:"updated_at"`
+	Name       string     `json:"name"`
+	State      StateValue `json:"state"`
+	IsRunning  bool       `json:"is_running"`
+	IsDeployed bool       `json:"is_deployed"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+	// Labels is omitted for an application without labels
+	Labels map[string]string `json:"labels,omitempty"`
 }
 
 // ApplicationStatus represents detailed application status for JSON serialization
 type ApplicationStatus struct {
-	Name       string    `json:"name"`
-	State      string    `json:"state"`
-	CreatedAt  time.Time `json:"created_at"`
-	UpdatedAt  time.Time `json:"updated_at"`
-	IsRunning  bool      `json:"is_running"`
-	IsDeployed bool      `json:"is_deployed"`
-	Domains    []string  `json:"domains"`
+	Name       string     `json:"name"`
+	State      StateValue `json:"state"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+	IsRunning  bool       `json:"is_running"`
+	IsDeployed bool       `json:"is_deployed"`
+	Domains    []string   `json:"domains"`
+	// HealthChecksSkipped explains a deploy that went straight to running without waiting on checks
+	HealthChecksSkipped bool `json:"health_checks_skipped"`
+	// Deployment is omitted for an application never deployed
+	Deployment *DeploymentSummaryData `json:"deployment,omitempty"`
+	// LastFailure is omitted unless a deployment failed since the last one completed
+	LastFailure *DeploymentFailureData `json:"last_failure,omitempty"`
+}
+
+// DeploymentFailureData represents the last failed deployment for JSON serialization
+type DeploymentFailureData struct {
+	Reason       string        `json:"reason"`
+	FailedAt     time.Time     `json:"failed_at"`
+	FailedChecks []CheckResult `json:"failed_checks,omitempty"`
+}
+
+// DeploymentSummaryData represents the last deployment of an application for JSON serialization
+type DeploymentSummaryData struct {
+	GitRef          string     `json:"git_ref,omitempty"`
+	LastDeployedAt  *time.Time `json:"last_deployed_at,omitempty"`
+	DeploymentCount int        `json:"deployment_count"`
+	BuildImage      string     `json:"build_image,omitempty"`
+	RunImage        string     `json:"run_image,omitempty"`
+	// DurationSeconds is how long the last finished deployment took
+	DurationSeconds float64 `json:"duration_seconds,omitempty"`
 }
 
 // ApplicationListData represents the application list resource data
@@ -361,6 +1395,45 @@ type ApplicationListData struct {
 	Count        int               `json:"count"`
 }
 
+// ApplicationConfigData represents the configuration of an application for JSON serialization.
+// Environment variable values serialize masked.
+type ApplicationConfigData struct {
+	Name       string                         `json:"name"`
+	Env        map[string]*shared.EnvVarValue `json:"env"`
+	Domains    []string                       `json:"domains"`
+	Buildpacks []string                       `json:"buildpacks"`
+	Processes  map[string]int                 `json:"processes"`
+}
+
+// ConfigData returns the configuration of the application safe to expose to clients
+func (a *Application) ConfigData() ApplicationConfigData {
+	return a.configuration.View(a.name.Value())
+}
+
+// View returns the configuration of the named application safe to expose to clients
+func (c *ApplicationConfiguration) View(name string) ApplicationConfigData {
+	data := ApplicationConfigData{
+		Name:       name,
+		Env:        make(map[string]*shared.EnvVarValue, len(c.environmentVars)),
+		Domains:    make([]string, len(c.domains)),
+		Buildpacks: make([]string, len(c.buildpacks)),
+		Processes:  make(map[string]int, len(c.processes)),
+	}
+	for key, value := range c.environmentVars {
+		data.Env[key.Value()] = value
+	}
+	for i, domain := range c.domains {
+		data.Domains[i] = domain.Value()
+	}
+	for i, buildpack := range c.buildpacks {
+		data.Buildpacks[i] = buildpack.Value()
+	}
+	for processType, proc := range c.processes {
+		data.Processes[string(processType)] = proc.Scale()
+	}
+	return data
+}
+
 // ApplicationSummaryData represents the application summary resource data
 type ApplicationSummaryData struct {
 	TotalApps    int `json:"total_apps"`
@@ -0,0 +1,1366 @@
+package app_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	app "github.com/dokku-mcp/dokku-mcp/internal/server-plugins/app/domain"
+	"github.com/dokku-mcp/dokku-mcp/internal/shared"
+	"github.com/dokku-mcp/dokku-mcp/internal/shared/process"
+)
+
+var _ = Describe("Application", func() {
+	var application *app.Application
+
+	BeforeEach(func() {
+		var err error
+		application, err = app.NewApplication("test-app")
+		Expect(err).NotTo(HaveOccurred())
+		application.ClearEvents()
+	})
+
+	Describe("AddProcess", func() {
+		It("should reject an invalid process type", func() {
+			err := application.AddProcess("", "npm start", 1)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("process type cannot be empty"))
+			Expect(application.GetProcessScale("")).To(Equal(0))
+		})
+
+		It("should store process types in lowercase", func() {
+			Expect(application.AddProcess("Web", "npm start", 1)).To(Succeed())
+			Expect(application.GetProcessScale(process.ProcessTypeWeb)).To(Equal(1))
+			Expect(application.GetProcessScale("WEB")).To(Equal(1))
+		})
+	})
+
+	Describe("SetProcessResourceLimits", func() {
+		It("should set limits on an existing process and emit an event", func() {
+			Expect(application.AddProcess(process.ProcessTypeWeb, "npm start", 1)).To(Succeed())
+
+			Expect(application.SetProcessResourceLimits(process.ProcessTypeWeb, "512m", "1", "1g")).To(Succeed())
+
+			limits := application.GetProcessResourceLimits(process.ProcessTypeWeb)
+			Expect(limits.Memory()).To(Equal("512m"))
+
+			events := application.GetEvents()
+			Expect(events).To(HaveLen(1))
+			changed, ok := events[0].(*app.ProcessLimitsChangedEvent)
+			Expect(ok).To(BeTrue())
+			Expect(changed.ProcessType()).To(Equal("web"))
+			Expect(changed.Memory()).To(Equal("512m"))
+			Expect(changed.CPU()).To(Equal("1"))
+			Expect(changed.Storage()).To(Equal("1g"))
+		})
+
+		It("should fail when the process doesn't exist", func() {
+			err := application.SetProcessResourceLimits(process.ProcessTypeWorker, "512m", "", "")
+			Expect(err).To(HaveOccurred())
+			Expect(application.GetEvents()).To(BeEmpty())
+		})
+
+		It("should reject invalid limits without emitting an event", func() {
+			Expect(application.AddProcess(process.ProcessTypeWeb, "npm start", 1)).To(Succeed())
+			Expect(application.SetProcessResourceLimits(process.ProcessTypeWeb, "huge", "", "")).NotTo(Succeed())
+			Expect(application.GetEvents()).To(BeEmpty())
+		})
+	})
+})
+
+var _ = Describe("Application health checks", func() {
+	var application *app.Application
+
+	BeforeEach(func() {
+		var err error
+		application, err = app.NewApplication("checks-app")
+		Expect(err).NotTo(HaveOccurred())
+		application.ClearEvents()
+	})
+
+	It("should default to Dokku's checks configuration", func() {
+		checks := application.GetHealthChecks()
+		Expect(checks.IsSkipped()).To(BeFalse())
+		Expect(checks.Wait()).To(Equal(app.DefaultChecksWait))
+		Expect(checks.Timeout()).To(Equal(app.DefaultChecksTimeout))
+		Expect(checks.Attempts()).To(Equal(app.DefaultChecksAttempts))
+	})
+
+	It("should update checks and emit an event", func() {
+		Expect(application.SetHealthChecks(10*time.Second, time.Minute, 3)).To(Succeed())
+
+		Expect(application.GetHealthChecks().Attempts()).To(Equal(3))
+		events := application.GetEvents()
+		Expect(events).To(HaveLen(1))
+		changed, ok := events[0].(*app.HealthChecksChangedEvent)
+		Expect(ok).To(BeTrue())
+		Expect(changed.Wait()).To(Equal(10 * time.Second))
+		Expect(changed.Skipped()).To(BeFalse())
+	})
+
+	It("should reject invalid settings", func() {
+		Expect(application.SetHealthChecks(-time.Second, time.Minute, 3)).NotTo(Succeed())
+		Expect(application.SetHealthChecks(time.Second, 0, 3)).NotTo(Succeed())
+		Expect(application.SetHealthChecks(time.Second, time.Minute, 0)).NotTo(Succeed())
+		Expect(application.GetEvents()).To(BeEmpty())
+	})
+
+	It("should record skipped checks on deploy", func() {
+		application.SkipHealthChecks()
+		Expect(application.GetEvents()).To(HaveLen(1))
+
+		Expect(application.Deploy(shared.MustNewGitRef("main"), nil)).To(Succeed())
+		Expect(application.LastDeploymentSkippedChecks()).To(BeTrue())
+		Expect(application.CompleteDeployment()).To(Succeed())
+
+		Expect(application.SetHealthChecks(app.DefaultChecksWait, app.DefaultChecksTimeout, app.DefaultChecksAttempts)).To(Succeed())
+		Expect(application.Deploy(shared.MustNewGitRef("main"), nil)).To(Succeed())
+		Expect(application.LastDeploymentSkippedChecks()).To(BeFalse())
+	})
+
+	It("should not emit an event when nothing changes", func() {
+		application.SkipHealthChecks()
+		application.ClearEvents()
+		application.SkipHealthChecks()
+		Expect(application.GetEvents()).To(BeEmpty())
+	})
+})
+
+var _ = Describe("Application ScaleAll", func() {
+	var application *app.Application
+
+	BeforeEach(func() {
+		var err error
+		application, err = app.NewApplication("scale-app")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(application.Scale(process.ProcessTypeWeb, 1)).To(Succeed())
+		application.ClearEvents()
+	})
+
+	It("should scale every process in one operation", func() {
+		Expect(application.ScaleAll(map[process.ProcessType]int{
+			process.ProcessTypeWeb:    3,
+			process.ProcessTypeWorker: 2,
+		})).To(Succeed())
+
+		Expect(application.GetProcessScale(process.ProcessTypeWeb)).To(Equal(3))
+		Expect(application.GetProcessScale(process.ProcessTypeWorker)).To(Equal(2))
+
+		events := application.GetEvents()
+		Expect(events).To(HaveLen(2))
+		web := events[0].(*app.ApplicationScaledEvent)
+		worker := events[1].(*app.ApplicationScaledEvent)
+		Expect(web.ProcessType()).To(Equal("web"))
+		Expect(web.OldScale()).To(Equal(1))
+		Expect(worker.ProcessType()).To(Equal("worker"))
+		Expect(worker.OldScale()).To(Equal(0))
+		Expect(web.OccurredAt()).To(Equal(worker.OccurredAt()))
+		Expect(application.UpdatedAt()).To(Equal(web.OccurredAt()))
+	})
+
+	It("should abort the whole batch on a negative count", func() {
+		err := application.ScaleAll(map[process.ProcessType]int{
+			process.ProcessTypeWeb:    3,
+			process.ProcessTypeWorker: -1,
+		})
+		Expect(err).To(HaveOccurred())
+
+		Expect(application.GetProcessScale(process.ProcessTypeWeb)).To(Equal(1))
+		Expect(application.GetProcessScale(process.ProcessTypeWorker)).To(Equal(0))
+		Expect(application.GetEvents()).To(BeEmpty())
+	})
+
+	It("should reject an empty batch", func() {
+		Expect(application.ScaleAll(nil)).NotTo(Succeed())
+	})
+
+	It("should reject scaling the release process above 1", func() {
+		Expect(application.Scale(process.ProcessTypeRelease, 2)).To(MatchError(ContainSubstring("runs once per deploy")))
+		Expect(application.ScaleAll(map[process.ProcessType]int{
+			process.ProcessTypeWeb:     3,
+			process.ProcessTypeRelease: 3,
+		})).NotTo(Succeed())
+
+		Expect(application.GetProcessScale(process.ProcessTypeWeb)).To(Equal(1))
+		Expect(application.GetEvents()).To(BeEmpty())
+		Expect(application.Scale(process.ProcessTypeRelease, 1)).To(Succeed())
+	})
+})
+
+var _ = Describe("Application RunAsUser", func() {
+	var application *app.Application
+
+	BeforeEach(func() {
+		var err error
+		application, err = app.NewApplication("user-app")
+		Expect(err).NotTo(HaveOccurred())
+		application.ClearEvents()
+	})
+
+	It("should set a numeric uid, flag a rebuild and emit an event", func() {
+		Expect(application.SetRunAsUser("1000")).To(Succeed())
+
+		Expect(application.RunAsUser().Value()).To(Equal("1000"))
+		Expect(application.RunAsUser().DockerOption()).To(Equal("--user 1000"))
+		Expect(application.RebuildRequired()).To(BeTrue())
+
+		events := application.GetEvents()
+		Expect(events).To(HaveLen(1))
+		changed, ok := events[0].(*app.RunAsUserChangedEvent)
+		Expect(ok).To(BeTrue())
+		Expect(changed.User()).To(Equal("1000"))
+	})
+
+	It("should clear the rebuild flag on deploy", func() {
+		Expect(application.SetRunAsUser("1000")).To(Succeed())
+		Expect(application.Deploy(shared.MustNewGitRef("main"), nil)).To(Succeed())
+		Expect(application.RebuildRequired()).To(BeFalse())
+	})
+
+	It("should reject invalid users without mutating", func() {
+		Expect(application.SetRunAsUser("not a user")).NotTo(Succeed())
+		Expect(application.RunAsUser()).To(BeNil())
+		Expect(application.RebuildRequired()).To(BeFalse())
+		Expect(application.GetEvents()).To(BeEmpty())
+	})
+
+	It("should restore the default user", func() {
+		Expect(application.SetRunAsUser("app")).To(Succeed())
+		application.ClearRunAsUser()
+		Expect(application.RunAsUser()).To(BeNil())
+		Expect(application.GetEvents()).To(HaveLen(2))
+	})
+
+	It("should replace the --user docker option of the previous user", func() {
+		Expect(application.SetRunAsUser("app")).To(Succeed())
+		Expect(application.SetRunAsUser("1000:1000")).To(Succeed())
+		application.ClearRunAsUser()
+
+		var options [][2]string
+		for _, event := range application.GetEvents() {
+			removed, added := event.(*app.RunAsUserChangedEvent).DockerOptions()
+			options = append(options, [2]string{removed, added})
+		}
+		Expect(options).To(Equal([][2]string{
+			{"", "--user app"},
+			{"--user app", "--user 1000:1000"},
+			{"--user 1000:1000", ""},
+		}))
+	})
+})
+
+var _ = Describe("Application rebuild", func() {
+	var application *app.Application
+
+	BeforeEach(func() {
+		var err error
+		application, err = app.NewApplication("my-app")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should refuse to rebuild an application never deployed", func() {
+		application.ClearEvents()
+		Expect(application.Rebuild(nil)).To(MatchError(app.ErrApplicationNotDeployed))
+		Expect(application.DeploymentInProgress()).To(BeFalse())
+		Expect(application.GetEvents()).To(BeEmpty())
+	})
+
+	It("should rebuild the current git ref with the build options", func() {
+		Expect(application.Deploy(shared.MustNewGitRef("v1.2.0"), nil)).To(Succeed())
+		Expect(application.CompleteDeployment()).To(Succeed())
+		application.ClearEvents()
+
+		Expect(application.Rebuild(&app.DeploymentOptions{NoCache: true, ForceClean: true})).To(Succeed())
+		Expect(application.DeploymentInProgress()).To(BeTrue())
+
+		summary := application.DeploymentSummary()
+		Expect(summary.GitRef).To(Equal("v1.2.0"))
+		Expect(summary.DeploymentCount).To(Equal(2))
+
+		events := application.GetEvents()
+		Expect(events).To(HaveLen(1))
+		rebuilt, ok := events[0].(*app.ApplicationRebuiltEvent)
+		Expect(ok).To(BeTrue())
+		Expect(rebuilt.GitRef()).To(Equal("v1.2.0"))
+		Expect(rebuilt.NoCache()).To(BeTrue())
+		Expect(rebuilt.ForceClean()).To(BeTrue())
+	})
+
+	It("should hold the deployment lock until the rebuild ends", func() {
+		Expect(application.Deploy(shared.MustNewGitRef("main"), nil)).To(Succeed())
+		Expect(application.Rebuild(nil)).To(MatchError(app.ErrDeploymentInProgress))
+		Expect(application.CompleteDeployment()).To(Succeed())
+
+		Expect(application.Rebuild(nil)).To(Succeed())
+		Expect(application.Deploy(shared.MustNewGitRef("main"), nil)).To(MatchError(app.ErrDeploymentInProgress))
+		Expect(application.FailDeployment("build failed")).To(Succeed())
+		Expect(application.Rebuild(nil)).To(Succeed())
+	})
+
+	It("should be restored by replay", func() {
+		Expect(application.Deploy(shared.MustNewGitRef("main"), nil)).To(Succeed())
+		Expect(application.CompleteDeployment()).To(Succeed())
+		Expect(application.Rebuild(nil)).To(Succeed())
+		Expect(application.CompleteDeployment()).To(Succeed())
+
+		replayed, err := app.ReplayApplication("my-app", application.GetEvents())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(replayed.DeploymentSummary().DeploymentCount).To(Equal(2))
+		Expect(replayed.DeploymentSummary().GitRef).To(Equal("main"))
+	})
+})
+
+var _ = Describe("Application deployment lock", func() {
+	var application *app.Application
+
+	BeforeEach(func() {
+		var err error
+		application, err = app.NewApplication("my-app")
+		Expect(err).NotTo(HaveOccurred())
+		application.ClearEvents()
+	})
+
+	It("should reject a deploy while another is in progress", func() {
+		Expect(application.Deploy(shared.MustNewGitRef("main"), nil)).To(Succeed())
+		Expect(application.DeploymentInProgress()).To(BeTrue())
+
+		err := application.Deploy(shared.MustNewGitRef("main"), nil)
+		Expect(err).To(MatchError(app.ErrDeploymentInProgress))
+		Expect(application.GetEvents()).To(HaveLen(1))
+	})
+
+	It("should release the lock on completion", func() {
+		Expect(application.Deploy(shared.MustNewGitRef("main"), nil)).To(Succeed())
+		Expect(application.CompleteDeployment()).To(Succeed())
+		Expect(application.DeploymentInProgress()).To(BeFalse())
+
+		Expect(application.Deploy(shared.MustNewGitRef("v2"), nil)).To(Succeed())
+	})
+
+	It("should release the lock on failure", func() {
+		Expect(application.Deploy(shared.MustNewGitRef("main"), nil)).To(Succeed())
+		Expect(application.FailDeployment("build failed")).To(Succeed())
+		Expect(application.DeploymentInProgress()).To(BeFalse())
+
+		Expect(application.Deploy(shared.MustNewGitRef("main"), nil)).To(Succeed())
+	})
+
+	It("should let only one of several concurrent deploys proceed", func() {
+		const deployers = 10
+		var (
+			wg        sync.WaitGroup
+			succeeded atomic.Int32
+			rejected  atomic.Int32
+		)
+
+		start := make(chan struct{})
+		for range deployers {
+			wg.Add(1)
+			go func() {
+				defer GinkgoRecover()
+				defer wg.Done()
+				<-start
+				err := application.Deploy(shared.MustNewGitRef("main"), nil)
+				if err == nil {
+					succeeded.Add(1)
+					return
+				}
+				Expect(err).To(MatchError(app.ErrDeploymentInProgress))
+				rejected.Add(1)
+			}()
+		}
+		close(start)
+		wg.Wait()
+
+		Expect(succeeded.Load()).To(Equal(int32(1)))
+		Expect(rejected.Load()).To(Equal(int32(deployers - 1)))
+		Expect(application.GetEvents()).To(HaveLen(1))
+	})
+})
+
+var _ = Describe("Application CompleteDeployment", func() {
+	It("should not complete a deployment that already failed", func() {
+		application, err := app.NewApplication("my-app")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(application.Deploy(shared.MustNewGitRef("main"), nil)).To(Succeed())
+		Expect(application.FailDeployment(app.DeploymentCancelledReason)).To(Succeed())
+		application.ClearEvents()
+
+		Expect(application.CompleteDeployment()).To(MatchError(app.ErrNoDeploymentInProgress))
+
+		Expect(application.State().Value()).To(Equal(app.StateError))
+		reason, failedAt := application.LastFailure()
+		Expect(reason).To(Equal(app.DeploymentCancelledReason))
+		Expect(failedAt).NotTo(BeNil())
+		Expect(application.GetEvents()).To(BeEmpty())
+	})
+})
+
+var _ = Describe("Application DeployWithContext", func() {
+	var application *app.Application
+
+	BeforeEach(func() {
+		var err error
+		application, err = app.NewApplication("my-app")
+		Expect(err).NotTo(HaveOccurred())
+		application.ClearEvents()
+	})
+
+	It("should leave a deployment cancelled later to the caller", func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		Expect(application.DeployWithContext(ctx, shared.MustNewGitRef("main"), nil)).To(Succeed())
+
+		cancel()
+		Expect(application.DeploymentInProgress()).To(BeTrue())
+
+		Expect(application.FailDeployment(app.DeploymentCancelledReason)).To(Succeed())
+		Expect(application.DeploymentInProgress()).To(BeFalse())
+		Expect(application.State().Value()).To(Equal(app.StateError))
+		events := application.GetEvents()
+		failed, ok := events[len(events)-2].(*app.ApplicationDeploymentFailedEvent)
+		Expect(ok).To(BeTrue())
+		Expect(failed.Reason()).To(Equal(app.DeploymentCancelledReason))
+
+		Expect(application.Deploy(shared.MustNewGitRef("main"), nil)).To(Succeed())
+	})
+
+	It("should not start a deployment with a context already done", func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		err := application.DeployWithContext(ctx, shared.MustNewGitRef("main"), nil)
+		Expect(err).To(MatchError(context.Canceled))
+		Expect(application.DeploymentInProgress()).To(BeFalse())
+		Expect(application.GetEvents()).To(BeEmpty())
+	})
+})
+
+var _ = Describe("Application DeployFromImage", func() {
+	var application *app.Application
+
+	BeforeEach(func() {
+		var err error
+		application, err = app.NewApplication("my-app")
+		Expect(err).NotTo(HaveOccurred())
+		application.ClearEvents()
+	})
+
+	It("should record an image deployment", func() {
+		image := shared.MustNewDockerImage("registry.example.com/my-app:1.2.3")
+		Expect(application.DeployFromImage(image, nil)).To(Succeed())
+		Expect(application.DeploymentInProgress()).To(BeTrue())
+
+		events := application.GetEvents()
+		Expect(events).To(HaveLen(1))
+		deployed, ok := events[0].(*app.ApplicationDeployedFromImageEvent)
+		Expect(ok).To(BeTrue())
+		Expect(deployed.Image()).To(Equal("registry.example.com/my-app:1.2.3"))
+	})
+
+	It("should reject a null image", func() {
+		Expect(application.DeployFromImage(nil, nil)).NotTo(Succeed())
+		Expect(application.DeploymentInProgress()).To(BeFalse())
+		Expect(application.GetEvents()).To(BeEmpty())
+	})
+
+	It("should share the deployment lock with git deploys", func() {
+		Expect(application.Deploy(shared.MustNewGitRef("main"), nil)).To(Succeed())
+
+		err := application.DeployFromImage(shared.MustNewDockerImage("my-app:latest"), nil)
+		Expect(err).To(MatchError(app.ErrDeploymentInProgress))
+	})
+})
+
+var _ = Describe("Application MarkUndeployed", func() {
+	var application *app.Application
+
+	BeforeEach(func() {
+		var err error
+		application, err = app.NewApplication("my-app")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(application.Deploy(shared.MustNewGitRef("main"), &app.DeploymentOptions{
+			RunImage: shared.MustNewDockerImage("registry.example.com/my-app:1.2.3"),
+		})).To(Succeed())
+		Expect(application.CompleteDeployment()).To(Succeed())
+	})
+
+	It("should clear the release and keep the deployment history", func() {
+		Expect(application.IsDeployed()).To(BeTrue())
+
+		Expect(application.MarkUndeployed("release torn down")).To(Succeed())
+
+		Expect(application.IsDeployed()).To(BeFalse())
+		Expect(application.State().Value()).To(Equal(app.StateExists))
+		summary := application.DeploymentSummary()
+		Expect(summary.GitRef).To(BeEmpty())
+		Expect(summary.RunImage).To(BeEmpty())
+		Expect(summary.DeploymentCount).To(Equal(1))
+		Expect(application.Validate()).To(Succeed())
+
+		events := application.GetEvents()
+		undeployed, ok := events[len(events)-1].(*app.ApplicationUndeployedEvent)
+		Expect(ok).To(BeTrue())
+		Expect(undeployed.Reason()).To(Equal("release torn down"))
+
+		replayed, err := app.ReplayApplication("my-app", events)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(replayed.IsDeployed()).To(BeFalse())
+		Expect(replayed.DeploymentSummary().GitRef).To(BeEmpty())
+	})
+
+	It("should be a no-op once undeployed", func() {
+		Expect(application.MarkUndeployed("")).To(Succeed())
+		application.ClearEvents()
+
+		Expect(application.MarkUndeployed("")).To(Succeed())
+		Expect(application.GetEvents()).To(BeEmpty())
+	})
+
+	It("should not undeploy during a deployment", func() {
+		Expect(application.Deploy(shared.MustNewGitRef("v2"), nil)).To(Succeed())
+
+		Expect(application.MarkUndeployed("")).To(MatchError(app.ErrDeploymentInProgress))
+	})
+})
+
+var _ = Describe("Application Destroy", func() {
+	var application *app.Application
+
+	BeforeEach(func() {
+		var err error
+		application, err = app.NewApplication("my-app")
+		Expect(err).NotTo(HaveOccurred())
+		application.ClearEvents()
+	})
+
+	It("should record the destruction once the name is confirmed", func() {
+		Expect(application.Destroy("my-app")).To(Succeed())
+
+		Expect(application.IsDestroyed()).To(BeTrue())
+		events := application.GetEvents()
+		Expect(events).To(HaveLen(1))
+		Expect(events[0].EventType()).To(Equal("application.destroyed"))
+
+		replayed, err := app.ReplayApplication("my-app", events)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(replayed.IsDestroyed()).To(BeTrue())
+
+		Expect(application.Destroy("my-app")).To(Succeed())
+		Expect(application.GetEvents()).To(HaveLen(1))
+	})
+
+	DescribeTable("should refuse a confirmation other than the name",
+		func(confirmation string) {
+			err := application.Destroy(confirmation)
+
+			Expect(err).To(MatchError(app.ErrDestroyNotConfirmed))
+			Expect(application.IsDestroyed()).To(BeFalse())
+			Expect(application.GetEvents()).To(BeEmpty())
+		},
+		Entry("empty", ""),
+		Entry("another application", "my-other-app"),
+		Entry("another case", "MY-APP"),
+	)
+
+	It("should not destroy during a deployment", func() {
+		Expect(application.Deploy(shared.MustNewGitRef("main"), nil)).To(Succeed())
+
+		Expect(application.Destroy("my-app")).To(MatchError(app.ErrDeploymentInProgress))
+	})
+})
+
+var _ = Describe("Application deployment summary", func() {
+	It("should return a zero summary for an application never deployed", func() {
+		application, err := app.NewApplication("my-app")
+		Expect(err).NotTo(HaveOccurred())
+
+		summary := application.DeploymentSummary()
+		Expect(summary).To(Equal(app.DeploymentSummaryData{}))
+
+		data, err := json.Marshal(app.ApplicationStatus{Name: "my-app"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).NotTo(ContainSubstring("deployment"))
+	})
+
+	It("should summarize the last deployment", func() {
+		application, err := app.NewApplication("my-app")
+		Expect(err).NotTo(HaveOccurred())
+
+		gitRef, err := shared.NewGitRef("main")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(application.Deploy(gitRef, &app.DeploymentOptions{
+			RunImage: shared.MustNewDockerImage("registry.example.com/my-app:1.2.3"),
+		})).To(Succeed())
+		Expect(application.CompleteDeployment()).To(Succeed())
+
+		summary := application.DeploymentSummary()
+		Expect(summary.GitRef).To(Equal("main"))
+		Expect(summary.DeploymentCount).To(Equal(1))
+		Expect(summary.LastDeployedAt).NotTo(BeNil())
+		Expect(summary.RunImage).To(Equal("registry.example.com/my-app:1.2.3"))
+		Expect(summary.BuildImage).To(BeEmpty())
+
+		data, err := json.Marshal(app.ApplicationStatus{Name: "my-app", Deployment: &summary})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).To(ContainSubstring(`"git_ref":"main"`))
+		Expect(string(data)).To(ContainSubstring(`"deployment_count":1`))
+	})
+})
+
+var _ = Describe("Application rename", func() {
+	var application *app.Application
+
+	BeforeEach(func() {
+		var err error
+		application, err = app.NewApplication("my-app")
+		Expect(err).NotTo(HaveOccurred())
+		application.ClearEvents()
+	})
+
+	It("should rename and emit an event keyed by the old name", func() {
+		Expect(application.Rename("My-New-App")).To(Succeed())
+
+		Expect(application.Name().Value()).To(Equal("my-new-app"))
+		events := application.GetEvents()
+		Expect(events).To(HaveLen(1))
+		renamed, ok := events[0].(*app.ApplicationRenamedEvent)
+		Expect(ok).To(BeTrue())
+		Expect(renamed.AggregateID()).To(Equal("my-app"))
+		Expect(renamed.OldName()).To(Equal("my-app"))
+		Expect(renamed.NewName()).To(Equal("my-new-app"))
+	})
+
+	It("should reject the current name", func() {
+		Expect(application.Rename("my-app")).NotTo(Succeed())
+		Expect(application.GetEvents()).To(BeEmpty())
+	})
+
+	It("should reject an invalid name", func() {
+		Expect(application.Rename("Not A Name!")).NotTo(Succeed())
+		Expect(application.Name().Value()).To(Equal("my-app"))
+	})
+
+	It("should replay events recorded under both names", func() {
+		now := time.Now()
+		replayed, err := app.ReplayApplication("my-app", []app.DomainEvent{
+			app.NewApplicationCreatedEvent("my-app", now),
+			app.NewApplicationRenamedEvent("my-app", "my-new-app", now),
+			app.NewDomainAddedEvent("my-new-app", "new.example.com", now),
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(replayed.Name().Value()).To(Equal("my-new-app"))
+		Expect(replayed.GetDomains()).To(HaveLen(1))
+	})
+})
+
+var _ = Describe("Application config data", func() {
+	It("should serialize environment variables masked", func() {
+		application, err := app.NewApplication("my-app")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(application.SetEnvironmentVariable("DATABASE_URL", "postgres://user:pass@db/app")).To(Succeed())
+		Expect(application.AddDomain("my-app.example.com")).To(Succeed())
+
+		data, err := json.Marshal(application.ConfigData())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).To(ContainSubstring(`"DATABASE_URL":"p****p"`))
+		Expect(string(data)).To(ContainSubstring("my-app.example.com"))
+		Expect(string(data)).NotTo(ContainSubstring("pass@db"))
+	})
+})
+
+var _ = Describe("Application global domains", func() {
+	var application *app.Application
+
+	BeforeEach(func() {
+		var err error
+		application, err = app.NewApplication("my-app")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(application.SetGlobalDomains([]string{"dokku.example.com"})).To(Succeed())
+		application.ClearEvents()
+	})
+
+	It("should derive the global vhosts of the app", func() {
+		Expect(application.GlobalVhosts()).To(Equal([]string{"my-app.dokku.example.com"}))
+	})
+
+	It("should treat adding a global vhost as a no-op", func() {
+		Expect(application.AddDomain("my-app.dokku.example.com")).To(Succeed())
+		Expect(application.AddDomain("My-App.Dokku.Example.com")).To(Succeed())
+
+		Expect(application.GetDomains()).To(BeEmpty())
+		Expect(application.GetEvents()).To(BeEmpty())
+	})
+
+	It("should still add other subdomains of the global domain", func() {
+		Expect(application.AddDomain("api.dokku.example.com")).To(Succeed())
+		Expect(application.GetDomains()).To(Equal([]string{"api.dokku.example.com"}))
+	})
+
+	It("should reject an invalid global domain and keep the previous ones", func() {
+		Expect(application.SetGlobalDomains([]string{"not a domain"})).NotTo(Succeed())
+		Expect(application.GlobalVhosts()).To(HaveLen(1))
+	})
+})
+
+var _ = Describe("Application maintenance mode", func() {
+	var application *app.Application
+
+	BeforeEach(func() {
+		var err error
+		application, err = app.NewApplicationWithState("my-app", app.StateRunning)
+		Expect(err).NotTo(HaveOccurred())
+		application.ClearEvents()
+	})
+
+	It("should toggle maintenance and emit an event for each change", func() {
+		Expect(application.EnableMaintenance()).To(Succeed())
+		Expect(application.MaintenanceEnabled()).To(BeTrue())
+		Expect(application.EnableMaintenance()).To(Succeed())
+
+		Expect(application.DisableMaintenance()).To(Succeed())
+		Expect(application.MaintenanceEnabled()).To(BeFalse())
+		Expect(application.DisableMaintenance()).To(Succeed())
+
+		events := application.GetEvents()
+		Expect(events).To(HaveLen(2))
+		Expect(events[0]).To(BeAssignableToTypeOf(&app.MaintenanceEnabledEvent{}))
+		Expect(events[1]).To(BeAssignableToTypeOf(&app.MaintenanceDisabledEvent{}))
+	})
+
+	It("should reject maintenance for an app never deployed", func() {
+		created, err := app.NewApplication("fresh")
+		Expect(err).NotTo(HaveOccurred())
+		created.ClearEvents()
+
+		Expect(created.EnableMaintenance()).NotTo(Succeed())
+		Expect(created.MaintenanceEnabled()).To(BeFalse())
+		Expect(created.GetEvents()).To(BeEmpty())
+	})
+
+	It("should be restored by replay", func() {
+		Expect(application.EnableMaintenance()).To(Succeed())
+
+		replayed, err := app.ReplayApplication("my-app", []app.DomainEvent{
+			app.NewApplicationCreatedEvent("my-app", time.Now()),
+			application.GetEvents()[0],
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(replayed.MaintenanceEnabled()).To(BeTrue())
+	})
+})
+
+var _ = Describe("Application operation errors", func() {
+	var application *app.Application
+
+	BeforeEach(func() {
+		var err error
+		application, err = app.NewApplication("my-app")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(application.AddDomain("my-app.example.com")).To(Succeed())
+	})
+
+	It("should match the error kind while keeping the message", func() {
+		err := application.AddDomain("my-app.example.com")
+		Expect(err).To(MatchError(app.ErrDomainAlreadyExists))
+		Expect(err).To(MatchError("the domain my-app.example.com already exists"))
+
+		err = application.RemoveDomain("other.example.com")
+		Expect(err).To(MatchError(app.ErrDomainNotFound))
+		Expect(err).To(MatchError("the domain other.example.com doesn't exist"))
+
+		err = application.SetProcessResourceLimits("worker", "512m", "", "")
+		Expect(err).To(MatchError(app.ErrProcessNotFound))
+
+		err = application.RemoveBuildpack("heroku/go")
+		Expect(err).To(MatchError(app.ErrBuildpackNotFound))
+	})
+
+	It("should wrap the underlying cause", func() {
+		err := application.AddDomain("not a domain")
+		Expect(err).To(MatchError(app.ErrInvalidDomain))
+		Expect(err.Error()).To(HavePrefix("invalid domain: "))
+
+		var operationErr *app.OperationError
+		Expect(errors.As(err, &operationErr)).To(BeTrue())
+		Expect(operationErr.Kind).To(Equal(app.ErrInvalidDomain))
+		Expect(operationErr.Cause).To(HaveOccurred())
+		Expect(errors.Is(err, operationErr.Cause)).To(BeTrue())
+	})
+
+	It("should report forbidden state transitions", func() {
+		failed, err := app.NewApplicationWithState("failed-app", app.StateError)
+		Expect(err).NotTo(HaveOccurred())
+		err = failed.Start()
+		Expect(err).To(MatchError(app.ErrInvalidState))
+		Expect(err).To(MatchError("cannot transition from error to running"))
+
+		var operationErr *app.OperationError
+		Expect(errors.As(err, &operationErr)).To(BeTrue())
+	})
+})
+
+var _ = Describe("Application event sequence", func() {
+	var application *app.Application
+
+	BeforeEach(func() {
+		var err error
+		application, err = app.NewApplication("my-app")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should number events in the order they were recorded", func() {
+		Expect(application.ScaleAll(map[process.ProcessType]int{"web": 2, "worker": 1})).To(Succeed())
+
+		events := application.GetEvents()
+		Expect(events).To(HaveLen(3))
+		for i, event := range events {
+			Expect(event.Sequence()).To(Equal(uint64(i + 1)))
+		}
+		Expect(application.LastEventSequence()).To(Equal(uint64(3)))
+	})
+
+	It("should return the events after a known position", func() {
+		Expect(application.AddDomain("a.example.com")).To(Succeed())
+		position := application.LastEventSequence()
+		Expect(application.AddDomain("b.example.com")).To(Succeed())
+
+		events := application.GetEventsSince(position)
+		Expect(events).To(HaveLen(1))
+		Expect(events[0].(*app.DomainAddedEvent).Domain()).To(Equal("b.example.com"))
+		Expect(application.GetEventsSince(application.LastEventSequence())).To(BeEmpty())
+	})
+
+	It("should keep numbering after the events are cleared", func() {
+		position := application.LastEventSequence()
+		application.ClearEvents()
+		Expect(application.AddDomain("a.example.com")).To(Succeed())
+
+		events := application.GetEventsSince(position)
+		Expect(events).To(HaveLen(1))
+		Expect(events[0].Sequence()).To(Equal(position + 1))
+	})
+})
+
+var _ = Describe("Application domain reconciliation", func() {
+	var application *app.Application
+
+	BeforeEach(func() {
+		var err error
+		application, err = app.NewApplication("my-app")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(application.SetGlobalDomains([]string{"dokku.example.com"})).To(Succeed())
+		application.ClearEvents()
+	})
+
+	It("should add a missing domain once", func() {
+		added, err := application.EnsureDomain("api.example.com")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(added).To(BeTrue())
+
+		added, err = application.EnsureDomain("API.example.com")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(added).To(BeFalse())
+
+		Expect(application.GetDomains()).To(Equal([]string{"api.example.com"}))
+		Expect(application.GetEvents()).To(HaveLen(1))
+	})
+
+	It("should not report a global vhost as added", func() {
+		added, err := application.EnsureDomain("my-app.dokku.example.com")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(added).To(BeFalse())
+		Expect(application.GetEvents()).To(BeEmpty())
+	})
+
+	It("should remove a present domain once", func() {
+		Expect(application.AddDomain("api.example.com")).To(Succeed())
+
+		removed, err := application.EnsureDomainAbsent("api.example.com")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(removed).To(BeTrue())
+
+		removed, err = application.EnsureDomainAbsent("api.example.com")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(removed).To(BeFalse())
+		Expect(application.GetEvents()).To(HaveLen(2))
+	})
+
+	It("should still reject invalid domains", func() {
+		_, err := application.EnsureDomain("not a domain")
+		Expect(err).To(MatchError(app.ErrInvalidDomain))
+		_, err = application.EnsureDomainAbsent("not a domain")
+		Expect(err).To(MatchError(app.ErrInvalidDomain))
+	})
+})
+
+var _ = Describe("Application buildpacks", func() {
+	var application *app.Application
+
+	BeforeEach(func() {
+		var err error
+		application, err = app.NewApplication("my-app")
+		Expect(err).NotTo(HaveOccurred())
+		application.ClearEvents()
+	})
+
+	It("should keep buildpacks in order", func() {
+		Expect(application.AddBuildpack("heroku/nodejs", 0)).To(Succeed())
+		Expect(application.AddBuildpack("heroku/apt", 1)).To(Succeed())
+		Expect(application.AddBuildpack("heroku/procfile", 0)).To(Succeed())
+
+		Expect(application.GetBuildpacks()).To(Equal([]string{"heroku/apt", "heroku/nodejs", "heroku/procfile"}))
+
+		events := application.GetEvents()
+		Expect(events).To(HaveLen(3))
+		added, ok := events[1].(*app.BuildpackAddedEvent)
+		Expect(ok).To(BeTrue())
+		Expect(added.Buildpack()).To(Equal("heroku/apt"))
+		Expect(added.Index()).To(Equal(1))
+		Expect(events[2].(*app.BuildpackAddedEvent).Index()).To(Equal(3))
+	})
+
+	It("should reject out of range indices and duplicates", func() {
+		Expect(application.AddBuildpack("heroku/nodejs", 2)).NotTo(Succeed())
+		Expect(application.AddBuildpack("heroku/nodejs", -1)).NotTo(Succeed())
+
+		Expect(application.AddBuildpack("heroku/nodejs", 1)).To(Succeed())
+		Expect(application.AddBuildpack("heroku/nodejs", 0)).NotTo(Succeed())
+		Expect(application.GetBuildpacks()).To(Equal([]string{"heroku/nodejs"}))
+		Expect(application.GetEvents()).To(HaveLen(1))
+	})
+
+	It("should remove a buildpack", func() {
+		Expect(application.AddBuildpack("heroku/apt", 0)).To(Succeed())
+		Expect(application.AddBuildpack("heroku/nodejs", 0)).To(Succeed())
+		application.ClearEvents()
+
+		Expect(application.RemoveBuildpack("heroku/apt")).To(Succeed())
+		Expect(application.GetBuildpacks()).To(Equal([]string{"heroku/nodejs"}))
+		Expect(application.GetEvents()[0].EventType()).To(Equal("application.buildpack.removed"))
+
+		Expect(application.RemoveBuildpack("heroku/apt")).NotTo(Succeed())
+	})
+
+	It("should replace every buildpack with SetBuildpack", func() {
+		Expect(application.AddBuildpack("heroku/apt", 0)).To(Succeed())
+		Expect(application.AddBuildpack("heroku/nodejs", 0)).To(Succeed())
+
+		Expect(application.SetBuildpack("heroku/python")).To(Succeed())
+		Expect(application.GetBuildpacks()).To(Equal([]string{"heroku/python"}))
+	})
+
+	It("should not record setting the current buildpack again", func() {
+		clock := &steppingClock{now: time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)}
+		application, err := app.NewApplication("my-app", app.WithClock(clock))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(application.SetBuildpack("heroku/nodejs")).To(Succeed())
+		application.ClearEvents()
+		updatedAt := application.UpdatedAt()
+
+		Expect(application.SetBuildpack("heroku/nodejs")).To(Succeed())
+		Expect(application.GetEvents()).To(BeEmpty())
+		Expect(application.UpdatedAt()).To(Equal(updatedAt))
+
+		Expect(application.SetBuildpack("heroku/nodejs?")).To(MatchError(app.ErrInvalidBuildpack))
+		Expect(application.SetBuildpack("heroku/python")).To(Succeed())
+		Expect(application.GetEvents()).To(HaveLen(1))
+	})
+
+	It("should still record narrowing several buildpacks to one of them", func() {
+		Expect(application.AddBuildpack("heroku/nodejs", 0)).To(Succeed())
+		Expect(application.AddBuildpack("heroku/apt", 0)).To(Succeed())
+		application.ClearEvents()
+
+		Expect(application.SetBuildpack("heroku/nodejs")).To(Succeed())
+		Expect(application.GetBuildpacks()).To(Equal([]string{"heroku/nodejs"}))
+		Expect(application.GetEvents()).To(HaveLen(1))
+	})
+
+	It("should replay the buildpack order", func() {
+		Expect(application.AddBuildpack("heroku/nodejs", 0)).To(Succeed())
+		Expect(application.AddBuildpack("heroku/apt", 1)).To(Succeed())
+		Expect(application.RemoveBuildpack("heroku/nodejs")).To(Succeed())
+
+		replayed, err := app.ReplayApplication("my-app", application.GetEvents())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(replayed.GetBuildpacks()).To(Equal([]string{"heroku/apt"}))
+	})
+})
+
+var _ = Describe("Application health results", func() {
+	var application *app.Application
+
+	BeforeEach(func() {
+		var err error
+		application, err = app.NewApplicationWithState("probed-app", app.StateRunning)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should not be healthy until a probe passes", func() {
+		Expect(application.IsRunning()).To(BeTrue())
+		Expect(application.IsHealthy()).To(BeFalse())
+		Expect(application.StaleHealth(time.Hour)).To(BeTrue())
+
+		application.RecordHealthResult(true, time.Now())
+		Expect(application.IsHealthy()).To(BeTrue())
+		Expect(application.StaleHealth(time.Hour)).To(BeFalse())
+	})
+
+	It("should not be healthy after a failed probe or when not running", func() {
+		application.RecordHealthResult(false, time.Now())
+		Expect(application.IsHealthy()).To(BeFalse())
+
+		application.RecordHealthResult(true, time.Now())
+		Expect(application.Stop()).To(Succeed())
+		Expect(application.IsHealthy()).To(BeFalse())
+	})
+
+	It("should keep the most recent probe", func() {
+		now := time.Now()
+		application.RecordHealthResult(true, now)
+		application.RecordHealthResult(false, now.Add(-time.Minute))
+		Expect(application.LastHealthResult().Passed()).To(BeTrue())
+		Expect(application.LastHealthResult().ObservedAt()).To(Equal(now))
+	})
+
+	It("should flag an old probe as stale", func() {
+		application.RecordHealthResult(true, time.Now().Add(-10*time.Minute))
+		Expect(application.StaleHealth(5 * time.Minute)).To(BeTrue())
+		Expect(application.IsHealthy()).To(BeTrue())
+	})
+
+	It("should forget the probe of the previous release on deploy", func() {
+		application.RecordHealthResult(true, time.Now())
+		Expect(application.Deploy(shared.MustNewGitRef("main"), nil)).To(Succeed())
+		Expect(application.CompleteDeployment()).To(Succeed())
+		Expect(application.LastHealthResult()).To(BeNil())
+		Expect(application.IsHealthy()).To(BeFalse())
+	})
+})
+
+var _ = Describe("Application labels", func() {
+	var application *app.Application
+
+	BeforeEach(func() {
+		var err error
+		application, err = app.NewApplication("labelled-app")
+		Expect(err).NotTo(HaveOccurred())
+		application.ClearEvents()
+	})
+
+	It("should set and remove labels with events", func() {
+		Expect(application.SetLabel("team", "payments")).To(Succeed())
+		Expect(application.SetLabel("env", "production")).To(Succeed())
+		Expect(application.Labels()).To(Equal(map[string]string{"team": "payments", "env": "production"}))
+
+		Expect(application.RemoveLabel("env")).To(Succeed())
+		Expect(application.Labels()).To(Equal(map[string]string{"team": "payments"}))
+
+		events := application.GetEvents()
+		Expect(events).To(HaveLen(3))
+		removed, ok := events[2].(*app.LabelChangedEvent)
+		Expect(ok).To(BeTrue())
+		Expect(removed.Key()).To(Equal("env"))
+		Expect(removed.Removed()).To(BeTrue())
+	})
+
+	It("should not emit an event when the value is unchanged", func() {
+		Expect(application.SetLabel("team", "payments")).To(Succeed())
+		Expect(application.SetLabel("team", "payments")).To(Succeed())
+		Expect(application.GetEvents()).To(HaveLen(1))
+	})
+
+	It("should return a copy of the labels", func() {
+		Expect(application.SetLabel("team", "payments")).To(Succeed())
+		application.Labels()["team"] = "search"
+		Expect(application.Labels()["team"]).To(Equal("payments"))
+	})
+
+	DescribeTable("should reject invalid labels",
+		func(key, value string) {
+			Expect(application.SetLabel(key, value)).To(MatchError(app.ErrInvalidLabel))
+			Expect(application.GetEvents()).To(BeEmpty())
+		},
+		Entry("empty key", "", "payments"),
+		Entry("uppercase key", "Team", "payments"),
+		Entry("key with a space", "my team", "payments"),
+		Entry("key ending with a hyphen", "team-", "payments"),
+		Entry("value with a newline", "team", "pay\nments"),
+	)
+
+	It("should fail to remove a missing label", func() {
+		Expect(application.RemoveLabel("team")).To(MatchError(app.ErrLabelNotFound))
+	})
+
+	It("should replay labels", func() {
+		Expect(application.SetLabel("team", "payments")).To(Succeed())
+		Expect(application.SetLabel("env", "staging")).To(Succeed())
+		Expect(application.RemoveLabel("env")).To(Succeed())
+
+		replayed, err := app.ReplayApplication("labelled-app", application.GetEvents())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(replayed.Labels()).To(Equal(map[string]string{"team": "payments"}))
+	})
+})
+
+type steppingClock struct {
+	now time.Time
+}
+
+func (c *steppingClock) Now() time.Time {
+	c.now = c.now.Add(time.Second)
+	return c.now
+}
+
+var _ = Describe("Application event retention", func() {
+	It("should drop the oldest events past the cap", func() {
+		application, err := app.NewApplication("busy-app", app.WithEventRetention(3))
+		Expect(err).NotTo(HaveOccurred())
+		for _, domain := range []string{"a.example.com", "b.example.com", "c.example.com", "d.example.com"} {
+			Expect(application.AddDomain(domain)).To(Succeed())
+		}
+
+		events := application.GetEvents()
+		Expect(events).To(HaveLen(3))
+		Expect(events[0].Sequence()).To(Equal(uint64(3)))
+		Expect(events[2].Sequence()).To(Equal(application.LastEventSequence()))
+		Expect(application.DroppedEventCount()).To(Equal(2))
+
+		application.ClearEvents()
+		Expect(application.DroppedEventCount()).To(BeZero())
+	})
+
+	It("should keep every event without a cap", func() {
+		application, _ := app.NewApplication("busy-app", app.WithEventRetention(0))
+		for i := range app.DefaultEventRetention {
+			Expect(application.SetLabel("build", fmt.Sprint(i))).To(Succeed())
+		}
+
+		Expect(application.GetEvents()).To(HaveLen(app.DefaultEventRetention + 1))
+		Expect(application.DroppedEventCount()).To(BeZero())
+	})
+
+	It("should roll a batch back even when events were dropped", func() {
+		application, _ := app.NewApplication("busy-app", app.WithEventRetention(2))
+		Expect(application.AddDomain("a.example.com")).To(Succeed())
+
+		_, err := application.ApplyChanges([]app.ConfigChange{
+			{Kind: app.ConfigChangeAddDomain, Key: "b.example.com"},
+			{Kind: app.ConfigChangeAddDomain, Key: "c.example.com"},
+			{Kind: app.ConfigChangeRemoveDomain, Key: "missing.example.com"},
+		}, true)
+		Expect(err).To(HaveOccurred())
+
+		Expect(application.GetDomains()).To(Equal([]string{"a.example.com"}))
+		for _, event := range application.GetEvents() {
+			Expect(event.Sequence()).To(BeNumerically("<=", 2))
+		}
+	})
+})
+
+var _ = Describe("Application clock", func() {
+	start := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	It("should timestamp the creation and the changes with the injected clock", func() {
+		clock := &steppingClock{now: start}
+		application, err := app.NewApplication("clocked-app", app.WithClock(clock))
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(application.CreatedAt()).To(Equal(start.Add(time.Second)))
+		Expect(application.GetEvents()[0].OccurredAt()).To(Equal(start.Add(3 * time.Second)))
+
+		Expect(application.AddDomain("example.com")).To(Succeed())
+		Expect(application.UpdatedAt()).To(Equal(start.Add(4 * time.Second)))
+		Expect(application.GetEvents()[1].OccurredAt()).To(Equal(start.Add(5 * time.Second)))
+	})
+
+	It("should measure the health result age with the clock", func() {
+		clock := &steppingClock{now: start}
+		application, _ := app.NewApplicationWithState("clocked-app", app.StateRunning, app.WithClock(clock))
+		application.RecordHealthResult(true, start)
+
+		clock.now = start.Add(time.Minute)
+		Expect(application.StaleHealth(2 * time.Minute)).To(BeFalse())
+		clock.now = start.Add(time.Hour)
+		Expect(application.StaleHealth(2 * time.Minute)).To(BeTrue())
+	})
+
+	It("should default to the real clock", func() {
+		before := time.Now()
+		application, _ := app.NewApplication("clocked-app")
+		Expect(application.CreatedAt()).To(BeTemporally(">=", before))
+		Expect(application.CreatedAt()).To(BeTemporally("<=", time.Now()))
+	})
+})
+
+// fixedClock only moves when the test sets it
+type fixedClock struct {
+	now time.Time
+}
+
+func (c *fixedClock) Now() time.Time {
+	return c.now
+}
+
+var _ = Describe("Application deployment duration", func() {
+	start := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	var (
+		clock       *fixedClock
+		application *app.Application
+	)
+
+	BeforeEach(func() {
+		clock = &fixedClock{now: start}
+		application, _ = app.NewApplication("timed-app", app.WithClock(clock))
+		Expect(application.Deploy(shared.MustNewGitRef("main"), nil)).To(Succeed())
+		application.ClearEvents()
+	})
+
+	It("should record the duration of a completed deployment", func() {
+		clock.now = clock.now.Add(2 * time.Minute)
+		Expect(application.CompleteDeployment()).To(Succeed())
+
+		Expect(application.LastDeploymentDuration()).To(Equal(2 * time.Minute))
+		completed, ok := application.GetEvents()[0].(*app.ApplicationDeploymentCompletedEvent)
+		Expect(ok).To(BeTrue())
+		Expect(completed.Duration()).To(Equal(application.LastDeploymentDuration()))
+		Expect(application.DeploymentSummary().DurationSeconds).To(Equal(120.0))
+	})
+
+	It("should record the duration of a failed deployment", func() {
+		clock.now = clock.now.Add(30 * time.Second)
+		Expect(application.FailDeployment("build failed")).To(Succeed())
+
+		failed, ok := application.GetEvents()[0].(*app.ApplicationDeploymentFailedEvent)
+		Expect(ok).To(BeTrue())
+		Expect(failed.Duration()).To(Equal(30 * time.Second))
+		Expect(application.LastDeploymentDuration()).To(Equal(30 * time.Second))
+	})
+
+	It("should keep the last failure until a deployment completes", func() {
+		reason, failedAt := application.LastFailure()
+		Expect(reason).To(BeEmpty())
+		Expect(failedAt).To(BeNil())
+
+		clock.now = clock.now.Add(time.Minute)
+		Expect(application.FailDeployment("build failed")).To(Succeed())
+
+		reason, failedAt = application.LastFailure()
+		Expect(reason).To(Equal("build failed"))
+		Expect(*failedAt).To(Equal(start.Add(time.Minute)))
+		Expect(application.Status().LastFailure).To(Equal(&app.DeploymentFailureData{
+			Reason:   "build failed",
+			FailedAt: start.Add(time.Minute),
+		}))
+
+		replayed, err := app.ReplayApplication("timed-app", application.GetEvents())
+		Expect(err).NotTo(HaveOccurred())
+		reason, _ = replayed.LastFailure()
+		Expect(reason).To(Equal("build failed"))
+
+		Expect(application.Deploy(shared.MustNewGitRef("main"), nil)).To(Succeed())
+		reason, _ = application.LastFailure()
+		Expect(reason).To(Equal("build failed"))
+
+		Expect(application.CompleteDeployment()).To(Succeed())
+		_, failedAt = application.LastFailure()
+		Expect(failedAt).To(BeNil())
+		Expect(application.Status().LastFailure).To(BeNil())
+	})
+
+	It("should reset the duration when a new deployment starts", func() {
+		Expect(application.CompleteDeployment()).To(Succeed())
+		Expect(application.Deploy(shared.MustNewGitRef("main"), nil)).To(Succeed())
+
+		Expect(application.LastDeploymentDuration()).To(BeZero())
+	})
+})
+
+var _ = Describe("Application restart policy", func() {
+	var application *app.Application
+
+	BeforeEach(func() {
+		var err error
+		application, err = app.NewApplication("restarting-app")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(application.AddProcess(process.ProcessTypeWeb, "npm start", 1)).To(Succeed())
+		application.ClearEvents()
+	})
+
+	It("should set the policy of a process and emit an event", func() {
+		policy, err := process.NewRestartPolicyFromString("on-failure:5")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(application.SetRestartPolicy(process.ProcessTypeWeb, policy)).To(Succeed())
+
+		Expect(application.GetProcessRestartPolicy(process.ProcessTypeWeb).MaxRestarts()).To(Equal(5))
+		events := application.GetEvents()
+		Expect(events).To(HaveLen(1))
+		changed, ok := events[0].(*app.RestartPolicyChangedEvent)
+		Expect(ok).To(BeTrue())
+		Expect(changed.ProcessType()).To(Equal("web"))
+		Expect(changed.Policy()).To(Equal("on-failure:5"))
+	})
+
+	It("should not emit an event when the policy is unchanged", func() {
+		first, _ := process.NewRestartPolicyFromString("always")
+		second, _ := process.NewRestartPolicyFromString("always")
+		Expect(application.SetRestartPolicy(process.ProcessTypeWeb, first)).To(Succeed())
+		Expect(application.SetRestartPolicy(process.ProcessTypeWeb, second)).To(Succeed())
+		Expect(application.GetEvents()).To(HaveLen(1))
+	})
+
+	It("should fail for a missing process", func() {
+		policy, _ := process.NewRestartPolicy(process.RestartPolicyAlways)
+		Expect(application.SetRestartPolicy("worker", policy)).To(MatchError(app.ErrProcessNotFound))
+		Expect(application.SetRestartPolicy(process.ProcessTypeWeb, nil)).NotTo(Succeed())
+	})
+
+	It("should replay the policy", func() {
+		policy, _ := process.NewRestartPolicyFromString("no")
+		Expect(application.SetRestartPolicy(process.ProcessTypeWeb, policy)).To(Succeed())
+
+		events := append([]app.DomainEvent{app.NewApplicationScaledEvent("restarting-app", "web", 0, 1, time.Now())}, application.GetEvents()...)
+		replayed, err := app.ReplayApplication("restarting-app", events)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(replayed.GetProcessRestartPolicy(process.ProcessTypeWeb).Policy()).To(Equal(process.RestartPolicyNever))
+	})
+})
+
+var _ = Describe("Application internal environment variables", func() {
+	var application *app.Application
+
+	BeforeEach(func() {
+		var err error
+		application, err = app.NewApplication("env-app")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(application.LoadEnvironmentVariable("DOKKU_PROXY_PORT", "80")).To(Succeed())
+		Expect(application.LoadEnvironmentVariable("GIT_REV", "abc123")).To(Succeed())
+		Expect(application.SetEnvironmentVariable("NODE_ENV", "production")).To(Succeed())
+	})
+
+	It("should only list the internal variables when asked", func() {
+		Expect(application.GetEnvironmentVariables(false)).To(Equal(map[string]string{"NODE_ENV": "production"}))
+		Expect(application.GetEnvironmentVariables(true)).To(HaveLen(3))
+		Expect(application.GetEnvironmentVariables(true)).To(HaveKeyWithValue("GIT_REV", "abc123"))
+	})
+
+	It("should refuse to set or unset an internal variable", func() {
+		err := application.SetEnvironmentVariable("DOKKU_PROXY_PORT", "8080")
+		Expect(err).To(MatchError(app.ErrInternalEnvVar))
+		Expect(err.Error()).To(ContainSubstring("DOKKU_PROXY_PORT"))
+		Expect(application.UnsetEnvironmentVariable("GIT_REV")).To(MatchError(app.ErrInternalEnvVar))
+		Expect(application.GetEnvironmentVariables(true)).To(HaveKeyWithValue("DOKKU_PROXY_PORT", "80"))
+	})
+
+	It("should keep the internal variables out of a reconcile plan", func() {
+		plan := application.Reconcile(&app.LiveAppState{EnvKeys: []string{"NODE_ENV", "DOKKU_APP_TYPE"}}, app.ReconcileToLive)
+		Expect(plan.Changes).To(BeEmpty())
+	})
+})
@@ -1,6 +1,9 @@
 package app
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
 
 // Application domain specific errors
 var (
@@ -10,5 +13,53 @@ var (
 	ErrInvalidApplicationName   = errors.New("invalid application name")
 	ErrApplicationNotDeployed   = errors.New("application not deployed")
 	ErrDeploymentInProgress     = errors.New("deployment already in progress")
+	ErrNoDeploymentInProgress   = errors.New("no deployment in progress")
 	ErrInvalidState             = errors.New("invalid application state")
+	ErrInvalidDomain            = errors.New("invalid domain")
+	ErrDomainAlreadyExists      = errors.New("domain already exists")
+	ErrDomainNotFound           = errors.New("domain not found")
+	ErrInvalidBuildpack         = errors.New("invalid buildpack")
+	ErrBuildpackAlreadyExists   = errors.New("buildpack already exists")
+	ErrBuildpackNotFound        = errors.New("buildpack not found")
+	ErrProcessNotFound          = errors.New("process not found")
+	ErrInternalEnvVar           = errors.New("environment variable managed by Dokku")
+	ErrInvalidLabel             = errors.New("invalid label")
+	ErrLabelNotFound            = errors.New("label not found")
+	ErrInvariantViolated        = errors.New("application invariant violated")
+	ErrScheduledTaskExists      = errors.New("scheduled task already exists")
+	ErrScheduledTaskNotFound    = errors.New("scheduled task not found")
+	ErrDockerOptionExists       = errors.New("docker option already exists")
+	ErrDockerOptionNotFound     = errors.New("docker option not found")
+	ErrDestroyNotConfirmed      = errors.New("destroy not confirmed")
+	ErrMemoryBudgetExceeded     = errors.New("host memory budget exceeded")
 )
+
+// OperationError is the error of a domain operation. It keeps the message of the
+// operation while matching its Kind, one of the errors above, and its Cause, if any,
+// through errors.Is and errors.As.
+type OperationError struct {
+	Kind    error
+	Cause   error
+	message string
+}
+
+func (e *OperationError) Error() string {
+	return e.message
+}
+
+func (e *OperationError) Unwrap() []error {
+	if e.Cause == nil {
+		return []error{e.Kind}
+	}
+	return []error{e.Kind, e.Cause}
+}
+
+// newOperationError formats the message like fmt.Errorf, taking the cause from a %w verb
+func newOperationError(kind error, format string, args ...any) *OperationError {
+	err := fmt.Errorf(format, args...)
+	return &OperationError{
+		Kind:    kind,
+		Cause:   errors.Unwrap(err),
+		message: err.Error(),
+	}
+}
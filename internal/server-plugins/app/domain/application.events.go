@@ -1,10 +1,25 @@
 package app
 
 import (
+	"maps"
+	"slices"
 	"time"
 )
 
+// sequenced gives an event its position in the aggregate event stream. Unlike
+// timestamps, sequence numbers are strictly increasing, even within one operation.
+type sequenced struct {
+	sequence uint64
+}
+
+// Sequence returns the position of the event in its aggregate stream, starting at 1.
+// It is 0 for an event not recorded by an aggregate yet.
+func (s *sequenced) Sequence() uint64 { return s.sequence }
+
+func (s *sequenced) setSequence(sequence uint64) { s.sequence = sequence }
+
 type ApplicationCreatedEvent struct {
+	sequenced
 	aggregateID string
 	occurredAt  time.Time
 }
@@ -20,7 +35,26 @@ func (e *ApplicationCreatedEvent) OccurredAt() time.Time { return e.occurredAt }
 func (e *ApplicationCreatedEvent) EventType() string     { return "application.created" }
 func (e *ApplicationCreatedEvent) AggregateID() string   { return e.aggregateID }
 
+// ApplicationDestroyedEvent records the destruction of the application, it ends the stream
+type ApplicationDestroyedEvent struct {
+	sequenced
+	aggregateID string
+	occurredAt  time.Time
+}
+
+func NewApplicationDestroyedEvent(aggregateID string, occurredAt time.Time) *ApplicationDestroyedEvent {
+	return &ApplicationDestroyedEvent{
+		aggregateID: aggregateID,
+		occurredAt:  occurredAt,
+	}
+}
+
+func (e *ApplicationDestroyedEvent) OccurredAt() time.Time { return e.occurredAt }
+func (e *ApplicationDestroyedEvent) EventType() string     { return "application.destroyed" }
+func (e *ApplicationDestroyedEvent) AggregateID() string   { return e.aggregateID }
+
 type ApplicationDeployedEvent struct {
+	sequenced
 	aggregateID string
 	gitRef      string
 	occurredAt  time.Time
@@ -39,26 +73,130 @@ func (e *ApplicationDeployedEvent) EventType() string     { return "application.
 func (e *ApplicationDeployedEvent) AggregateID() string   { return e.aggregateID }
 func (e *ApplicationDeployedEvent) GitRef() string        { return e.gitRef }
 
+type ApplicationDeployedFromImageEvent struct {
+	sequenced
+	aggregateID string
+	image       string
+	occurredAt  time.Time
+}
+
+func NewApplicationDeployedFromImageEvent(aggregateID, image string, occurredAt time.Time) *ApplicationDeployedFromImageEvent {
+	return &ApplicationDeployedFromImageEvent{
+		aggregateID: aggregateID,
+		image:       image,
+		occurredAt:  occurredAt,
+	}
+}
+
+func (e *ApplicationDeployedFromImageEvent) OccurredAt() time.Time { return e.occurredAt }
+func (e *ApplicationDeployedFromImageEvent) EventType() string     { return "application.deployed.image" }
+func (e *ApplicationDeployedFromImageEvent) AggregateID() string   { return e.aggregateID }
+func (e *ApplicationDeployedFromImageEvent) Image() string         { return e.image }
+
+// ApplicationRebuiltEvent records a rebuild of the current git ref, as ps:rebuild
+// does, the rebuild being completed or failed like a deployment
+type ApplicationRebuiltEvent struct {
+	sequenced
+	aggregateID string
+	gitRef      string
+	noCache     bool
+	forceClean  bool
+	occurredAt  time.Time
+}
+
+func NewApplicationRebuiltEvent(aggregateID, gitRef string, noCache, forceClean bool, occurredAt time.Time) *ApplicationRebuiltEvent {
+	return &ApplicationRebuiltEvent{
+		aggregateID: aggregateID,
+		gitRef:      gitRef,
+		noCache:     noCache,
+		forceClean:  forceClean,
+		occurredAt:  occurredAt,
+	}
+}
+
+func (e *ApplicationRebuiltEvent) OccurredAt() time.Time { return e.occurredAt }
+func (e *ApplicationRebuiltEvent) EventType() string     { return "application.rebuilt" }
+func (e *ApplicationRebuiltEvent) AggregateID() string   { return e.aggregateID }
+func (e *ApplicationRebuiltEvent) GitRef() string        { return e.gitRef }
+func (e *ApplicationRebuiltEvent) NoCache() bool         { return e.noCache }
+func (e *ApplicationRebuiltEvent) ForceClean() bool      { return e.forceClean }
+
+// ApplicationDeploymentCompletedEvent records the success of the deployment in progress,
+// the deployed event being recorded when it starts
+type ApplicationDeploymentCompletedEvent struct {
+	sequenced
+	aggregateID string
+	duration    time.Duration
+	occurredAt  time.Time
+}
+
+func NewApplicationDeploymentCompletedEvent(aggregateID string, duration time.Duration, occurredAt time.Time) *ApplicationDeploymentCompletedEvent {
+	return &ApplicationDeploymentCompletedEvent{
+		aggregateID: aggregateID,
+		duration:    duration,
+		occurredAt:  occurredAt,
+	}
+}
+
+func (e *ApplicationDeploymentCompletedEvent) OccurredAt() time.Time { return e.occurredAt }
+func (e *ApplicationDeploymentCompletedEvent) EventType() string {
+	return "application.deployment.completed"
+}
+func (e *ApplicationDeploymentCompletedEvent) AggregateID() string     { return e.aggregateID }
+func (e *ApplicationDeploymentCompletedEvent) Duration() time.Duration { return e.duration }
+
 type ApplicationDeploymentFailedEvent struct {
+	sequenced
+	aggregateID  string
+	reason       string
+	duration     time.Duration
+	checkResults []CheckResult
+	occurredAt   time.Time
+}
+
+// NewApplicationDeploymentFailedEvent creates the event, with the failed checks if the
+// deployment failed its zero-downtime checks
+func NewApplicationDeploymentFailedEvent(aggregateID, reason string, duration time.Duration, occurredAt time.Time, checkResults ...CheckResult) *ApplicationDeploymentFailedEvent {
+	return &ApplicationDeploymentFailedEvent{
+		aggregateID:  aggregateID,
+		reason:       reason,
+		duration:     duration,
+		checkResults: checkResults,
+		occurredAt:   occurredAt,
+	}
+}
+
+func (e *ApplicationDeploymentFailedEvent) OccurredAt() time.Time   { return e.occurredAt }
+func (e *ApplicationDeploymentFailedEvent) EventType() string       { return "application.deployment.failed" }
+func (e *ApplicationDeploymentFailedEvent) AggregateID() string     { return e.aggregateID }
+func (e *ApplicationDeploymentFailedEvent) Reason() string          { return e.reason }
+func (e *ApplicationDeploymentFailedEvent) Duration() time.Duration { return e.duration }
+func (e *ApplicationDeploymentFailedEvent) CheckResults() []CheckResult {
+	return slices.Clone(e.checkResults)
+}
+
+type ApplicationUndeployedEvent struct {
+	sequenced
 	aggregateID string
 	reason      string
 	occurredAt  time.Time
 }
 
-func NewApplicationDeploymentFailedEvent(aggregateID, reason string, occurredAt time.Time) *ApplicationDeploymentFailedEvent {
-	return &ApplicationDeploymentFailedEvent{
+func NewApplicationUndeployedEvent(aggregateID, reason string, occurredAt time.Time) *ApplicationUndeployedEvent {
+	return &ApplicationUndeployedEvent{
 		aggregateID: aggregateID,
 		reason:      reason,
 		occurredAt:  occurredAt,
 	}
 }
 
-func (e *ApplicationDeploymentFailedEvent) OccurredAt() time.Time { return e.occurredAt }
-func (e *ApplicationDeploymentFailedEvent) EventType() string     { return "application.deployment.failed" }
-func (e *ApplicationDeploymentFailedEvent) AggregateID() string   { return e.aggregateID }
-func (e *ApplicationDeploymentFailedEvent) Reason() string        { return e.reason }
+func (e *ApplicationUndeployedEvent) OccurredAt() time.Time { return e.occurredAt }
+func (e *ApplicationUndeployedEvent) EventType() string     { return "application.undeployed" }
+func (e *ApplicationUndeployedEvent) AggregateID() string   { return e.aggregateID }
+func (e *ApplicationUndeployedEvent) Reason() string        { return e.reason }
 
 type ApplicationScaledEvent struct {
+	sequenced
 	aggregateID string
 	processType string
 	oldScale    int
@@ -84,6 +222,7 @@ func (e *ApplicationScaledEvent) OldScale() int         { return e.oldScale }
 func (e *ApplicationScaledEvent) NewScale() int         { return e.newScale }
 
 type ApplicationStateChangedEvent struct {
+	sequenced
 	aggregateID string
 	oldState    string
 	newState    string
@@ -106,6 +245,7 @@ func (e *ApplicationStateChangedEvent) OldState() string      { return e.oldStat
 func (e *ApplicationStateChangedEvent) NewState() string      { return e.newState }
 
 type DomainAddedEvent struct {
+	sequenced
 	aggregateID string
 	domain      string
 	occurredAt  time.Time
@@ -125,6 +265,7 @@ func (e *DomainAddedEvent) AggregateID() string   { return e.aggregateID }
 func (e *DomainAddedEvent) Domain() string        { return e.domain }
 
 type DomainRemovedEvent struct {
+	sequenced
 	aggregateID string
 	domain      string
 	occurredAt  time.Time
@@ -144,6 +285,7 @@ func (e *DomainRemovedEvent) AggregateID() string   { return e.aggregateID }
 func (e *DomainRemovedEvent) Domain() string        { return e.domain }
 
 type BuildpackChangedEvent struct {
+	sequenced
 	aggregateID string
 	buildpack   string
 	occurredAt  time.Time
@@ -161,3 +303,418 @@ func (e *BuildpackChangedEvent) OccurredAt() time.Time { return e.occurredAt }
 func (e *BuildpackChangedEvent) EventType() string     { return "application.buildpack.changed" }
 func (e *BuildpackChangedEvent) AggregateID() string   { return e.aggregateID }
 func (e *BuildpackChangedEvent) Buildpack() string     { return e.buildpack }
+
+type BuildpackAddedEvent struct {
+	sequenced
+	aggregateID string
+	buildpack   string
+	index       int
+	occurredAt  time.Time
+}
+
+func NewBuildpackAddedEvent(aggregateID, buildpack string, index int, occurredAt time.Time) *BuildpackAddedEvent {
+	return &BuildpackAddedEvent{
+		aggregateID: aggregateID,
+		buildpack:   buildpack,
+		index:       index,
+		occurredAt:  occurredAt,
+	}
+}
+
+func (e *BuildpackAddedEvent) OccurredAt() time.Time { return e.occurredAt }
+func (e *BuildpackAddedEvent) EventType() string     { return "application.buildpack.added" }
+func (e *BuildpackAddedEvent) AggregateID() string   { return e.aggregateID }
+func (e *BuildpackAddedEvent) Buildpack() string     { return e.buildpack }
+func (e *BuildpackAddedEvent) Index() int            { return e.index }
+
+type BuildpackRemovedEvent struct {
+	sequenced
+	aggregateID string
+	buildpack   string
+	occurredAt  time.Time
+}
+
+func NewBuildpackRemovedEvent(aggregateID, buildpack string, occurredAt time.Time) *BuildpackRemovedEvent {
+	return &BuildpackRemovedEvent{
+		aggregateID: aggregateID,
+		buildpack:   buildpack,
+		occurredAt:  occurredAt,
+	}
+}
+
+func (e *BuildpackRemovedEvent) OccurredAt() time.Time { return e.occurredAt }
+func (e *BuildpackRemovedEvent) EventType() string     { return "application.buildpack.removed" }
+func (e *BuildpackRemovedEvent) AggregateID() string   { return e.aggregateID }
+func (e *BuildpackRemovedEvent) Buildpack() string     { return e.buildpack }
+
+type ProcessLimitsChangedEvent struct {
+	sequenced
+	aggregateID string
+	processType string
+	memory      string
+	cpu         string
+	storage     string
+	occurredAt  time.Time
+}
+
+func NewProcessLimitsChangedEvent(aggregateID, processType, memory, cpu, storage string, occurredAt time.Time) *ProcessLimitsChangedEvent {
+	return &ProcessLimitsChangedEvent{
+		aggregateID: aggregateID,
+		processType: processType,
+		memory:      memory,
+		cpu:         cpu,
+		storage:     storage,
+		occurredAt:  occurredAt,
+	}
+}
+
+func (e *ProcessLimitsChangedEvent) OccurredAt() time.Time { return e.occurredAt }
+func (e *ProcessLimitsChangedEvent) EventType() string     { return "application.process.limits.changed" }
+func (e *ProcessLimitsChangedEvent) AggregateID() string   { return e.aggregateID }
+func (e *ProcessLimitsChangedEvent) ProcessType() string   { return e.processType }
+func (e *ProcessLimitsChangedEvent) Memory() string        { return e.memory }
+func (e *ProcessLimitsChangedEvent) CPU() string           { return e.cpu }
+func (e *ProcessLimitsChangedEvent) Storage() string       { return e.storage }
+
+type HealthChecksChangedEvent struct {
+	sequenced
+	aggregateID string
+	wait        time.Duration
+	timeout     time.Duration
+	attempts    int
+	skipped     bool
+	occurredAt  time.Time
+}
+
+func NewHealthChecksChangedEvent(aggregateID string, wait, timeout time.Duration, attempts int, skipped bool, occurredAt time.Time) *HealthChecksChangedEvent {
+	return &HealthChecksChangedEvent{
+		aggregateID: aggregateID,
+		wait:        wait,
+		timeout:     timeout,
+		attempts:    attempts,
+		skipped:     skipped,
+		occurredAt:  occurredAt,
+	}
+}
+
+func (e *HealthChecksChangedEvent) OccurredAt() time.Time  { return e.occurredAt }
+func (e *HealthChecksChangedEvent) EventType() string      { return "application.healthchecks.changed" }
+func (e *HealthChecksChangedEvent) AggregateID() string    { return e.aggregateID }
+func (e *HealthChecksChangedEvent) Wait() time.Duration    { return e.wait }
+func (e *HealthChecksChangedEvent) Timeout() time.Duration { return e.timeout }
+func (e *HealthChecksChangedEvent) Attempts() int          { return e.attempts }
+func (e *HealthChecksChangedEvent) Skipped() bool          { return e.skipped }
+
+type RunAsUserChangedEvent struct {
+	sequenced
+	aggregateID  string
+	previousUser string
+	user         string
+	occurredAt   time.Time
+}
+
+func NewRunAsUserChangedEvent(aggregateID, previousUser, user string, occurredAt time.Time) *RunAsUserChangedEvent {
+	return &RunAsUserChangedEvent{
+		aggregateID:  aggregateID,
+		previousUser: previousUser,
+		user:         user,
+		occurredAt:   occurredAt,
+	}
+}
+
+func (e *RunAsUserChangedEvent) OccurredAt() time.Time { return e.occurredAt }
+func (e *RunAsUserChangedEvent) EventType() string     { return "application.runasuser.changed" }
+func (e *RunAsUserChangedEvent) AggregateID() string   { return e.aggregateID }
+func (e *RunAsUserChangedEvent) User() string          { return e.user }
+func (e *RunAsUserChangedEvent) PreviousUser() string  { return e.previousUser }
+
+// DockerOptions returns the --user docker options to remove and to add, empty when the
+// container ran or now runs as the image default user
+func (e *RunAsUserChangedEvent) DockerOptions() (removed, added string) {
+	if e.previousUser != "" {
+		removed = (&ContainerUser{value: e.previousUser}).DockerOption()
+	}
+	if e.user != "" {
+		added = (&ContainerUser{value: e.user}).DockerOption()
+	}
+	return removed, added
+}
+
+// ApplicationRenamedEvent records a change of the application identity. Its aggregate ID
+// is the old name, the identity the stream was keyed by until then; the events that
+// follow carry the new name, so a stream keyed by name ends with this event.
+type ApplicationRenamedEvent struct {
+	sequenced
+	aggregateID string
+	newName     string
+	occurredAt  time.Time
+}
+
+func NewApplicationRenamedEvent(oldName, newName string, occurredAt time.Time) *ApplicationRenamedEvent {
+	return &ApplicationRenamedEvent{
+		aggregateID: oldName,
+		newName:     newName,
+		occurredAt:  occurredAt,
+	}
+}
+
+func (e *ApplicationRenamedEvent) OccurredAt() time.Time { return e.occurredAt }
+func (e *ApplicationRenamedEvent) EventType() string     { return "application.renamed" }
+func (e *ApplicationRenamedEvent) AggregateID() string   { return e.aggregateID }
+func (e *ApplicationRenamedEvent) OldName() string       { return e.aggregateID }
+func (e *ApplicationRenamedEvent) NewName() string       { return e.newName }
+
+type GitConfigChangedEvent struct {
+	sequenced
+	aggregateID  string
+	deployBranch string
+	revEnvVar    string
+	keepGitDir   bool
+	occurredAt   time.Time
+}
+
+func NewGitConfigChangedEvent(aggregateID, deployBranch, revEnvVar string, keepGitDir bool, occurredAt time.Time) *GitConfigChangedEvent {
+	return &GitConfigChangedEvent{
+		aggregateID:  aggregateID,
+		deployBranch: deployBranch,
+		revEnvVar:    revEnvVar,
+		keepGitDir:   keepGitDir,
+		occurredAt:   occurredAt,
+	}
+}
+
+func (e *GitConfigChangedEvent) OccurredAt() time.Time { return e.occurredAt }
+func (e *GitConfigChangedEvent) EventType() string     { return "application.git.changed" }
+func (e *GitConfigChangedEvent) AggregateID() string   { return e.aggregateID }
+func (e *GitConfigChangedEvent) DeployBranch() string  { return e.deployBranch }
+func (e *GitConfigChangedEvent) RevEnvVar() string     { return e.revEnvVar }
+func (e *GitConfigChangedEvent) KeepGitDir() bool      { return e.keepGitDir }
+
+type MaintenanceEnabledEvent struct {
+	sequenced
+	aggregateID string
+	occurredAt  time.Time
+}
+
+func NewMaintenanceEnabledEvent(aggregateID string, occurredAt time.Time) *MaintenanceEnabledEvent {
+	return &MaintenanceEnabledEvent{
+		aggregateID: aggregateID,
+		occurredAt:  occurredAt,
+	}
+}
+
+func (e *MaintenanceEnabledEvent) OccurredAt() time.Time { return e.occurredAt }
+func (e *MaintenanceEnabledEvent) EventType() string     { return "application.maintenance.enabled" }
+func (e *MaintenanceEnabledEvent) AggregateID() string   { return e.aggregateID }
+
+type MaintenanceDisabledEvent struct {
+	sequenced
+	aggregateID string
+	occurredAt  time.Time
+}
+
+func NewMaintenanceDisabledEvent(aggregateID string, occurredAt time.Time) *MaintenanceDisabledEvent {
+	return &MaintenanceDisabledEvent{
+		aggregateID: aggregateID,
+		occurredAt:  occurredAt,
+	}
+}
+
+func (e *MaintenanceDisabledEvent) OccurredAt() time.Time { return e.occurredAt }
+func (e *MaintenanceDisabledEvent) EventType() string     { return "application.maintenance.disabled" }
+func (e *MaintenanceDisabledEvent) AggregateID() string   { return e.aggregateID }
+
+// RestartPolicyChangedEvent records the restart policy of a process, in the ps:set form
+type RestartPolicyChangedEvent struct {
+	sequenced
+	aggregateID string
+	processType string
+	policy      string
+	occurredAt  time.Time
+}
+
+func NewRestartPolicyChangedEvent(aggregateID, processType, policy string, occurredAt time.Time) *RestartPolicyChangedEvent {
+	return &RestartPolicyChangedEvent{
+		aggregateID: aggregateID,
+		processType: processType,
+		policy:      policy,
+		occurredAt:  occurredAt,
+	}
+}
+
+func (e *RestartPolicyChangedEvent) OccurredAt() time.Time { return e.occurredAt }
+func (e *RestartPolicyChangedEvent) EventType() string {
+	return "application.process.restart_policy.changed"
+}
+func (e *RestartPolicyChangedEvent) AggregateID() string { return e.aggregateID }
+func (e *RestartPolicyChangedEvent) ProcessType() string { return e.processType }
+func (e *RestartPolicyChangedEvent) Policy() string      { return e.policy }
+
+// PortMappingsChangedEvent records the port mappings set for the application,
+// in the <scheme>:<host port>:<container port> notation
+type PortMappingsChangedEvent struct {
+	sequenced
+	aggregateID string
+	mappings    []string
+	occurredAt  time.Time
+}
+
+func NewPortMappingsChangedEvent(aggregateID string, mappings []string, occurredAt time.Time) *PortMappingsChangedEvent {
+	return &PortMappingsChangedEvent{
+		aggregateID: aggregateID,
+		mappings:    slices.Clone(mappings),
+		occurredAt:  occurredAt,
+	}
+}
+
+func (e *PortMappingsChangedEvent) OccurredAt() time.Time { return e.occurredAt }
+func (e *PortMappingsChangedEvent) EventType() string     { return "application.ports.changed" }
+func (e *PortMappingsChangedEvent) AggregateID() string   { return e.aggregateID }
+func (e *PortMappingsChangedEvent) Mappings() []string    { return slices.Clone(e.mappings) }
+
+type ScheduledTaskAddedEvent struct {
+	sequenced
+	aggregateID string
+	schedule    string
+	command     string
+	occurredAt  time.Time
+}
+
+func NewScheduledTaskAddedEvent(aggregateID, schedule, command string, occurredAt time.Time) *ScheduledTaskAddedEvent {
+	return &ScheduledTaskAddedEvent{
+		aggregateID: aggregateID,
+		schedule:    schedule,
+		command:     command,
+		occurredAt:  occurredAt,
+	}
+}
+
+func (e *ScheduledTaskAddedEvent) OccurredAt() time.Time { return e.occurredAt }
+func (e *ScheduledTaskAddedEvent) EventType() string     { return "application.scheduled_task.added" }
+func (e *ScheduledTaskAddedEvent) AggregateID() string   { return e.aggregateID }
+func (e *ScheduledTaskAddedEvent) Schedule() string      { return e.schedule }
+func (e *ScheduledTaskAddedEvent) Command() string       { return e.command }
+
+type ScheduledTaskRemovedEvent struct {
+	sequenced
+	aggregateID string
+	schedule    string
+	command     string
+	occurredAt  time.Time
+}
+
+func NewScheduledTaskRemovedEvent(aggregateID, schedule, command string, occurredAt time.Time) *ScheduledTaskRemovedEvent {
+	return &ScheduledTaskRemovedEvent{
+		aggregateID: aggregateID,
+		schedule:    schedule,
+		command:     command,
+		occurredAt:  occurredAt,
+	}
+}
+
+func (e *ScheduledTaskRemovedEvent) OccurredAt() time.Time { return e.occurredAt }
+func (e *ScheduledTaskRemovedEvent) EventType() string     { return "application.scheduled_task.removed" }
+func (e *ScheduledTaskRemovedEvent) AggregateID() string   { return e.aggregateID }
+func (e *ScheduledTaskRemovedEvent) Schedule() string      { return e.schedule }
+func (e *ScheduledTaskRemovedEvent) Command() string       { return e.command }
+
+type DockerOptionAddedEvent struct {
+	sequenced
+	aggregateID string
+	phase       string
+	option      string
+	occurredAt  time.Time
+}
+
+func NewDockerOptionAddedEvent(aggregateID, phase, option string, occurredAt time.Time) *DockerOptionAddedEvent {
+	return &DockerOptionAddedEvent{
+		aggregateID: aggregateID,
+		phase:       phase,
+		option:      option,
+		occurredAt:  occurredAt,
+	}
+}
+
+func (e *DockerOptionAddedEvent) OccurredAt() time.Time { return e.occurredAt }
+func (e *DockerOptionAddedEvent) EventType() string     { return "application.docker_option.added" }
+func (e *DockerOptionAddedEvent) AggregateID() string   { return e.aggregateID }
+func (e *DockerOptionAddedEvent) Phase() string         { return e.phase }
+func (e *DockerOptionAddedEvent) Option() string        { return e.option }
+
+type DockerOptionRemovedEvent struct {
+	sequenced
+	aggregateID string
+	phase       string
+	option      string
+	occurredAt  time.Time
+}
+
+func NewDockerOptionRemovedEvent(aggregateID, phase, option string, occurredAt time.Time) *DockerOptionRemovedEvent {
+	return &DockerOptionRemovedEvent{
+		aggregateID: aggregateID,
+		phase:       phase,
+		option:      option,
+		occurredAt:  occurredAt,
+	}
+}
+
+func (e *DockerOptionRemovedEvent) OccurredAt() time.Time { return e.occurredAt }
+func (e *DockerOptionRemovedEvent) EventType() string     { return "application.docker_option.removed" }
+func (e *DockerOptionRemovedEvent) AggregateID() string   { return e.aggregateID }
+func (e *DockerOptionRemovedEvent) Phase() string         { return e.phase }
+func (e *DockerOptionRemovedEvent) Option() string        { return e.option }
+
+// LabelChangedEvent records a label set to a value, or removed
+type LabelChangedEvent struct {
+	sequenced
+	aggregateID string
+	key         string
+	value       string
+	removed     bool
+	occurredAt  time.Time
+}
+
+func NewLabelChangedEvent(aggregateID, key, value string, removed bool, occurredAt time.Time) *LabelChangedEvent {
+	return &LabelChangedEvent{
+		aggregateID: aggregateID,
+		key:         key,
+		value:       value,
+		removed:     removed,
+		occurredAt:  occurredAt,
+	}
+}
+
+func (e *LabelChangedEvent) OccurredAt() time.Time { return e.occurredAt }
+func (e *LabelChangedEvent) EventType() string     { return "application.label.changed" }
+func (e *LabelChangedEvent) AggregateID() string   { return e.aggregateID }
+func (e *LabelChangedEvent) Key() string           { return e.key }
+func (e *LabelChangedEvent) Value() string         { return e.value }
+func (e *LabelChangedEvent) Removed() bool         { return e.removed }
+
+// ConfigurationReconciledEvent summarizes a reconcile pass, recorded after the events
+// of the changes it applied
+type ConfigurationReconciledEvent struct {
+	sequenced
+	aggregateID string
+	summary     ReconcileSummary
+	occurredAt  time.Time
+}
+
+func NewConfigurationReconciledEvent(aggregateID string, summary ReconcileSummary, occurredAt time.Time) *ConfigurationReconciledEvent {
+	return &ConfigurationReconciledEvent{
+		aggregateID: aggregateID,
+		summary:     summary,
+		occurredAt:  occurredAt,
+	}
+}
+
+func (e *ConfigurationReconciledEvent) OccurredAt() time.Time { return e.occurredAt }
+func (e *ConfigurationReconciledEvent) EventType() string {
+	return "application.configuration.reconciled"
+}
+func (e *ConfigurationReconciledEvent) AggregateID() string { return e.aggregateID }
+func (e *ConfigurationReconciledEvent) Summary() ReconcileSummary {
+	summary := e.summary
+	summary.ScaleDeltas = maps.Clone(e.summary.ScaleDeltas)
+	return summary
+}
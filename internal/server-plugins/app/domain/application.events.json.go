@@ -0,0 +1,664 @@
+package app
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// JSON payloads of the application events, used by the event registry
+
+type eventHeaderJSON struct {
+	AggregateID string    `json:"aggregate_id"`
+	OccurredAt  time.Time `json:"occurred_at"`
+	Sequence    uint64    `json:"sequence,omitempty"`
+}
+
+func (e *ApplicationCreatedEvent) MarshalJSON() ([]byte, error) {
+	return json.Marshal(eventHeaderJSON{AggregateID: e.aggregateID, OccurredAt: e.occurredAt, Sequence: e.sequence})
+}
+
+func (e *ApplicationCreatedEvent) UnmarshalJSON(data []byte) error {
+	var payload eventHeaderJSON
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return err
+	}
+	e.aggregateID, e.occurredAt, e.sequence = payload.AggregateID, payload.OccurredAt, payload.Sequence
+	return nil
+}
+
+func (e *ApplicationDestroyedEvent) MarshalJSON() ([]byte, error) {
+	return json.Marshal(eventHeaderJSON{AggregateID: e.aggregateID, OccurredAt: e.occurredAt, Sequence: e.sequence})
+}
+
+func (e *ApplicationDestroyedEvent) UnmarshalJSON(data []byte) error {
+	var payload eventHeaderJSON
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return err
+	}
+	e.aggregateID, e.occurredAt, e.sequence = payload.AggregateID, payload.OccurredAt, payload.Sequence
+	return nil
+}
+
+type applicationDeployedEventJSON struct {
+	eventHeaderJSON
+	GitRef string `json:"git_ref"`
+}
+
+func (e *ApplicationDeployedEvent) MarshalJSON() ([]byte, error) {
+	return json.Marshal(applicationDeployedEventJSON{
+		eventHeaderJSON: eventHeaderJSON{AggregateID: e.aggregateID, OccurredAt: e.occurredAt, Sequence: e.sequence},
+		GitRef:          e.gitRef,
+	})
+}
+
+func (e *ApplicationDeployedEvent) UnmarshalJSON(data []byte) error {
+	var payload applicationDeployedEventJSON
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return err
+	}
+	e.aggregateID, e.occurredAt, e.sequence = payload.AggregateID, payload.OccurredAt, payload.Sequence
+	e.gitRef = payload.GitRef
+	return nil
+}
+
+type applicationDeployedFromImageEventJSON struct {
+	eventHeaderJSON
+	Image string `json:"image"`
+}
+
+func (e *ApplicationDeployedFromImageEvent) MarshalJSON() ([]byte, error) {
+	return json.Marshal(applicationDeployedFromImageEventJSON{
+		eventHeaderJSON: eventHeaderJSON{AggregateID: e.aggregateID, OccurredAt: e.occurredAt, Sequence: e.sequence},
+		Image:           e.image,
+	})
+}
+
+func (e *ApplicationDeployedFromImageEvent) UnmarshalJSON(data []byte) error {
+	var payload applicationDeployedFromImageEventJSON
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return err
+	}
+	e.aggregateID, e.occurredAt, e.sequence = payload.AggregateID, payload.OccurredAt, payload.Sequence
+	e.image = payload.Image
+	return nil
+}
+
+type applicationRebuiltEventJSON struct {
+	eventHeaderJSON
+	GitRef     string `json:"git_ref"`
+	NoCache    bool   `json:"no_cache,omitempty"`
+	ForceClean bool   `json:"force_clean,omitempty"`
+}
+
+func (e *ApplicationRebuiltEvent) MarshalJSON() ([]byte, error) {
+	return json.Marshal(applicationRebuiltEventJSON{
+		eventHeaderJSON: eventHeaderJSON{AggregateID: e.aggregateID, OccurredAt: e.occurredAt, Sequence: e.sequence},
+		GitRef:          e.gitRef,
+		NoCache:         e.noCache,
+		ForceClean:      e.forceClean,
+	})
+}
+
+func (e *ApplicationRebuiltEvent) UnmarshalJSON(data []byte) error {
+	var payload applicationRebuiltEventJSON
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return err
+	}
+	e.aggregateID, e.occurredAt, e.sequence = payload.AggregateID, payload.OccurredAt, payload.Sequence
+	e.gitRef, e.noCache, e.forceClean = payload.GitRef, payload.NoCache, payload.ForceClean
+	return nil
+}
+
+type applicationDeploymentCompletedEventJSON struct {
+	eventHeaderJSON
+	Duration time.Duration `json:"duration"`
+}
+
+func (e *ApplicationDeploymentCompletedEvent) MarshalJSON() ([]byte, error) {
+	return json.Marshal(applicationDeploymentCompletedEventJSON{
+		eventHeaderJSON: eventHeaderJSON{AggregateID: e.aggregateID, OccurredAt: e.occurredAt, Sequence: e.sequence},
+		Duration:        e.duration,
+	})
+}
+
+func (e *ApplicationDeploymentCompletedEvent) UnmarshalJSON(data []byte) error {
+	var payload applicationDeploymentCompletedEventJSON
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return err
+	}
+	e.aggregateID, e.occurredAt, e.sequence = payload.AggregateID, payload.OccurredAt, payload.Sequence
+	e.duration = payload.Duration
+	return nil
+}
+
+type applicationDeploymentFailedEventJSON struct {
+	eventHeaderJSON
+	Reason       string        `json:"reason"`
+	Duration     time.Duration `json:"duration"`
+	CheckResults []CheckResult `json:"check_results,omitempty"`
+}
+
+func (e *ApplicationDeploymentFailedEvent) MarshalJSON() ([]byte, error) {
+	return json.Marshal(applicationDeploymentFailedEventJSON{
+		eventHeaderJSON: eventHeaderJSON{AggregateID: e.aggregateID, OccurredAt: e.occurredAt, Sequence: e.sequence},
+		Reason:          e.reason,
+		Duration:        e.duration,
+		CheckResults:    e.checkResults,
+	})
+}
+
+func (e *ApplicationDeploymentFailedEvent) UnmarshalJSON(data []byte) error {
+	var payload applicationDeploymentFailedEventJSON
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return err
+	}
+	e.aggregateID, e.occurredAt, e.sequence = payload.AggregateID, payload.OccurredAt, payload.Sequence
+	e.reason, e.duration, e.checkResults = payload.Reason, payload.Duration, payload.CheckResults
+	return nil
+}
+
+type applicationUndeployedEventJSON struct {
+	eventHeaderJSON
+	Reason string `json:"reason,omitempty"`
+}
+
+func (e *ApplicationUndeployedEvent) MarshalJSON() ([]byte, error) {
+	return json.Marshal(applicationUndeployedEventJSON{
+		eventHeaderJSON: eventHeaderJSON{AggregateID: e.aggregateID, OccurredAt: e.occurredAt, Sequence: e.sequence},
+		Reason:          e.reason,
+	})
+}
+
+func (e *ApplicationUndeployedEvent) UnmarshalJSON(data []byte) error {
+	var payload applicationUndeployedEventJSON
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return err
+	}
+	e.aggregateID, e.occurredAt, e.sequence = payload.AggregateID, payload.OccurredAt, payload.Sequence
+	e.reason = payload.Reason
+	return nil
+}
+
+type applicationScaledEventJSON struct {
+	eventHeaderJSON
+	ProcessType string `json:"process_type"`
+	OldScale    int    `json:"old_scale"`
+	NewScale    int    `json:"new_scale"`
+}
+
+func (e *ApplicationScaledEvent) MarshalJSON() ([]byte, error) {
+	return json.Marshal(applicationScaledEventJSON{
+		eventHeaderJSON: eventHeaderJSON{AggregateID: e.aggregateID, OccurredAt: e.occurredAt, Sequence: e.sequence},
+		ProcessType:     e.processType,
+		OldScale:        e.oldScale,
+		NewScale:        e.newScale,
+	})
+}
+
+func (e *ApplicationScaledEvent) UnmarshalJSON(data []byte) error {
+	var payload applicationScaledEventJSON
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return err
+	}
+	e.aggregateID, e.occurredAt, e.sequence = payload.AggregateID, payload.OccurredAt, payload.Sequence
+	e.processType, e.oldScale, e.newScale = payload.ProcessType, payload.OldScale, payload.NewScale
+	return nil
+}
+
+type applicationStateChangedEventJSON struct {
+	eventHeaderJSON
+	OldState string `json:"old_state"`
+	NewState string `json:"new_state"`
+}
+
+func (e *ApplicationStateChangedEvent) MarshalJSON() ([]byte, error) {
+	return json.Marshal(applicationStateChangedEventJSON{
+		eventHeaderJSON: eventHeaderJSON{AggregateID: e.aggregateID, OccurredAt: e.occurredAt, Sequence: e.sequence},
+		OldState:        e.oldState,
+		NewState:        e.newState,
+	})
+}
+
+func (e *ApplicationStateChangedEvent) UnmarshalJSON(data []byte) error {
+	var payload applicationStateChangedEventJSON
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return err
+	}
+	e.aggregateID, e.occurredAt, e.sequence = payload.AggregateID, payload.OccurredAt, payload.Sequence
+	e.oldState, e.newState = payload.OldState, payload.NewState
+	return nil
+}
+
+type domainEventJSON struct {
+	eventHeaderJSON
+	Domain string `json:"domain"`
+}
+
+func (e *DomainAddedEvent) MarshalJSON() ([]byte, error) {
+	return json.Marshal(domainEventJSON{
+		eventHeaderJSON: eventHeaderJSON{AggregateID: e.aggregateID, OccurredAt: e.occurredAt, Sequence: e.sequence},
+		Domain:          e.domain,
+	})
+}
+
+func (e *DomainAddedEvent) UnmarshalJSON(data []byte) error {
+	var payload domainEventJSON
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return err
+	}
+	e.aggregateID, e.occurredAt, e.sequence = payload.AggregateID, payload.OccurredAt, payload.Sequence
+	e.domain = payload.Domain
+	return nil
+}
+
+func (e *DomainRemovedEvent) MarshalJSON() ([]byte, error) {
+	return json.Marshal(domainEventJSON{
+		eventHeaderJSON: eventHeaderJSON{AggregateID: e.aggregateID, OccurredAt: e.occurredAt, Sequence: e.sequence},
+		Domain:          e.domain,
+	})
+}
+
+func (e *DomainRemovedEvent) UnmarshalJSON(data []byte) error {
+	var payload domainEventJSON
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return err
+	}
+	e.aggregateID, e.occurredAt, e.sequence = payload.AggregateID, payload.OccurredAt, payload.Sequence
+	e.domain = payload.Domain
+	return nil
+}
+
+type buildpackChangedEventJSON struct {
+	eventHeaderJSON
+	Buildpack string `json:"buildpack"`
+}
+
+func (e *BuildpackChangedEvent) MarshalJSON() ([]byte, error) {
+	return json.Marshal(buildpackChangedEventJSON{
+		eventHeaderJSON: eventHeaderJSON{AggregateID: e.aggregateID, OccurredAt: e.occurredAt, Sequence: e.sequence},
+		Buildpack:       e.buildpack,
+	})
+}
+
+func (e *BuildpackChangedEvent) UnmarshalJSON(data []byte) error {
+	var payload buildpackChangedEventJSON
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return err
+	}
+	e.aggregateID, e.occurredAt, e.sequence = payload.AggregateID, payload.OccurredAt, payload.Sequence
+	e.buildpack = payload.Buildpack
+	return nil
+}
+
+type buildpackAddedEventJSON struct {
+	eventHeaderJSON
+	Buildpack string `json:"buildpack"`
+	Index     int    `json:"index"`
+}
+
+func (e *BuildpackAddedEvent) MarshalJSON() ([]byte, error) {
+	return json.Marshal(buildpackAddedEventJSON{
+		eventHeaderJSON: eventHeaderJSON{AggregateID: e.aggregateID, OccurredAt: e.occurredAt, Sequence: e.sequence},
+		Buildpack:       e.buildpack,
+		Index:           e.index,
+	})
+}
+
+func (e *BuildpackAddedEvent) UnmarshalJSON(data []byte) error {
+	var payload buildpackAddedEventJSON
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return err
+	}
+	e.aggregateID, e.occurredAt, e.sequence = payload.AggregateID, payload.OccurredAt, payload.Sequence
+	e.buildpack, e.index = payload.Buildpack, payload.Index
+	return nil
+}
+
+func (e *BuildpackRemovedEvent) MarshalJSON() ([]byte, error) {
+	return json.Marshal(buildpackChangedEventJSON{
+		eventHeaderJSON: eventHeaderJSON{AggregateID: e.aggregateID, OccurredAt: e.occurredAt, Sequence: e.sequence},
+		Buildpack:       e.buildpack,
+	})
+}
+
+func (e *BuildpackRemovedEvent) UnmarshalJSON(data []byte) error {
+	var payload buildpackChangedEventJSON
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return err
+	}
+	e.aggregateID, e.occurredAt, e.sequence = payload.AggregateID, payload.OccurredAt, payload.Sequence
+	e.buildpack = payload.Buildpack
+	return nil
+}
+
+type processLimitsChangedEventJSON struct {
+	eventHeaderJSON
+	ProcessType string `json:"process_type"`
+	Memory      string `json:"memory,omitempty"`
+	CPU         string `json:"cpu,omitempty"`
+	Storage     string `json:"storage,omitempty"`
+}
+
+func (e *ProcessLimitsChangedEvent) MarshalJSON() ([]byte, error) {
+	return json.Marshal(processLimitsChangedEventJSON{
+		eventHeaderJSON: eventHeaderJSON{AggregateID: e.aggregateID, OccurredAt: e.occurredAt, Sequence: e.sequence},
+		ProcessType:     e.processType,
+		Memory:          e.memory,
+		CPU:             e.cpu,
+		Storage:         e.storage,
+	})
+}
+
+func (e *ProcessLimitsChangedEvent) UnmarshalJSON(data []byte) error {
+	var payload processLimitsChangedEventJSON
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return err
+	}
+	e.aggregateID, e.occurredAt, e.sequence = payload.AggregateID, payload.OccurredAt, payload.Sequence
+	e.processType, e.memory, e.cpu, e.storage = payload.ProcessType, payload.Memory, payload.CPU, payload.Storage
+	return nil
+}
+
+type healthChecksChangedEventJSON struct {
+	eventHeaderJSON
+	Wait     time.Duration `json:"wait"`
+	Timeout  time.Duration `json:"timeout"`
+	Attempts int           `json:"attempts"`
+	Skipped  bool          `json:"skipped"`
+}
+
+func (e *HealthChecksChangedEvent) MarshalJSON() ([]byte, error) {
+	return json.Marshal(healthChecksChangedEventJSON{
+		eventHeaderJSON: eventHeaderJSON{AggregateID: e.aggregateID, OccurredAt: e.occurredAt, Sequence: e.sequence},
+		Wait:            e.wait,
+		Timeout:         e.timeout,
+		Attempts:        e.attempts,
+		Skipped:         e.skipped,
+	})
+}
+
+func (e *HealthChecksChangedEvent) UnmarshalJSON(data []byte) error {
+	var payload healthChecksChangedEventJSON
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return err
+	}
+	e.aggregateID, e.occurredAt, e.sequence = payload.AggregateID, payload.OccurredAt, payload.Sequence
+	e.wait, e.timeout, e.attempts, e.skipped = payload.Wait, payload.Timeout, payload.Attempts, payload.Skipped
+	return nil
+}
+
+type runAsUserChangedEventJSON struct {
+	eventHeaderJSON
+	PreviousUser string `json:"previous_user,omitempty"`
+	User         string `json:"user,omitempty"`
+}
+
+func (e *RunAsUserChangedEvent) MarshalJSON() ([]byte, error) {
+	return json.Marshal(runAsUserChangedEventJSON{
+		eventHeaderJSON: eventHeaderJSON{AggregateID: e.aggregateID, OccurredAt: e.occurredAt, Sequence: e.sequence},
+		PreviousUser:    e.previousUser,
+		User:            e.user,
+	})
+}
+
+func (e *RunAsUserChangedEvent) UnmarshalJSON(data []byte) error {
+	var payload runAsUserChangedEventJSON
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return err
+	}
+	e.aggregateID, e.occurredAt, e.sequence = payload.AggregateID, payload.OccurredAt, payload.Sequence
+	e.previousUser, e.user = payload.PreviousUser, payload.User
+	return nil
+}
+
+type applicationRenamedEventJSON struct {
+	eventHeaderJSON
+	NewName string `json:"new_name"`
+}
+
+func (e *ApplicationRenamedEvent) MarshalJSON() ([]byte, error) {
+	return json.Marshal(applicationRenamedEventJSON{
+		eventHeaderJSON: eventHeaderJSON{AggregateID: e.aggregateID, OccurredAt: e.occurredAt, Sequence: e.sequence},
+		NewName:         e.newName,
+	})
+}
+
+func (e *ApplicationRenamedEvent) UnmarshalJSON(data []byte) error {
+	var payload applicationRenamedEventJSON
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return err
+	}
+	e.aggregateID, e.occurredAt, e.sequence = payload.AggregateID, payload.OccurredAt, payload.Sequence
+	e.newName = payload.NewName
+	return nil
+}
+
+type gitConfigChangedEventJSON struct {
+	eventHeaderJSON
+	DeployBranch string `json:"deploy_branch"`
+	RevEnvVar    string `json:"rev_env_var"`
+	KeepGitDir   bool   `json:"keep_git_dir"`
+}
+
+func (e *GitConfigChangedEvent) MarshalJSON() ([]byte, error) {
+	return json.Marshal(gitConfigChangedEventJSON{
+		eventHeaderJSON: eventHeaderJSON{AggregateID: e.aggregateID, OccurredAt: e.occurredAt, Sequence: e.sequence},
+		DeployBranch:    e.deployBranch,
+		RevEnvVar:       e.revEnvVar,
+		KeepGitDir:      e.keepGitDir,
+	})
+}
+
+func (e *GitConfigChangedEvent) UnmarshalJSON(data []byte) error {
+	var payload gitConfigChangedEventJSON
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return err
+	}
+	e.aggregateID, e.occurredAt, e.sequence = payload.AggregateID, payload.OccurredAt, payload.Sequence
+	e.deployBranch, e.revEnvVar, e.keepGitDir = payload.DeployBranch, payload.RevEnvVar, payload.KeepGitDir
+	return nil
+}
+
+func (e *MaintenanceEnabledEvent) MarshalJSON() ([]byte, error) {
+	return json.Marshal(eventHeaderJSON{AggregateID: e.aggregateID, OccurredAt: e.occurredAt, Sequence: e.sequence})
+}
+
+func (e *MaintenanceEnabledEvent) UnmarshalJSON(data []byte) error {
+	var payload eventHeaderJSON
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return err
+	}
+	e.aggregateID, e.occurredAt, e.sequence = payload.AggregateID, payload.OccurredAt, payload.Sequence
+	return nil
+}
+
+func (e *MaintenanceDisabledEvent) MarshalJSON() ([]byte, error) {
+	return json.Marshal(eventHeaderJSON{AggregateID: e.aggregateID, OccurredAt: e.occurredAt, Sequence: e.sequence})
+}
+
+func (e *MaintenanceDisabledEvent) UnmarshalJSON(data []byte) error {
+	var payload eventHeaderJSON
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return err
+	}
+	e.aggregateID, e.occurredAt, e.sequence = payload.AggregateID, payload.OccurredAt, payload.Sequence
+	return nil
+}
+
+type labelChangedEventJSON struct {
+	eventHeaderJSON
+	Key     string `json:"key"`
+	Value   string `json:"value"`
+	Removed bool   `json:"removed"`
+}
+
+func (e *LabelChangedEvent) MarshalJSON() ([]byte, error) {
+	return json.Marshal(labelChangedEventJSON{
+		eventHeaderJSON: eventHeaderJSON{AggregateID: e.aggregateID, OccurredAt: e.occurredAt, Sequence: e.sequence},
+		Key:             e.key,
+		Value:           e.value,
+		Removed:         e.removed,
+	})
+}
+
+func (e *LabelChangedEvent) UnmarshalJSON(data []byte) error {
+	var payload labelChangedEventJSON
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return err
+	}
+	e.aggregateID, e.occurredAt, e.sequence = payload.AggregateID, payload.OccurredAt, payload.Sequence
+	e.key, e.value, e.removed = payload.Key, payload.Value, payload.Removed
+	return nil
+}
+
+type portMappingsChangedEventJSON struct {
+	eventHeaderJSON
+	Mappings []string `json:"mappings"`
+}
+
+func (e *PortMappingsChangedEvent) MarshalJSON() ([]byte, error) {
+	return json.Marshal(portMappingsChangedEventJSON{
+		eventHeaderJSON: eventHeaderJSON{AggregateID: e.aggregateID, OccurredAt: e.occurredAt, Sequence: e.sequence},
+		Mappings:        e.mappings,
+	})
+}
+
+func (e *PortMappingsChangedEvent) UnmarshalJSON(data []byte) error {
+	var payload portMappingsChangedEventJSON
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return err
+	}
+	e.aggregateID, e.occurredAt, e.sequence = payload.AggregateID, payload.OccurredAt, payload.Sequence
+	e.mappings = payload.Mappings
+	return nil
+}
+
+type scheduledTaskEventJSON struct {
+	eventHeaderJSON
+	Schedule string `json:"schedule"`
+	Command  string `json:"command"`
+}
+
+func (e *ScheduledTaskAddedEvent) MarshalJSON() ([]byte, error) {
+	return json.Marshal(scheduledTaskEventJSON{
+		eventHeaderJSON: eventHeaderJSON{AggregateID: e.aggregateID, OccurredAt: e.occurredAt, Sequence: e.sequence},
+		Schedule:        e.schedule,
+		Command:         e.command,
+	})
+}
+
+func (e *ScheduledTaskAddedEvent) UnmarshalJSON(data []byte) error {
+	var payload scheduledTaskEventJSON
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return err
+	}
+	e.aggregateID, e.occurredAt, e.sequence = payload.AggregateID, payload.OccurredAt, payload.Sequence
+	e.schedule, e.command = payload.Schedule, payload.Command
+	return nil
+}
+
+func (e *ScheduledTaskRemovedEvent) MarshalJSON() ([]byte, error) {
+	return json.Marshal(scheduledTaskEventJSON{
+		eventHeaderJSON: eventHeaderJSON{AggregateID: e.aggregateID, OccurredAt: e.occurredAt, Sequence: e.sequence},
+		Schedule:        e.schedule,
+		Command:         e.command,
+	})
+}
+
+func (e *ScheduledTaskRemovedEvent) UnmarshalJSON(data []byte) error {
+	var payload scheduledTaskEventJSON
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return err
+	}
+	e.aggregateID, e.occurredAt, e.sequence = payload.AggregateID, payload.OccurredAt, payload.Sequence
+	e.schedule, e.command = payload.Schedule, payload.Command
+	return nil
+}
+
+type dockerOptionEventJSON struct {
+	eventHeaderJSON
+	Phase  string `json:"phase"`
+	Option string `json:"option"`
+}
+
+func (e *DockerOptionAddedEvent) MarshalJSON() ([]byte, error) {
+	return json.Marshal(dockerOptionEventJSON{
+		eventHeaderJSON: eventHeaderJSON{AggregateID: e.aggregateID, OccurredAt: e.occurredAt, Sequence: e.sequence},
+		Phase:           e.phase,
+		Option:          e.option,
+	})
+}
+
+func (e *DockerOptionAddedEvent) UnmarshalJSON(data []byte) error {
+	var payload dockerOptionEventJSON
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return err
+	}
+	e.aggregateID, e.occurredAt, e.sequence = payload.AggregateID, payload.OccurredAt, payload.Sequence
+	e.phase, e.option = payload.Phase, payload.Option
+	return nil
+}
+
+func (e *DockerOptionRemovedEvent) MarshalJSON() ([]byte, error) {
+	return json.Marshal(dockerOptionEventJSON{
+		eventHeaderJSON: eventHeaderJSON{AggregateID: e.aggregateID, OccurredAt: e.occurredAt, Sequence: e.sequence},
+		Phase:           e.phase,
+		Option:          e.option,
+	})
+}
+
+func (e *DockerOptionRemovedEvent) UnmarshalJSON(data []byte) error {
+	var payload dockerOptionEventJSON
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return err
+	}
+	e.aggregateID, e.occurredAt, e.sequence = payload.AggregateID, payload.OccurredAt, payload.Sequence
+	e.phase, e.option = payload.Phase, payload.Option
+	return nil
+}
+
+type restartPolicyChangedEventJSON struct {
+	eventHeaderJSON
+	ProcessType string `json:"process_type"`
+	Policy      string `json:"policy"`
+}
+
+func (e *RestartPolicyChangedEvent) MarshalJSON() ([]byte, error) {
+	return json.Marshal(restartPolicyChangedEventJSON{
+		eventHeaderJSON: eventHeaderJSON{AggregateID: e.aggregateID, OccurredAt: e.occurredAt, Sequence: e.sequence},
+		ProcessType:     e.processType,
+		Policy:          e.policy,
+	})
+}
+
+func (e *RestartPolicyChangedEvent) UnmarshalJSON(data []byte) error {
+	var payload restartPolicyChangedEventJSON
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return err
+	}
+	e.aggregateID, e.occurredAt, e.sequence = payload.AggregateID, payload.OccurredAt, payload.Sequence
+	e.processType, e.policy = payload.ProcessType, payload.Policy
+	return nil
+}
+
+type configurationReconciledEventJSON struct {
+	eventHeaderJSON
+	Summary ReconcileSummary `json:"summary"`
+}
+
+func (e *ConfigurationReconciledEvent) MarshalJSON() ([]byte, error) {
+	return json.Marshal(configurationReconciledEventJSON{
+		eventHeaderJSON: eventHeaderJSON{AggregateID: e.aggregateID, OccurredAt: e.occurredAt, Sequence: e.sequence},
+		Summary:         e.summary,
+	})
+}
+
+func (e *ConfigurationReconciledEvent) UnmarshalJSON(data []byte) error {
+	var payload configurationReconciledEventJSON
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return err
+	}
+	e.aggregateID, e.occurredAt, e.sequence = payload.AggregateID, payload.OccurredAt, payload.Sequence
+	e.summary = payload.Summary
+	return nil
+}
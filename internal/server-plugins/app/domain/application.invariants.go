@@ -0,0 +1,73 @@
+package app
+
+import (
+	"errors"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// Validate checks that the aggregate is internally consistent and returns every
+// broken invariant, joined, or nil. Repositories call it before persisting so a
+// partial reconciliation or a faulty parser never reaches Dokku or the clients.
+func (a *Application) Validate() error {
+	var violations []error
+	violate := func(format string, args ...any) {
+		violations = append(violations, newOperationError(ErrInvariantViolated, format, args...))
+	}
+
+	// The name and the state are set by every constructor
+	if a.name == nil {
+		violate("the application has no name")
+	}
+	if a.state == nil {
+		violate("the application has no state")
+	}
+
+	// Changes cannot predate the creation
+	if a.updatedAt.Before(a.createdAt) {
+		violate("updated at %s before its creation at %s", a.updatedAt, a.createdAt)
+	}
+
+	// A deployment made through the aggregate records when it started and what it
+	// deployed: a git ref or an image. Applications loaded from Dokku have no
+	// recorded deployment, their source being unknown.
+	if info := a.deploymentInfo; info != nil && info.deploymentCount > 0 {
+		if info.lastDeployedAt == nil {
+			violate("%d deployments recorded without a deployment time", info.deploymentCount)
+		}
+		if a.state != nil && a.state.IsDeployed() && info.currentGitRef == nil && info.runImage == nil {
+			violate("deployed without a git ref or a run image")
+		}
+	}
+
+	if a.configuration != nil {
+		// Scales are never negative. A running application may be scaled down to
+		// zero, Dokku keeping it deployed.
+		for processType, proc := range a.configuration.processes {
+			if proc.Scale() < 0 {
+				violate("process %s has a negative scale %d", processType, proc.Scale())
+			}
+		}
+
+		// Each domain is attached once, domain names being case insensitive
+		seen := make([]string, 0, len(a.configuration.domains))
+		for _, domain := range a.configuration.domains {
+			value := strings.ToLower(domain.Value())
+			if slices.Contains(seen, value) {
+				violate("domain %s is attached twice", domain.Value())
+				continue
+			}
+			seen = append(seen, value)
+		}
+	}
+
+	// Labels obey the rules of SetLabel
+	for _, key := range slices.Sorted(maps.Keys(a.labels)) {
+		if err := validateLabel(key, a.labels[key]); err != nil {
+			violate("%w", err)
+		}
+	}
+
+	return errors.Join(violations...)
+}
@@ -0,0 +1,76 @@
+package app_test
+
+import (
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	app "github.com/dokku-mcp/dokku-mcp/internal/server-plugins/app/domain"
+	"github.com/dokku-mcp/dokku-mcp/internal/shared"
+	"github.com/dokku-mcp/dokku-mcp/internal/shared/process"
+)
+
+// rewindingClock goes back one second on each reading
+type rewindingClock struct {
+	now time.Time
+}
+
+func (c *rewindingClock) Now() time.Time {
+	c.now = c.now.Add(-time.Second)
+	return c.now
+}
+
+var _ = Describe("Application Validate", func() {
+	It("should accept a consistent application", func() {
+		application, err := app.NewApplication("valid-app")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(application.AddDomain("example.com")).To(Succeed())
+		Expect(application.Scale(process.ProcessTypeWeb, 2)).To(Succeed())
+		Expect(application.Deploy(shared.MustNewGitRef("main"), nil)).To(Succeed())
+		Expect(application.CompleteDeployment()).To(Succeed())
+		Expect(application.SetLabel("team", "payments")).To(Succeed())
+
+		Expect(application.Validate()).To(Succeed())
+	})
+
+	It("should accept a running application loaded without a deployment record", func() {
+		application, err := app.NewApplicationWithState("loaded-app", app.StateRunning)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(application.Validate()).To(Succeed())
+	})
+
+	It("should accept a running application scaled to zero", func() {
+		application, _ := app.NewApplicationWithState("idle-app", app.StateRunning)
+		Expect(application.Scale(process.ProcessTypeWeb, 0)).To(Succeed())
+
+		Expect(application.Validate()).To(Succeed())
+	})
+
+	It("should report every broken invariant", func() {
+		createdAt := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+		application, err := app.ReplayApplication("broken-app", []app.DomainEvent{
+			app.NewApplicationCreatedEvent("broken-app", createdAt),
+			app.NewApplicationStateChangedEvent("broken-app", "exists", "running", createdAt),
+			app.NewApplicationRebuiltEvent("broken-app", "", false, false, createdAt.Add(-time.Hour)),
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		err = application.Validate()
+		Expect(errors.Is(err, app.ErrInvariantViolated)).To(BeTrue())
+		Expect(err.Error()).To(ContainSubstring("before its creation"))
+		Expect(err.Error()).To(ContainSubstring("without a git ref or a run image"))
+	})
+
+	It("should reject changes predating the creation", func() {
+		clock := &rewindingClock{now: time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)}
+		application, _ := app.NewApplicationWithState("broken-app", app.StateRunning, app.WithClock(clock))
+		Expect(application.Scale(process.ProcessTypeWorker, 1)).To(Succeed())
+
+		err := application.Validate()
+		Expect(errors.Is(err, app.ErrInvariantViolated)).To(BeTrue())
+		Expect(err.Error()).To(ContainSubstring("before its creation"))
+	})
+})
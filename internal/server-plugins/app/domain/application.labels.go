@@ -0,0 +1,60 @@
+package app
+
+import (
+	"maps"
+	"regexp"
+	"strings"
+)
+
+// MaxLabelValueLength bounds label values, which are meant for short tags
+const MaxLabelValueLength = 255
+
+// labelKeyRegex accepts a DNS label: lowercase alphanumerics and hyphens, at most 63 characters
+var labelKeyRegex = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?$`)
+
+// Labels returns a copy of the labels of the application
+func (a *Application) Labels() map[string]string {
+	return maps.Clone(a.labels)
+}
+
+// SetLabel tags the application, e.g. team=payments. Setting a label to its
+// current value is a no-op.
+func (a *Application) SetLabel(key, value string) error {
+	if err := validateLabel(key, value); err != nil {
+		return err
+	}
+	if current, exists := a.labels[key]; exists && current == value {
+		return nil
+	}
+
+	a.labels[key] = value
+	a.updatedAt = a.clock.Now()
+	a.addEvent(NewLabelChangedEvent(a.name.Value(), key, value, false, a.clock.Now()))
+	return nil
+}
+
+// RemoveLabel removes a label of the application
+func (a *Application) RemoveLabel(key string) error {
+	if _, exists := a.labels[key]; !exists {
+		return newOperationError(ErrLabelNotFound, "the label %s doesn't exist", key)
+	}
+
+	delete(a.labels, key)
+	a.updatedAt = a.clock.Now()
+	a.addEvent(NewLabelChangedEvent(a.name.Value(), key, "", true, a.clock.Now()))
+	return nil
+}
+
+func validateLabel(key, value string) error {
+	if !labelKeyRegex.MatchString(key) {
+		return newOperationError(ErrInvalidLabel,
+			"invalid label key '%s': must be a DNS label of lowercase alphanumerics and hyphens, at most 63 characters", key)
+	}
+	if len(value) > MaxLabelValueLength {
+		return newOperationError(ErrInvalidLabel, "the value of label %s exceeds %d characters", key, MaxLabelValueLength)
+	}
+	if strings.ContainsFunc(value, func(r rune) bool { return r < ' ' || r == 0x7f }) {
+		return newOperationError(ErrInvalidLabel, "the value of label %s contains control characters", key)
+	}
+	return nil
+}
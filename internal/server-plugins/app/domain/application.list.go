@@ -0,0 +1,120 @@
+package app
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+const (
+	// DefaultListPageLimit is the page size used when no limit is given
+	DefaultListPageLimit = 100
+	// MaxListPageLimit bounds the page size to keep payloads small
+	MaxListPageLimit = 500
+)
+
+// ApplicationListPage represents one page of the application list
+type ApplicationListPage struct {
+	Applications []ApplicationInfo `json:"applications"`
+	Count        int               `json:"count"`
+	Offset       int               `json:"offset"`
+	Limit        int               `json:"limit"`
+	// NextOffset is the offset of the next page, absent on the last one
+	NextOffset *int `json:"next_offset,omitempty"`
+}
+
+// NewApplicationListPage builds a page from the full application list.
+// A limit of 0 uses DefaultListPageLimit, and an offset past the end gives
+// an empty page that still reports the total count.
+func NewApplicationListPage(apps []ApplicationInfo, offset, limit int) (*ApplicationListPage, error) {
+	if offset < 0 {
+		return nil, fmt.Errorf("offset cannot be negative: %d", offset)
+	}
+	if limit < 0 {
+		return nil, fmt.Errorf("limit cannot be negative: %d", limit)
+	}
+	if limit == 0 {
+		limit = DefaultListPageLimit
+	}
+	if limit > MaxListPageLimit {
+		return nil, fmt.Errorf("limit cannot exceed %d: %d", MaxListPageLimit, limit)
+	}
+
+	total := len(apps)
+	start := min(offset, total)
+	end := min(start+limit, total)
+
+	page := &ApplicationListPage{
+		Applications: append(make([]ApplicationInfo, 0, end-start), apps[start:end]...),
+		Count:        total,
+		Offset:       offset,
+		Limit:        limit,
+	}
+	if end < total {
+		page.NextOffset = &end
+	}
+
+	return page, nil
+}
+
+// ListFilter selects applications in a listing. Zero values match everything.
+type ListFilter struct {
+	State StateValue
+	// Deployed keeps only deployed, or only undeployed, applications when set
+	Deployed *bool
+	// NameContains is matched case-insensitively
+	NameContains string
+	// Labels must all be set to the given values
+	Labels map[string]string
+}
+
+// Matches returns true if the application passes the filter
+func (f ListFilter) Matches(info ApplicationInfo) bool {
+	if f.State != "" && info.State != f.State {
+		return false
+	}
+	if f.Deployed != nil && info.IsDeployed != *f.Deployed {
+		return false
+	}
+	if f.NameContains != "" && !strings.Contains(strings.ToLower(info.Name), strings.ToLower(f.NameContains)) {
+		return false
+	}
+	for key, value := range f.Labels {
+		if label, exists := info.Labels[key]; !exists || label != value {
+			return false
+		}
+	}
+	return true
+}
+
+// FilterAndSort returns the applications matching the filter, sorted by the given field.
+// Fields not carried by ApplicationInfo sort by name, which also breaks ties.
+func FilterAndSort(apps []ApplicationInfo, filter ListFilter, sortBy ApplicationSortField, desc bool) []ApplicationInfo {
+	filtered := make([]ApplicationInfo, 0, len(apps))
+	for _, info := range apps {
+		if filter.Matches(info) {
+			filtered = append(filtered, info)
+		}
+	}
+
+	slices.SortStableFunc(filtered, func(a, b ApplicationInfo) int {
+		var order int
+		switch sortBy {
+		case SortByCreatedAt:
+			order = a.CreatedAt.Compare(b.CreatedAt)
+		case SortByUpdatedAt:
+			order = a.UpdatedAt.Compare(b.UpdatedAt)
+		case SortByState:
+			order = strings.Compare(a.State.String(), b.State.String())
+		}
+		if order == 0 {
+			order = strings.Compare(a.Name, b.Name)
+		}
+		if desc {
+			return -order
+		}
+		return order
+	})
+
+	return filtered
+}
@@ -0,0 +1,126 @@
+package app_test
+
+import (
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	app "github.com/dokku-mcp/dokku-mcp/internal/server-plugins/app/domain"
+)
+
+var _ = Describe("ApplicationListPage", func() {
+	apps := make([]app.ApplicationInfo, 5)
+	for i := range apps {
+		apps[i] = app.ApplicationInfo{Name: fmt.Sprintf("app-%d", i)}
+	}
+
+	It("should return a page with the offset of the next one", func() {
+		page, err := app.NewApplicationListPage(apps, 1, 2)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(page.Applications).To(HaveLen(2))
+		Expect(page.Applications[0].Name).To(Equal("app-1"))
+		Expect(page.Count).To(Equal(5))
+		Expect(page.Offset).To(Equal(1))
+		Expect(page.Limit).To(Equal(2))
+		Expect(page.NextOffset).NotTo(BeNil())
+		Expect(*page.NextOffset).To(Equal(3))
+	})
+
+	It("should not have a next offset on the last page", func() {
+		page, err := app.NewApplicationListPage(apps, 3, 2)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(page.Applications).To(HaveLen(2))
+		Expect(page.NextOffset).To(BeNil())
+	})
+
+	It("should return an empty page past the end", func() {
+		page, err := app.NewApplicationListPage(apps, 10, 2)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(page.Applications).To(BeEmpty())
+		Expect(page.Count).To(Equal(5))
+		Expect(page.NextOffset).To(BeNil())
+	})
+
+	It("should use the default limit for 0", func() {
+		page, err := app.NewApplicationListPage(apps, 0, 0)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(page.Limit).To(Equal(app.DefaultListPageLimit))
+		Expect(page.Applications).To(HaveLen(5))
+	})
+
+	It("should reject out of bounds parameters", func() {
+		_, err := app.NewApplicationListPage(apps, -1, 2)
+		Expect(err).To(HaveOccurred())
+		_, err = app.NewApplicationListPage(apps, 0, -2)
+		Expect(err).To(HaveOccurred())
+		_, err = app.NewApplicationListPage(apps, 0, app.MaxListPageLimit+1)
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+var _ = Describe("FilterAndSort", func() {
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	apps := []app.ApplicationInfo{
+		{Name: "web", State: "running", IsRunning: true, IsDeployed: true, CreatedAt: base, UpdatedAt: base.Add(3 * time.Hour)},
+		{Name: "Api", State: "error", CreatedAt: base.Add(time.Hour), UpdatedAt: base.Add(5 * time.Hour)},
+		{Name: "worker", State: "stopped", IsDeployed: true, CreatedAt: base.Add(2 * time.Hour), UpdatedAt: base.Add(time.Hour)},
+		{Name: "api-staging", State: "error", CreatedAt: base.Add(3 * time.Hour), UpdatedAt: base.Add(2 * time.Hour)},
+	}
+
+	names := func(infos []app.ApplicationInfo) []string {
+		result := make([]string, len(infos))
+		for i, info := range infos {
+			result[i] = info.Name
+		}
+		return result
+	}
+
+	It("should sort by name by default", func() {
+		Expect(names(app.FilterAndSort(apps, app.ListFilter{}, app.SortByName, false))).
+			To(Equal([]string{"Api", "api-staging", "web", "worker"}))
+	})
+
+	It("should filter by state, newest first", func() {
+		failed := app.FilterAndSort(apps, app.ListFilter{State: app.StateError}, app.SortByUpdatedAt, true)
+		Expect(names(failed)).To(Equal([]string{"Api", "api-staging"}))
+	})
+
+	It("should filter deployed applications", func() {
+		deployed := true
+		result := app.FilterAndSort(apps, app.ListFilter{Deployed: &deployed}, app.SortByCreatedAt, false)
+		Expect(names(result)).To(Equal([]string{"web", "worker"}))
+
+		deployed = false
+		result = app.FilterAndSort(apps, app.ListFilter{Deployed: &deployed}, app.SortByCreatedAt, true)
+		Expect(names(result)).To(Equal([]string{"api-staging", "Api"}))
+	})
+
+	It("should match names case-insensitively", func() {
+		result := app.FilterAndSort(apps, app.ListFilter{NameContains: "API"}, app.SortByName, false)
+		Expect(names(result)).To(Equal([]string{"Api", "api-staging"}))
+	})
+
+	It("should not modify the input", func() {
+		app.FilterAndSort(apps, app.ListFilter{}, app.SortByUpdatedAt, true)
+		Expect(apps[0].Name).To(Equal("web"))
+	})
+})
+
+var _ = Describe("ListFilter labels", func() {
+	apps := []app.ApplicationInfo{
+		{Name: "api", Labels: map[string]string{"team": "payments", "env": "production"}},
+		{Name: "api-staging", Labels: map[string]string{"team": "payments", "env": "staging"}},
+		{Name: "blog"},
+	}
+
+	It("should keep the applications carrying every label", func() {
+		result := app.FilterAndSort(apps, app.ListFilter{Labels: map[string]string{"team": "payments"}}, app.SortByName, false)
+		Expect(result).To(HaveLen(2))
+
+		result = app.FilterAndSort(apps, app.ListFilter{Labels: map[string]string{"team": "payments", "env": "staging"}}, app.SortByName, false)
+		Expect(result).To(HaveLen(1))
+		Expect(result[0].Name).To(Equal("api-staging"))
+	})
+})
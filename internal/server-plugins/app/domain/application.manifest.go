@@ -0,0 +1,207 @@
+package app
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/dokku-mcp/dokku-mcp/internal/shared"
+	"github.com/dokku-mcp/dokku-mcp/internal/shared/process"
+)
+
+// ApplicationManifest describes the desired configuration of an application
+type ApplicationManifest struct {
+	Buildpack string            `json:"buildpack,omitempty"`
+	Domains   []string          `json:"domains,omitempty"`
+	Env       map[string]string `json:"env,omitempty"`
+	Processes map[string]int    `json:"processes,omitempty"`
+}
+
+// Validate checks every field of the manifest and returns all the invalid ones, with
+// their path, e.g. domains[2] or env.FOO. A valid manifest returns nil.
+func (m *ApplicationManifest) Validate() ValidationErrors {
+	var errs ValidationErrors
+	invalid := func(field, code string, err error) {
+		errs = append(errs, ValidationError{Field: field, Message: err.Error(), Code: code})
+	}
+
+	if m.Buildpack != "" {
+		if _, err := shared.NewBuildpackName(m.Buildpack); err != nil {
+			invalid("buildpack", "INVALID_BUILDPACK", err)
+		}
+	}
+	for i, domainName := range m.Domains {
+		if _, err := shared.NewDomainName(domainName); err != nil {
+			invalid(fmt.Sprintf("domains[%d]", i), "INVALID_DOMAIN", err)
+		}
+	}
+	for _, key := range sortedKeys(m.Env) {
+		if _, err := shared.NewEnvVarKey(key); err != nil {
+			invalid(envField(key), "INVALID_ENV_KEY", err)
+		}
+	}
+	for _, name := range sortedKeys(m.Processes) {
+		if _, err := process.NormalizeProcessType(process.ProcessType(name)); err != nil {
+			invalid("processes."+name, "INVALID_PROCESS_TYPE", err)
+			continue
+		}
+		if _, err := process.NewProcessScale(m.Processes[name]); err != nil {
+			invalid("processes."+name, "INVALID_SCALE", err)
+		}
+	}
+
+	return errs
+}
+
+// ManifestChangeAction is the kind of change a manifest applies to a field
+type ManifestChangeAction string
+
+const (
+	ManifestChangeAdd    ManifestChangeAction = "add"
+	ManifestChangeUpdate ManifestChangeAction = "update"
+)
+
+// ManifestChange is a single change a manifest applies to the current configuration.
+// Environment values are masked.
+type ManifestChange struct {
+	Field    string               `json:"field"`
+	Key      string               `json:"key,omitempty"`
+	Action   ManifestChangeAction `json:"action"`
+	OldValue string               `json:"old_value,omitempty"`
+	NewValue string               `json:"new_value,omitempty"`
+}
+
+// ManifestPreview is the effective configuration after a manifest apply, with the changes leading to it
+type ManifestPreview struct {
+	Configuration ApplicationConfigData `json:"configuration"`
+	Changes       []ManifestChange      `json:"changes"`
+}
+
+// HasChanges returns true if applying the manifest would change the configuration
+func (p *ManifestPreview) HasChanges() bool {
+	return len(p.Changes) > 0
+}
+
+// PreviewManifest computes the configuration resulting from merging the manifest over
+// the current one. The application itself is left untouched and no event is emitted.
+// An invalid manifest returns its ValidationErrors.
+func (a *Application) PreviewManifest(manifest *ApplicationManifest) (*ManifestPreview, error) {
+	if manifest == nil {
+		return nil, fmt.Errorf("manifest cannot be null")
+	}
+	if errs := manifest.Validate(); len(errs) > 0 {
+		return nil, errs
+	}
+
+	effective := a.copyConfiguration()
+	changes := make([]ManifestChange, 0)
+
+	if manifest.Buildpack != "" {
+		buildpack, err := shared.NewBuildpackName(manifest.Buildpack)
+		if err != nil {
+			return nil, fmt.Errorf("invalid buildpack: %w", err)
+		}
+		// A manifest buildpack replaces every current one, as SetBuildpack does
+		switch {
+		case len(effective.buildpacks) == 0:
+			changes = append(changes, ManifestChange{Field: "buildpack", Action: ManifestChangeAdd, NewValue: buildpack.Value()})
+		case len(effective.buildpacks) > 1 || !effective.buildpacks[0].Equal(buildpack):
+			current := make([]string, len(effective.buildpacks))
+			for i, existing := range effective.buildpacks {
+				current[i] = existing.Value()
+			}
+			changes = append(changes, ManifestChange{
+				Field:    "buildpack",
+				Action:   ManifestChangeUpdate,
+				OldValue: strings.Join(current, ", "),
+				NewValue: buildpack.Value(),
+			})
+		}
+		effective.buildpacks = []*shared.BuildpackName{buildpack}
+	}
+
+	for _, domainName := range manifest.Domains {
+		domain, err := shared.NewDomainName(domainName)
+		if err != nil {
+			return nil, fmt.Errorf("invalid domain: %w", err)
+		}
+		if containsDomain(effective.domains, domain) {
+			continue
+		}
+		effective.domains = append(effective.domains, domain)
+		changes = append(changes, ManifestChange{Field: "domains", Action: ManifestChangeAdd, NewValue: domain.Value()})
+	}
+
+	for _, key := range sortedKeys(manifest.Env) {
+		envKey, err := shared.NewEnvVarKey(key)
+		if err != nil {
+			return nil, err
+		}
+		envValue := shared.NewEnvVarValue(manifest.Env[key])
+		existing, exists := effective.environmentVars[*envKey]
+		switch {
+		case !exists:
+			changes = append(changes, ManifestChange{Field: "env", Key: key, Action: ManifestChangeAdd, NewValue: envValue.Masked()})
+		case !existing.Equal(envValue):
+			changes = append(changes, ManifestChange{Field: "env", Key: key, Action: ManifestChangeUpdate, OldValue: existing.Masked(), NewValue: envValue.Masked()})
+		}
+		effective.environmentVars[*envKey] = envValue
+	}
+
+	for _, name := range sortedKeys(manifest.Processes) {
+		processType, err := process.NormalizeProcessType(process.ProcessType(name))
+		if err != nil {
+			return nil, fmt.Errorf("invalid process type: %w", err)
+		}
+		scale := manifest.Processes[name]
+		existing, exists := effective.processes[processType]
+
+		var proc *process.Process
+		if exists && existing.HasCommand() {
+			proc, err = process.NewProcess(processType, existing.Command().Value(), scale)
+		} else {
+			proc, err = process.NewProcessForScaling(processType, scale)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid scale for process %s: %w", name, err)
+		}
+
+		switch {
+		case !exists:
+			changes = append(changes, ManifestChange{Field: "processes", Key: name, Action: ManifestChangeAdd, NewValue: fmt.Sprint(scale)})
+		case existing.Scale() != scale:
+			changes = append(changes, ManifestChange{
+				Field:    "processes",
+				Key:      name,
+				Action:   ManifestChangeUpdate,
+				OldValue: fmt.Sprint(existing.Scale()),
+				NewValue: fmt.Sprint(scale),
+			})
+		}
+		// Replace rather than mutate, processes are shared with the live configuration
+		effective.processes[processType] = proc
+	}
+
+	return &ManifestPreview{
+		Configuration: effective.View(a.name.Value()),
+		Changes:       changes,
+	}, nil
+}
+
+func containsDomain(domains []*shared.DomainName, domain *shared.DomainName) bool {
+	for _, existing := range domains {
+		if existing.Equal(domain) {
+			return true
+		}
+	}
+	return false
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
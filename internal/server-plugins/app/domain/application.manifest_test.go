@@ -0,0 +1,141 @@
+package app_test
+
+import (
+	"encoding/json"
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	app "github.com/dokku-mcp/dokku-mcp/internal/server-plugins/app/domain"
+	"github.com/dokku-mcp/dokku-mcp/internal/shared"
+	"github.com/dokku-mcp/dokku-mcp/internal/shared/process"
+)
+
+var _ = Describe("Application manifest preview", func() {
+	var application *app.Application
+
+	BeforeEach(func() {
+		var err error
+		application, err = app.NewApplication("manifest-app")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(application.SetBuildpack("heroku/nodejs")).To(Succeed())
+		Expect(application.AddDomain("example.com")).To(Succeed())
+		Expect(application.SetEnvironmentVariable("PORT", "5000")).To(Succeed())
+		Expect(application.SetEnvironmentVariable("API_TOKEN", "secret")).To(Succeed())
+		Expect(application.AddProcess(process.ProcessTypeWeb, "npm start", 1)).To(Succeed())
+		application.ClearEvents()
+	})
+
+	It("should reflect manifest overrides in the effective configuration", func() {
+		preview, err := application.PreviewManifest(&app.ApplicationManifest{
+			Buildpack: "heroku/python",
+			Domains:   []string{"example.com", "www.example.com"},
+			Env:       map[string]string{"PORT": "8080", "LOG_LEVEL": "debug"},
+			Processes: map[string]int{"web": 3, "worker": 1},
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		config := preview.Configuration
+		Expect(config.Name).To(Equal("manifest-app"))
+		Expect(config.Buildpacks).To(Equal([]string{"heroku/python"}))
+		Expect(config.Domains).To(Equal([]string{"example.com", "www.example.com"}))
+		Expect(config.Env).To(HaveKey("API_TOKEN"))
+		Expect(config.Env).To(HaveKey("LOG_LEVEL"))
+		Expect(config.Processes).To(Equal(map[string]int{"web": 3, "worker": 1}))
+
+		Expect(preview.Changes).To(ConsistOf(
+			app.ManifestChange{Field: "buildpack", Action: app.ManifestChangeUpdate, OldValue: "heroku/nodejs", NewValue: "heroku/python"},
+			app.ManifestChange{Field: "domains", Action: app.ManifestChangeAdd, NewValue: "www.example.com"},
+			app.ManifestChange{Field: "env", Key: "LOG_LEVEL", Action: app.ManifestChangeAdd, NewValue: shared.NewEnvVarValue("debug").Masked()},
+			app.ManifestChange{Field: "env", Key: "PORT", Action: app.ManifestChangeUpdate, OldValue: shared.NewEnvVarValue("5000").Masked(), NewValue: shared.NewEnvVarValue("8080").Masked()},
+			app.ManifestChange{Field: "processes", Key: "web", Action: app.ManifestChangeUpdate, OldValue: "1", NewValue: "3"},
+			app.ManifestChange{Field: "processes", Key: "worker", Action: app.ManifestChangeAdd, NewValue: "1"},
+		))
+	})
+
+	It("should mask environment values in the serialization", func() {
+		preview, err := application.PreviewManifest(&app.ApplicationManifest{
+			Env: map[string]string{"DATABASE_URL": "postgres://user:pass@db/app"},
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		data, err := json.Marshal(preview)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).NotTo(ContainSubstring("pass@db"))
+		Expect(string(data)).NotTo(ContainSubstring("secret"))
+		Expect(preview.Configuration.Env["DATABASE_URL"].Masked()).NotTo(ContainSubstring("pass@db"))
+	})
+
+	It("should leave the application untouched", func() {
+		_, err := application.PreviewManifest(&app.ApplicationManifest{
+			Buildpack: "heroku/python",
+			Domains:   []string{"www.example.com"},
+			Processes: map[string]int{"web": 5},
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(application.GetProcessScale(process.ProcessTypeWeb)).To(Equal(1))
+		Expect(application.GetDomains()).To(Equal([]string{"example.com"}))
+		Expect(application.Configuration().View("manifest-app").Buildpacks).To(Equal([]string{"heroku/nodejs"}))
+		Expect(application.GetEvents()).To(BeEmpty())
+	})
+
+	It("should report no changes for a matching manifest", func() {
+		preview, err := application.PreviewManifest(&app.ApplicationManifest{
+			Domains:   []string{"example.com"},
+			Env:       map[string]string{"PORT": "5000"},
+			Processes: map[string]int{"web": 1},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(preview.HasChanges()).To(BeFalse())
+	})
+
+	It("should reject an invalid manifest", func() {
+		_, err := application.PreviewManifest(&app.ApplicationManifest{Processes: map[string]int{"web": -1}})
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should report every invalid field of a manifest at once", func() {
+		_, err := application.PreviewManifest(&app.ApplicationManifest{
+			Domains:   []string{"example.org", "valid.example.com", "not a domain"},
+			Env:       map[string]string{"1BAD": "x", "GOOD": "y", "ALSO-BAD": "z"},
+			Processes: map[string]int{"web": -1, "worker": 2},
+		})
+
+		var errs app.ValidationErrors
+		Expect(errors.As(err, &errs)).To(BeTrue())
+		fields := make([]string, len(errs))
+		for i, validationErr := range errs {
+			fields[i] = validationErr.Field
+			Expect(validationErr.Message).NotTo(BeEmpty())
+		}
+		Expect(fields).To(Equal([]string{"domains[2]", "env.1BAD", "env.ALSO-BAD", "processes.web"}))
+		Expect(errs[0].Code).To(Equal("INVALID_DOMAIN"))
+		Expect(errs[1].Code).To(Equal("INVALID_ENV_KEY"))
+		Expect(errs[3].Code).To(Equal("INVALID_SCALE"))
+		Expect(err.Error()).To(ContainSubstring("domains[2]: "))
+	})
+})
+
+var _ = Describe("Application manifest validation", func() {
+	It("should accept a valid manifest", func() {
+		manifest := &app.ApplicationManifest{
+			Buildpack: "heroku/nodejs",
+			Domains:   []string{"example.com"},
+			Env:       map[string]string{"PORT": "5000"},
+			Processes: map[string]int{"web": 2},
+		}
+		Expect(manifest.Validate()).To(BeNil())
+	})
+
+	It("should serialize the errors with their field path", func() {
+		errs := (&app.ApplicationManifest{Processes: map[string]int{"bad type": 1}}).Validate()
+		Expect(errs).To(HaveLen(1))
+
+		data, err := json.Marshal(errs)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).To(ContainSubstring(`"field":"processes.bad type","message":`))
+		Expect(string(data)).To(ContainSubstring(`"code":"INVALID_PROCESS_TYPE"`))
+	})
+})
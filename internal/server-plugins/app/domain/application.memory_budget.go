@@ -0,0 +1,89 @@
+package app
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/dokku-mcp/dokku-mcp/internal/shared/process"
+)
+
+// WithMemoryBudget caps the memory, in bytes, the formation of the application may
+// request in total. Scaling or changing limits beyond it is refused. Zero or less
+// removes the budget.
+func WithMemoryBudget(bytes int64) ApplicationOption {
+	return func(a *Application) {
+		a.memoryBudget = max(bytes, 0)
+	}
+}
+
+// TotalRequestedMemory returns the memory the formation requests, in bytes: the memory
+// limit of each process times its scale. A process without a memory limit requests
+// nothing, as Dokku does not reserve memory for it.
+func (c *ApplicationConfiguration) TotalRequestedMemory() (int64, error) {
+	return c.requestedMemory(nil, nil)
+}
+
+// requestedMemory returns the memory the formation would request with the scales and
+// memory limits overridden for some processes, possibly not declared yet
+func (c *ApplicationConfiguration) requestedMemory(scales map[process.ProcessType]int, memory map[process.ProcessType]string) (int64, error) {
+	formation := make(map[process.ProcessType]struct {
+		scale  int
+		memory string
+	}, len(c.processes))
+	for processType, proc := range c.processes {
+		entry := formation[processType]
+		entry.scale = proc.Scale()
+		if limits := proc.ResourceLimits(); limits != nil {
+			entry.memory = limits.Memory()
+		}
+		formation[processType] = entry
+	}
+	for processType, scale := range scales {
+		entry := formation[processType]
+		entry.scale = scale
+		formation[processType] = entry
+	}
+	for processType, limit := range memory {
+		entry := formation[processType]
+		entry.memory = limit
+		formation[processType] = entry
+	}
+
+	var total int64
+	for processType, entry := range formation {
+		bytes, err := process.ParseSizeLimit(entry.memory)
+		if err != nil {
+			return 0, fmt.Errorf("process %s: %w", processType, err)
+		}
+		scale := int64(entry.scale)
+		if scale > 0 && bytes > (math.MaxInt64-total)/scale {
+			return 0, fmt.Errorf("process %s: the memory requested by the formation overflows", processType)
+		}
+		total += bytes * scale
+	}
+	return total, nil
+}
+
+// checkMemoryBudget refuses a formation, with the overrides of requestedMemory, that
+// would request more memory than the host budget. A formation already over the budget,
+// e.g. since the budget was lowered, can still shrink.
+func (a *Application) checkMemoryBudget(scales map[process.ProcessType]int, memory map[process.ProcessType]string) error {
+	budget := a.memoryBudget
+	if budget == 0 {
+		return nil
+	}
+	total, err := a.configuration.requestedMemory(scales, memory)
+	if err != nil || total <= budget {
+		return err
+	}
+	if current, err := a.configuration.TotalRequestedMemory(); err == nil && total <= current {
+		return nil
+	}
+	return newOperationError(ErrMemoryBudgetExceeded, "the formation of %s would request %s of memory, over the host budget of %s",
+		a.name.Value(), formatMemory(total), formatMemory(budget))
+}
+
+// formatMemory prints a byte count in MiB, e.g. 1536MiB
+func formatMemory(bytes int64) string {
+	return fmt.Sprintf("%dMiB", bytes>>20)
+}
@@ -0,0 +1,100 @@
+package app_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	app "github.com/dokku-mcp/dokku-mcp/internal/server-plugins/app/domain"
+	"github.com/dokku-mcp/dokku-mcp/internal/shared/process"
+)
+
+var _ = Describe("Application memory budget", func() {
+	var (
+		application *app.Application
+		opts        []app.ApplicationOption
+	)
+
+	BeforeEach(func() {
+		opts = nil
+	})
+
+	JustBeforeEach(func() {
+		var err error
+		application, err = app.NewApplication("budget-app", opts...)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(application.ScaleAll(map[process.ProcessType]int{"web": 2, "worker": 1})).To(Succeed())
+		Expect(application.SetProcessResourceLimits("web", "512m", "", "")).To(Succeed())
+		application.ClearEvents()
+	})
+
+	It("should total the memory limits times the scales", func() {
+		Expect(application.Configuration().TotalRequestedMemory()).To(Equal(int64(1024 << 20)))
+
+		Expect(application.SetProcessResourceLimits("worker", "1g", "", "")).To(Succeed())
+		Expect(application.Configuration().TotalRequestedMemory()).To(Equal(int64(2048 << 20)))
+	})
+
+	It("should count processes without a memory limit as requesting nothing", func() {
+		Expect(application.Scale("worker", 5)).To(Succeed())
+		Expect(application.Configuration().TotalRequestedMemory()).To(Equal(int64(1024 << 20)))
+	})
+
+	It("should refuse a formation whose requested memory overflows", func() {
+		Expect(application.SetProcessResourceLimits("web", "8000000000g", "", "")).To(Succeed())
+		_, err := application.Configuration().TotalRequestedMemory()
+		Expect(err).To(MatchError(ContainSubstring("overflows")))
+	})
+
+	Context("with a host budget", func() {
+		BeforeEach(func() {
+			opts = append(opts, app.WithMemoryBudget(2048<<20))
+		})
+
+		It("should allow a formation within the budget", func() {
+			Expect(application.Scale("web", 4)).To(Succeed())
+			Expect(application.Configuration().TotalRequestedMemory()).To(Equal(int64(2048 << 20)))
+		})
+
+		It("should refuse scaling over the budget", func() {
+			err := application.Scale("web", 5)
+			Expect(err).To(MatchError(app.ErrMemoryBudgetExceeded))
+			Expect(err.Error()).To(ContainSubstring("2560MiB"))
+			Expect(application.GetProcessScale("web")).To(Equal(2))
+			Expect(application.GetEvents()).To(BeEmpty())
+		})
+
+		It("should refuse a whole batch over the budget", func() {
+			Expect(application.SetProcessResourceLimits("worker", "512m", "", "")).To(Succeed())
+
+			err := application.ScaleAll(map[process.ProcessType]int{"web": 3, "worker": 2})
+			Expect(err).To(MatchError(app.ErrMemoryBudgetExceeded))
+			Expect(application.GetProcessScale("web")).To(Equal(2))
+			Expect(application.GetProcessScale("worker")).To(Equal(1))
+		})
+
+		It("should refuse a memory limit over the budget", func() {
+			err := application.SetProcessResourceLimits("web", "2g", "", "")
+			Expect(err).To(MatchError(app.ErrMemoryBudgetExceeded))
+			Expect(application.GetProcessResourceLimits("web").Memory()).To(Equal("512m"))
+		})
+
+		It("should refuse a memory limit whose total overflows", func() {
+			err := application.SetProcessResourceLimits("web", "8000000000g", "", "")
+			Expect(err).To(MatchError(ContainSubstring("overflows")))
+			Expect(application.GetProcessResourceLimits("web").Memory()).To(Equal("512m"))
+		})
+
+		It("should allow shrinking a formation already over the budget", func() {
+			source, err := app.NewApplication("budget-app")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(source.Scale("web", 2)).To(Succeed())
+			Expect(source.SetProcessResourceLimits("web", "512m", "", "")).To(Succeed())
+
+			// A replayed formation is loaded as it is, over the budget
+			replayed, err := app.ReplayApplication("budget-app", source.GetEvents(), app.WithMemoryBudget(256<<20))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(replayed.Scale("web", 1)).To(Succeed())
+			Expect(replayed.Scale("web", 2)).To(MatchError(app.ErrMemoryBudgetExceeded))
+		})
+	})
+})
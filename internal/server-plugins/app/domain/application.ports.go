@@ -0,0 +1,42 @@
+package app
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/dokku-mcp/dokku-mcp/internal/shared"
+)
+
+// GetPortMappings returns the port mappings set for the application, empty when
+// the mappings detected by Dokku apply
+func (a *Application) GetPortMappings() []shared.PortMapping {
+	return slices.Clone(a.configuration.portMappings)
+}
+
+// SetPortMappings replaces the port mappings of the application, e.g. http:80:5000
+// and https:443:5000. Each host port is mapped once and every container port is set.
+// An empty list clears the override. Setting the current mappings is a no-op.
+func (a *Application) SetPortMappings(mappings []shared.PortMapping) error {
+	hostPorts := make(map[int]shared.PortMapping, len(mappings))
+	for _, mapping := range mappings {
+		if mapping.ContainerPort() == 0 {
+			return fmt.Errorf("invalid port mapping %s: the container port is required", mapping)
+		}
+		if existing, exists := hostPorts[mapping.HostPort()]; exists {
+			return fmt.Errorf("host port %d is mapped twice: %s and %s", mapping.HostPort(), existing, mapping)
+		}
+		hostPorts[mapping.HostPort()] = mapping
+	}
+	if slices.Equal(mappings, a.configuration.portMappings) {
+		return nil
+	}
+
+	a.configuration.portMappings = slices.Clone(mappings)
+	values := make([]string, len(mappings))
+	for i, mapping := range mappings {
+		values[i] = mapping.String()
+	}
+	a.updatedAt = a.clock.Now()
+	a.addEvent(NewPortMappingsChangedEvent(a.name.Value(), values, a.clock.Now()))
+	return nil
+}
@@ -0,0 +1,67 @@
+package app_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	app "github.com/dokku-mcp/dokku-mcp/internal/server-plugins/app/domain"
+	"github.com/dokku-mcp/dokku-mcp/internal/shared"
+)
+
+var _ = Describe("Application port mappings", func() {
+	var application *app.Application
+
+	mustParse := func(values ...string) []shared.PortMapping {
+		mappings := make([]shared.PortMapping, 0, len(values))
+		for _, value := range values {
+			mapping, err := shared.ParsePortMapping(value)
+			Expect(err).NotTo(HaveOccurred())
+			mappings = append(mappings, mapping)
+		}
+		return mappings
+	}
+
+	BeforeEach(func() {
+		var err error
+		application, err = app.NewApplication("ported-app")
+		Expect(err).NotTo(HaveOccurred())
+		application.ClearEvents()
+	})
+
+	It("should set the mappings and emit an event", func() {
+		Expect(application.SetPortMappings(mustParse("http:80:5000", "https:443:5000"))).To(Succeed())
+
+		Expect(application.GetPortMappings()).To(Equal(mustParse("http:80:5000", "https:443:5000")))
+		events := application.GetEvents()
+		Expect(events).To(HaveLen(1))
+		changed, ok := events[0].(*app.PortMappingsChangedEvent)
+		Expect(ok).To(BeTrue())
+		Expect(changed.Mappings()).To(Equal([]string{"http:80:5000", "https:443:5000"}))
+	})
+
+	It("should reject a host port mapped twice", func() {
+		err := application.SetPortMappings(mustParse("http:80:5000", "tcp:80:6000"))
+		Expect(err).To(MatchError(ContainSubstring("host port 80 is mapped twice")))
+		Expect(application.GetPortMappings()).To(BeEmpty())
+	})
+
+	It("should reject a mapping without container port", func() {
+		Expect(application.SetPortMappings([]shared.PortMapping{{}})).NotTo(Succeed())
+	})
+
+	It("should not emit an event when the mappings are unchanged", func() {
+		Expect(application.SetPortMappings(mustParse("http:80:5000"))).To(Succeed())
+		application.ClearEvents()
+
+		Expect(application.SetPortMappings(mustParse("http:80:5000"))).To(Succeed())
+		Expect(application.GetEvents()).To(BeEmpty())
+	})
+
+	It("should restore the mappings on replay", func() {
+		Expect(application.SetPortMappings(mustParse("https:443:5000"))).To(Succeed())
+
+		replayed, err := app.ReplayApplication("ported-app", application.GetEvents())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(replayed.GetPortMappings()).To(Equal(mustParse("https:443:5000")))
+	})
+})
@@ -0,0 +1,259 @@
+package app
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/dokku-mcp/dokku-mcp/internal/shared"
+	"github.com/dokku-mcp/dokku-mcp/internal/shared/process"
+)
+
+// LiveAppState is the configuration of an application as reported by Dokku:
+// domains:report, ps:report, buildpacks:report and the keys of config:show
+type LiveAppState struct {
+	Domains   []string
+	Scales    map[process.ProcessType]int
+	Buildpack string
+	EnvKeys   []string
+}
+
+// ReconcileDirection tells which side of a reconciliation is changed
+type ReconcileDirection string
+
+const (
+	// ReconcileToLive plans the changes making the live system match the entity
+	ReconcileToLive ReconcileDirection = "to_live"
+	// ReconcileFromLive plans the changes making the entity match the live system
+	ReconcileFromLive ReconcileDirection = "from_live"
+)
+
+// ReconcilePlan lists the changes aligning an application with its live state
+type ReconcilePlan struct {
+	Direction ReconcileDirection `json:"direction"`
+	Changes   []ConfigChange     `json:"changes"`
+	// Unresolved describes the differences no change can fix, such as a variable
+	// only set live, whose value the report does not carry
+	Unresolved []string `json:"unresolved"`
+}
+
+// IsEmpty returns true if both sides already match
+func (p ReconcilePlan) IsEmpty() bool {
+	return len(p.Changes) == 0 && len(p.Unresolved) == 0
+}
+
+// Reconcile compares the application with its live state and plans the minimal
+// changes aligning the side given by the direction. Neither side is mutated: the
+// changes are meant to be reviewed, then applied with ApplyChanges when aligning
+// the entity, or through Dokku when aligning the live system. Domains Dokku
+// generates from the global domains are ignored.
+func (a *Application) Reconcile(live *LiveAppState, direction ReconcileDirection) ReconcilePlan {
+	if live == nil {
+		live = &LiveAppState{}
+	}
+	if direction != ReconcileFromLive {
+		direction = ReconcileToLive
+	}
+	plan := ReconcilePlan{
+		Direction:  direction,
+		Changes:    make([]ConfigChange, 0),
+		Unresolved: make([]string, 0),
+	}
+
+	desired, current := a.GetDomains(), a.liveDomains(live.Domains)
+	if direction == ReconcileFromLive {
+		desired, current = current, desired
+	}
+	for _, domain := range desired {
+		if !slices.Contains(current, domain) {
+			plan.Changes = append(plan.Changes, ConfigChange{Kind: ConfigChangeAddDomain, Key: domain})
+		}
+	}
+	for _, domain := range current {
+		if !slices.Contains(desired, domain) {
+			plan.Changes = append(plan.Changes, ConfigChange{Kind: ConfigChangeRemoveDomain, Key: domain})
+		}
+	}
+
+	scales := make(map[process.ProcessType]int, len(a.configuration.processes))
+	for processType, proc := range a.configuration.processes {
+		scales[processType] = proc.Scale()
+	}
+	desiredScales, currentScales := scales, live.Scales
+	if direction == ReconcileFromLive {
+		desiredScales, currentScales = currentScales, desiredScales
+	}
+	processTypes := slices.Collect(maps.Keys(desiredScales))
+	for processType := range currentScales {
+		if _, exists := desiredScales[processType]; !exists {
+			processTypes = append(processTypes, processType)
+		}
+	}
+	slices.Sort(processTypes)
+	for _, processType := range processTypes {
+		// A process missing from one side counts as scaled to 0
+		if desiredScales[processType] != currentScales[processType] {
+			plan.Changes = append(plan.Changes, ConfigChange{
+				Kind: ConfigChangeScale, Key: string(processType), Scale: desiredScales[processType],
+			})
+		}
+	}
+
+	buildpack := ""
+	if buildpacks := a.GetBuildpacks(); len(buildpacks) > 0 {
+		buildpack = buildpacks[0]
+	}
+	desiredBuildpack, currentBuildpack := buildpack, live.Buildpack
+	if direction == ReconcileFromLive {
+		desiredBuildpack, currentBuildpack = currentBuildpack, desiredBuildpack
+	}
+	switch {
+	case desiredBuildpack == currentBuildpack:
+	case desiredBuildpack == "":
+		plan.Unresolved = append(plan.Unresolved, "buildpack "+currentBuildpack+" is set on one side only, and cannot be cleared")
+	default:
+		plan.Changes = append(plan.Changes, ConfigChange{Kind: ConfigChangeSetBuildpack, Key: desiredBuildpack})
+	}
+
+	// The variables Dokku manages are never part of the plan
+	entityEnv := a.GetEnvironmentVariables(false)
+	entityKeys := slices.Sorted(maps.Keys(entityEnv))
+	liveKeys := make([]string, 0, len(live.EnvKeys))
+	for _, key := range live.EnvKeys {
+		if envKey, err := shared.NewEnvVarKey(key); err == nil && !envKey.IsInternal() {
+			liveKeys = append(liveKeys, key)
+		}
+	}
+	slices.Sort(liveKeys)
+	for _, key := range entityKeys {
+		if slices.Contains(liveKeys, key) {
+			continue
+		}
+		if direction == ReconcileFromLive {
+			plan.Changes = append(plan.Changes, ConfigChange{Kind: ConfigChangeUnsetEnv, Key: key})
+		} else {
+			plan.Changes = append(plan.Changes, ConfigChange{
+				Kind: ConfigChangeSetEnv, Key: key, Value: entityEnv[key],
+			})
+		}
+	}
+	for _, key := range liveKeys {
+		if slices.Contains(entityKeys, key) {
+			continue
+		}
+		if direction == ReconcileFromLive {
+			plan.Unresolved = append(plan.Unresolved, "environment variable "+key+" is only set live, its value is unknown")
+		} else {
+			plan.Changes = append(plan.Changes, ConfigChange{Kind: ConfigChangeUnsetEnv, Key: key})
+		}
+	}
+
+	return plan
+}
+
+// ReconcileSummary counts what a reconcile pass applied
+type ReconcileSummary struct {
+	DomainsAdded   int `json:"domains_added"`
+	DomainsRemoved int `json:"domains_removed"`
+	EnvKeysSet     int `json:"env_keys_set"`
+	EnvKeysUnset   int `json:"env_keys_unset"`
+	// ScaleDeltas is the instance count change of each rescaled process type
+	ScaleDeltas      map[string]int `json:"scale_deltas,omitempty"`
+	BuildpackChanged bool           `json:"buildpack_changed"`
+}
+
+// IsEmpty returns true if the pass applied nothing
+func (s ReconcileSummary) IsEmpty() bool {
+	return s.DomainsAdded == 0 && s.DomainsRemoved == 0 && s.EnvKeysSet == 0 &&
+		s.EnvKeysUnset == 0 && len(s.ScaleDeltas) == 0 && !s.BuildpackChanged
+}
+
+// String describes the summary, e.g. "domains added: 1, web scaled by +2"
+func (s ReconcileSummary) String() string {
+	if s.IsEmpty() {
+		return "no change"
+	}
+	parts := make([]string, 0)
+	count := func(n int, what string) {
+		if n > 0 {
+			parts = append(parts, fmt.Sprintf("%s: %d", what, n))
+		}
+	}
+	count(s.DomainsAdded, "domains added")
+	count(s.DomainsRemoved, "domains removed")
+	count(s.EnvKeysSet, "env keys set")
+	count(s.EnvKeysUnset, "env keys unset")
+	for _, processType := range slices.Sorted(maps.Keys(s.ScaleDeltas)) {
+		parts = append(parts, fmt.Sprintf("%s scaled by %+d", processType, s.ScaleDeltas[processType]))
+	}
+	if s.BuildpackChanged {
+		parts = append(parts, "buildpack changed")
+	}
+	return strings.Join(parts, ", ")
+}
+
+// ApplyReconcilePlan aligns the entity with its live state by applying a from_live
+// plan with ApplyChanges. The granular events of the changes are recorded as usual,
+// followed by a ConfigurationReconciledEvent summarizing the pass, unless nothing was
+// applied. A to_live plan is carried out through Dokku and is refused.
+func (a *Application) ApplyReconcilePlan(plan ReconcilePlan, atomic bool) (ChangeResult, error) {
+	if plan.Direction != ReconcileFromLive {
+		return ChangeResult{}, fmt.Errorf("only a %s plan can be applied to the application, got %s", ReconcileFromLive, plan.Direction)
+	}
+
+	scales := make(map[process.ProcessType]int, len(a.configuration.processes))
+	for processType, proc := range a.configuration.processes {
+		scales[processType] = proc.Scale()
+	}
+
+	result, err := a.ApplyChanges(plan.Changes, atomic)
+
+	summary := ReconcileSummary{ScaleDeltas: make(map[string]int)}
+	for _, outcome := range result.Outcomes {
+		if !outcome.Applied {
+			continue
+		}
+		switch change := outcome.Change; change.Kind {
+		case ConfigChangeAddDomain:
+			summary.DomainsAdded++
+		case ConfigChangeRemoveDomain:
+			summary.DomainsRemoved++
+		case ConfigChangeSetEnv:
+			summary.EnvKeysSet++
+		case ConfigChangeUnsetEnv:
+			summary.EnvKeysUnset++
+		case ConfigChangeScale:
+			processType := process.ProcessType(change.Key)
+			if delta := change.Scale - scales[processType]; delta != 0 {
+				summary.ScaleDeltas[change.Key] += delta
+			}
+			scales[processType] = change.Scale
+		case ConfigChangeSetBuildpack:
+			summary.BuildpackChanged = true
+		}
+	}
+	if len(summary.ScaleDeltas) == 0 {
+		summary.ScaleDeltas = nil
+	}
+	if !summary.IsEmpty() {
+		a.updatedAt = a.clock.Now()
+		a.addEvent(NewConfigurationReconciledEvent(a.name.Value(), summary, a.clock.Now()))
+	}
+
+	return result, err
+}
+
+// liveDomains returns the reported domains the application configures itself,
+// leaving out the vhosts generated from the global domains
+func (a *Application) liveDomains(domains []string) []string {
+	configured := make([]string, 0, len(domains))
+	for _, domain := range domains {
+		domainVO, err := shared.NewDomainName(domain)
+		if err != nil || a.isGlobalVhost(domainVO) || slices.Contains(configured, domainVO.Value()) {
+			continue
+		}
+		configured = append(configured, domainVO.Value())
+	}
+	return configured
+}
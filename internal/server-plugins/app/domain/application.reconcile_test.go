@@ -0,0 +1,132 @@
+package app_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	app "github.com/dokku-mcp/dokku-mcp/internal/server-plugins/app/domain"
+	"github.com/dokku-mcp/dokku-mcp/internal/shared/process"
+)
+
+var _ = Describe("Application Reconcile", func() {
+	var (
+		application *app.Application
+		live        *app.LiveAppState
+	)
+
+	BeforeEach(func() {
+		var err error
+		application, err = app.NewApplication("reconciled-app")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(application.SetGlobalDomains([]string{"dokku.me"})).To(Succeed())
+		Expect(application.AddDomain("api.example.com")).To(Succeed())
+		Expect(application.AddDomain("www.example.com")).To(Succeed())
+		Expect(application.Scale(process.ProcessTypeWeb, 2)).To(Succeed())
+		Expect(application.SetBuildpack("heroku/nodejs")).To(Succeed())
+		Expect(application.SetEnvironmentVariable("NODE_ENV", "production")).To(Succeed())
+		Expect(application.SetEnvironmentVariable("PORT", "5000")).To(Succeed())
+		application.ClearEvents()
+
+		live = &app.LiveAppState{
+			Domains:   []string{"reconciled-app.dokku.me", "api.example.com", "old.example.com"},
+			Scales:    map[process.ProcessType]int{"web": 1, "worker": 1},
+			Buildpack: "heroku/nodejs",
+			EnvKeys:   []string{"PORT", "LEGACY_FLAG"},
+		}
+	})
+
+	It("should plan the changes making the live system match the entity", func() {
+		plan := application.Reconcile(live, app.ReconcileToLive)
+
+		Expect(plan.Direction).To(Equal(app.ReconcileToLive))
+		Expect(plan.Changes).To(Equal([]app.ConfigChange{
+			{Kind: app.ConfigChangeAddDomain, Key: "www.example.com"},
+			{Kind: app.ConfigChangeRemoveDomain, Key: "old.example.com"},
+			{Kind: app.ConfigChangeScale, Key: "web", Scale: 2},
+			{Kind: app.ConfigChangeScale, Key: "worker", Scale: 0},
+			{Kind: app.ConfigChangeSetEnv, Key: "NODE_ENV", Value: "production"},
+			{Kind: app.ConfigChangeUnsetEnv, Key: "LEGACY_FLAG"},
+		}))
+		Expect(plan.Unresolved).To(BeEmpty())
+	})
+
+	It("should plan the changes making the entity match the live system", func() {
+		plan := application.Reconcile(live, app.ReconcileFromLive)
+
+		Expect(plan.Changes).To(Equal([]app.ConfigChange{
+			{Kind: app.ConfigChangeAddDomain, Key: "old.example.com"},
+			{Kind: app.ConfigChangeRemoveDomain, Key: "www.example.com"},
+			{Kind: app.ConfigChangeScale, Key: "web", Scale: 1},
+			{Kind: app.ConfigChangeScale, Key: "worker", Scale: 1},
+			{Kind: app.ConfigChangeUnsetEnv, Key: "NODE_ENV"},
+		}))
+		Expect(plan.Unresolved).To(ConsistOf(ContainSubstring("LEGACY_FLAG")))
+	})
+
+	It("should not mutate either side", func() {
+		before := application.ConfigData()
+		application.Reconcile(live, app.ReconcileFromLive)
+
+		Expect(application.ConfigData()).To(Equal(before))
+		Expect(application.GetEvents()).To(BeEmpty())
+		Expect(live.Domains).To(HaveLen(3))
+		Expect(live.Scales).To(HaveLen(2))
+	})
+
+	It("should apply a plan from the live system onto the entity", func() {
+		plan := application.Reconcile(live, app.ReconcileFromLive)
+		_, err := application.ApplyChanges(plan.Changes, true)
+		Expect(err).NotTo(HaveOccurred())
+
+		again := application.Reconcile(live, app.ReconcileFromLive)
+		Expect(again.Changes).To(BeEmpty())
+	})
+
+	It("should summarize the applied plan in a last event", func() {
+		plan := application.Reconcile(live, app.ReconcileFromLive)
+		_, err := application.ApplyReconcilePlan(plan, true)
+		Expect(err).NotTo(HaveOccurred())
+
+		events := application.GetEvents()
+		Expect(len(events)).To(BeNumerically(">", 1))
+		reconciled, ok := events[len(events)-1].(*app.ConfigurationReconciledEvent)
+		Expect(ok).To(BeTrue())
+		Expect(reconciled.Summary()).To(Equal(app.ReconcileSummary{
+			DomainsAdded:   1,
+			DomainsRemoved: 1,
+			EnvKeysUnset:   1,
+			ScaleDeltas:    map[string]int{"web": -1, "worker": 1},
+		}))
+		Expect(reconciled.Summary().String()).To(Equal(
+			"domains added: 1, domains removed: 1, env keys unset: 1, web scaled by -1, worker scaled by +1"))
+	})
+
+	It("should not summarize a plan applying nothing", func() {
+		_, err := application.ApplyReconcilePlan(app.ReconcilePlan{Direction: app.ReconcileFromLive}, true)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(application.GetEvents()).To(BeEmpty())
+	})
+
+	It("should not summarize a rolled back plan", func() {
+		plan := application.Reconcile(live, app.ReconcileFromLive)
+		plan.Changes = append(plan.Changes, app.ConfigChange{Kind: app.ConfigChangeRemoveDomain, Key: "missing.example.com"})
+
+		result, err := application.ApplyReconcilePlan(plan, true)
+		Expect(err).To(HaveOccurred())
+		Expect(result.RolledBack).To(BeTrue())
+		Expect(application.GetEvents()).To(BeEmpty())
+	})
+
+	It("should refuse to apply a plan meant for the live system", func() {
+		_, err := application.ApplyReconcilePlan(application.Reconcile(live, app.ReconcileToLive), false)
+		Expect(err).To(MatchError(ContainSubstring("from_live")))
+	})
+
+	It("should return an empty plan when both sides match", func() {
+		live.Domains = []string{"api.example.com", "www.example.com"}
+		live.Scales = map[process.ProcessType]int{"web": 2}
+		live.EnvKeys = []string{"NODE_ENV", "PORT"}
+
+		Expect(application.Reconcile(live, app.ReconcileToLive).IsEmpty()).To(BeTrue())
+	})
+})
@@ -0,0 +1,260 @@
+package app
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/dokku-mcp/dokku-mcp/internal/shared"
+	"github.com/dokku-mcp/dokku-mcp/internal/shared/process"
+)
+
+// ReplayApplication rebuilds an application by folding its event stream in order.
+// No new event is recorded, so the returned aggregate has an empty event list.
+// The name is the initial one: after a rename event, the events belong to the new name.
+// The options configure the aggregate, the past events being applied whatever they say.
+func ReplayApplication(name string, events []DomainEvent, opts ...ApplicationOption) (*Application, error) {
+	app, err := NewApplication(name, opts...)
+	if err != nil {
+		return nil, err
+	}
+	app.ClearEvents()
+	app.lastSequence = 0
+
+	for i, event := range events {
+		if event == nil {
+			return nil, fmt.Errorf("unable to replay event %d: event is null", i)
+		}
+		if event.AggregateID() != app.name.Value() {
+			return nil, fmt.Errorf("unable to replay event %d: %s belongs to %s, not %s",
+				i, event.EventType(), event.AggregateID(), app.name.Value())
+		}
+		if err := app.apply(event); err != nil {
+			return nil, fmt.Errorf("unable to replay event %d (%s): %w", i, event.EventType(), err)
+		}
+		app.updatedAt = event.OccurredAt()
+		app.lastSequence = max(app.lastSequence, event.Sequence())
+	}
+
+	return app, nil
+}
+
+// apply mutates the aggregate state from a past event without emitting anything
+func (a *Application) apply(event DomainEvent) error {
+	switch e := event.(type) {
+	case *ApplicationCreatedEvent:
+		a.createdAt = e.OccurredAt()
+	case *ApplicationDestroyedEvent:
+		a.destroyed = true
+	case *ApplicationDeployedEvent:
+		gitRef, err := shared.NewGitRef(e.GitRef())
+		if err != nil {
+			return err
+		}
+		deployedAt := e.OccurredAt()
+		a.deploymentInfo.currentGitRef = gitRef
+		a.deploymentInfo.lastDeployedAt = &deployedAt
+		a.deploymentInfo.deploymentCount++
+		a.deploymentInfo.checksSkipped = a.configuration.healthChecks.IsSkipped()
+		a.deploymentInfo.rebuildRequired = false
+		a.deploymentInfo.lastDeploymentDuration = 0
+	case *ApplicationDeployedFromImageEvent:
+		image, err := shared.NewDockerImage(e.Image())
+		if err != nil {
+			return err
+		}
+		deployedAt := e.OccurredAt()
+		a.deploymentInfo.currentGitRef = nil
+		a.deploymentInfo.runImage = image
+		a.deploymentInfo.lastDeployedAt = &deployedAt
+		a.deploymentInfo.deploymentCount++
+		a.deploymentInfo.checksSkipped = a.configuration.healthChecks.IsSkipped()
+		a.deploymentInfo.rebuildRequired = false
+		a.deploymentInfo.lastDeploymentDuration = 0
+	case *ApplicationRebuiltEvent:
+		rebuiltAt := e.OccurredAt()
+		a.deploymentInfo.lastDeployedAt = &rebuiltAt
+		a.deploymentInfo.deploymentCount++
+		a.deploymentInfo.checksSkipped = a.configuration.healthChecks.IsSkipped()
+		a.deploymentInfo.rebuildRequired = false
+		a.deploymentInfo.lastDeploymentDuration = 0
+	case *ApplicationDeploymentCompletedEvent:
+		a.deploymentInfo.lastDeploymentDuration = e.Duration()
+		a.deploymentInfo.lastFailureReason = ""
+		a.deploymentInfo.lastFailureAt = nil
+		a.deploymentInfo.lastCheckResults = nil
+	case *ApplicationDeploymentFailedEvent:
+		failedAt := e.OccurredAt()
+		a.deploymentInfo.lastDeploymentDuration = e.Duration()
+		a.deploymentInfo.lastFailureReason = e.Reason()
+		a.deploymentInfo.lastFailureAt = &failedAt
+		a.deploymentInfo.lastCheckResults = e.CheckResults()
+		a.state = MustNewApplicationState(StateError)
+	case *ApplicationUndeployedEvent:
+		a.clearRelease()
+		a.state = MustNewApplicationState(StateExists)
+	case *ApplicationStateChangedEvent:
+		state, err := NewApplicationState(StateValue(e.NewState()))
+		if err != nil {
+			return err
+		}
+		a.state = state
+	case *ApplicationScaledEvent:
+		proc, err := a.replayedProcess(e.ProcessType())
+		if err != nil {
+			return err
+		}
+		return proc.SetScale(e.NewScale())
+	case *DomainAddedEvent:
+		domain, err := shared.NewDomainName(e.Domain())
+		if err != nil {
+			return err
+		}
+		if !containsDomain(a.configuration.domains, domain) {
+			a.configuration.domains = append(a.configuration.domains, domain)
+		}
+	case *DomainRemovedEvent:
+		domain, err := shared.NewDomainName(e.Domain())
+		if err != nil {
+			return err
+		}
+		for i, existing := range a.configuration.domains {
+			if existing.Equal(domain) {
+				a.configuration.domains = append(a.configuration.domains[:i], a.configuration.domains[i+1:]...)
+				break
+			}
+		}
+	case *BuildpackChangedEvent:
+		buildpack, err := shared.NewBuildpackName(e.Buildpack())
+		if err != nil {
+			return err
+		}
+		a.configuration.buildpacks = []*shared.BuildpackName{buildpack}
+	case *BuildpackAddedEvent:
+		buildpack, err := shared.NewBuildpackName(e.Buildpack())
+		if err != nil {
+			return err
+		}
+		index := e.Index()
+		if index < 1 || index > len(a.configuration.buildpacks)+1 {
+			return fmt.Errorf("invalid buildpack index %d", index)
+		}
+		a.configuration.buildpacks = slices.Insert(a.configuration.buildpacks, index-1, buildpack)
+	case *BuildpackRemovedEvent:
+		a.configuration.buildpacks = slices.DeleteFunc(a.configuration.buildpacks, func(existing *shared.BuildpackName) bool {
+			return existing.Value() == e.Buildpack()
+		})
+	case *ProcessLimitsChangedEvent:
+		proc, err := a.replayedProcess(e.ProcessType())
+		if err != nil {
+			return err
+		}
+		return proc.SetResourceLimits(e.Memory(), e.CPU(), e.Storage())
+	case *RestartPolicyChangedEvent:
+		proc, err := a.replayedProcess(e.ProcessType())
+		if err != nil {
+			return err
+		}
+		policy, err := process.NewRestartPolicyFromString(e.Policy())
+		if err != nil {
+			return err
+		}
+		proc.SetRestartPolicy(policy)
+	case *HealthChecksChangedEvent:
+		if e.Skipped() {
+			a.configuration.healthChecks = SkippedHealthCheck()
+			return nil
+		}
+		healthCheck, err := NewHealthCheck(e.Wait(), e.Timeout(), e.Attempts())
+		if err != nil {
+			return err
+		}
+		a.configuration.healthChecks = healthCheck
+	case *ApplicationRenamedEvent:
+		name, err := NewApplicationName(e.NewName())
+		if err != nil {
+			return err
+		}
+		a.name = name
+	case *GitConfigChangedEvent:
+		git, err := NewGitConfiguration(e.DeployBranch(), e.RevEnvVar(), e.KeepGitDir())
+		if err != nil {
+			return err
+		}
+		a.configuration.git = git
+	case *MaintenanceEnabledEvent:
+		a.configuration.maintenanceEnabled = true
+	case *MaintenanceDisabledEvent:
+		a.configuration.maintenanceEnabled = false
+	case *PortMappingsChangedEvent:
+		mappings := make([]shared.PortMapping, 0, len(e.Mappings()))
+		for _, value := range e.Mappings() {
+			mapping, err := shared.ParsePortMapping(value)
+			if err != nil {
+				return err
+			}
+			mappings = append(mappings, mapping)
+		}
+		a.configuration.portMappings = mappings
+	case *ScheduledTaskAddedEvent:
+		task, err := NewScheduledTask(e.Schedule(), e.Command())
+		if err != nil {
+			return err
+		}
+		a.configuration.scheduledTasks = append(a.configuration.scheduledTasks, task)
+	case *ScheduledTaskRemovedEvent:
+		a.configuration.scheduledTasks = slices.DeleteFunc(a.configuration.scheduledTasks, func(task *ScheduledTask) bool {
+			return task.Schedule() == e.Schedule() && task.Command() == e.Command()
+		})
+	case *DockerOptionAddedEvent:
+		phase, err := shared.NewDockerOptionPhase(e.Phase())
+		if err != nil {
+			return err
+		}
+		a.configuration.dockerOptions[phase] = append(a.configuration.dockerOptions[phase], e.Option())
+	case *DockerOptionRemovedEvent:
+		phase := shared.DockerOptionPhase(e.Phase())
+		a.configuration.dockerOptions[phase] = slices.DeleteFunc(a.configuration.dockerOptions[phase], func(option string) bool {
+			return option == e.Option()
+		})
+	case *LabelChangedEvent:
+		if e.Removed() {
+			delete(a.labels, e.Key())
+			return nil
+		}
+		if err := validateLabel(e.Key(), e.Value()); err != nil {
+			return err
+		}
+		a.labels[e.Key()] = e.Value()
+	case *RunAsUserChangedEvent:
+		a.deploymentInfo.rebuildRequired = true
+		if e.User() == "" {
+			a.configuration.runAsUser = nil
+			return nil
+		}
+		user, err := NewContainerUser(e.User())
+		if err != nil {
+			return err
+		}
+		a.configuration.runAsUser = user
+	case *ConfigurationReconciledEvent:
+		// The events of the applied changes carry the state, the summary adds nothing
+	default:
+		return fmt.Errorf("unknown event type %s", event.EventType())
+	}
+
+	return nil
+}
+
+// replayedProcess returns the process an event changes, creating it unscaled when the
+// stream never mentioned it: AddProcess and AddProcessForScaling record no event
+func (a *Application) replayedProcess(processType string) (*process.Process, error) {
+	if proc, exists := a.findProcess(process.ProcessType(processType)); exists {
+		return proc, nil
+	}
+	proc, err := process.NewProcessForScaling(process.ProcessType(processType), 0)
+	if err != nil {
+		return nil, err
+	}
+	a.configuration.processes[proc.Type()] = proc
+	return proc, nil
+}
@@ -0,0 +1,86 @@
+package app_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	app "github.com/dokku-mcp/dokku-mcp/internal/server-plugins/app/domain"
+	"github.com/dokku-mcp/dokku-mcp/internal/shared"
+	"github.com/dokku-mcp/dokku-mcp/internal/shared/process"
+)
+
+type unknownEvent struct{}
+
+func (unknownEvent) OccurredAt() time.Time { return time.Now() }
+func (unknownEvent) EventType() string     { return "application.unknown" }
+func (unknownEvent) AggregateID() string   { return "replay-app" }
+func (unknownEvent) Sequence() uint64      { return 0 }
+
+var _ = Describe("ReplayApplication", func() {
+	It("should rebuild an application from its recorded events", func() {
+		original, err := app.NewApplication("replay-app")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(original.AddDomain("example.com")).To(Succeed())
+		Expect(original.AddDomain("old.example.com")).To(Succeed())
+		Expect(original.RemoveDomain("old.example.com")).To(Succeed())
+		Expect(original.SetBuildpack("heroku/nodejs")).To(Succeed())
+		Expect(original.Scale(process.ProcessTypeWeb, 2)).To(Succeed())
+		Expect(original.Scale(process.ProcessTypeWeb, 3)).To(Succeed())
+		Expect(original.SetProcessResourceLimits(process.ProcessTypeWeb, "512m", "", "")).To(Succeed())
+		original.SkipHealthChecks()
+		Expect(original.Deploy(shared.MustNewGitRef("v1.0.0"), nil)).To(Succeed())
+		Expect(original.CompleteDeployment()).To(Succeed())
+
+		replayed, err := app.ReplayApplication("replay-app", original.GetEvents())
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(replayed.GetEvents()).To(BeEmpty())
+		Expect(replayed.GetDomains()).To(Equal([]string{"example.com"}))
+		Expect(replayed.GetProcessScale(process.ProcessTypeWeb)).To(Equal(3))
+		Expect(replayed.GetProcessResourceLimits(process.ProcessTypeWeb).Memory()).To(Equal("512m"))
+		Expect(replayed.GetHealthChecks().IsSkipped()).To(BeTrue())
+		Expect(replayed.LastDeploymentSkippedChecks()).To(BeTrue())
+		Expect(replayed.State().Value()).To(Equal(app.StateRunning))
+		Expect(replayed.ConfigData()).To(Equal(original.ConfigData()))
+		Expect(replayed.CreatedAt()).To(Equal(original.GetEvents()[0].OccurredAt()))
+		Expect(replayed.LastEventSequence()).To(Equal(original.LastEventSequence()))
+	})
+
+	It("should replay a failed deployment into the error state", func() {
+		original, _ := app.NewApplication("replay-app")
+		Expect(original.Deploy(shared.MustNewGitRef("main"), nil)).To(Succeed())
+		Expect(original.FailDeployment("build failed")).To(Succeed())
+
+		replayed, err := app.ReplayApplication("replay-app", original.GetEvents())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(replayed.State().Value()).To(Equal(app.StateError))
+	})
+
+	It("should replay changes of a process added without an event", func() {
+		original, _ := app.NewApplication("replay-app")
+		Expect(original.AddProcess(process.ProcessTypeWorker, "bin/worker", 1)).To(Succeed())
+		Expect(original.SetProcessResourceLimits(process.ProcessTypeWorker, "256m", "", "")).To(Succeed())
+		policy, err := process.NewRestartPolicyFromString("always")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(original.SetRestartPolicy(process.ProcessTypeWorker, policy)).To(Succeed())
+
+		replayed, err := app.ReplayApplication("replay-app", original.GetEvents())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(replayed.GetProcessResourceLimits(process.ProcessTypeWorker).Memory()).To(Equal("256m"))
+		Expect(replayed.GetProcessRestartPolicy(process.ProcessTypeWorker).String()).To(Equal("always"))
+	})
+
+	It("should name the offending event type when it is unknown", func() {
+		_, err := app.ReplayApplication("replay-app", []app.DomainEvent{unknownEvent{}})
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("application.unknown"))
+	})
+
+	It("should reject events from another aggregate", func() {
+		other, _ := app.NewApplication("other-app")
+		_, err := app.ReplayApplication("replay-app", other.GetEvents())
+		Expect(err).To(HaveOccurred())
+	})
+})
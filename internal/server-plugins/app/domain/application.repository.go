@@ -20,6 +20,25 @@ type ApplicationRepository interface {
 	GetApplicationMetrics(ctx context.Context) (*ApplicationMetrics, error)
 }
 
+// DomainsLoader loads the domains of applications from a single domains report,
+// for clients only interested in vhosts. An empty name loads every application.
+type DomainsLoader interface {
+	LoadDomains(ctx context.Context, appName string) (map[string][]string, error)
+}
+
+// ServiceLinksLoader loads the services linked to every application, for the blast
+// radius of a destroy
+type ServiceLinksLoader interface {
+	LoadServiceLinks(ctx context.Context) ([]*ServiceLink, error)
+}
+
+// ApplicationDescriber reads every report of an application into a single snapshot,
+// for diagnostics. A report failing leaves its section unavailable, only a missing
+// application fails the call.
+type ApplicationDescriber interface {
+	DescribeApplication(ctx context.Context, name *ApplicationName) (*ApplicationSnapshot, error)
+}
+
 type ApplicationMetrics struct {
 	TotalApplications     int
 	RunningApplications   int
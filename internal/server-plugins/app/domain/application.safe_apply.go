@@ -0,0 +1,91 @@
+package app
+
+import (
+	"fmt"
+	"slices"
+)
+
+// ChangeRisk grades how much a change may disrupt a running application
+type ChangeRisk string
+
+const (
+	ChangeRiskLow    ChangeRisk = "low"
+	ChangeRiskMedium ChangeRisk = "medium"
+	ChangeRiskHigh   ChangeRisk = "high"
+)
+
+// Reversible returns true if the change can be undone by another change. Unsetting a
+// variable loses its value, which the application does not keep anywhere else.
+func (c ConfigChange) Reversible() bool {
+	return c.Kind != ConfigChangeUnsetEnv
+}
+
+// Risk grades the change: adding a domain or a variable is harmless, removing a domain,
+// scaling or switching buildpack changes what serves traffic, and irreversible changes
+// are the riskiest
+func (c ConfigChange) Risk() ChangeRisk {
+	switch {
+	case !c.Reversible():
+		return ChangeRiskHigh
+	case c.Kind == ConfigChangeAddDomain, c.Kind == ConfigChangeSetEnv:
+		return ChangeRiskLow
+	default:
+		return ChangeRiskMedium
+	}
+}
+
+// PlanCheckpoint is where a safe batch paused: the changes left to apply, from the first
+// irreversible one on, once the caller confirmed them
+type PlanCheckpoint struct {
+	AppName string         `json:"app_name"`
+	Pending []ConfigChange `json:"pending"`
+	// Sequence is the last event sequence when paused, a later event of the application
+	// makes the checkpoint stale
+	Sequence uint64 `json:"sequence"`
+	// configuration is the configuration when paused, for the changes recording no
+	// event. A checkpoint decoded from JSON only has its sequence to go by.
+	configuration *ApplicationConfiguration
+}
+
+// ApplyChangesSafely applies the changes in their order and pauses before the first
+// irreversible one, so no change runs ahead of a change listed before it. The changes
+// left, from that one on, are returned in a checkpoint, nil when every change is
+// reversible, to be applied with ResumeChanges after confirmation. If a change before
+// the pause fails, no checkpoint is returned.
+func (a *Application) ApplyChangesSafely(changes []ConfigChange, atomic bool) (ChangeResult, *PlanCheckpoint, error) {
+	pause := slices.IndexFunc(changes, func(change ConfigChange) bool {
+		return !change.Reversible()
+	})
+	if pause < 0 {
+		pause = len(changes)
+	}
+
+	result, err := a.ApplyChanges(slices.Clone(changes[:pause]), atomic)
+	if err != nil || pause == len(changes) {
+		return result, nil, err
+	}
+
+	return result, &PlanCheckpoint{
+		AppName:       a.name.Value(),
+		Pending:       slices.Clone(changes[pause:]),
+		Sequence:      a.lastSequence,
+		configuration: a.copyConfiguration(),
+	}, nil
+}
+
+// ResumeChanges applies the confirmed changes of a checkpoint. The checkpoint is
+// refused if it belongs to another application or if the application changed since.
+func (a *Application) ResumeChanges(checkpoint *PlanCheckpoint, atomic bool) (ChangeResult, error) {
+	if checkpoint == nil {
+		return ChangeResult{}, fmt.Errorf("checkpoint cannot be null")
+	}
+	if checkpoint.AppName != a.name.Value() {
+		return ChangeResult{}, fmt.Errorf("checkpoint is for %s, not %s", checkpoint.AppName, a.name.Value())
+	}
+	stale := checkpoint.configuration != nil && !checkpoint.configuration.Equal(a.configuration)
+	if stale || checkpoint.Sequence != a.lastSequence {
+		return ChangeResult{}, fmt.Errorf("checkpoint is stale: %s changed since it was paused", a.name.Value())
+	}
+
+	return a.ApplyChanges(slices.Clone(checkpoint.Pending), atomic)
+}
@@ -0,0 +1,85 @@
+package app_test
+
+import (
+	"slices"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	app "github.com/dokku-mcp/dokku-mcp/internal/server-plugins/app/domain"
+	"github.com/dokku-mcp/dokku-mcp/internal/shared/process"
+)
+
+var _ = Describe("Application safe changes", func() {
+	var application *app.Application
+
+	BeforeEach(func() {
+		var err error
+		application, err = app.NewApplication("safe-app")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(application.AddDomain("old.example.com")).To(Succeed())
+		Expect(application.SetEnvironmentVariable("LEGACY_TOKEN", "secret")).To(Succeed())
+	})
+
+	plan := []app.ConfigChange{
+		{Kind: app.ConfigChangeAddDomain, Key: "new.example.com"},
+		{Kind: app.ConfigChangeUnsetEnv, Key: "LEGACY_TOKEN"},
+		{Kind: app.ConfigChangeRemoveDomain, Key: "old.example.com"},
+		{Kind: app.ConfigChangeScale, Key: "web", Scale: 0},
+	}
+
+	DescribeTable("classifying changes",
+		func(change app.ConfigChange, reversible bool, risk app.ChangeRisk) {
+			Expect(change.Reversible()).To(Equal(reversible))
+			Expect(change.Risk()).To(Equal(risk))
+		},
+		Entry("adding a domain", plan[0], true, app.ChangeRiskLow),
+		Entry("removing a domain", plan[2], true, app.ChangeRiskMedium),
+		Entry("scaling down", plan[3], true, app.ChangeRiskMedium),
+		Entry("unsetting a variable", plan[1], false, app.ChangeRiskHigh),
+	)
+
+	It("should apply the changes in order and pause before the first irreversible one", func() {
+		result, checkpoint, err := application.ApplyChangesSafely(plan, true)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(result.AppliedCount()).To(Equal(1))
+		Expect(application.GetDomains()).To(Equal([]string{"old.example.com", "new.example.com"}))
+		Expect(application.GetEnvironmentVariables(false)).To(HaveKey("LEGACY_TOKEN"))
+
+		Expect(checkpoint).NotTo(BeNil())
+		Expect(checkpoint.Pending).To(Equal(plan[1:]))
+
+		result, err = application.ResumeChanges(checkpoint, true)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.AppliedCount()).To(Equal(3))
+		Expect(application.GetEnvironmentVariables(false)).NotTo(HaveKey("LEGACY_TOKEN"))
+		Expect(application.GetDomains()).To(Equal([]string{"new.example.com"}))
+		Expect(application.GetProcessScale(process.ProcessTypeWeb)).To(BeZero())
+	})
+
+	It("should return no checkpoint when every change is reversible", func() {
+		_, checkpoint, err := application.ApplyChangesSafely(slices.Delete(slices.Clone(plan), 1, 2), false)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(checkpoint).To(BeNil())
+	})
+
+	It("should not pause when a reversible change fails", func() {
+		failing := append([]app.ConfigChange{{Kind: app.ConfigChangeRemoveDomain, Key: "missing.example.com"}}, plan...)
+
+		_, checkpoint, err := application.ApplyChangesSafely(failing, true)
+		Expect(err).To(MatchError(app.ErrDomainNotFound))
+		Expect(checkpoint).To(BeNil())
+		Expect(application.GetDomains()).To(Equal([]string{"old.example.com"}))
+	})
+
+	It("should refuse a stale checkpoint", func() {
+		_, checkpoint, err := application.ApplyChangesSafely(plan, true)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(application.SetEnvironmentVariable("LEGACY_TOKEN", "rotated")).To(Succeed())
+
+		_, err = application.ResumeChanges(checkpoint, true)
+		Expect(err).To(MatchError(ContainSubstring("checkpoint is stale")))
+		Expect(application.GetEnvironmentVariables(false)).To(HaveKeyWithValue("LEGACY_TOKEN", "rotated"))
+	})
+})
@@ -0,0 +1,101 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dokku-mcp/dokku-mcp/internal/shared"
+)
+
+// SnapshotSection names a part of an application snapshot, each read from one report
+type SnapshotSection string
+
+const (
+	// SnapshotSectionProcesses is read from ps:report and gives the state of the application
+	SnapshotSectionProcesses  SnapshotSection = "processes"
+	SnapshotSectionDomains    SnapshotSection = "domains"
+	SnapshotSectionSSL        SnapshotSection = "ssl"
+	SnapshotSectionProxy      SnapshotSection = "proxy"
+	SnapshotSectionScheduler  SnapshotSection = "scheduler"
+	SnapshotSectionBuildpacks SnapshotSection = "buildpacks"
+	SnapshotSectionGit        SnapshotSection = "git"
+	SnapshotSectionEnv        SnapshotSection = "env"
+)
+
+// ApplicationSnapshot is everything Dokku reports about an application, read at once
+// for diagnostics. A report that could not be read leaves its section empty and listed
+// in Unavailable with the reason, the other sections stay usable.
+type ApplicationSnapshot struct {
+	Name       string         `json:"name"`
+	State      StateValue     `json:"state,omitempty"`
+	Processes  *ProcessReport `json:"processes,omitempty"`
+	Domains    *DomainReport  `json:"domains,omitempty"`
+	SSLEnabled bool           `json:"ssl_enabled"`
+	ProxyType  string         `json:"proxy_type,omitempty"`
+	Scheduler  string         `json:"scheduler,omitempty"`
+	Buildpacks []string       `json:"buildpacks,omitempty"`
+	Git        *GitReport     `json:"git,omitempty"`
+	// EnvKeys are the keys of the environment variables, config:keys giving no value
+	EnvKeys     []string                   `json:"env_keys,omitempty"`
+	Unavailable map[SnapshotSection]string `json:"unavailable,omitempty"`
+}
+
+// NewApplicationSnapshot creates an empty snapshot of the application
+func NewApplicationSnapshot(name string) *ApplicationSnapshot {
+	return &ApplicationSnapshot{
+		Name:        name,
+		Unavailable: make(map[SnapshotSection]string),
+	}
+}
+
+// MarkUnavailable records that the section could not be read and why
+func (s *ApplicationSnapshot) MarkUnavailable(section SnapshotSection, err error) {
+	reason := "report unavailable"
+	if err != nil {
+		reason = err.Error()
+	}
+	s.Unavailable[section] = reason
+}
+
+// IsAvailable returns true if the section was read
+func (s *ApplicationSnapshot) IsAvailable(section SnapshotSection) bool {
+	_, unavailable := s.Unavailable[section]
+	return !unavailable
+}
+
+// SetProcessReport records the processes, their scales and restart policies, and the
+// state they give the application
+func (s *ApplicationSnapshot) SetProcessReport(report *ProcessReport) {
+	s.Processes = report
+	s.State = report.observedState()
+}
+
+// SetDomainReport records the domains, and their SSL status when the report carries
+// the certs section
+func (s *ApplicationSnapshot) SetDomainReport(report *DomainReport) {
+	s.Domains = report
+	s.SSLEnabled = report.SSLEnabled
+}
+
+// SetEnvKeys records the keys of the environment variables
+func (s *ApplicationSnapshot) SetEnvKeys(keys []string) {
+	s.EnvKeys = keys
+}
+
+// ParseConfigKeys parses the output of config:keys, one key per line, refusing any
+// line that is not a valid environment variable key
+func ParseConfigKeys(raw string) ([]string, error) {
+	keys := make([]string, 0)
+	for _, line := range strings.Split(raw, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "=====>") {
+			continue
+		}
+		key, err := shared.NewEnvVarKey(line)
+		if err != nil {
+			return nil, fmt.Errorf("invalid config keys: %w", err)
+		}
+		keys = append(keys, key.Value())
+	}
+	return keys, nil
+}
@@ -0,0 +1,81 @@
+package app_test
+
+import (
+	"encoding/json"
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	app "github.com/dokku-mcp/dokku-mcp/internal/server-plugins/app/domain"
+)
+
+var _ = Describe("ApplicationSnapshot", func() {
+	var snapshot *app.ApplicationSnapshot
+
+	BeforeEach(func() {
+		snapshot = app.NewApplicationSnapshot("my-app")
+	})
+
+	It("should derive the state from the process report", func() {
+		report, err := app.ParseProcessReport(psReport)
+		Expect(err).NotTo(HaveOccurred())
+
+		snapshot.SetProcessReport(report)
+
+		Expect(snapshot.State).To(Equal(app.StateRunning))
+		Expect(snapshot.Processes.RestartPolicy).To(Equal("on-failure:10"))
+		Expect(snapshot.IsAvailable(app.SnapshotSectionProcesses)).To(BeTrue())
+	})
+
+	It("should list the environment keys without values", func() {
+		snapshot.SetEnvKeys([]string{"DATABASE_URL", "SECRET_KEY"})
+
+		data, err := json.Marshal(snapshot)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).To(ContainSubstring(`"env_keys":["DATABASE_URL","SECRET_KEY"]`))
+	})
+
+	It("should list the unavailable sections with their reason", func() {
+		snapshot.MarkUnavailable(app.SnapshotSectionGit, errors.New("git:report: timeout"))
+
+		Expect(snapshot.IsAvailable(app.SnapshotSectionGit)).To(BeFalse())
+		Expect(snapshot.IsAvailable(app.SnapshotSectionProxy)).To(BeTrue())
+
+		data, err := json.Marshal(snapshot)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).To(ContainSubstring(`"unavailable":{"git":"git:report: timeout"}`))
+		Expect(string(data)).NotTo(ContainSubstring(`"git":{`))
+	})
+})
+
+var _ = Describe("ParseConfigKeys", func() {
+	It("should read one key per line", func() {
+		keys, err := app.ParseConfigKeys("=====> my-app env keys\nAPP_NAME\nDATABASE_URL\n\n")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(keys).To(Equal([]string{"APP_NAME", "DATABASE_URL"}))
+	})
+
+	It("should reject a line that is not a key", func() {
+		_, err := app.ParseConfigKeys("DATABASE_URL=postgres://db")
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+var _ = Describe("ParseBuildpacksReport", func() {
+	It("should read the buildpacks in order", func() {
+		buildpacks, err := app.ParseBuildpacksReport(`=====> my-app buildpacks information
+       Buildpacks computed stack:     gliderlabs/herokuish:latest
+       Buildpacks list:               https://github.com/heroku/heroku-buildpack-nodejs,https://github.com/heroku/heroku-buildpack-python`)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(buildpacks).To(Equal([]string{
+			"https://github.com/heroku/heroku-buildpack-nodejs",
+			"https://github.com/heroku/heroku-buildpack-python",
+		}))
+	})
+
+	It("should reject output that is not a buildpacks report", func() {
+		_, err := app.ParseBuildpacksReport("=====> my-app git information")
+		Expect(err).To(HaveOccurred())
+	})
+})
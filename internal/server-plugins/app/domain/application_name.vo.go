@@ -3,12 +3,16 @@ package app
 import (
 	"fmt"
 	"regexp"
+	"slices"
 	"strings"
 )
 
-// ApplicationName represents a valid Dokku application name
+// ApplicationName represents a valid Dokku application name. Names are normalized
+// on creation, so "MyApp " and "myapp" name the same application.
 type ApplicationName struct {
 	value string
+	// original is the input the name was created from, before normalization
+	original string
 }
 
 var (
@@ -17,15 +21,23 @@ var (
 	applicationNamePattern = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?$`)
 )
 
-// NewApplicationName creates a new application name with validation
+// NormalizeApplicationName returns the form Dokku knows an application name by:
+// surrounding whitespace trimmed and lowercased. The result is not validated.
+func NormalizeApplicationName(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+// NewApplicationName creates a new application name with validation. The name is
+// normalized with NormalizeApplicationName first, the raw input staying available
+// through OriginalInput.
 func NewApplicationName(name string) (*ApplicationName, error) {
-	name = strings.TrimSpace(strings.ToLower(name))
+	normalized := NormalizeApplicationName(name)
 
-	if err := validateApplicationName(name); err != nil {
+	if err := validateApplicationName(normalized); err != nil {
 		return nil, fmt.Errorf("invalid application name: %w", err)
 	}
 
-	return &ApplicationName{value: name}, nil
+	return &ApplicationName{value: normalized, original: name}, nil
 }
 
 // MustNewApplicationName creates an application name, panicking on error
@@ -37,11 +49,21 @@ func MustNewApplicationName(name string) *ApplicationName {
 	return appName
 }
 
-// Value returns the value of the application name
+// Value returns the normalized application name
 func (an *ApplicationName) Value() string {
 	return an.value
 }
 
+// OriginalInput returns the name as given on creation, before normalization
+func (an *ApplicationName) OriginalInput() string {
+	return an.original
+}
+
+// WasNormalized returns true if normalization changed the input
+func (an *ApplicationName) WasNormalized() bool {
+	return an.original != an.value
+}
+
 // String implements fmt.Stringer
 func (an *ApplicationName) String() string {
 	return an.value
@@ -57,57 +79,44 @@ func (an *ApplicationName) Equal(other *ApplicationName) bool {
 
 // IsReserved checks if the name is a reserved name by Dokku
 func (an *ApplicationName) IsReserved() bool {
-	reservedNames := []string{
-		"dokku", "tls", "app", "plugin", "plugins", "config", "logs",
-		"ps", "run", "shell", "enter", "backup", "restore", "certs",
-		"domains", "git", "storage", "network", "proxy", "apps",
-		"service", "services", "builder", "scheduler", "registry",
-	}
+	return slices.Contains(reservedApplicationNames, an.value)
+}
 
-	for _, reserved := range reservedNames {
-		if an.value == reserved {
-			return true
-		}
-	}
-	return false
+// reservedApplicationNames are the names Dokku keeps for itself
+var reservedApplicationNames = []string{
+	"dokku", "tls", "app", "plugin", "plugins", "config", "logs",
+	"ps", "run", "shell", "enter", "backup", "restore", "certs",
+	"domains", "git", "storage", "network", "proxy", "apps",
+	"service", "services", "builder", "scheduler", "registry",
 }
 
-// validateApplicationName validates an application name according to Dokku rules
+// validateApplicationName validates an application name against the installed name
+// policy, then according to Dokku rules, which no policy can relax
 func validateApplicationName(name string) error {
 	if name == "" {
-		return fmt.Errorf("application name cannot be empty")
+		return &NameRuleError{Rule: NameRuleRequired, message: "application name cannot be empty"}
 	}
 
-	if len(name) > 63 {
-		return fmt.Errorf("application name cannot exceed 63 characters")
+	if err := CurrentNamePolicy().check(name); err != nil {
+		return err
 	}
 
-	if len(name) < 1 {
-		return fmt.Errorf("application name must contain at least 1 character")
+	if len(name) > maxApplicationNameLength {
+		return &NameRuleError{Rule: NameRuleMaxLength, message: fmt.Sprintf("application name cannot exceed %d characters", maxApplicationNameLength)}
 	}
 
 	// Check DNS pattern
 	if !applicationNamePattern.MatchString(name) {
-		return fmt.Errorf("application name must respect DNS format (lowercase letters, numbers, hyphens)")
+		return &NameRuleError{Rule: NameRuleDNS, message: "application name must respect DNS format (lowercase letters, numbers, hyphens)"}
 	}
 
 	// Cannot start or end with a hyphen
 	if strings.HasPrefix(name, "-") || strings.HasSuffix(name, "-") {
-		return fmt.Errorf("application name cannot start or end with a hyphen")
-	}
-
-	// Check reserved names
-	reservedNames := []string{
-		"dokku", "tls", "app", "plugin", "plugins", "config", "logs",
-		"ps", "run", "shell", "enter", "backup", "restore", "certs",
-		"domains", "git", "storage", "network", "proxy", "apps",
-		"service", "services", "builder", "scheduler", "registry",
+		return &NameRuleError{Rule: NameRuleDNS, message: "application name cannot start or end with a hyphen"}
 	}
 
-	for _, reserved := range reservedNames {
-		if name == reserved {
-			return fmt.Errorf("name '%s' is reserved by Dokku", name)
-		}
+	if slices.Contains(reservedApplicationNames, name) {
+		return &NameRuleError{Rule: NameRuleReserved, message: fmt.Sprintf("name '%s' is reserved by Dokku", name)}
 	}
 
 	return nil
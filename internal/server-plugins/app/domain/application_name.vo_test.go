@@ -0,0 +1,85 @@
+package app_test
+
+import (
+	"errors"
+	"regexp"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	app "github.com/dokku-mcp/dokku-mcp/internal/server-plugins/app/domain"
+)
+
+var _ = Describe("ApplicationName", func() {
+	DescribeTable("should name the broken rule",
+		func(name, rule string) {
+			_, err := app.NewApplicationName(name)
+			var ruleErr *app.NameRuleError
+			Expect(errors.As(err, &ruleErr)).To(BeTrue())
+			Expect(ruleErr.Rule).To(Equal(rule))
+			Expect(err.Error()).To(ContainSubstring("rule " + rule))
+		},
+		Entry("empty", " ", app.NameRuleRequired),
+		Entry("too long", "a123456789012345678901234567890123456789012345678901234567890123", app.NameRuleMaxLength),
+		Entry("invalid characters", "my_app", app.NameRulePattern),
+		Entry("reserved", "dokku", app.NameRuleReserved),
+	)
+
+	DescribeTable("should normalize the name",
+		func(input, normalized string) {
+			name, err := app.NewApplicationName(input)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(name.Value()).To(Equal(normalized))
+			Expect(name.String()).To(Equal(normalized))
+			Expect(name.OriginalInput()).To(Equal(input))
+			Expect(name.WasNormalized()).To(Equal(input != normalized))
+			Expect(name.Equal(app.MustNewApplicationName(normalized))).To(BeTrue())
+		},
+		Entry("surrounding whitespace", "  my-app\t", "my-app"),
+		Entry("uppercase", "My-App", "my-app"),
+		Entry("uppercase and whitespace", "MyApp ", "myapp"),
+		Entry("already normalized", "my-app", "my-app"),
+	)
+
+	It("should validate the normalized name", func() {
+		_, err := app.NewApplicationName(" DOKKU ")
+		Expect(err).To(MatchError(ContainSubstring("rule reserved")))
+	})
+
+	Context("with a custom name policy", func() {
+		BeforeEach(func() {
+			DeferCleanup(app.SetNamePolicy, app.CurrentNamePolicy())
+			Expect(app.SetNamePolicy(app.NamePolicy{
+				Pattern:     regexp.MustCompile(`^[a-z]+-(dev|staging|prod)-[a-z0-9-]+$`),
+				Description: "team-env-service",
+				MinLength:   8,
+				MaxLength:   30,
+			})).To(Succeed())
+		})
+
+		It("should accept names following the convention", func() {
+			name, err := app.NewApplicationName("payments-prod-api")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(name.Value()).To(Equal("payments-prod-api"))
+		})
+
+		It("should reject names breaking the convention", func() {
+			_, err := app.NewApplicationName("payments-api")
+			Expect(err).To(MatchError(ContainSubstring("team-env-service")))
+
+			_, err = app.NewApplicationName("a-dev-b")
+			Expect(err).To(MatchError(ContainSubstring("rule min_length")))
+		})
+
+		It("should still enforce Dokku rules", func() {
+			_, err := app.NewApplicationName("payments-prod-api-")
+			Expect(err).To(MatchError(ContainSubstring("rule dns_format")))
+		})
+	})
+
+	It("should reject unusable policies", func() {
+		Expect(app.SetNamePolicy(app.NamePolicy{MinLength: 0, MaxLength: 10})).NotTo(Succeed())
+		Expect(app.SetNamePolicy(app.NamePolicy{MinLength: 1, MaxLength: 64})).NotTo(Succeed())
+		Expect(app.CurrentNamePolicy().MaxLength).To(Equal(63))
+	})
+})
@@ -0,0 +1,105 @@
+package app
+
+import (
+	"fmt"
+	"regexp"
+	"sync"
+)
+
+// maxApplicationNameLength is the longest name Dokku accepts, a DNS label
+const maxApplicationNameLength = 63
+
+// Rules an application name can break, reported by NameRuleError
+const (
+	NameRuleRequired  = "required"
+	NameRuleMinLength = "min_length"
+	NameRuleMaxLength = "max_length"
+	NameRulePattern   = "pattern"
+	NameRuleDNS       = "dns_format"
+	NameRuleReserved  = "reserved"
+)
+
+// NameRuleError is the error of an application name breaking a naming rule
+type NameRuleError struct {
+	// Rule is the broken rule, one of the NameRule constants
+	Rule    string
+	message string
+}
+
+func (e *NameRuleError) Error() string {
+	return fmt.Sprintf("%s (rule %s)", e.message, e.Rule)
+}
+
+// NamePolicy is a site naming convention, such as team-env-service, that application
+// names must follow. It narrows Dokku's own rules, which always apply on top of it.
+type NamePolicy struct {
+	// Pattern the name must match, nil accepting any name
+	Pattern *regexp.Regexp
+	// Description explains the pattern in the error of a name not matching it
+	Description string
+	MinLength   int
+	MaxLength   int
+}
+
+// DefaultNamePolicy returns the policy enforcing Dokku's rules only
+func DefaultNamePolicy() NamePolicy {
+	return NamePolicy{
+		Pattern:     applicationNamePattern,
+		Description: "lowercase letters, numbers and hyphens",
+		MinLength:   1,
+		MaxLength:   maxApplicationNameLength,
+	}
+}
+
+// Validate checks the policy bounds are usable
+func (p NamePolicy) Validate() error {
+	if p.MinLength < 1 {
+		return fmt.Errorf("name policy minimum length must be at least 1, got %d", p.MinLength)
+	}
+	if p.MaxLength < p.MinLength || p.MaxLength > maxApplicationNameLength {
+		return fmt.Errorf("name policy maximum length must be between %d and %d, got %d",
+			p.MinLength, maxApplicationNameLength, p.MaxLength)
+	}
+	return nil
+}
+
+func (p NamePolicy) check(name string) error {
+	if len(name) < p.MinLength {
+		return &NameRuleError{Rule: NameRuleMinLength, message: fmt.Sprintf("application name must contain at least %d characters", p.MinLength)}
+	}
+	if len(name) > p.MaxLength {
+		return &NameRuleError{Rule: NameRuleMaxLength, message: fmt.Sprintf("application name cannot exceed %d characters", p.MaxLength)}
+	}
+	if p.Pattern != nil && !p.Pattern.MatchString(name) {
+		expected := p.Description
+		if expected == "" {
+			expected = p.Pattern.String()
+		}
+		return &NameRuleError{Rule: NameRulePattern, message: fmt.Sprintf("application name must match the naming policy (%s)", expected)}
+	}
+	return nil
+}
+
+var (
+	namePolicyMu sync.RWMutex
+	namePolicy   = DefaultNamePolicy()
+)
+
+// SetNamePolicy installs the policy NewApplicationName enforces from now on. Names
+// created earlier are not checked again.
+func SetNamePolicy(policy NamePolicy) error {
+	if err := policy.Validate(); err != nil {
+		return err
+	}
+	namePolicyMu.Lock()
+	defer namePolicyMu.Unlock()
+	namePolicy = policy
+	return nil
+}
+
+// CurrentNamePolicy returns the installed name policy
+func CurrentNamePolicy() NamePolicy {
+	namePolicyMu.RLock()
+	defer namePolicyMu.RUnlock()
+	return namePolicy
+}
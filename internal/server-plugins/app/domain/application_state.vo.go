@@ -1,6 +1,7 @@
 package app
 
 import (
+	"encoding/json"
 	"fmt"
 	"slices"
 )
@@ -15,6 +16,69 @@ const (
 	StateError   StateValue = "error"   // Application is in an error state
 )
 
+// String returns the canonical wire name of the state
+func (s StateValue) String() string {
+	return string(s)
+}
+
+// ParseStateValue parses a canonical state name, rejecting any other string
+func ParseStateValue(value string) (StateValue, error) {
+	state := StateValue(value)
+	if !isValidState(state) {
+		return "", fmt.Errorf("unknown application state '%s', must be one of: %v", value, validStates)
+	}
+	return state, nil
+}
+
+// MarshalJSON serializes the state as its canonical name, refusing an unknown state.
+// The zero value is an unset state, serialized as an empty string.
+func (s StateValue) MarshalJSON() ([]byte, error) {
+	if s != "" && !isValidState(s) {
+		return nil, fmt.Errorf("unknown application state '%s'", string(s))
+	}
+	return json.Marshal(string(s))
+}
+
+// UnmarshalJSON parses a canonical state name, an unknown name being an error rather
+// than a default state. An empty string is the unset state.
+func (s *StateValue) UnmarshalJSON(data []byte) error {
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return fmt.Errorf("application state must be a string: %w", err)
+	}
+	if value == "" {
+		*s = ""
+		return nil
+	}
+	state, err := ParseStateValue(value)
+	if err != nil {
+		return err
+	}
+	*s = state
+	return nil
+}
+
+// allowedTransitions lists the legal state changes. Staying in the same state is
+// always allowed. An application in error must be redeployed, which goes through
+// exists, before it can run again. An undeployed application is back to exists.
+var allowedTransitions = map[StateValue][]StateValue{
+	StateExists:  {StateRunning, StateStopped, StateError},
+	StateRunning: {StateStopped, StateError, StateExists},
+	StateStopped: {StateRunning, StateError, StateExists},
+	StateError:   {StateExists, StateStopped},
+}
+
+// CanTransition checks if an application may move from one state to another
+func CanTransition(from, to StateValue) bool {
+	if !isValidState(from) || !isValidState(to) {
+		return false
+	}
+	if from == to {
+		return true
+	}
+	return slices.Contains(allowedTransitions[from], to)
+}
+
 // ApplicationState represents the state of an application
 type ApplicationState struct {
 	value StateValue
@@ -87,11 +151,10 @@ func (as *ApplicationState) Description() string {
 	return string(as.value)
 }
 
+// validStates are the canonical states, their names being the stable wire form
+var validStates = []StateValue{StateExists, StateRunning, StateStopped, StateError}
+
 // isValidState checks if a state value is valid
 func isValidState(state StateValue) bool {
-	validStates := []StateValue{
-		StateExists, StateRunning, StateStopped, StateError,
-	}
-
 	return slices.Contains(validStates, state)
 }
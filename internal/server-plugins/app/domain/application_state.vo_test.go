@@ -0,0 +1,119 @@
+package app_test
+
+import (
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	app "github.com/dokku-mcp/dokku-mcp/internal/server-plugins/app/domain"
+	"github.com/dokku-mcp/dokku-mcp/internal/shared"
+)
+
+var _ = Describe("Application state transitions", func() {
+	DescribeTable("CanTransition",
+		func(from, to app.StateValue, allowed bool) {
+			Expect(app.CanTransition(from, to)).To(Equal(allowed))
+		},
+		Entry("exists to running", app.StateExists, app.StateRunning, true),
+		Entry("exists to stopped", app.StateExists, app.StateStopped, true),
+		Entry("exists to error", app.StateExists, app.StateError, true),
+		Entry("running to stopped", app.StateRunning, app.StateStopped, true),
+		Entry("running to error", app.StateRunning, app.StateError, true),
+		Entry("running to exists", app.StateRunning, app.StateExists, true),
+		Entry("stopped to running", app.StateStopped, app.StateRunning, true),
+		Entry("stopped to error", app.StateStopped, app.StateError, true),
+		Entry("stopped to exists", app.StateStopped, app.StateExists, true),
+		Entry("error to exists", app.StateError, app.StateExists, true),
+		Entry("error to stopped", app.StateError, app.StateStopped, true),
+		Entry("error to running", app.StateError, app.StateRunning, false),
+		Entry("same state", app.StateRunning, app.StateRunning, true),
+		Entry("unknown source", app.StateValue("paused"), app.StateRunning, false),
+		Entry("unknown target", app.StateRunning, app.StateValue("paused"), false),
+	)
+
+	Describe("lifecycle", func() {
+		var application *app.Application
+
+		BeforeEach(func() {
+			var err error
+			application, err = app.NewApplication("my-app")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should stop and start a running application", func() {
+			Expect(application.Deploy(shared.MustNewGitRef("main"), nil)).To(Succeed())
+			Expect(application.CompleteDeployment()).To(Succeed())
+			Expect(application.Stop()).To(Succeed())
+			Expect(application.State().Value()).To(Equal(app.StateStopped))
+			Expect(application.Start()).To(Succeed())
+			Expect(application.State().Value()).To(Equal(app.StateRunning))
+		})
+
+		It("should not start an application in error without a redeploy", func() {
+			Expect(application.FailDeployment("build failed")).To(Succeed())
+
+			err := application.Start()
+			Expect(err).To(MatchError(app.ErrInvalidState))
+			Expect(application.State().Value()).To(Equal(app.StateError))
+
+			Expect(application.Deploy(shared.MustNewGitRef("main"), nil)).To(Succeed())
+			Expect(application.State().Value()).To(Equal(app.StateExists))
+			Expect(application.CompleteDeployment()).To(Succeed())
+			Expect(application.State().Value()).To(Equal(app.StateRunning))
+		})
+	})
+})
+
+var _ = Describe("StateValue serialization", func() {
+	DescribeTable("should round-trip the canonical names",
+		func(state app.StateValue, wire string) {
+			Expect(state.String()).To(Equal(wire))
+
+			data, err := json.Marshal(state)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(data)).To(Equal(`"` + wire + `"`))
+
+			var decoded app.StateValue
+			Expect(json.Unmarshal(data, &decoded)).To(Succeed())
+			Expect(decoded).To(Equal(state))
+		},
+		Entry("exists", app.StateExists, "exists"),
+		Entry("running", app.StateRunning, "running"),
+		Entry("stopped", app.StateStopped, "stopped"),
+		Entry("error", app.StateError, "error"),
+	)
+
+	It("should reject an unknown name on unmarshal", func() {
+		var state app.StateValue
+		Expect(json.Unmarshal([]byte(`"paused"`), &state)).To(MatchError(ContainSubstring("unknown application state 'paused'")))
+		Expect(json.Unmarshal([]byte(`"Running"`), &state)).NotTo(Succeed())
+		Expect(json.Unmarshal([]byte(`1`), &state)).NotTo(Succeed())
+		Expect(state).To(BeEmpty())
+	})
+
+	It("should refuse to marshal an unknown state", func() {
+		_, err := json.Marshal(app.StateValue("paused"))
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should keep the unset state empty", func() {
+		data, err := json.Marshal(app.StateValue(""))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).To(Equal(`""`))
+
+		state := app.StateRunning
+		Expect(json.Unmarshal(data, &state)).To(Succeed())
+		Expect(state).To(BeEmpty())
+	})
+
+	It("should serialize the state of the application info", func() {
+		data, err := json.Marshal(app.ApplicationInfo{Name: "my-app", State: app.StateRunning})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).To(ContainSubstring(`"state":"running"`))
+
+		var info app.ApplicationInfo
+		Expect(json.Unmarshal(data, &info)).To(Succeed())
+		Expect(info.State).To(Equal(app.StateRunning))
+	})
+})
@@ -0,0 +1,117 @@
+package app
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// BlastRadius describes what a destroy of an application would affect
+type BlastRadius struct {
+	AppName string `json:"app_name"`
+	// OrphanedServices are linked services no other application uses
+	OrphanedServices []string `json:"orphaned_services"`
+	// SharedServices are linked services other applications still use
+	SharedServices []string `json:"shared_services"`
+	// DependentApps are the applications consuming this one
+	DependentApps []string `json:"dependent_apps"`
+	// FreedDomains are the domains no longer owned by any application
+	FreedDomains []string `json:"freed_domains"`
+}
+
+// IsEmpty returns true if destroying the application affects nothing else
+func (b *BlastRadius) IsEmpty() bool {
+	return len(b.OrphanedServices) == 0 && len(b.DependentApps) == 0 && len(b.FreedDomains) == 0
+}
+
+// Summary describes the blast radius in one line, for destroy confirmation previews
+func (b *BlastRadius) Summary() string {
+	if b.IsEmpty() {
+		return fmt.Sprintf("Destroying %s affects no other resource", b.AppName)
+	}
+
+	impacts := make([]string, 0, 3)
+	if len(b.OrphanedServices) > 0 {
+		impacts = append(impacts, fmt.Sprintf("orphans %s", strings.Join(b.OrphanedServices, ", ")))
+	}
+	if len(b.DependentApps) > 0 {
+		impacts = append(impacts, fmt.Sprintf("breaks %s", strings.Join(b.DependentApps, ", ")))
+	}
+	if len(b.FreedDomains) > 0 {
+		impacts = append(impacts, fmt.Sprintf("frees %s", strings.Join(b.FreedDomains, ", ")))
+	}
+	return fmt.Sprintf("Destroying %s %s", b.AppName, strings.Join(impacts, "; "))
+}
+
+// BlastRadiusService is a read-only domain service computing the impact of destroying an application
+type BlastRadiusService struct{}
+
+// NewBlastRadiusService creates a new blast radius service
+func NewBlastRadiusService() *BlastRadiusService {
+	return &BlastRadiusService{}
+}
+
+// Compute returns the blast radius of destroying target, given the other applications
+// and every known service link. Nothing is modified.
+func (s *BlastRadiusService) Compute(target *Application, apps []*Application, links []*ServiceLink) (*BlastRadius, error) {
+	if target == nil {
+		return nil, fmt.Errorf("application cannot be null")
+	}
+
+	appName := target.Name().Value()
+	radius := &BlastRadius{
+		AppName:          appName,
+		OrphanedServices: make([]string, 0),
+		SharedServices:   make([]string, 0),
+		DependentApps:    make([]string, 0),
+		FreedDomains:     make([]string, 0),
+	}
+
+	consumers := make(map[string][]string)
+	for _, link := range links {
+		if link == nil {
+			continue
+		}
+		serviceID := link.ServiceID()
+		if !slices.Contains(consumers[serviceID], link.AppName()) {
+			consumers[serviceID] = append(consumers[serviceID], link.AppName())
+		}
+		if link.IsAppLink() && link.ServiceName() == appName && link.AppName() != appName &&
+			!slices.Contains(radius.DependentApps, link.AppName()) {
+			radius.DependentApps = append(radius.DependentApps, link.AppName())
+		}
+	}
+
+	for serviceID, appNames := range consumers {
+		if !slices.Contains(appNames, appName) || strings.HasPrefix(serviceID, ServiceTypeApp+":") {
+			continue
+		}
+		if len(appNames) == 1 {
+			radius.OrphanedServices = append(radius.OrphanedServices, serviceID)
+		} else {
+			radius.SharedServices = append(radius.SharedServices, serviceID)
+		}
+	}
+
+	ownedElsewhere := make(map[string]bool)
+	for _, app := range apps {
+		if app == nil || app.Name().Value() == appName {
+			continue
+		}
+		for _, domain := range app.GetDomains() {
+			ownedElsewhere[domain] = true
+		}
+	}
+	for _, domain := range target.GetDomains() {
+		if !ownedElsewhere[domain] {
+			radius.FreedDomains = append(radius.FreedDomains, domain)
+		}
+	}
+
+	slices.Sort(radius.OrphanedServices)
+	slices.Sort(radius.SharedServices)
+	slices.Sort(radius.DependentApps)
+	slices.Sort(radius.FreedDomains)
+
+	return radius, nil
+}
@@ -0,0 +1,89 @@
+package app_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	app "github.com/dokku-mcp/dokku-mcp/internal/server-plugins/app/domain"
+)
+
+var _ = Describe("BlastRadiusService", func() {
+	var (
+		service *app.BlastRadiusService
+		api     *app.Application
+		web     *app.Application
+		worker  *app.Application
+		links   []*app.ServiceLink
+	)
+
+	newApp := func(name string, domains ...string) *app.Application {
+		application, err := app.NewApplication(name)
+		Expect(err).NotTo(HaveOccurred())
+		for _, domain := range domains {
+			Expect(application.AddDomain(domain)).To(Succeed())
+		}
+		return application
+	}
+
+	newLink := func(serviceType, serviceName, appName string) *app.ServiceLink {
+		link, err := app.NewServiceLink(serviceType, serviceName, appName)
+		Expect(err).NotTo(HaveOccurred())
+		return link
+	}
+
+	BeforeEach(func() {
+		service = app.NewBlastRadiusService()
+		api = newApp("api", "api.example.com", "example.com")
+		web = newApp("web", "www.example.com", "example.com")
+		worker = newApp("worker")
+
+		links = []*app.ServiceLink{
+			newLink("postgres", "api-db", "api"),
+			newLink("redis", "cache", "api"),
+			newLink("redis", "cache", "worker"),
+			newLink("app", "api", "web"),
+			newLink("app", "api", "worker"),
+		}
+	})
+
+	It("should report orphaned datastores, dependent apps and freed domains", func() {
+		radius, err := service.Compute(api, []*app.Application{api, web, worker}, links)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(radius.AppName).To(Equal("api"))
+		Expect(radius.OrphanedServices).To(Equal([]string{"postgres:api-db"}))
+		Expect(radius.SharedServices).To(Equal([]string{"redis:cache"}))
+		Expect(radius.DependentApps).To(Equal([]string{"web", "worker"}))
+		Expect(radius.FreedDomains).To(Equal([]string{"api.example.com"}))
+		Expect(radius.IsEmpty()).To(BeFalse())
+		Expect(radius.Summary()).To(Equal(
+			"Destroying api orphans postgres:api-db; breaks web, worker; frees api.example.com"))
+	})
+
+	It("should report an empty blast radius for an isolated app", func() {
+		isolated := newApp("isolated")
+
+		radius, err := service.Compute(isolated, []*app.Application{api, web, worker, isolated}, links)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(radius.IsEmpty()).To(BeTrue())
+		Expect(radius.Summary()).To(Equal("Destroying isolated affects no other resource"))
+	})
+
+	It("should not modify the applications", func() {
+		_, err := service.Compute(api, []*app.Application{api, web, worker}, links)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(api.GetDomains()).To(ConsistOf("api.example.com", "example.com"))
+	})
+
+	It("should reject a null application", func() {
+		_, err := service.Compute(nil, nil, links)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should reject invalid service links", func() {
+		_, err := app.NewServiceLink("postgres", "my db", "api")
+		Expect(err).To(HaveOccurred())
+		_, err = app.NewServiceLink("postgres", "api-db", "Not Valid!")
+		Expect(err).To(HaveOccurred())
+	})
+})
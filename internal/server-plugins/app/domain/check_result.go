@@ -0,0 +1,171 @@
+package app
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/dokku-mcp/dokku-mcp/internal/shared/process"
+)
+
+// CheckResult is the outcome of one attempt of a zero-downtime check during a deploy
+type CheckResult struct {
+	Process  process.ProcessType `json:"process"`
+	Name     string              `json:"name,omitempty"`
+	Endpoint string              `json:"endpoint,omitempty"`
+	Attempt  int                 `json:"attempt"`
+	Success  bool                `json:"success"`
+	Message  string              `json:"message,omitempty"`
+}
+
+// ParseCheckResults extracts the check attempts from the output of a deploy. It reads
+// the healthchecker lines, e.g. "Running healthcheck name='web' path='/health' port=5000"
+// then "Healthcheck succeeded name='web'" or "Failure in name='web': <message>", and the
+// legacy CHECKS lines "Check attempt 1/5 failed". Checks without a process are Dokku's
+// default ones, run against web. Other lines are ignored.
+func ParseCheckResults(output string) []CheckResult {
+	results := make([]CheckResult, 0)
+	checks := make(map[string]CheckResult)
+	var legacyEndpoint string
+
+	for _, line := range strings.Split(output, "\n") {
+		line = trimDeployOutputPrefix(line)
+
+		switch {
+		case strings.HasPrefix(line, "Running healthcheck "):
+			attrs := parseCheckAttributes(strings.TrimPrefix(line, "Running healthcheck "))
+			check := checks[attrs["name"]]
+			check.Name = attrs["name"]
+			check.Process = checkProcess(attrs["process"])
+			check.Endpoint = checkEndpoint(attrs["path"], attrs["port"])
+			check.Attempt++
+			checks[check.Name] = check
+
+		case strings.HasPrefix(line, "Healthcheck succeeded "):
+			attrs := parseCheckAttributes(strings.TrimPrefix(line, "Healthcheck succeeded "))
+			result := checkAttempt(checks, attrs)
+			result.Success = true
+			results = append(results, result)
+
+		case strings.HasPrefix(line, "Failure in "), strings.HasPrefix(line, "Healthcheck failed "):
+			rest := strings.TrimPrefix(strings.TrimPrefix(line, "Failure in "), "Healthcheck failed ")
+			rest, message, _ := strings.Cut(rest, ": ")
+			result := checkAttempt(checks, parseCheckAttributes(rest))
+			result.Message = strings.TrimSpace(message)
+			results = append(results, result)
+
+		case strings.HasPrefix(line, "http://"), strings.HasPrefix(line, "https://"):
+			// Legacy CHECKS expectations: "http://localhost/health => \"ok\""
+			legacyEndpoint, _, _ = strings.Cut(line, " ")
+
+		case strings.HasPrefix(line, "Check attempt "):
+			fields := strings.Fields(line)
+			if len(fields) < 3 {
+				continue
+			}
+			attempt, _, _ := strings.Cut(fields[2], "/")
+			number, _ := strconv.Atoi(attempt)
+			results = append(results, CheckResult{
+				Process:  process.ProcessTypeWeb,
+				Endpoint: legacyEndpoint,
+				Attempt:  number,
+				Success:  !strings.HasSuffix(strings.TrimSuffix(line, "."), "failed"),
+				Message:  line,
+			})
+		}
+	}
+	return results
+}
+
+// FailedChecks returns the failed attempts among the results
+func FailedChecks(results []CheckResult) []CheckResult {
+	failed := make([]CheckResult, 0)
+	for _, result := range results {
+		if !result.Success {
+			failed = append(failed, result)
+		}
+	}
+	return failed
+}
+
+// String describes the result, e.g. "web check 'web' on :5000/health attempt 2 failed: timeout"
+func (r CheckResult) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s check", r.Process)
+	if r.Name != "" {
+		fmt.Fprintf(&b, " '%s'", r.Name)
+	}
+	if r.Endpoint != "" {
+		fmt.Fprintf(&b, " on %s", r.Endpoint)
+	}
+	fmt.Fprintf(&b, " attempt %d", r.Attempt)
+	if r.Success {
+		b.WriteString(" succeeded")
+		return b.String()
+	}
+	b.WriteString(" failed")
+	if r.Message != "" {
+		fmt.Fprintf(&b, ": %s", r.Message)
+	}
+	return b.String()
+}
+
+// trimDeployOutputPrefix strips the git remote prefix and Dokku's markers from a line
+func trimDeployOutputPrefix(line string) string {
+	line = strings.TrimSpace(line)
+	line = strings.TrimSpace(strings.TrimPrefix(line, "remote:"))
+	for _, marker := range []string{"!", "----->", "=====>"} {
+		line = strings.TrimSpace(strings.TrimPrefix(line, marker))
+	}
+	return line
+}
+
+// checkAttempt returns the result of the current attempt of the check named by attrs
+func checkAttempt(checks map[string]CheckResult, attrs map[string]string) CheckResult {
+	result, known := checks[attrs["name"]]
+	if !known {
+		result = CheckResult{Name: attrs["name"], Process: checkProcess(attrs["process"]), Attempt: 1}
+	}
+	if attempt, err := strconv.Atoi(attrs["attempt"]); err == nil {
+		result.Attempt = attempt
+	}
+	return result
+}
+
+func checkProcess(name string) process.ProcessType {
+	if name == "" {
+		return process.ProcessTypeWeb
+	}
+	return process.ProcessType(name)
+}
+
+func checkEndpoint(path, port string) string {
+	if port != "" {
+		return ":" + port + path
+	}
+	return path
+}
+
+// parseCheckAttributes parses key=value pairs, values being bare or single quoted
+func parseCheckAttributes(raw string) map[string]string {
+	attrs := make(map[string]string)
+	for raw = strings.TrimSpace(raw); raw != ""; raw = strings.TrimSpace(raw) {
+		key, rest, found := strings.Cut(raw, "=")
+		if !found {
+			break
+		}
+		var value string
+		if strings.HasPrefix(rest, "'") {
+			var closed bool
+			value, rest, closed = strings.Cut(rest[1:], "'")
+			if !closed {
+				rest = ""
+			}
+		} else {
+			value, rest, _ = strings.Cut(rest, " ")
+		}
+		attrs[strings.TrimSpace(key)] = value
+		raw = rest
+	}
+	return attrs
+}
@@ -0,0 +1,103 @@
+package app_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	app "github.com/dokku-mcp/dokku-mcp/internal/server-plugins/app/domain"
+	"github.com/dokku-mcp/dokku-mcp/internal/shared"
+	"github.com/dokku-mcp/dokku-mcp/internal/shared/process"
+)
+
+const failedChecksOutput = `-----> Deploying web (count=1)
+=====> Processing deployment checks
+remote:        Running healthcheck name='listening' attempts=3 port=5000 type='listening' wait=5
+remote:        Healthcheck succeeded name='listening'
+remote:        Running healthcheck name='health' attempts=2 path='/health' port=5000 process='web' type='path'
+remote:  !     Failure in name='health': Get "http://172.17.0.3:5000/health": connection refused
+remote:        Running healthcheck name='health' attempts=2 path='/health' port=5000 process='web' type='path'
+remote:  !     Failure in name='health': Get "http://172.17.0.3:5000/health": context deadline exceeded
+ !     Could not start due to 1 failed checks`
+
+var _ = Describe("ParseCheckResults", func() {
+	It("should parse every attempt of the healthchecker checks", func() {
+		results := app.ParseCheckResults(failedChecksOutput)
+
+		Expect(results).To(Equal([]app.CheckResult{
+			{Process: process.ProcessTypeWeb, Name: "listening", Endpoint: ":5000", Attempt: 1, Success: true},
+			{Process: process.ProcessTypeWeb, Name: "health", Endpoint: ":5000/health", Attempt: 1,
+				Message: `Get "http://172.17.0.3:5000/health": connection refused`},
+			{Process: process.ProcessTypeWeb, Name: "health", Endpoint: ":5000/health", Attempt: 2,
+				Message: `Get "http://172.17.0.3:5000/health": context deadline exceeded`},
+		}))
+		Expect(app.FailedChecks(results)).To(HaveLen(2))
+	})
+
+	It("should parse the legacy CHECKS attempts", func() {
+		output := `-----> Attempt 1/2 Waiting for 5 seconds ...
+       CHECKS expected result:
+       http://localhost/health => "ok"
+ !     Check attempt 1/2 failed.`
+
+		results := app.ParseCheckResults(output)
+
+		Expect(results).To(HaveLen(1))
+		Expect(results[0].Process).To(Equal(process.ProcessTypeWeb))
+		Expect(results[0].Endpoint).To(Equal("http://localhost/health"))
+		Expect(results[0].Attempt).To(Equal(1))
+		Expect(results[0].Success).To(BeFalse())
+	})
+
+	It("should find no checks in unrelated output", func() {
+		Expect(app.ParseCheckResults("-----> Building my-app from herokuish\n")).To(BeEmpty())
+	})
+
+	It("should describe a failed attempt", func() {
+		result := app.CheckResult{Process: "web", Name: "health", Endpoint: ":5000/health", Attempt: 2, Message: "timeout"}
+		Expect(result.String()).To(Equal("web check 'health' on :5000/health attempt 2 failed: timeout"))
+	})
+})
+
+var _ = Describe("Application FailDeploymentChecks", func() {
+	var application *app.Application
+
+	BeforeEach(func() {
+		var err error
+		application, err = app.NewApplication("my-app")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(application.DeployWithContext(context.Background(), shared.MustNewGitRef("main"), nil)).To(Succeed())
+		application.ClearEvents()
+	})
+
+	It("should keep the failed checks on the application and the event", func() {
+		Expect(application.FailDeploymentChecks("checks failed", app.ParseCheckResults(failedChecksOutput))).To(Succeed())
+
+		failed := application.LastCheckResults()
+		Expect(failed).To(HaveLen(2))
+		Expect(failed[1].Attempt).To(Equal(2))
+		Expect(application.Status().LastFailure.FailedChecks).To(Equal(failed))
+
+		events := application.GetEvents()
+		event, ok := events[0].(*app.ApplicationDeploymentFailedEvent)
+		Expect(ok).To(BeTrue())
+		Expect(event.CheckResults()).To(Equal(failed))
+	})
+
+	It("should forget the failed checks once a deployment completes", func() {
+		Expect(application.FailDeploymentChecks("checks failed", app.ParseCheckResults(failedChecksOutput))).To(Succeed())
+
+		Expect(application.Deploy(shared.MustNewGitRef("main"), nil)).To(Succeed())
+		Expect(application.CompleteDeployment()).To(Succeed())
+		Expect(application.LastCheckResults()).To(BeEmpty())
+	})
+
+	It("should restore the failed checks on replay", func() {
+		Expect(application.FailDeploymentChecks("checks failed", app.ParseCheckResults(failedChecksOutput))).To(Succeed())
+
+		replayed, err := app.ReplayApplication("my-app", application.GetEvents())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(replayed.LastCheckResults()).To(Equal(application.LastCheckResults()))
+	})
+})
@@ -0,0 +1,28 @@
+package app
+
+import "time"
+
+// Clock tells the time to the aggregate, so tests can pin its timestamps
+type Clock interface {
+	Now() time.Time
+}
+
+// systemClock is the real clock used by default
+type systemClock struct{}
+
+func (systemClock) Now() time.Time { return time.Now() }
+
+// DefaultClock is the clock of the applications created without WithClock
+var DefaultClock Clock = systemClock{}
+
+// ApplicationOption configures an application at creation
+type ApplicationOption func(*Application)
+
+// WithClock makes the application read the time from the given clock
+func WithClock(clock Clock) ApplicationOption {
+	return func(a *Application) {
+		if clock != nil {
+			a.clock = clock
+		}
+	}
+}
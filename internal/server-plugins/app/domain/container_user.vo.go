@@ -0,0 +1,81 @@
+package app
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/dokku-mcp/dokku-mcp/internal/shared"
+)
+
+// ContainerUserPhases are the docker-options phases whose containers run as the container user
+var ContainerUserPhases = []shared.DockerOptionPhase{shared.DockerOptionPhaseDeploy, shared.DockerOptionPhaseRun}
+
+var (
+	containerUsernameRegex = regexp.MustCompile(`^[a-z_][a-z0-9_-]{0,31}$`)
+	containerUIDRegex      = regexp.MustCompile(`^[0-9]+$`)
+)
+
+// ContainerUser is the user a container runs as, either a numeric uid or a username,
+// optionally followed by a group (uid:gid)
+type ContainerUser struct {
+	value string
+}
+
+// NewContainerUser creates a container user, validating each part is a numeric id or a username
+func NewContainerUser(user string) (*ContainerUser, error) {
+	user = strings.TrimSpace(user)
+	if user == "" {
+		return nil, fmt.Errorf("container user cannot be empty")
+	}
+
+	parts := strings.Split(user, ":")
+	if len(parts) > 2 {
+		return nil, fmt.Errorf("invalid container user %q: expected user or user:group", user)
+	}
+	for _, part := range parts {
+		if err := validateContainerUserPart(part); err != nil {
+			return nil, fmt.Errorf("invalid container user %q: %w", user, err)
+		}
+	}
+
+	return &ContainerUser{value: user}, nil
+}
+
+func validateContainerUserPart(part string) error {
+	if containerUIDRegex.MatchString(part) {
+		if _, err := strconv.ParseUint(part, 10, 32); err != nil {
+			return fmt.Errorf("id %s is out of range", part)
+		}
+		return nil
+	}
+	if !containerUsernameRegex.MatchString(part) {
+		return fmt.Errorf("%q is neither a numeric id nor a valid username", part)
+	}
+	return nil
+}
+
+// Value returns the user as given to docker
+func (u *ContainerUser) Value() string {
+	return u.value
+}
+
+// IsRoot returns true if the container runs as root
+func (u *ContainerUser) IsRoot() bool {
+	user := strings.Split(u.value, ":")[0]
+	return user == "0" || user == "root"
+}
+
+// DockerOption returns the docker-option applying this user
+func (u *ContainerUser) DockerOption() string {
+	return "--user " + u.value
+}
+
+// Equal checks equality with another container user
+func (u *ContainerUser) Equal(other *ContainerUser) bool {
+	if other == nil {
+		return false
+	}
+	return u.value == other.value
+}
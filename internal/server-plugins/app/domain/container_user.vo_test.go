@@ -0,0 +1,39 @@
+package app_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	app "github.com/dokku-mcp/dokku-mcp/internal/server-plugins/app/domain"
+)
+
+var _ = Describe("ContainerUser", func() {
+	DescribeTable("validation",
+		func(input string, expectError bool) {
+			user, err := app.NewContainerUser(input)
+			if expectError {
+				Expect(err).To(HaveOccurred())
+				Expect(user).To(BeNil())
+			} else {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(user.DockerOption()).To(Equal("--user " + input))
+			}
+		},
+		Entry("numeric uid", "1000", false),
+		Entry("uid and gid", "1000:1000", false),
+		Entry("username", "app", false),
+		Entry("username and group", "app:www-data", false),
+		Entry("empty", "", true),
+		Entry("negative uid", "-1", true),
+		Entry("uid out of range", "99999999999", true),
+		Entry("uppercase username", "App", true),
+		Entry("shell metacharacters", "app;rm", true),
+		Entry("too many parts", "1000:1000:1000", true),
+	)
+
+	It("should detect root", func() {
+		Expect(app.NewContainerUser("0")).To(WithTransform((*app.ContainerUser).IsRoot, BeTrue()))
+		Expect(app.NewContainerUser("root:root")).To(WithTransform((*app.ContainerUser).IsRoot, BeTrue()))
+		Expect(app.NewContainerUser("1000")).To(WithTransform((*app.ContainerUser).IsRoot, BeFalse()))
+	})
+})
@@ -0,0 +1,155 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// DeploymentScheduler caps the deployments running at once across every application,
+// so a burst of deploys cannot exhaust the host. Deployments beyond the cap wait in
+// line, first come first served, until a slot is released or their context is done.
+type DeploymentScheduler struct {
+	maxConcurrent int
+
+	mu      sync.Mutex
+	running int
+	queue   []*deploymentWaiter
+}
+
+type deploymentWaiter struct {
+	appName string
+	ready   chan struct{}
+	granted bool
+}
+
+// NewDeploymentScheduler creates a scheduler running at most maxConcurrent deployments
+func NewDeploymentScheduler(maxConcurrent int) (*DeploymentScheduler, error) {
+	if maxConcurrent < 1 {
+		return nil, fmt.Errorf("max concurrent deployments must be at least 1, got %d", maxConcurrent)
+	}
+	return &DeploymentScheduler{maxConcurrent: maxConcurrent}, nil
+}
+
+// MaxConcurrent returns the number of deployment slots
+func (s *DeploymentScheduler) MaxConcurrent() int {
+	return s.maxConcurrent
+}
+
+// Acquire waits for a deployment slot for the application and returns the function
+// releasing it, which is safe to call more than once. It fails with the context error
+// if the context is done while waiting, leaving the line.
+func (s *DeploymentScheduler) Acquire(ctx context.Context, appName string) (func(), error) {
+	s.mu.Lock()
+	if s.running < s.maxConcurrent && len(s.queue) == 0 {
+		s.running++
+		s.mu.Unlock()
+		return s.releaseFunc(), nil
+	}
+	waiter := &deploymentWaiter{appName: appName, ready: make(chan struct{})}
+	s.queue = append(s.queue, waiter)
+	s.mu.Unlock()
+
+	select {
+	case <-waiter.ready:
+		return s.releaseFunc(), nil
+	case <-ctx.Done():
+		s.mu.Lock()
+		granted := waiter.granted
+		if !granted {
+			s.removeWaiter(waiter)
+		}
+		s.mu.Unlock()
+		// The slot was handed over while the context ended, it goes to the next in line
+		if granted {
+			s.release()
+		}
+		return nil, fmt.Errorf("deployment %s while queued: %w", DeploymentCancelledReason, ctx.Err())
+	}
+}
+
+// Running returns the number of deployments holding a slot
+func (s *DeploymentScheduler) Running() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.running
+}
+
+// QueueDepth returns the number of deployments waiting for a slot
+func (s *DeploymentScheduler) QueueDepth() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.queue)
+}
+
+// QueuePosition returns how many deployments are ahead of the first queued deployment
+// of the application, false if it has none queued
+func (s *DeploymentScheduler) QueuePosition(appName string) (int, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i, waiter := range s.queue {
+		if waiter.appName == appName {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+func (s *DeploymentScheduler) releaseFunc() func() {
+	var once sync.Once
+	return func() { once.Do(s.release) }
+}
+
+// release hands the slot to the first deployment in line, or frees it
+func (s *DeploymentScheduler) release() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.queue) == 0 {
+		s.running--
+		return
+	}
+	next := s.queue[0]
+	s.queue = s.queue[1:]
+	next.granted = true
+	close(next.ready)
+}
+
+// removeWaiter takes a deployment out of the line. The caller must hold mu.
+func (s *DeploymentScheduler) removeWaiter(waiter *deploymentWaiter) {
+	for i, queued := range s.queue {
+		if queued == waiter {
+			s.queue = append(s.queue[:i], s.queue[i+1:]...)
+			return
+		}
+	}
+}
+
+var (
+	deploymentSchedulerMu sync.RWMutex
+	deploymentScheduler   *DeploymentScheduler
+)
+
+// SetDeploymentScheduler installs the scheduler every deployment acquires a slot from,
+// nil removing the cap. Deployments already running keep their slot.
+func SetDeploymentScheduler(scheduler *DeploymentScheduler) {
+	deploymentSchedulerMu.Lock()
+	defer deploymentSchedulerMu.Unlock()
+	deploymentScheduler = scheduler
+}
+
+// CurrentDeploymentScheduler returns the installed scheduler, nil if deployments are not capped
+func CurrentDeploymentScheduler() *DeploymentScheduler {
+	deploymentSchedulerMu.RLock()
+	defer deploymentSchedulerMu.RUnlock()
+	return deploymentScheduler
+}
+
+// acquireDeploymentSlot acquires a slot from the installed scheduler, if any
+func acquireDeploymentSlot(ctx context.Context, appName string) (func(), error) {
+	scheduler := CurrentDeploymentScheduler()
+	if scheduler == nil {
+		return func() {}, nil
+	}
+	return scheduler.Acquire(ctx, appName)
+}
@@ -0,0 +1,166 @@
+package app_test
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	app "github.com/dokku-mcp/dokku-mcp/internal/server-plugins/app/domain"
+	"github.com/dokku-mcp/dokku-mcp/internal/shared"
+)
+
+var _ = Describe("DeploymentScheduler", func() {
+	var scheduler *app.DeploymentScheduler
+
+	BeforeEach(func() {
+		var err error
+		scheduler, err = app.NewDeploymentScheduler(2)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should refuse less than one slot", func() {
+		_, err := app.NewDeploymentScheduler(0)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should never run more deployments than slots", func() {
+		const deployers = 10
+		var (
+			running, peak atomic.Int32
+			wg            sync.WaitGroup
+		)
+		for i := range deployers {
+			wg.Add(1)
+			go func() {
+				defer GinkgoRecover()
+				defer wg.Done()
+				release, err := scheduler.Acquire(context.Background(), fmt.Sprintf("app-%d", i))
+				Expect(err).NotTo(HaveOccurred())
+				current := running.Add(1)
+				for {
+					seen := peak.Load()
+					if current <= seen || peak.CompareAndSwap(seen, current) {
+						break
+					}
+				}
+				time.Sleep(5 * time.Millisecond)
+				running.Add(-1)
+				release()
+			}()
+		}
+		wg.Wait()
+
+		Expect(peak.Load()).To(Equal(int32(2)))
+		Expect(scheduler.Running()).To(BeZero())
+		Expect(scheduler.QueueDepth()).To(BeZero())
+	})
+
+	It("should report the queue position of waiting deployments", func() {
+		first, err := scheduler.Acquire(context.Background(), "api")
+		Expect(err).NotTo(HaveOccurred())
+		_, err = scheduler.Acquire(context.Background(), "web")
+		Expect(err).NotTo(HaveOccurred())
+
+		acquired := make(chan string, 2)
+		for _, name := range []string{"worker", "cron"} {
+			go func() {
+				release, err := scheduler.Acquire(context.Background(), name)
+				if err == nil {
+					acquired <- name
+					release()
+				}
+			}()
+			Eventually(func() bool { _, queued := scheduler.QueuePosition(name); return queued }).Should(BeTrue())
+		}
+
+		Expect(scheduler.QueueDepth()).To(Equal(2))
+		position, queued := scheduler.QueuePosition("cron")
+		Expect(queued).To(BeTrue())
+		Expect(position).To(Equal(1))
+		_, queued = scheduler.QueuePosition("api")
+		Expect(queued).To(BeFalse())
+
+		first()
+		Eventually(acquired).Should(Receive(Equal("worker")))
+		Eventually(acquired).Should(Receive(Equal("cron")))
+		Expect(scheduler.QueueDepth()).To(BeZero())
+	})
+
+	It("should leave the queue when the context is done while waiting", func() {
+		_, err := scheduler.Acquire(context.Background(), "api")
+		Expect(err).NotTo(HaveOccurred())
+		release, err := scheduler.Acquire(context.Background(), "web")
+		Expect(err).NotTo(HaveOccurred())
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		_, err = scheduler.Acquire(ctx, "worker")
+		Expect(err).To(MatchError(context.DeadlineExceeded))
+		Expect(scheduler.QueueDepth()).To(BeZero())
+
+		release()
+		release()
+		Expect(scheduler.Running()).To(Equal(1))
+	})
+
+	Context("when installed", func() {
+		AfterEach(func() {
+			app.SetDeploymentScheduler(nil)
+		})
+
+		It("should hold a slot for the whole deployment", func() {
+			single, err := app.NewDeploymentScheduler(1)
+			Expect(err).NotTo(HaveOccurred())
+			app.SetDeploymentScheduler(single)
+
+			first, err := app.NewApplication("first-app")
+			Expect(err).NotTo(HaveOccurred())
+			second, err := app.NewApplication("second-app")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(first.Deploy(shared.MustNewGitRef("main"), nil)).To(Succeed())
+			Expect(single.Running()).To(Equal(1))
+
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+			defer cancel()
+			err = second.DeployWithContext(ctx, shared.MustNewGitRef("main"), nil)
+			Expect(err).To(MatchError(context.DeadlineExceeded))
+			Expect(second.DeploymentInProgress()).To(BeFalse())
+
+			Expect(first.CompleteDeployment()).To(Succeed())
+			Expect(single.Running()).To(BeZero())
+			Expect(second.Deploy(shared.MustNewGitRef("main"), nil)).To(Succeed())
+		})
+
+		It("should stop waiting for a slot when the context of a rebuild or image deployment is done", func() {
+			single, err := app.NewDeploymentScheduler(1)
+			Expect(err).NotTo(HaveOccurred())
+			app.SetDeploymentScheduler(single)
+
+			first, err := app.NewApplication("first-app")
+			Expect(err).NotTo(HaveOccurred())
+			second, err := app.NewApplication("second-app")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(second.Deploy(shared.MustNewGitRef("main"), nil)).To(Succeed())
+			Expect(second.CompleteDeployment()).To(Succeed())
+
+			Expect(first.Deploy(shared.MustNewGitRef("main"), nil)).To(Succeed())
+
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+			defer cancel()
+			Expect(second.RebuildWithContext(ctx, nil)).To(MatchError(context.DeadlineExceeded))
+			Expect(second.DeployFromImageWithContext(ctx, shared.MustNewDockerImage("second-app:latest"), nil)).To(MatchError(context.DeadlineExceeded))
+			Expect(second.DeploymentInProgress()).To(BeFalse())
+			Expect(single.QueueDepth()).To(BeZero())
+
+			Expect(first.CompleteDeployment()).To(Succeed())
+			Expect(second.RebuildWithContext(context.Background(), nil)).To(Succeed())
+			Expect(single.Running()).To(Equal(1))
+		})
+	})
+})
@@ -0,0 +1,167 @@
+package app
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/dokku-mcp/dokku-mcp/internal/shared"
+)
+
+// ReportedDomain is a domain listed in a domains report
+type ReportedDomain struct {
+	Name string `json:"name"`
+	SSL  bool   `json:"ssl"`
+}
+
+// DomainReport is the typed form of `domains:report <app>`, optionally followed
+// by the `certs:report <app>` section giving the SSL status of each domain
+type DomainReport struct {
+	AppName       string           `json:"app_name"`
+	VhostsEnabled bool             `json:"vhosts_enabled"`
+	AppDomains    []ReportedDomain `json:"app_domains"`
+	GlobalEnabled bool             `json:"global_enabled"`
+	GlobalDomains []string         `json:"global_domains"`
+	SSLEnabled    bool             `json:"ssl_enabled"`
+}
+
+// DomainNames returns the names of the app domains
+func (r *DomainReport) DomainNames() []string {
+	names := make([]string, len(r.AppDomains))
+	for i, domain := range r.AppDomains {
+		names[i] = domain.Name
+	}
+	return names
+}
+
+// ParseDomainReport parses the output of domains:report for a single application
+func ParseDomainReport(raw string) (*DomainReport, error) {
+	report := &DomainReport{
+		AppDomains:    make([]ReportedDomain, 0),
+		GlobalDomains: make([]string, 0),
+	}
+
+	var sslHostnames []string
+	found := false
+	for _, line := range strings.Split(raw, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		if strings.HasPrefix(line, "=====>") {
+			fields := strings.Fields(strings.TrimPrefix(line, "=====>"))
+			if len(fields) > 0 && report.AppName == "" {
+				report.AppName = fields[0]
+			}
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		value = strings.TrimSpace(value)
+
+		switch strings.ToLower(strings.TrimSpace(key)) {
+		case "domains app enabled":
+			report.VhostsEnabled = value == "true"
+			found = true
+		case "domains app vhosts":
+			for _, name := range reportValues(value) {
+				report.AppDomains = append(report.AppDomains, ReportedDomain{Name: name})
+			}
+			found = true
+		case "domains global enabled":
+			report.GlobalEnabled = value == "true"
+		case "domains global vhosts":
+			report.GlobalDomains = reportValues(value)
+		case "ssl enabled":
+			report.SSLEnabled = value == "true"
+		case "ssl hostnames":
+			sslHostnames = reportValues(value)
+		}
+	}
+
+	if !found {
+		return nil, fmt.Errorf("invalid domains report: no app domains section")
+	}
+
+	if report.SSLEnabled {
+		for i, domain := range report.AppDomains {
+			report.AppDomains[i].SSL = slices.Contains(sslHostnames, domain.Name)
+		}
+	}
+
+	return report, nil
+}
+
+// ParseDomainsByApp parses the output of domains:report, for one application or all of
+// them, into the domains of each application, without hydrating any aggregate. An
+// application with vhosts disabled serves none of its domains and maps to an empty list.
+func ParseDomainsByApp(raw string) (map[string][]string, error) {
+	domainsByApp := make(map[string][]string)
+	for _, block := range splitReportBlocks(raw) {
+		section, ok := block.section("domains")
+		if !ok {
+			continue
+		}
+		report, err := ParseDomainReport(section)
+		if err != nil {
+			return nil, fmt.Errorf("application %s: %w", block.appName, err)
+		}
+		if !report.VhostsEnabled {
+			domainsByApp[block.appName] = []string{}
+			continue
+		}
+		domainsByApp[block.appName] = report.DomainNames()
+	}
+	return domainsByApp, nil
+}
+
+// ReconcileDomains aligns the domain list with a parsed report, emitting an
+// event for each domain added or removed. Disabled vhosts do not clear the list,
+// the domains stay configured in Dokku.
+func (a *Application) ReconcileDomains(report *DomainReport) error {
+	if report == nil {
+		return fmt.Errorf("domain report cannot be null")
+	}
+	if report.AppName != "" && report.AppName != a.name.Value() {
+		return fmt.Errorf("domain report is for %s, not %s", report.AppName, a.name.Value())
+	}
+
+	desired := make([]*shared.DomainName, 0, len(report.AppDomains))
+	for _, reported := range report.AppDomains {
+		domain, err := shared.NewDomainName(reported.Name)
+		if err != nil {
+			return fmt.Errorf("invalid domain in report: %w", err)
+		}
+		if !containsDomain(desired, domain) {
+			desired = append(desired, domain)
+		}
+	}
+
+	now := a.clock.Now()
+	for _, existing := range a.configuration.domains {
+		if !containsDomain(desired, existing) {
+			a.addEvent(NewDomainRemovedEvent(a.name.Value(), existing.Value(), now))
+		}
+	}
+	for _, domain := range desired {
+		if !containsDomain(a.configuration.domains, domain) {
+			a.addEvent(NewDomainAddedEvent(a.name.Value(), domain.Value(), now))
+		}
+	}
+
+	a.configuration.domains = desired
+	a.updatedAt = now
+	return nil
+}
+
+// reportValues splits a space separated report value, treating "none" as empty
+func reportValues(value string) []string {
+	if value == "" || value == "none" {
+		return []string{}
+	}
+	return strings.Fields(value)
+}
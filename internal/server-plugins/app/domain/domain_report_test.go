@@ -0,0 +1,155 @@
+package app_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	app "github.com/dokku-mcp/dokku-mcp/internal/server-plugins/app/domain"
+)
+
+var _ = Describe("ParseDomainReport", func() {
+	It("should parse app and global domains", func() {
+		report, err := app.ParseDomainReport(`=====> my-app domains information
+       Domains app enabled:           true
+       Domains app vhosts:            my-app.example.com www.example.com
+       Domains global enabled:        true
+       Domains global vhosts:         example.com`)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(report.AppName).To(Equal("my-app"))
+		Expect(report.VhostsEnabled).To(BeTrue())
+		Expect(report.DomainNames()).To(Equal([]string{"my-app.example.com", "www.example.com"}))
+		Expect(report.GlobalEnabled).To(BeTrue())
+		Expect(report.GlobalDomains).To(Equal([]string{"example.com"}))
+		Expect(report.SSLEnabled).To(BeFalse())
+	})
+
+	It("should parse an app with vhosts disabled", func() {
+		report, err := app.ParseDomainReport(`=====> worker domains information
+       Domains app enabled:           false
+       Domains app vhosts:            worker.example.com
+       Domains global enabled:        false
+       Domains global vhosts:`)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(report.VhostsEnabled).To(BeFalse())
+		Expect(report.DomainNames()).To(Equal([]string{"worker.example.com"}))
+		Expect(report.GlobalDomains).To(BeEmpty())
+	})
+
+	It("should parse an app with no domains", func() {
+		report, err := app.ParseDomainReport(`=====> bare domains information
+       Domains app enabled:           true
+       Domains app vhosts:
+       Domains global enabled:        true
+       Domains global vhosts:         none`)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(report.AppDomains).To(BeEmpty())
+		Expect(report.GlobalDomains).To(BeEmpty())
+	})
+
+	It("should flag SSL per domain from the certs section", func() {
+		report, err := app.ParseDomainReport(`=====> my-app domains information
+       Domains app enabled:           true
+       Domains app vhosts:            secure.example.com plain.example.com
+=====> my-app ssl information
+       Ssl enabled:                   true
+       Ssl hostnames:                 secure.example.com`)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(report.SSLEnabled).To(BeTrue())
+		Expect(report.AppDomains).To(Equal([]app.ReportedDomain{
+			{Name: "secure.example.com", SSL: true},
+			{Name: "plain.example.com", SSL: false},
+		}))
+	})
+
+	It("should reject output without a domains section", func() {
+		_, err := app.ParseDomainReport("!     App my-app does not exist")
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+var _ = Describe("Application ReconcileDomains", func() {
+	var application *app.Application
+
+	BeforeEach(func() {
+		var err error
+		application, err = app.NewApplication("my-app")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(application.AddDomain("old.example.com")).To(Succeed())
+		Expect(application.AddDomain("kept.example.com")).To(Succeed())
+		application.ClearEvents()
+	})
+
+	It("should add and remove domains to match the report", func() {
+		report := &app.DomainReport{
+			AppName:    "my-app",
+			AppDomains: []app.ReportedDomain{{Name: "kept.example.com"}, {Name: "new.example.com"}},
+		}
+
+		Expect(application.ReconcileDomains(report)).To(Succeed())
+		Expect(application.GetDomains()).To(Equal([]string{"kept.example.com", "new.example.com"}))
+
+		events := application.GetEvents()
+		Expect(events).To(HaveLen(2))
+		Expect(events[0].EventType()).To(Equal("application.domain.removed"))
+		Expect(events[1].EventType()).To(Equal("application.domain.added"))
+	})
+
+	It("should clear the domains of an app without any", func() {
+		Expect(application.ReconcileDomains(&app.DomainReport{AppName: "my-app"})).To(Succeed())
+		Expect(application.GetDomains()).To(BeEmpty())
+		Expect(application.GetEvents()).To(HaveLen(2))
+	})
+
+	It("should not emit anything when already in sync", func() {
+		report := &app.DomainReport{
+			AppName:    "my-app",
+			AppDomains: []app.ReportedDomain{{Name: "old.example.com"}, {Name: "kept.example.com"}},
+		}
+		Expect(application.ReconcileDomains(report)).To(Succeed())
+		Expect(application.GetEvents()).To(BeEmpty())
+	})
+
+	It("should reject a report for another app", func() {
+		Expect(application.ReconcileDomains(&app.DomainReport{AppName: "other"})).NotTo(Succeed())
+		Expect(application.GetDomains()).To(HaveLen(2))
+	})
+})
+
+var _ = Describe("ParseDomainsByApp", func() {
+	It("should map every application of the report to its domains", func() {
+		domains, err := app.ParseDomainsByApp(`=====> api domains information
+       Domains app enabled:           true
+       Domains app vhosts:            api.example.com www.example.com
+       Domains global enabled:        true
+       Domains global vhosts:         dokku.me
+=====> worker domains information
+       Domains app enabled:           false
+       Domains app vhosts:            worker.example.com
+=====> blog domains information
+       Domains app enabled:           true
+       Domains app vhosts:`)
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(domains).To(Equal(map[string][]string{
+			"api":    {"api.example.com", "www.example.com"},
+			"worker": {},
+			"blog":   {},
+		}))
+	})
+
+	It("should return an empty map for an empty report", func() {
+		domains, err := app.ParseDomainsByApp("")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(domains).To(BeEmpty())
+	})
+
+	It("should name the application of an invalid section", func() {
+		_, err := app.ParseDomainsByApp(`=====> api domains information
+       Domains global enabled:        true`)
+		Expect(err).To(MatchError(ContainSubstring("application api")))
+	})
+})
@@ -0,0 +1,182 @@
+package app
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/dokku-mcp/dokku-mcp/internal/shared"
+)
+
+// ParseDotenv parses a .env file into variables. Blank lines and # comments are skipped,
+// an "export " prefix is accepted, and values may be double quoted, with \n, \", \\ escapes,
+// or single quoted, taken literally. Quoted values may span several lines. Unquoted values
+// end at a " #" comment. A key set twice keeps its last value. Errors give the line number.
+func ParseDotenv(data []byte) (map[string]string, error) {
+	vars := make(map[string]string)
+	lines := strings.Split(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n")
+
+	for i := 0; i < len(lines); i++ {
+		lineNumber := i + 1
+		line := strings.TrimSpace(lines[i])
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimSpace(strings.TrimPrefix(line, "export "))
+
+		key, rawValue, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, fmt.Errorf("line %d: expected KEY=VALUE", lineNumber)
+		}
+		key = strings.TrimSpace(key)
+		if _, err := shared.NewEnvVarKey(key); err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNumber, err)
+		}
+		rawValue = strings.TrimSpace(rawValue)
+
+		if rawValue == "" || (rawValue[0] != '"' && rawValue[0] != '\'') {
+			if comment := strings.Index(rawValue, " #"); comment >= 0 {
+				rawValue = strings.TrimSpace(rawValue[:comment])
+			}
+			vars[key] = rawValue
+			continue
+		}
+
+		// A quoted value runs until its closing quote, possibly on a later line
+		quote := rawValue[0]
+		rest := rawValue[1:]
+		for {
+			value, remainder, closed := closeQuotedValue(rest, quote)
+			if closed {
+				remainder = strings.TrimSpace(remainder)
+				if remainder != "" && !strings.HasPrefix(remainder, "#") {
+					return nil, fmt.Errorf("line %d: unexpected characters after the quoted value of %s", lineNumber, key)
+				}
+				vars[key] = value
+				break
+			}
+			if i+1 >= len(lines) {
+				return nil, fmt.Errorf("line %d: unterminated quoted value of %s", lineNumber, key)
+			}
+			i++
+			rest += "\n" + lines[i]
+		}
+	}
+
+	return vars, nil
+}
+
+// closeQuotedValue returns the value up to the closing quote and what follows it, or
+// false if the quote is not closed. Double quoted values resolve their escapes.
+func closeQuotedValue(raw string, quote byte) (string, string, bool) {
+	if quote == '\'' {
+		value, remainder, found := strings.Cut(raw, "'")
+		return value, remainder, found
+	}
+
+	var value strings.Builder
+	for i := 0; i < len(raw); i++ {
+		switch c := raw[i]; {
+		case c == '"':
+			return value.String(), raw[i+1:], true
+		case c == '\\' && i+1 < len(raw):
+			i++
+			switch raw[i] {
+			case 'n':
+				value.WriteByte('\n')
+			case 'r':
+				value.WriteByte('\r')
+			case 't':
+				value.WriteByte('\t')
+			default:
+				value.WriteByte(raw[i])
+			}
+		default:
+			value.WriteByte(c)
+		}
+	}
+	return "", "", false
+}
+
+// SetEnvironmentVariables sets several variables in one atomic batch: if one of them is
+// refused, none is set
+func (a *Application) SetEnvironmentVariables(vars map[string]string) error {
+	changes := make([]ConfigChange, 0, len(vars))
+	for _, key := range slices.Sorted(maps.Keys(vars)) {
+		changes = append(changes, ConfigChange{Kind: ConfigChangeSetEnv, Key: key, Value: vars[key]})
+	}
+	_, err := a.ApplyChanges(changes, true)
+	return err
+}
+
+// ImportDotenv sets every variable of a .env file, or none if the file is malformed or
+// one of its variables is refused
+func (a *Application) ImportDotenv(data []byte) error {
+	vars, err := ParseDotenv(data)
+	if err != nil {
+		return fmt.Errorf("invalid dotenv: %w", err)
+	}
+	return a.SetEnvironmentVariables(vars)
+}
+
+// EnvExportFormat is the output format of an environment export
+type EnvExportFormat string
+
+const (
+	// EnvExportDotenv writes KEY=value lines that ParseDotenv reads back
+	EnvExportDotenv EnvExportFormat = "dotenv"
+	// EnvExportShell writes export KEY='value' lines for a POSIX shell
+	EnvExportShell EnvExportFormat = "shell"
+)
+
+// ExportEnv writes the variables Dokku does not manage, sorted by key, in the given
+// format. Values are masked unless reveal is set, since the export may reach a client,
+// and the ones whose key or value looks like a secret stay masked even then.
+func (a *Application) ExportEnv(format EnvExportFormat, reveal bool) (string, error) {
+	var quote func(string) string
+	var prefix string
+	switch format {
+	case EnvExportDotenv:
+		quote = quoteDotenvValue
+	case EnvExportShell:
+		quote = quoteShellValue
+		prefix = "export "
+	default:
+		return "", fmt.Errorf("unknown environment export format '%s', expected %s or %s", format, EnvExportDotenv, EnvExportShell)
+	}
+
+	vars := a.GetEnvironmentVariables(false)
+	var out strings.Builder
+	for _, key := range slices.Sorted(maps.Keys(vars)) {
+		value := shared.NewEnvVarValue(vars[key])
+		plain := value.Masked()
+		if reveal && !value.LooksLikeSecret() && !looksLikeSecretKey(key) {
+			plain = value.Value()
+		}
+		fmt.Fprintf(&out, "%s%s=%s\n", prefix, key, quote(plain))
+	}
+	return out.String(), nil
+}
+
+// quoteDotenvValue leaves simple values bare and double quotes the others, escaping
+// what closeQuotedValue resolves
+func quoteDotenvValue(value string) string {
+	if value != "" && !strings.ContainsAny(value, " \t\r\n\"'\\#=$`") {
+		return value
+	}
+	escaper := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\r", `\r`, "\t", `\t`)
+	return `"` + escaper.Replace(value) + `"`
+}
+
+// quoteShellValue single quotes the value, where the shell expands nothing, closing
+// the quotes around each embedded single quote
+func quoteShellValue(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
+
+// looksLikeSecretKey returns true if the key conventionally holds a credential
+func looksLikeSecretKey(key string) bool {
+	envKey, err := shared.NewEnvVarKey(key)
+	return err == nil && envKey.LooksLikeSecret()
+}
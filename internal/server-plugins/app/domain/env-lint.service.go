@@ -0,0 +1,138 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/dokku-mcp/dokku-mcp/internal/shared"
+)
+
+// MaxEnvValueSize is the largest environment value accepted without a finding
+const MaxEnvValueSize = 32 * 1024
+
+var envReferenceRegex = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)\}|\$([A-Za-z_][A-Za-z0-9_]*)`)
+
+// LintEnv runs every environment check on a proposed set of variables without applying it.
+// Invalid keys, keys managed by Dokku and oversized values are errors; duplicate keys,
+// suspected secrets and undefined references are reported as warnings.
+func (s *ValidationService) LintEnv(ctx context.Context, env map[string]string) *ValidationResult {
+	result := &ValidationResult{
+		IsValid:  true,
+		Errors:   make([]ValidationError, 0),
+		Warnings: make([]ValidationWarning, 0),
+	}
+
+	keys := make([]string, 0, len(env))
+	for key := range env {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		value := env[key]
+		s.validateEnvKey(key, result)
+		s.validateEnvValueSize(key, value, result)
+		s.checkSuspiciousSecret(key, value, result)
+		s.checkEnvReferences(key, value, env, result)
+	}
+	s.checkDuplicateEnvKeys(keys, result)
+
+	return result
+}
+
+// validateEnvKey checks the key format and that Dokku does not manage the key
+func (s *ValidationService) validateEnvKey(key string, result *ValidationResult) {
+	envKey, err := shared.NewEnvVarKey(key)
+	if err != nil {
+		result.IsValid = false
+		result.Errors = append(result.Errors, ValidationError{
+			Field:   envField(key),
+			Message: err.Error(),
+			Code:    "INVALID_ENV_KEY",
+		})
+		return
+	}
+
+	if envKey.IsInternal() {
+		result.IsValid = false
+		result.Errors = append(result.Errors, ValidationError{
+			Field:   envField(key),
+			Message: "Key is managed by Dokku",
+			Code:    "RESERVED_ENV_PREFIX",
+		})
+	}
+}
+
+// validateEnvValueSize rejects values larger than MaxEnvValueSize
+func (s *ValidationService) validateEnvValueSize(key, value string, result *ValidationResult) {
+	if len(value) > MaxEnvValueSize {
+		result.IsValid = false
+		result.Errors = append(result.Errors, ValidationError{
+			Field:   envField(key),
+			Message: fmt.Sprintf("Value is %d bytes, the maximum is %d", len(value), MaxEnvValueSize),
+			Code:    "OVERSIZED_ENV_VALUE",
+		})
+	}
+}
+
+// checkSuspiciousSecret warns about values that look like committed credentials
+func (s *ValidationService) checkSuspiciousSecret(key, value string, result *ValidationResult) {
+	if kind, found := shared.SuspectedSecretKind(value); found {
+		result.Warnings = append(result.Warnings, ValidationWarning{
+			Field:   envField(key),
+			Message: fmt.Sprintf("Value looks like a %s", kind),
+			Code:    "SUSPECTED_SECRET",
+		})
+	}
+}
+
+// checkEnvReferences warns about ${VAR} or $VAR references to keys that are not defined
+func (s *ValidationService) checkEnvReferences(key, value string, env map[string]string, result *ValidationResult) {
+	seen := make(map[string]bool)
+	for _, match := range envReferenceRegex.FindAllStringSubmatch(value, -1) {
+		target := match[1]
+		if target == "" {
+			target = match[2]
+		}
+		if seen[target] {
+			continue
+		}
+		seen[target] = true
+
+		if _, defined := env[target]; !defined {
+			result.Warnings = append(result.Warnings, ValidationWarning{
+				Field:   envField(key),
+				Message: fmt.Sprintf("Value references undefined variable %s", target),
+				Code:    "UNDEFINED_ENV_REFERENCE",
+			})
+		}
+	}
+}
+
+// checkDuplicateEnvKeys warns about keys that only differ by case
+func (s *ValidationService) checkDuplicateEnvKeys(keys []string, result *ValidationResult) {
+	byNormalized := make(map[string][]string)
+	for _, key := range keys {
+		normalized := strings.ToUpper(strings.TrimSpace(key))
+		byNormalized[normalized] = append(byNormalized[normalized], key)
+	}
+
+	for _, key := range keys {
+		group := byNormalized[strings.ToUpper(strings.TrimSpace(key))]
+		if len(group) < 2 || group[0] != key {
+			continue
+		}
+		result.Warnings = append(result.Warnings, ValidationWarning{
+			Field:   envField(key),
+			Message: fmt.Sprintf("Keys %s collide once normalized", strings.Join(group, ", ")),
+			Code:    "DUPLICATE_ENV_KEY",
+		})
+	}
+}
+
+func envField(key string) string {
+	return "env." + key
+}
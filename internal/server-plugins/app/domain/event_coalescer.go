@@ -0,0 +1,201 @@
+package app
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+)
+
+// DefaultCoalescingWindow is how long events of a coalesced type are batched by default
+const DefaultCoalescingWindow = 5 * time.Second
+
+// Notification is what the coalescer delivers: either a single event or a summary of a batch
+type Notification struct {
+	EventType    string
+	Summary      string
+	Count        int
+	AggregateIDs []string
+	Critical     bool
+	Events       []DomainEvent
+}
+
+// NotificationSink receives notifications from the coalescer
+type NotificationSink func(Notification)
+
+// CoalescerConfig configures which event types are batched and for how long
+type CoalescerConfig struct {
+	// Window is how long events of a coalesced type are batched after the first one
+	Window time.Duration
+	// CoalescedTypes are the event types batched into a single summary
+	CoalescedTypes []string
+	// CriticalTypes are always delivered immediately, even if listed as coalesced
+	CriticalTypes []string
+}
+
+// DefaultCoalescerConfig batches deploy and scale events and delivers failures immediately
+func DefaultCoalescerConfig() CoalescerConfig {
+	return CoalescerConfig{
+		Window:         DefaultCoalescingWindow,
+		CoalescedTypes: []string{"application.deployed", "application.scaled"},
+		CriticalTypes:  []string{"application.deployment.failed"},
+	}
+}
+
+// eventSummaryVerbs describe coalesced event types in summaries
+var eventSummaryVerbs = map[string]string{
+	"application.created":           "created",
+	"application.deployed":          "deployed",
+	"application.deployed.image":    "deployed from an image",
+	"application.scaled":            "scaled",
+	"application.domain.added":      "had a domain added",
+	"application.domain.removed":    "had a domain removed",
+	"application.buildpack.changed": "had their buildpack changed",
+}
+
+// coalescedBatch is the events of one type waiting for their window to close
+type coalescedBatch struct {
+	events []DomainEvent
+	dueAt  time.Time
+}
+
+// EventCoalescer batches bursts of events of the same type into summarized notifications,
+// so a mass deploy produces one "5 apps deployed" notification instead of five. It runs
+// no timer: a batch is due once the window after its first event elapsed on the clock,
+// and is delivered by the next FlushDue, which the caller runs periodically.
+type EventCoalescer struct {
+	config CoalescerConfig
+	clock  Clock
+	sink   NotificationSink
+
+	mu      sync.Mutex
+	pending map[string]*coalescedBatch
+}
+
+// NewEventCoalescer creates an event coalescer delivering to the given sink. A nil
+// clock is the system clock.
+func NewEventCoalescer(config CoalescerConfig, clock Clock, sink NotificationSink) (*EventCoalescer, error) {
+	if sink == nil {
+		return nil, fmt.Errorf("notification sink cannot be null")
+	}
+	if config.Window <= 0 {
+		return nil, fmt.Errorf("coalescing window must be positive")
+	}
+	if clock == nil {
+		clock = DefaultClock
+	}
+
+	return &EventCoalescer{
+		config:  config,
+		clock:   clock,
+		sink:    sink,
+		pending: make(map[string]*coalescedBatch),
+	}, nil
+}
+
+// Subscribe publishes the events the dispatcher delivers
+func (c *EventCoalescer) Subscribe(dispatcher *EventDispatcher) {
+	dispatcher.Subscribe(AllEventTypes, c.Publish)
+}
+
+// Publish delivers critical and non-coalesced events immediately, and batches the others
+func (c *EventCoalescer) Publish(event DomainEvent) {
+	eventType := event.EventType()
+	critical := slices.Contains(c.config.CriticalTypes, eventType)
+
+	if critical || !slices.Contains(c.config.CoalescedTypes, eventType) {
+		c.sink(newNotification(eventType, []DomainEvent{event}, critical))
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	batch, exists := c.pending[eventType]
+	if !exists {
+		batch = &coalescedBatch{dueAt: c.clock.Now().Add(c.config.Window)}
+		c.pending[eventType] = batch
+	}
+	batch.events = append(batch.events, event)
+}
+
+// FlushDue delivers the batches whose window elapsed
+func (c *EventCoalescer) FlushDue() {
+	now := c.clock.Now()
+	c.flush(func(batch *coalescedBatch) bool {
+		return !batch.dueAt.After(now)
+	})
+}
+
+// Flush delivers every pending batch immediately
+func (c *EventCoalescer) Flush() {
+	c.flush(func(*coalescedBatch) bool { return true })
+}
+
+// Pending returns the number of events waiting to be delivered
+func (c *EventCoalescer) Pending() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	count := 0
+	for _, batch := range c.pending {
+		count += len(batch.events)
+	}
+	return count
+}
+
+// flush takes the selected batches out under the lock, so each is delivered once, and
+// delivers them by event type
+func (c *EventCoalescer) flush(selected func(*coalescedBatch) bool) {
+	c.mu.Lock()
+	batches := make(map[string][]DomainEvent)
+	for eventType, batch := range c.pending {
+		if selected(batch) {
+			batches[eventType] = batch.events
+			delete(c.pending, eventType)
+		}
+	}
+	c.mu.Unlock()
+
+	eventTypes := make([]string, 0, len(batches))
+	for eventType := range batches {
+		eventTypes = append(eventTypes, eventType)
+	}
+	slices.Sort(eventTypes)
+	for _, eventType := range eventTypes {
+		c.sink(newNotification(eventType, batches[eventType], false))
+	}
+}
+
+func newNotification(eventType string, events []DomainEvent, critical bool) Notification {
+	aggregateIDs := make([]string, 0, len(events))
+	for _, event := range events {
+		if !slices.Contains(aggregateIDs, event.AggregateID()) {
+			aggregateIDs = append(aggregateIDs, event.AggregateID())
+		}
+	}
+
+	return Notification{
+		EventType:    eventType,
+		Summary:      summarize(eventType, events, aggregateIDs),
+		Count:        len(events),
+		AggregateIDs: aggregateIDs,
+		Critical:     critical,
+		Events:       events,
+	}
+}
+
+func summarize(eventType string, events []DomainEvent, aggregateIDs []string) string {
+	if len(events) == 1 {
+		return fmt.Sprintf("%s: %s", events[0].AggregateID(), eventType)
+	}
+
+	verb, known := eventSummaryVerbs[eventType]
+	if !known {
+		return fmt.Sprintf("%d %s events across %d apps", len(events), eventType, len(aggregateIDs))
+	}
+	if len(aggregateIDs) == 1 {
+		return fmt.Sprintf("%s %s %d times", aggregateIDs[0], verb, len(events))
+	}
+	return fmt.Sprintf("%d apps %s", len(aggregateIDs), verb)
+}
@@ -0,0 +1,115 @@
+package app_test
+
+import (
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	app "github.com/dokku-mcp/dokku-mcp/internal/server-plugins/app/domain"
+)
+
+var _ = Describe("EventCoalescer", func() {
+	var (
+		clock         *fixedClock
+		notifications []app.Notification
+		coalescer     *app.EventCoalescer
+	)
+
+	received := func() []app.Notification {
+		return notifications
+	}
+
+	BeforeEach(func() {
+		notifications = nil
+		clock = &fixedClock{now: time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)}
+
+		var err error
+		coalescer, err = app.NewEventCoalescer(app.DefaultCoalescerConfig(), clock, func(n app.Notification) {
+			notifications = append(notifications, n)
+		})
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should coalesce deploy events within the window into one notification", func() {
+		for i := 1; i <= 5; i++ {
+			coalescer.Publish(app.NewApplicationDeployedEvent(fmt.Sprintf("app-%d", i), "main", clock.Now()))
+			clock.now = clock.now.Add(500 * time.Millisecond)
+		}
+		coalescer.FlushDue()
+		Expect(received()).To(BeEmpty())
+
+		clock.now = clock.now.Add(app.DefaultCoalescingWindow)
+		coalescer.FlushDue()
+		coalescer.FlushDue()
+		Expect(received()).To(HaveLen(1))
+
+		notification := received()[0]
+		Expect(notification.EventType).To(Equal("application.deployed"))
+		Expect(notification.Count).To(Equal(5))
+		Expect(notification.Summary).To(Equal("5 apps deployed"))
+		Expect(notification.Critical).To(BeFalse())
+		Expect(coalescer.Pending()).To(BeZero())
+	})
+
+	It("should deliver failures immediately", func() {
+		coalescer.Publish(app.NewApplicationDeployedEvent("app-1", "main", clock.Now()))
+		coalescer.Publish(app.NewApplicationDeploymentFailedEvent("app-2", "build failed", time.Second, clock.Now()))
+
+		notifications := received()
+		Expect(notifications).To(HaveLen(1))
+		Expect(notifications[0].Critical).To(BeTrue())
+		Expect(notifications[0].AggregateIDs).To(Equal([]string{"app-2"}))
+		Expect(coalescer.Pending()).To(Equal(1))
+	})
+
+	It("should deliver non-coalesced types immediately", func() {
+		coalescer.Publish(app.NewDomainAddedEvent("app-1", "example.com", clock.Now()))
+		Expect(received()).To(HaveLen(1))
+	})
+
+	It("should start a new window after a batch is delivered", func() {
+		coalescer.Publish(app.NewApplicationDeployedEvent("app-1", "main", clock.Now()))
+		clock.now = clock.now.Add(app.DefaultCoalescingWindow)
+		coalescer.FlushDue()
+
+		coalescer.Publish(app.NewApplicationDeployedEvent("app-2", "main", clock.Now()))
+		coalescer.FlushDue()
+		Expect(received()).To(HaveLen(1))
+		Expect(coalescer.Pending()).To(Equal(1))
+
+		clock.now = clock.now.Add(app.DefaultCoalescingWindow)
+		coalescer.FlushDue()
+		Expect(received()).To(HaveLen(2))
+		Expect(received()[1].AggregateIDs).To(Equal([]string{"app-2"}))
+	})
+
+	It("should deliver the events the dispatcher delivers", func() {
+		dispatcher := app.NewEventDispatcher(nil)
+		coalescer.Subscribe(dispatcher)
+
+		dispatcher.Dispatch([]app.DomainEvent{app.NewDomainAddedEvent("app-1", "example.com", clock.Now())})
+		dispatcher.Wait()
+		Expect(received()).To(HaveLen(1))
+	})
+
+	It("should keep separate batches per event type", func() {
+		coalescer.Publish(app.NewApplicationDeployedEvent("app-1", "main", clock.Now()))
+		coalescer.Publish(app.NewApplicationScaledEvent("app-1", "web", 1, 2, clock.Now()))
+		coalescer.Publish(app.NewApplicationScaledEvent("app-1", "web", 2, 3, clock.Now()))
+		coalescer.Flush()
+
+		notifications := received()
+		Expect(notifications).To(HaveLen(2))
+		Expect(notifications[0].EventType).To(Equal("application.deployed"))
+		Expect(notifications[1].Summary).To(Equal("app-1 scaled 2 times"))
+	})
+
+	It("should reject an invalid configuration", func() {
+		_, err := app.NewEventCoalescer(app.CoalescerConfig{}, nil, func(app.Notification) {})
+		Expect(err).To(HaveOccurred())
+		_, err = app.NewEventCoalescer(app.DefaultCoalescerConfig(), nil, nil)
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -0,0 +1,94 @@
+package app
+
+import (
+	"log/slog"
+	"slices"
+	"sync"
+)
+
+// AllEventTypes subscribes a handler to every event type
+const AllEventTypes = "*"
+
+// EventHandler reacts to a dispatched event
+type EventHandler func(DomainEvent)
+
+// EventDispatcher delivers the events of saved applications to their subscribers.
+// Each dispatch runs in its own goroutine, delivering its events in order, so the
+// save path never waits on a handler. A panicking handler is recovered and logged.
+type EventDispatcher struct {
+	logger *slog.Logger
+
+	mu       sync.RWMutex
+	handlers map[string][]EventHandler
+
+	inFlight sync.WaitGroup
+}
+
+// NewEventDispatcher creates a dispatcher without subscribers
+func NewEventDispatcher(logger *slog.Logger) *EventDispatcher {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &EventDispatcher{
+		logger:   logger,
+		handlers: make(map[string][]EventHandler),
+	}
+}
+
+// Subscribe registers a handler for an event type, e.g. "application.deployed",
+// or for every type with AllEventTypes
+func (d *EventDispatcher) Subscribe(eventType string, handler func(DomainEvent)) {
+	if handler == nil {
+		return
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.handlers[eventType] = append(d.handlers[eventType], handler)
+}
+
+// Dispatch delivers the events to the matching subscribers in the background
+func (d *EventDispatcher) Dispatch(events []DomainEvent) {
+	if len(events) == 0 {
+		return
+	}
+	events = slices.Clone(events)
+
+	d.mu.RLock()
+	handlers := make(map[string][]EventHandler, len(d.handlers))
+	for eventType, subscribed := range d.handlers {
+		handlers[eventType] = slices.Clone(subscribed)
+	}
+	d.mu.RUnlock()
+
+	d.inFlight.Add(1)
+	go func() {
+		defer d.inFlight.Done()
+		for _, event := range events {
+			for _, handler := range handlers[event.EventType()] {
+				d.deliver(handler, event)
+			}
+			for _, handler := range handlers[AllEventTypes] {
+				d.deliver(handler, event)
+			}
+		}
+	}()
+}
+
+// Wait blocks until every dispatch in progress is delivered
+func (d *EventDispatcher) Wait() {
+	d.inFlight.Wait()
+}
+
+func (d *EventDispatcher) deliver(handler EventHandler, event DomainEvent) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			d.logger.Error("Event handler panicked",
+				"event_type", event.EventType(),
+				"aggregate_id", event.AggregateID(),
+				"panic", recovered)
+		}
+	}()
+	handler(event)
+}
@@ -0,0 +1,77 @@
+package app_test
+
+import (
+	"io"
+	"log/slog"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	app "github.com/dokku-mcp/dokku-mcp/internal/server-plugins/app/domain"
+)
+
+var _ = Describe("EventDispatcher", func() {
+	var (
+		dispatcher *app.EventDispatcher
+		mu         sync.Mutex
+		received   []string
+	)
+
+	record := func(prefix string) func(app.DomainEvent) {
+		return func(event app.DomainEvent) {
+			mu.Lock()
+			defer mu.Unlock()
+			received = append(received, prefix+event.EventType())
+		}
+	}
+
+	BeforeEach(func() {
+		dispatcher = app.NewEventDispatcher(slog.New(slog.NewTextHandler(io.Discard, nil)))
+		received = nil
+	})
+
+	It("should deliver events to the subscribers of their type, in order", func() {
+		dispatcher.Subscribe("application.domain.added", record(""))
+		dispatcher.Subscribe(app.AllEventTypes, record("*"))
+
+		now := time.Now()
+		dispatcher.Dispatch([]app.DomainEvent{
+			app.NewApplicationCreatedEvent("my-app", now),
+			app.NewDomainAddedEvent("my-app", "example.com", now),
+		})
+		dispatcher.Wait()
+
+		Expect(received).To(Equal([]string{
+			"*application.created",
+			"application.domain.added",
+			"*application.domain.added",
+		}))
+	})
+
+	It("should not block the caller on a slow handler", func() {
+		release := make(chan struct{})
+		dispatcher.Subscribe(app.AllEventTypes, func(app.DomainEvent) { <-release })
+
+		done := make(chan struct{})
+		go func() {
+			dispatcher.Dispatch([]app.DomainEvent{app.NewApplicationCreatedEvent("my-app", time.Now())})
+			close(done)
+		}()
+		Eventually(done).Should(BeClosed())
+
+		close(release)
+		dispatcher.Wait()
+	})
+
+	It("should recover a panicking handler and keep delivering", func() {
+		dispatcher.Subscribe("application.created", func(app.DomainEvent) { panic("boom") })
+		dispatcher.Subscribe("application.created", record(""))
+
+		dispatcher.Dispatch([]app.DomainEvent{app.NewApplicationCreatedEvent("my-app", time.Now())})
+		dispatcher.Wait()
+
+		Expect(received).To(Equal([]string{"application.created"}))
+	})
+})
@@ -0,0 +1,141 @@
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+)
+
+// EventRegistry maps event types to factories so events can be serialized and restored
+type EventRegistry struct {
+	mu        sync.RWMutex
+	factories map[string]func() DomainEvent
+}
+
+// NewEventRegistry creates an empty event registry
+func NewEventRegistry() *EventRegistry {
+	return &EventRegistry{
+		factories: make(map[string]func() DomainEvent),
+	}
+}
+
+// eventEnvelope is the serialized form of an event
+type eventEnvelope struct {
+	EventType string          `json:"event_type"`
+	Payload   json.RawMessage `json:"payload"`
+}
+
+// Register associates an event type with a factory returning an empty event of that type.
+// The event must implement json.Marshaler and json.Unmarshaler.
+func (r *EventRegistry) Register(eventType string, factory func() DomainEvent) {
+	if eventType == "" {
+		panic("event type cannot be empty")
+	}
+	if factory == nil {
+		panic(fmt.Sprintf("factory for event type %s cannot be nil", eventType))
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.factories[eventType] = factory
+}
+
+// IsRegistered checks whether an event type has a factory
+func (r *EventRegistry) IsRegistered(eventType string) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	_, exists := r.factories[eventType]
+	return exists
+}
+
+// Marshal serializes an event with its type so it can be restored by Unmarshal
+func (r *EventRegistry) Marshal(event DomainEvent) ([]byte, error) {
+	if event == nil {
+		return nil, fmt.Errorf("event cannot be null")
+	}
+	if !r.IsRegistered(event.EventType()) {
+		return nil, fmt.Errorf("event type %s is not registered", event.EventType())
+	}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal event %s: %w", event.EventType(), err)
+	}
+
+	return json.Marshal(eventEnvelope{EventType: event.EventType(), Payload: payload})
+}
+
+// Unmarshal restores an event serialized by Marshal
+func (r *EventRegistry) Unmarshal(data []byte) (DomainEvent, error) {
+	var envelope eventEnvelope
+	if err := json.Unmarshal(data, &envelope); err != nil {
+		return nil, fmt.Errorf("failed to decode event envelope: %w", err)
+	}
+
+	r.mu.RLock()
+	factory, exists := r.factories[envelope.EventType]
+	r.mu.RUnlock()
+	if !exists {
+		return nil, fmt.Errorf("event type %q is not registered", envelope.EventType)
+	}
+
+	event := factory()
+	if err := json.Unmarshal(envelope.Payload, event); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal event %s: %w", envelope.EventType, err)
+	}
+
+	return event, nil
+}
+
+var defaultEventRegistry = newApplicationEventRegistry()
+
+// newApplicationEventRegistry creates a registry with every application event registered
+func newApplicationEventRegistry() *EventRegistry {
+	registry := NewEventRegistry()
+	registry.Register("application.created", func() DomainEvent { return &ApplicationCreatedEvent{} })
+	registry.Register("application.destroyed", func() DomainEvent { return &ApplicationDestroyedEvent{} })
+	registry.Register("application.deployed", func() DomainEvent { return &ApplicationDeployedEvent{} })
+	registry.Register("application.deployed.image", func() DomainEvent { return &ApplicationDeployedFromImageEvent{} })
+	registry.Register("application.rebuilt", func() DomainEvent { return &ApplicationRebuiltEvent{} })
+	registry.Register("application.deployment.completed", func() DomainEvent { return &ApplicationDeploymentCompletedEvent{} })
+	registry.Register("application.deployment.failed", func() DomainEvent { return &ApplicationDeploymentFailedEvent{} })
+	registry.Register("application.undeployed", func() DomainEvent { return &ApplicationUndeployedEvent{} })
+	registry.Register("application.scaled", func() DomainEvent { return &ApplicationScaledEvent{} })
+	registry.Register("application.state.changed", func() DomainEvent { return &ApplicationStateChangedEvent{} })
+	registry.Register("application.domain.added", func() DomainEvent { return &DomainAddedEvent{} })
+	registry.Register("application.domain.removed", func() DomainEvent { return &DomainRemovedEvent{} })
+	registry.Register("application.buildpack.changed", func() DomainEvent { return &BuildpackChangedEvent{} })
+	registry.Register("application.buildpack.added", func() DomainEvent { return &BuildpackAddedEvent{} })
+	registry.Register("application.buildpack.removed", func() DomainEvent { return &BuildpackRemovedEvent{} })
+	registry.Register("application.process.limits.changed", func() DomainEvent { return &ProcessLimitsChangedEvent{} })
+	registry.Register("application.healthchecks.changed", func() DomainEvent { return &HealthChecksChangedEvent{} })
+	registry.Register("application.runasuser.changed", func() DomainEvent { return &RunAsUserChangedEvent{} })
+	registry.Register("application.renamed", func() DomainEvent { return &ApplicationRenamedEvent{} })
+	registry.Register("application.git.changed", func() DomainEvent { return &GitConfigChangedEvent{} })
+	registry.Register("application.maintenance.enabled", func() DomainEvent { return &MaintenanceEnabledEvent{} })
+	registry.Register("application.maintenance.disabled", func() DomainEvent { return &MaintenanceDisabledEvent{} })
+	registry.Register("application.process.restart_policy.changed", func() DomainEvent { return &RestartPolicyChangedEvent{} })
+	registry.Register("application.ports.changed", func() DomainEvent { return &PortMappingsChangedEvent{} })
+	registry.Register("application.scheduled_task.added", func() DomainEvent { return &ScheduledTaskAddedEvent{} })
+	registry.Register("application.scheduled_task.removed", func() DomainEvent { return &ScheduledTaskRemovedEvent{} })
+	registry.Register("application.docker_option.added", func() DomainEvent { return &DockerOptionAddedEvent{} })
+	registry.Register("application.docker_option.removed", func() DomainEvent { return &DockerOptionRemovedEvent{} })
+	registry.Register("application.label.changed", func() DomainEvent { return &LabelChangedEvent{} })
+	registry.Register("application.configuration.reconciled", func() DomainEvent { return &ConfigurationReconciledEvent{} })
+	return registry
+}
+
+// RegisterEvent registers an event type in the default registry
+func RegisterEvent(eventType string, factory func() DomainEvent) {
+	defaultEventRegistry.Register(eventType, factory)
+}
+
+// MarshalEvent serializes an event using the default registry
+func MarshalEvent(event DomainEvent) ([]byte, error) {
+	return defaultEventRegistry.Marshal(event)
+}
+
+// UnmarshalEvent restores an event using the default registry
+func UnmarshalEvent(data []byte) (DomainEvent, error) {
+	return defaultEventRegistry.Unmarshal(data)
+}
@@ -0,0 +1,103 @@
+package app_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	app "github.com/dokku-mcp/dokku-mcp/internal/server-plugins/app/domain"
+)
+
+var _ = Describe("Event registry", func() {
+	occurredAt := time.Date(2025, 3, 1, 10, 30, 0, 0, time.UTC)
+
+	It("should round-trip the sequence of a recorded event", func() {
+		application, err := app.NewApplication("my-app")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(application.AddDomain("example.com")).To(Succeed())
+
+		data, err := app.MarshalEvent(application.GetEvents()[1])
+		Expect(err).NotTo(HaveOccurred())
+		restored, err := app.UnmarshalEvent(data)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(restored.Sequence()).To(Equal(uint64(2)))
+	})
+
+	DescribeTable("round-trips every application event",
+		func(event app.DomainEvent) {
+			data, err := app.MarshalEvent(event)
+			Expect(err).NotTo(HaveOccurred())
+
+			restored, err := app.UnmarshalEvent(data)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(restored).To(Equal(event))
+			Expect(restored.EventType()).To(Equal(event.EventType()))
+			Expect(restored.OccurredAt().Equal(occurredAt)).To(BeTrue())
+		},
+		Entry("created", app.NewApplicationCreatedEvent("my-app", occurredAt)),
+		Entry("destroyed", app.NewApplicationDestroyedEvent("my-app", occurredAt)),
+		Entry("deployed", app.NewApplicationDeployedEvent("my-app", "v1.2.3", occurredAt)),
+		Entry("rebuilt", app.NewApplicationRebuiltEvent("my-app", "v1.2.3", true, false, occurredAt)),
+		Entry("deployed from image", app.NewApplicationDeployedFromImageEvent("my-app", "registry.example.com/my-app:1.2.3", occurredAt)),
+		Entry("deployment completed", app.NewApplicationDeploymentCompletedEvent("my-app", 90*time.Second, occurredAt)),
+		Entry("deployment failed", app.NewApplicationDeploymentFailedEvent("my-app", "build failed", 30*time.Second, occurredAt)),
+		Entry("deployment failed on checks", app.NewApplicationDeploymentFailedEvent("my-app", "checks failed", 30*time.Second, occurredAt,
+			app.CheckResult{Process: "web", Name: "health", Endpoint: ":5000/health", Attempt: 3, Message: "connection refused"})),
+		Entry("undeployed", app.NewApplicationUndeployedEvent("my-app", "release torn down", occurredAt)),
+		Entry("scaled", app.NewApplicationScaledEvent("my-app", "web", 1, 3, occurredAt)),
+		Entry("state changed", app.NewApplicationStateChangedEvent("my-app", "exists", "running", occurredAt)),
+		Entry("domain added", app.NewDomainAddedEvent("my-app", "example.com", occurredAt)),
+		Entry("domain removed", app.NewDomainRemovedEvent("my-app", "example.com", occurredAt)),
+		Entry("buildpack changed", app.NewBuildpackChangedEvent("my-app", "heroku/nodejs", occurredAt)),
+		Entry("buildpack added", app.NewBuildpackAddedEvent("my-app", "heroku/nodejs", 2, occurredAt)),
+		Entry("buildpack removed", app.NewBuildpackRemovedEvent("my-app", "heroku/nodejs", occurredAt)),
+		Entry("process limits changed", app.NewProcessLimitsChangedEvent("my-app", "web", "512m", "1", "1g", occurredAt)),
+		Entry("run as user changed", app.NewRunAsUserChangedEvent("my-app", "app", "1000", occurredAt)),
+		Entry("renamed", app.NewApplicationRenamedEvent("my-app", "my-new-app", occurredAt)),
+		Entry("git config changed", app.NewGitConfigChangedEvent("my-app", "main", "GIT_REV", true, occurredAt)),
+		Entry("maintenance enabled", app.NewMaintenanceEnabledEvent("my-app", occurredAt)),
+		Entry("maintenance disabled", app.NewMaintenanceDisabledEvent("my-app", occurredAt)),
+		Entry("restart policy changed", app.NewRestartPolicyChangedEvent("my-app", "web", "on-failure:5", occurredAt)),
+		Entry("port mappings changed", app.NewPortMappingsChangedEvent("my-app", []string{"http:80:5000", "https:443:5000"}, occurredAt)),
+		Entry("scheduled task added", app.NewScheduledTaskAddedEvent("my-app", "0 3 * * *", "npm run cleanup", occurredAt)),
+		Entry("scheduled task removed", app.NewScheduledTaskRemovedEvent("my-app", "@daily", "npm run report", occurredAt)),
+		Entry("docker option added", app.NewDockerOptionAddedEvent("my-app", "deploy", "--add-host db:10.0.0.2", occurredAt)),
+		Entry("docker option removed", app.NewDockerOptionRemovedEvent("my-app", "build", "--no-cache", occurredAt)),
+		Entry("configuration reconciled", app.NewConfigurationReconciledEvent("my-app", app.ReconcileSummary{
+			DomainsAdded: 1, EnvKeysUnset: 2, ScaleDeltas: map[string]int{"web": 2}, BuildpackChanged: true,
+		}, occurredAt)),
+		Entry("label changed", app.NewLabelChangedEvent("my-app", "team", "payments", false, occurredAt)),
+		Entry("health checks changed", app.NewHealthChecksChangedEvent("my-app", 5*time.Second, 30*time.Second, 5, true, occurredAt)),
+	)
+
+	It("should fail to unmarshal an unregistered event type", func() {
+		_, err := app.UnmarshalEvent([]byte(`{"event_type":"application.teleported","payload":{}}`))
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("application.teleported"))
+	})
+
+	It("should fail to marshal an unregistered event type", func() {
+		registry := app.NewEventRegistry()
+		_, err := registry.Marshal(app.NewApplicationCreatedEvent("my-app", occurredAt))
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("application.created"))
+	})
+
+	It("should reject malformed input", func() {
+		_, err := app.UnmarshalEvent([]byte(`not json`))
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should round-trip events registered in a custom registry", func() {
+		registry := app.NewEventRegistry()
+		registry.Register("application.created", func() app.DomainEvent { return &app.ApplicationCreatedEvent{} })
+		Expect(registry.IsRegistered("application.created")).To(BeTrue())
+
+		data, err := registry.Marshal(app.NewApplicationCreatedEvent("my-app", occurredAt))
+		Expect(err).NotTo(HaveOccurred())
+		restored, err := registry.Unmarshal(data)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(restored.AggregateID()).To(Equal("my-app"))
+	})
+})
@@ -0,0 +1,143 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dokku-mcp/dokku-mcp/internal/shared"
+)
+
+const (
+	// DefaultDeployBranch is the branch Dokku deploys when none is configured
+	DefaultDeployBranch = "master"
+	// DefaultRevEnvVar is the variable Dokku exposes the deployed revision in
+	DefaultRevEnvVar = "GIT_REV"
+)
+
+// GitConfiguration holds the git settings of an application: the branch a push deploys,
+// the variable the deployed revision is exposed in (empty to disable it) and whether
+// the .git directory is kept in the build context
+type GitConfiguration struct {
+	deployBranch string
+	revEnvVar    string
+	keepGitDir   bool
+}
+
+// DefaultGitConfiguration returns the settings of an application with no git config
+func DefaultGitConfiguration() *GitConfiguration {
+	return &GitConfiguration{
+		deployBranch: DefaultDeployBranch,
+		revEnvVar:    DefaultRevEnvVar,
+	}
+}
+
+// NewGitConfiguration creates git settings, validating the branch and the variable name
+func NewGitConfiguration(deployBranch, revEnvVar string, keepGitDir bool) (*GitConfiguration, error) {
+	branch, err := shared.NewBranchRef(deployBranch)
+	if err != nil {
+		return nil, fmt.Errorf("invalid deploy branch: %w", err)
+	}
+
+	revEnvVar = strings.TrimSpace(revEnvVar)
+	if revEnvVar != "" {
+		if _, err := shared.NewEnvVarKey(revEnvVar); err != nil {
+			return nil, fmt.Errorf("invalid rev env var: %w", err)
+		}
+	}
+
+	return &GitConfiguration{
+		deployBranch: branch.Value(),
+		revEnvVar:    revEnvVar,
+		keepGitDir:   keepGitDir,
+	}, nil
+}
+
+func (g *GitConfiguration) DeployBranch() string { return g.deployBranch }
+func (g *GitConfiguration) RevEnvVar() string    { return g.revEnvVar }
+func (g *GitConfiguration) KeepGitDir() bool     { return g.keepGitDir }
+
+// Equal checks if two git configurations hold the same settings
+func (g *GitConfiguration) Equal(other *GitConfiguration) bool {
+	if other == nil {
+		return false
+	}
+	return *g == *other
+}
+
+// GitReport is the typed form of `git:report <app>`
+type GitReport struct {
+	AppName            string `json:"app_name"`
+	DeployBranch       string `json:"deploy_branch,omitempty"`
+	GlobalDeployBranch string `json:"global_deploy_branch,omitempty"`
+	KeepGitDir         bool   `json:"keep_git_dir"`
+	RevEnvVar          string `json:"rev_env_var,omitempty"`
+	SHA                string `json:"sha,omitempty"`
+	SourceImage        string `json:"source_image,omitempty"`
+	LastUpdatedAt      string `json:"last_updated_at,omitempty"`
+}
+
+// Configuration returns the effective git settings of the app: its deploy branch,
+// falling back on the global one then on the Dokku default
+func (r *GitReport) Configuration() (*GitConfiguration, error) {
+	branch := r.DeployBranch
+	if branch == "" {
+		branch = r.GlobalDeployBranch
+	}
+	if branch == "" {
+		branch = DefaultDeployBranch
+	}
+	return NewGitConfiguration(branch, r.RevEnvVar, r.KeepGitDir)
+}
+
+// ParseGitReport parses the output of git:report for a single application.
+// An app that never had git config parses to the Dokku defaults.
+func ParseGitReport(raw string) (*GitReport, error) {
+	report := &GitReport{RevEnvVar: DefaultRevEnvVar}
+
+	found := false
+	for _, line := range strings.Split(raw, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		if strings.HasPrefix(line, "=====>") {
+			fields := strings.Fields(strings.TrimPrefix(line, "=====>"))
+			if len(fields) > 0 && report.AppName == "" {
+				report.AppName = fields[0]
+			}
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		value = strings.TrimSpace(value)
+
+		switch strings.ToLower(strings.TrimSpace(key)) {
+		case "git deploy branch":
+			report.DeployBranch = value
+			found = true
+		case "git global deploy branch":
+			report.GlobalDeployBranch = value
+			found = true
+		case "git keep git dir":
+			report.KeepGitDir = value == "true"
+		case "git rev env var":
+			report.RevEnvVar = value
+		case "git sha":
+			report.SHA = value
+		case "git source image":
+			report.SourceImage = value
+		case "git last updated at":
+			report.LastUpdatedAt = value
+		}
+	}
+
+	if !found {
+		return nil, fmt.Errorf("invalid git report: no git section")
+	}
+
+	return report, nil
+}
@@ -0,0 +1,129 @@
+package app_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	app "github.com/dokku-mcp/dokku-mcp/internal/server-plugins/app/domain"
+)
+
+var _ = Describe("GitConfiguration", func() {
+	It("should validate the deploy branch and the rev env var", func() {
+		git, err := app.NewGitConfiguration("main", "", true)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(git.DeployBranch()).To(Equal("main"))
+		Expect(git.RevEnvVar()).To(BeEmpty())
+		Expect(git.KeepGitDir()).To(BeTrue())
+
+		_, err = app.NewGitConfiguration("", "GIT_REV", false)
+		Expect(err).To(HaveOccurred())
+		_, err = app.NewGitConfiguration("main", "1-INVALID", false)
+		Expect(err).To(HaveOccurred())
+	})
+
+	Describe("ParseGitReport", func() {
+		It("should parse the git settings of an app", func() {
+			report, err := app.ParseGitReport(`=====> my-app git information
+       Git deploy branch:             main
+       Git global deploy branch:      master
+       Git keep git dir:              true
+       Git rev env var:               SOURCE_VERSION
+       Git sha:                       a1b2c3d
+       Git source image:
+       Git last updated at:           1700000000`)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(report.AppName).To(Equal("my-app"))
+			Expect(report.SHA).To(Equal("a1b2c3d"))
+			git, err := report.Configuration()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(git.DeployBranch()).To(Equal("main"))
+			Expect(git.RevEnvVar()).To(Equal("SOURCE_VERSION"))
+			Expect(git.KeepGitDir()).To(BeTrue())
+		})
+
+		It("should default an app that never had git config", func() {
+			report, err := app.ParseGitReport(`=====> fresh git information
+       Git deploy branch:
+       Git global deploy branch:`)
+			Expect(err).NotTo(HaveOccurred())
+
+			git, err := report.Configuration()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(git.Equal(app.DefaultGitConfiguration())).To(BeTrue())
+		})
+
+		It("should fall back on the global deploy branch", func() {
+			report, err := app.ParseGitReport(`=====> my-app git information
+       Git deploy branch:
+       Git global deploy branch:      trunk`)
+			Expect(err).NotTo(HaveOccurred())
+
+			git, err := report.Configuration()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(git.DeployBranch()).To(Equal("trunk"))
+		})
+
+		It("should reject output that is not a git report", func() {
+			_, err := app.ParseGitReport("=====> my-app ps information")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
+
+var _ = Describe("Application git configuration", func() {
+	var application *app.Application
+
+	BeforeEach(func() {
+		var err error
+		application, err = app.NewApplication("my-app")
+		Expect(err).NotTo(HaveOccurred())
+		application.ClearEvents()
+	})
+
+	It("should start with the Dokku defaults", func() {
+		Expect(application.GitConfiguration().DeployBranch()).To(Equal(app.DefaultDeployBranch))
+		Expect(application.GitConfiguration().RevEnvVar()).To(Equal(app.DefaultRevEnvVar))
+		Expect(application.GitConfiguration().KeepGitDir()).To(BeFalse())
+	})
+
+	It("should emit an event carrying the whole configuration on change", func() {
+		Expect(application.SetDeployBranch("main")).To(Succeed())
+		Expect(application.SetKeepGitDir(true)).To(Succeed())
+
+		events := application.GetEvents()
+		Expect(events).To(HaveLen(2))
+		changed, ok := events[1].(*app.GitConfigChangedEvent)
+		Expect(ok).To(BeTrue())
+		Expect(changed.DeployBranch()).To(Equal("main"))
+		Expect(changed.RevEnvVar()).To(Equal(app.DefaultRevEnvVar))
+		Expect(changed.KeepGitDir()).To(BeTrue())
+	})
+
+	It("should not emit anything when nothing changes", func() {
+		Expect(application.SetDeployBranch(app.DefaultDeployBranch)).To(Succeed())
+		Expect(application.SetRevEnvVar(app.DefaultRevEnvVar)).To(Succeed())
+		Expect(application.GetEvents()).To(BeEmpty())
+	})
+
+	It("should reject an invalid branch without changing anything", func() {
+		for _, branch := range []string{"  ", "my branch", "main..dev", "/main"} {
+			Expect(application.SetDeployBranch(branch)).NotTo(Succeed(), branch)
+		}
+		Expect(application.GitConfiguration().DeployBranch()).To(Equal(app.DefaultDeployBranch))
+		Expect(application.GetEvents()).To(BeEmpty())
+	})
+
+	It("should be restored by replay", func() {
+		Expect(application.SetRevEnvVar("")).To(Succeed())
+
+		replayed, err := app.ReplayApplication("my-app", []app.DomainEvent{
+			app.NewApplicationCreatedEvent("my-app", time.Now()),
+			application.GetEvents()[0],
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(replayed.GitConfiguration().Equal(application.GitConfiguration())).To(BeTrue())
+	})
+})
@@ -0,0 +1,99 @@
+package app
+
+import (
+	"fmt"
+	"time"
+)
+
+// Default zero-downtime check settings, matching Dokku's CHECKS_* defaults
+const (
+	DefaultChecksWait     = 5 * time.Second
+	DefaultChecksTimeout  = 30 * time.Second
+	DefaultChecksAttempts = 5
+)
+
+// HealthCheck represents the zero-downtime checks configuration of an application
+type HealthCheck struct {
+	wait     time.Duration
+	timeout  time.Duration
+	attempts int
+	skipped  bool
+}
+
+// NewHealthCheck creates an enabled health check configuration
+func NewHealthCheck(wait, timeout time.Duration, attempts int) (*HealthCheck, error) {
+	if wait < 0 {
+		return nil, fmt.Errorf("checks wait time cannot be negative")
+	}
+	if timeout <= 0 {
+		return nil, fmt.Errorf("checks timeout must be positive")
+	}
+	if attempts < 1 {
+		return nil, fmt.Errorf("checks attempts must be at least 1")
+	}
+
+	return &HealthCheck{wait: wait, timeout: timeout, attempts: attempts}, nil
+}
+
+// DefaultHealthCheck returns Dokku's default checks configuration
+func DefaultHealthCheck() *HealthCheck {
+	return &HealthCheck{
+		wait:     DefaultChecksWait,
+		timeout:  DefaultChecksTimeout,
+		attempts: DefaultChecksAttempts,
+	}
+}
+
+// SkippedHealthCheck returns a configuration where checks are skipped
+func SkippedHealthCheck() *HealthCheck {
+	hc := DefaultHealthCheck()
+	hc.skipped = true
+	return hc
+}
+
+// Wait returns the delay before the first check
+func (hc *HealthCheck) Wait() time.Duration {
+	return hc.wait
+}
+
+// Timeout returns the timeout of a single check
+func (hc *HealthCheck) Timeout() time.Duration {
+	return hc.timeout
+}
+
+// Attempts returns the number of attempts before a deploy fails
+func (hc *HealthCheck) Attempts() int {
+	return hc.attempts
+}
+
+// IsSkipped returns true if checks are skipped on deploy
+func (hc *HealthCheck) IsSkipped() bool {
+	return hc.skipped
+}
+
+// Equal checks equality with another health check configuration
+func (hc *HealthCheck) Equal(other *HealthCheck) bool {
+	if other == nil {
+		return false
+	}
+	return hc.wait == other.wait &&
+		hc.timeout == other.timeout &&
+		hc.attempts == other.attempts &&
+		hc.skipped == other.skipped
+}
+
+// HealthResult is the outcome of the last probe of a running application
+type HealthResult struct {
+	passed     bool
+	observedAt time.Time
+}
+
+// Passed returns true if the application responded to the probe
+func (r *HealthResult) Passed() bool {
+	return r.passed
+}
+
+// ObservedAt returns when the probe ran
+func (r *HealthResult) ObservedAt() time.Time {
+	return r.observedAt
+}
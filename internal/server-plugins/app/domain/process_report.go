@@ -0,0 +1,190 @@
+package app
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/dokku-mcp/dokku-mcp/internal/shared/process"
+)
+
+// Observed statuses of a reported process
+const (
+	ProcessStatusRunning = "running"
+	ProcessStatusPartial = "partial"
+	ProcessStatusStopped = "stopped"
+)
+
+// ReportedProcess is the observed state of one process type in a ps report.
+// Desired is the number of containers Dokku scheduled, Running those actually running.
+type ReportedProcess struct {
+	Type          process.ProcessType `json:"type"`
+	Running       int                 `json:"running"`
+	Desired       int                 `json:"desired"`
+	RestartPolicy string              `json:"restart_policy,omitempty"`
+	Status        string              `json:"status"`
+}
+
+// ProcessReport is the typed form of `ps:report <app>`
+type ProcessReport struct {
+	AppName       string            `json:"app_name"`
+	Deployed      bool              `json:"deployed"`
+	RestartPolicy string            `json:"restart_policy,omitempty"`
+	Processes     []ReportedProcess `json:"processes"`
+}
+
+// Process returns the reported state of a process type
+func (r *ProcessReport) Process(processType process.ProcessType) (ReportedProcess, bool) {
+	normalized, err := process.NormalizeProcessType(processType)
+	if err != nil {
+		return ReportedProcess{}, false
+	}
+	for _, reported := range r.Processes {
+		if reported.Type == normalized {
+			return reported, true
+		}
+	}
+	return ReportedProcess{}, false
+}
+
+// ParseProcessReport parses the output of ps:report for a single application.
+// Each "Status <type> <index>" line is one scheduled container of that process type.
+func ParseProcessReport(raw string) (*ProcessReport, error) {
+	report := &ProcessReport{
+		Processes: make([]ReportedProcess, 0),
+	}
+
+	byType := make(map[process.ProcessType]*ReportedProcess)
+	var order []process.ProcessType
+	found := false
+	for _, line := range strings.Split(raw, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		if strings.HasPrefix(line, "=====>") {
+			fields := strings.Fields(strings.TrimPrefix(line, "=====>"))
+			if len(fields) > 0 && report.AppName == "" {
+				report.AppName = fields[0]
+			}
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		key = strings.ToLower(strings.TrimSpace(key))
+		value = strings.TrimSpace(value)
+
+		switch {
+		case key == "deployed":
+			report.Deployed = value == "true"
+			found = true
+		case key == "processes":
+			found = true
+		case key == "ps restart policy":
+			if value != "" {
+				if _, err := process.NewRestartPolicyFromString(value); err != nil {
+					return nil, fmt.Errorf("invalid ps report: %w", err)
+				}
+			}
+			report.RestartPolicy = value
+		case strings.HasPrefix(key, "status "):
+			fields := strings.Fields(strings.TrimPrefix(key, "status "))
+			if len(fields) != 2 {
+				continue
+			}
+			if _, err := strconv.Atoi(fields[1]); err != nil {
+				continue
+			}
+			processType, err := process.NormalizeProcessType(process.ProcessType(fields[0]))
+			if err != nil {
+				return nil, fmt.Errorf("invalid ps report: %w", err)
+			}
+
+			reported, exists := byType[processType]
+			if !exists {
+				reported = &ReportedProcess{Type: processType}
+				byType[processType] = reported
+				order = append(order, processType)
+			}
+			reported.Desired++
+			if status := strings.Fields(value); len(status) > 0 && status[0] == "running" {
+				reported.Running++
+			}
+		}
+	}
+
+	if !found {
+		return nil, fmt.Errorf("invalid ps report: no process section")
+	}
+
+	for _, processType := range order {
+		reported := byType[processType]
+		reported.RestartPolicy = report.RestartPolicy
+		switch reported.Running {
+		case reported.Desired:
+			reported.Status = ProcessStatusRunning
+		case 0:
+			reported.Status = ProcessStatusStopped
+		default:
+			reported.Status = ProcessStatusPartial
+		}
+		report.Processes = append(report.Processes, *reported)
+	}
+
+	return report, nil
+}
+
+// ReconcileProcesses aligns the process scales and restart policies with what Dokku
+// actually scheduled, adding the processes it runs that are not configured. No event is emitted,
+// this records observed state rather than a change. Configured processes absent
+// from the report are left untouched and returned so the caller can flag them.
+func (a *Application) ReconcileProcesses(report *ProcessReport) ([]process.ProcessType, error) {
+	if report == nil {
+		return nil, fmt.Errorf("process report cannot be null")
+	}
+	if report.AppName != "" && report.AppName != a.name.Value() {
+		return nil, fmt.Errorf("process report is for %s, not %s", report.AppName, a.name.Value())
+	}
+
+	for _, reported := range report.Processes {
+		var policy *process.RestartPolicy
+		if reported.RestartPolicy != "" {
+			var err error
+			if policy, err = process.NewRestartPolicyFromString(reported.RestartPolicy); err != nil {
+				return nil, fmt.Errorf("invalid restart policy for process %s: %w", reported.Type, err)
+			}
+		}
+
+		proc, exists := a.findProcess(reported.Type)
+		if exists {
+			if err := proc.SetScale(reported.Desired); err != nil {
+				return nil, fmt.Errorf("invalid scale for process %s: %w", reported.Type, err)
+			}
+		} else {
+			var err error
+			if proc, err = process.NewProcessForScaling(reported.Type, reported.Desired); err != nil {
+				return nil, fmt.Errorf("invalid process in report: %w", err)
+			}
+			a.configuration.processes[proc.Type()] = proc
+		}
+		if policy != nil {
+			proc.SetRestartPolicy(policy)
+		}
+	}
+
+	missing := make([]process.ProcessType, 0)
+	for processType := range a.configuration.processes {
+		if _, reported := report.Process(processType); !reported {
+			missing = append(missing, processType)
+		}
+	}
+	slices.Sort(missing)
+
+	a.updatedAt = a.clock.Now()
+	return missing, nil
+}
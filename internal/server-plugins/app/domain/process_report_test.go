@@ -0,0 +1,111 @@
+package app_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	app "github.com/dokku-mcp/dokku-mcp/internal/server-plugins/app/domain"
+	"github.com/dokku-mcp/dokku-mcp/internal/shared/process"
+)
+
+const psReport = `=====> my-app ps information
+       Deployed:                      true
+       Processes:                     4
+       Ps can scale:                  true
+       Ps restart policy:             on-failure:10
+       Restore:                       true
+       Running:                       mixed
+       Status web 1:                  running (CID: d65fa70d1f2)
+       Status web 2:                  running (CID: 1ed4b3c3fc2)
+       Status worker 1:               running (CID: 0c3a5a1b2e4)
+       Status worker 2:               exited (CID: 7f1e9d8c6b5)
+       Status clock 1:                exited (CID: 3b2a1c0d9e8)`
+
+var _ = Describe("ParseProcessReport", func() {
+	It("should count scheduled and running containers per process type", func() {
+		report, err := app.ParseProcessReport(psReport)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(report.AppName).To(Equal("my-app"))
+		Expect(report.Deployed).To(BeTrue())
+		Expect(report.RestartPolicy).To(Equal("on-failure:10"))
+		Expect(report.Processes).To(Equal([]app.ReportedProcess{
+			{Type: "web", Running: 2, Desired: 2, RestartPolicy: "on-failure:10", Status: app.ProcessStatusRunning},
+			{Type: "worker", Running: 1, Desired: 2, RestartPolicy: "on-failure:10", Status: app.ProcessStatusPartial},
+			{Type: "clock", Running: 0, Desired: 1, RestartPolicy: "on-failure:10", Status: app.ProcessStatusStopped},
+		}))
+	})
+
+	It("should parse an undeployed app without processes", func() {
+		report, err := app.ParseProcessReport(`=====> fresh ps information
+       Deployed:                      false
+       Processes:                     0
+       Ps restart policy:             always`)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(report.Deployed).To(BeFalse())
+		Expect(report.Processes).To(BeEmpty())
+	})
+
+	It("should reject output that is not a ps report", func() {
+		_, err := app.ParseProcessReport("=====> my-app domains information")
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should reject an invalid restart policy", func() {
+		_, err := app.ParseProcessReport(`=====> my-app ps information
+       Deployed:                      true
+       Ps restart policy:             sometimes`)
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+var _ = Describe("Application process reconciliation", func() {
+	var application *app.Application
+
+	BeforeEach(func() {
+		var err error
+		application, err = app.NewApplication("my-app")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(application.AddProcess("web", "npm start", 1)).To(Succeed())
+		Expect(application.AddProcess("release", "npm run migrate", 1)).To(Succeed())
+		application.ClearEvents()
+	})
+
+	It("should align scales with the report without emitting scale events", func() {
+		report, err := app.ParseProcessReport(psReport)
+		Expect(err).NotTo(HaveOccurred())
+
+		missing, err := application.ReconcileProcesses(report)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(application.GetProcessScale("web")).To(Equal(2))
+		Expect(application.GetProcessScale("worker")).To(Equal(2))
+		Expect(application.GetProcessScale("clock")).To(Equal(1))
+		Expect(application.GetEvents()).To(BeEmpty())
+		Expect(missing).To(Equal([]process.ProcessType{"release"}))
+		Expect(application.GetProcessScale("release")).To(Equal(1))
+	})
+
+	It("should record the reported restart policy", func() {
+		report, err := app.ParseProcessReport(psReport)
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = application.ReconcileProcesses(report)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(application.GetProcessRestartPolicy("web").String()).To(Equal("on-failure:10"))
+		Expect(application.GetProcessRestartPolicy("worker").String()).To(Equal("on-failure:10"))
+		Expect(application.GetProcessRestartPolicy("release")).To(BeNil())
+	})
+
+	It("should reject a report for another application", func() {
+		_, err := application.ReconcileProcesses(&app.ProcessReport{AppName: "other-app"})
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should reject a null report", func() {
+		_, err := application.ReconcileProcesses(nil)
+		Expect(err).To(HaveOccurred())
+	})
+})
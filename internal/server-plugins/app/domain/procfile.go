@@ -0,0 +1,90 @@
+package app
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/dokku-mcp/dokku-mcp/internal/shared/process"
+)
+
+// ParseProcfile parses a Procfile into the command of each process. Blank lines and
+// # comments are skipped, every other line is "name: command". Names are validated and
+// lowercased like ps:scale does, so a process declared twice, even with another case,
+// is an error. Errors give the line number.
+func ParseProcfile(data []byte) (map[process.ProcessType]string, error) {
+	procs := make(map[process.ProcessType]string)
+	declaredAt := make(map[process.ProcessType]int)
+
+	for i, line := range strings.Split(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n") {
+		lineNumber := i + 1
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		name, command, ok := strings.Cut(line, ":")
+		if !ok {
+			return nil, fmt.Errorf("line %d: expected name: command", lineNumber)
+		}
+		processType, err := process.NormalizeProcessType(process.ProcessType(strings.TrimSpace(name)))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNumber, err)
+		}
+		if previous, exists := declaredAt[processType]; exists {
+			return nil, fmt.Errorf("line %d: process %s is already declared on line %d", lineNumber, processType, previous)
+		}
+		cmd, err := process.NewProcessCommand(command)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: process %s: %w", lineNumber, processType, err)
+		}
+
+		procs[processType] = cmd.Value()
+		declaredAt[processType] = lineNumber
+	}
+
+	return procs, nil
+}
+
+// ApplyProcfile sets the command of each process, adding the missing processes unscaled
+// and keeping the scale of the existing ones. Every process is validated first, so an
+// invalid one leaves the application unchanged.
+func (a *Application) ApplyProcfile(procs map[process.ProcessType]string) error {
+	if len(procs) == 0 {
+		return fmt.Errorf("the Procfile declares no process")
+	}
+
+	commands := make(map[process.ProcessType]string, len(procs))
+	for processType, command := range procs {
+		normalized, err := process.NormalizeProcessType(processType)
+		if err != nil {
+			return fmt.Errorf("invalid process type: %w", err)
+		}
+		if _, exists := commands[normalized]; exists {
+			return fmt.Errorf("process %s is declared twice", normalized)
+		}
+		cmd, err := process.NewProcessCommand(command)
+		if err != nil {
+			return fmt.Errorf("invalid command of process %s: %w", normalized, err)
+		}
+		commands[normalized] = cmd.Value()
+	}
+
+	for _, processType := range slices.Sorted(maps.Keys(commands)) {
+		proc, exists := a.configuration.processes[processType]
+		if !exists {
+			created, err := process.NewProcess(processType, commands[processType], 0)
+			if err != nil {
+				return fmt.Errorf("unable to add process: %w", err)
+			}
+			a.configuration.processes[processType] = created
+			continue
+		}
+		// The command was validated above, it cannot be refused
+		_ = proc.SetCommand(commands[processType])
+	}
+	a.updatedAt = a.clock.Now()
+
+	return nil
+}
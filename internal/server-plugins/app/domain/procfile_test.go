@@ -0,0 +1,72 @@
+package app_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	app "github.com/dokku-mcp/dokku-mcp/internal/server-plugins/app/domain"
+	"github.com/dokku-mcp/dokku-mcp/internal/shared/process"
+)
+
+var _ = Describe("ParseProcfile", func() {
+	It("should parse the command of each process", func() {
+		procs, err := app.ParseProcfile([]byte(`# processes
+web: bundle exec puma -C config/puma.rb
+
+worker:   bundle exec sidekiq
+release: bin/rails db:migrate
+`))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(procs).To(Equal(map[process.ProcessType]string{
+			process.ProcessTypeWeb:     "bundle exec puma -C config/puma.rb",
+			process.ProcessTypeWorker:  "bundle exec sidekiq",
+			process.ProcessTypeRelease: "bin/rails db:migrate",
+		}))
+	})
+
+	DescribeTable("should report the line of an invalid process",
+		func(data, message string) {
+			_, err := app.ParseProcfile([]byte(data))
+			Expect(err).To(MatchError(ContainSubstring(message)))
+		},
+		Entry("duplicate process", "web: a\nworker: b\nweb: c\n", "line 3: process web is already declared on line 1"),
+		Entry("duplicate process with another case", "web: a\nWeb: c\n", "line 2: process web is already declared on line 1"),
+		Entry("missing colon", "web: a\nworker\n", "line 2: expected name: command"),
+		Entry("invalid name", "\nmy worker: a\n", "line 2:"),
+		Entry("empty command", "web:   \n", "line 1: process web: process command cannot be empty"),
+	)
+})
+
+var _ = Describe("Application ApplyProcfile", func() {
+	var application *app.Application
+
+	BeforeEach(func() {
+		var err error
+		application, err = app.NewApplication("procfile-app")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(application.Scale(process.ProcessTypeWeb, 3)).To(Succeed())
+	})
+
+	It("should set the commands and keep the existing scales", func() {
+		Expect(application.ApplyProcfile(map[process.ProcessType]string{
+			process.ProcessTypeWeb:    "npm start",
+			process.ProcessTypeWorker: "npm run worker",
+		})).To(Succeed())
+
+		Expect(application.GetProcessCommand(process.ProcessTypeWeb)).To(Equal("npm start"))
+		Expect(application.GetProcessScale(process.ProcessTypeWeb)).To(Equal(3))
+		Expect(application.GetProcessCommand(process.ProcessTypeWorker)).To(Equal("npm run worker"))
+		Expect(application.GetProcessScale(process.ProcessTypeWorker)).To(BeZero())
+	})
+
+	It("should leave the application unchanged when a process is invalid", func() {
+		err := application.ApplyProcfile(map[process.ProcessType]string{
+			process.ProcessTypeWeb:    "npm start",
+			process.ProcessTypeWorker: " ",
+		})
+		Expect(err).To(MatchError(ContainSubstring("invalid command of process worker")))
+
+		Expect(application.GetProcessCommand(process.ProcessTypeWeb)).To(BeEmpty())
+		Expect(application.GetProcessScale(process.ProcessTypeWorker)).To(BeZero())
+	})
+})
@@ -0,0 +1,61 @@
+package app
+
+import (
+	"fmt"
+	"math"
+)
+
+// ReplicaTarget declares the replica bounds an autoscaler may move a process within
+type ReplicaTarget struct {
+	min int
+	max int
+}
+
+// NewReplicaTarget creates a replica target, validating that 0 <= min <= max
+func NewReplicaTarget(minReplicas, maxReplicas int) (*ReplicaTarget, error) {
+	if minReplicas < 0 || maxReplicas < 0 {
+		return nil, fmt.Errorf("replica bounds cannot be negative (min=%d, max=%d)", minReplicas, maxReplicas)
+	}
+	if minReplicas > maxReplicas {
+		return nil, fmt.Errorf("minimum replicas (%d) cannot exceed maximum replicas (%d)", minReplicas, maxReplicas)
+	}
+
+	return &ReplicaTarget{min: minReplicas, max: maxReplicas}, nil
+}
+
+// Min returns the minimum number of replicas
+func (rt *ReplicaTarget) Min() int {
+	return rt.min
+}
+
+// Max returns the maximum number of replicas
+func (rt *ReplicaTarget) Max() int {
+	return rt.max
+}
+
+// Clamp bounds a replica count to the target range
+func (rt *ReplicaTarget) Clamp(replicas int) int {
+	return max(rt.min, min(rt.max, replicas))
+}
+
+// Desired computes the replica count for the given load signal.
+// The load is a ratio of observed to target utilization: 1.0 keeps the
+// current scale, 2.0 doubles it and 0.5 halves it, always within bounds.
+// The count is clamped before converting back to int, so a huge or infinite
+// load cannot overflow; a load that is not a number keeps the current scale.
+func (rt *ReplicaTarget) Desired(current int, load float64) int {
+	base := max(current, 1)
+	desired := math.Ceil(float64(base) * load)
+	if math.IsNaN(desired) {
+		return rt.Clamp(current)
+	}
+	return int(math.Max(float64(rt.min), math.Min(float64(rt.max), desired)))
+}
+
+// Equal checks equality with another replica target
+func (rt *ReplicaTarget) Equal(other *ReplicaTarget) bool {
+	if other == nil {
+		return false
+	}
+	return rt.min == other.min && rt.max == other.max
+}
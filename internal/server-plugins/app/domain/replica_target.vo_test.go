@@ -0,0 +1,28 @@
+package app_test
+
+import (
+	"math"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	app "github.com/dokku-mcp/dokku-mcp/internal/server-plugins/app/domain"
+)
+
+var _ = Describe("ReplicaTarget", func() {
+	DescribeTable("desired replicas",
+		func(current int, load float64, expected int) {
+			target, err := app.NewReplicaTarget(1, 10)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(target.Desired(current, load)).To(Equal(expected))
+		},
+		Entry("steady load", 3, 1.0, 3),
+		Entry("doubled load", 3, 2.0, 6),
+		Entry("halved load", 3, 0.5, 2),
+		Entry("load above the int range", 3, 1e300, 10),
+		Entry("current at the int bound", math.MaxInt, 2.0, 10),
+		Entry("infinite load", 3, math.Inf(1), 10),
+		Entry("negative load", 3, -1.0, 1),
+		Entry("load that is not a number", 3, math.NaN(), 3),
+	)
+})
@@ -0,0 +1,197 @@
+package app
+
+import (
+	"slices"
+	"sync"
+	"time"
+)
+
+// ReportType is the kind of parsed report a ReportCache holds
+type ReportType string
+
+const (
+	ReportTypeDomains       ReportType = "domains"
+	ReportTypeProcess       ReportType = "ps"
+	ReportTypeGit           ReportType = "git"
+	ReportTypeProxy         ReportType = "proxy"
+	ReportTypeDockerOptions ReportType = "docker-options"
+)
+
+var allReportTypes = []ReportType{
+	ReportTypeDomains, ReportTypeProcess, ReportTypeGit, ReportTypeProxy, ReportTypeDockerOptions,
+}
+
+// reportInvalidations lists, by event type, the reports an event makes stale. A rename
+// makes every report of the application stale and is handled apart.
+var reportInvalidations = map[string][]ReportType{
+	"application.deployed":                       {ReportTypeProcess, ReportTypeGit},
+	"application.deployed.image":                 {ReportTypeProcess, ReportTypeGit},
+	"application.rebuilt":                        {ReportTypeProcess},
+	"application.deployment.failed":              {ReportTypeProcess},
+	"application.undeployed":                     {ReportTypeProcess, ReportTypeGit},
+	"application.scaled":                         {ReportTypeProcess},
+	"application.state.changed":                  {ReportTypeProcess},
+	"application.process.limits.changed":         {ReportTypeProcess},
+	"application.process.restart_policy.changed": {ReportTypeProcess},
+	"application.domain.added":                   {ReportTypeDomains},
+	"application.domain.removed":                 {ReportTypeDomains},
+	"application.git.changed":                    {ReportTypeGit},
+	"application.ports.changed":                  {ReportTypeProxy},
+	"application.docker_option.added":            {ReportTypeDockerOptions},
+	"application.docker_option.removed":          {ReportTypeDockerOptions},
+}
+
+// ReportCacheMetrics is told of every lookup, hit or miss, e.g. to feed counters
+type ReportCacheMetrics func(reportType ReportType, hit bool)
+
+// ReportCacheStats counts the lookups of a ReportCache
+type ReportCacheStats struct {
+	Hits   uint64 `json:"hits"`
+	Misses uint64 `json:"misses"`
+}
+
+type reportCacheKey struct {
+	appName    string
+	reportType ReportType
+}
+
+type reportCacheEntry struct {
+	report   any
+	cachedAt time.Time
+}
+
+// ReportCache keeps parsed reports by application and report type, so a report is
+// not fetched and parsed again until an event of the application makes it stale.
+// The TTL bounds how long a report is trusted, since changes made outside the server
+// emit no event. It is safe for concurrent use.
+type ReportCache struct {
+	ttl     time.Duration
+	clock   Clock
+	metrics ReportCacheMetrics
+
+	mu      sync.Mutex
+	entries map[reportCacheKey]reportCacheEntry
+	// generations counts the invalidations of each key, a load started before one is
+	// not stored
+	generations map[reportCacheKey]uint64
+	stats       ReportCacheStats
+}
+
+// NewReportCache creates an empty cache trusting reports for the TTL, or until
+// invalidated if the TTL is zero or less. A nil clock is the system clock.
+func NewReportCache(ttl time.Duration, clock Clock) *ReportCache {
+	if clock == nil {
+		clock = DefaultClock
+	}
+	return &ReportCache{
+		ttl:         ttl,
+		clock:       clock,
+		entries:     make(map[reportCacheKey]reportCacheEntry),
+		generations: make(map[reportCacheKey]uint64),
+	}
+}
+
+// SetMetrics sets the hook told of every lookup
+func (c *ReportCache) SetMetrics(metrics ReportCacheMetrics) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.metrics = metrics
+}
+
+// Subscribe invalidates the cached reports as the dispatcher delivers the events
+func (c *ReportCache) Subscribe(dispatcher *EventDispatcher) {
+	dispatcher.Subscribe(AllEventTypes, c.HandleEvent)
+}
+
+// HandleEvent invalidates the reports the event makes stale
+func (c *ReportCache) HandleEvent(event DomainEvent) {
+	if renamed, ok := event.(*ApplicationRenamedEvent); ok {
+		c.Invalidate(renamed.OldName())
+		c.Invalidate(renamed.NewName())
+		return
+	}
+	if reportTypes, exists := reportInvalidations[event.EventType()]; exists {
+		c.Invalidate(event.AggregateID(), reportTypes...)
+	}
+}
+
+// Invalidate drops the given reports of the application, or all of them if none is given
+func (c *ReportCache) Invalidate(appName string, reportTypes ...ReportType) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if len(reportTypes) == 0 {
+		reportTypes = slices.Clone(allReportTypes)
+		for key := range c.entries {
+			if key.appName == appName && !slices.Contains(reportTypes, key.reportType) {
+				reportTypes = append(reportTypes, key.reportType)
+			}
+		}
+	}
+	for _, reportType := range reportTypes {
+		key := reportCacheKey{appName: appName, reportType: reportType}
+		delete(c.entries, key)
+		c.generations[key]++
+	}
+}
+
+// Stats returns the lookups counted so far
+func (c *ReportCache) Stats() ReportCacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.stats
+}
+
+// lookup returns the cached report of the key, counting the hit or miss, and the
+// generation of the key to store a loaded report with
+func (c *ReportCache) lookup(key reportCacheKey) (any, uint64, bool) {
+	c.mu.Lock()
+	entry, found := c.entries[key]
+	if found && c.ttl > 0 && c.clock.Now().Sub(entry.cachedAt) >= c.ttl {
+		delete(c.entries, key)
+		found = false
+	}
+	if found {
+		c.stats.Hits++
+	} else {
+		c.stats.Misses++
+	}
+	generation, metrics := c.generations[key], c.metrics
+	c.mu.Unlock()
+
+	if metrics != nil {
+		metrics(key.reportType, found)
+	}
+	return entry.report, generation, found
+}
+
+// store keeps the report unless the key was invalidated since the generation was read
+func (c *ReportCache) store(key reportCacheKey, generation uint64, report any) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.generations[key] != generation {
+		return
+	}
+	c.entries[key] = reportCacheEntry{report: report, cachedAt: c.clock.Now()}
+}
+
+// CachedReport returns the cached report of the application, or loads, caches and
+// returns it. The load runs without holding the cache, and a failed load caches nothing.
+// A nil cache loads the report every time.
+func CachedReport[T any](cache *ReportCache, appName string, reportType ReportType, load func() (T, error)) (T, error) {
+	if cache == nil {
+		return load()
+	}
+	key := reportCacheKey{appName: appName, reportType: reportType}
+	cached, generation, found := cache.lookup(key)
+	if report, ok := cached.(T); found && ok {
+		return report, nil
+	}
+
+	report, err := load()
+	if err != nil {
+		return report, err
+	}
+	cache.store(key, generation, report)
+	return report, nil
+}
@@ -0,0 +1,108 @@
+package app_test
+
+import (
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	app "github.com/dokku-mcp/dokku-mcp/internal/server-plugins/app/domain"
+)
+
+var _ = Describe("ReportCache", func() {
+	var (
+		cache *app.ReportCache
+		clock *fixedClock
+		loads int
+	)
+
+	loadProcessReport := func() (*app.ProcessReport, error) {
+		loads++
+		return &app.ProcessReport{AppName: "cached-app", Deployed: true}, nil
+	}
+
+	BeforeEach(func() {
+		clock = &fixedClock{now: time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)}
+		cache = app.NewReportCache(time.Minute, clock)
+		loads = 0
+	})
+
+	It("should parse a report once until it is invalidated", func() {
+		for range 3 {
+			report, err := app.CachedReport(cache, "cached-app", app.ReportTypeProcess, loadProcessReport)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(report.Deployed).To(BeTrue())
+		}
+
+		Expect(loads).To(Equal(1))
+		Expect(cache.Stats()).To(Equal(app.ReportCacheStats{Hits: 2, Misses: 1}))
+	})
+
+	It("should invalidate the reports made stale by an event", func() {
+		_, err := app.CachedReport(cache, "cached-app", app.ReportTypeProcess, loadProcessReport)
+		Expect(err).NotTo(HaveOccurred())
+		_, err = app.CachedReport(cache, "cached-app", app.ReportTypeDomains, func() (*app.DomainReport, error) {
+			return &app.DomainReport{AppName: "cached-app"}, nil
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		cache.HandleEvent(app.NewApplicationScaledEvent("cached-app", "web", 1, 2, clock.now))
+
+		_, err = app.CachedReport(cache, "cached-app", app.ReportTypeProcess, loadProcessReport)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(loads).To(Equal(2))
+
+		_, err = app.CachedReport(cache, "cached-app", app.ReportTypeDomains, func() (*app.DomainReport, error) {
+			return nil, errors.New("the domains report should still be cached")
+		})
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should invalidate through the event dispatcher", func() {
+		dispatcher := app.NewEventDispatcher(nil)
+		cache.Subscribe(dispatcher)
+		_, err := app.CachedReport(cache, "cached-app", app.ReportTypeProcess, loadProcessReport)
+		Expect(err).NotTo(HaveOccurred())
+
+		dispatcher.Dispatch([]app.DomainEvent{app.NewApplicationRenamedEvent("cached-app", "renamed-app", clock.now)})
+		dispatcher.Wait()
+
+		_, err = app.CachedReport(cache, "cached-app", app.ReportTypeProcess, loadProcessReport)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(loads).To(Equal(2))
+	})
+
+	It("should expire reports past the TTL", func() {
+		_, err := app.CachedReport(cache, "cached-app", app.ReportTypeProcess, loadProcessReport)
+		Expect(err).NotTo(HaveOccurred())
+
+		clock.now = clock.now.Add(time.Minute)
+		_, err = app.CachedReport(cache, "cached-app", app.ReportTypeProcess, loadProcessReport)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(loads).To(Equal(2))
+	})
+
+	It("should cache nothing when the load fails and report every lookup", func() {
+		var lookups []bool
+		cache.SetMetrics(func(reportType app.ReportType, hit bool) {
+			Expect(reportType).To(Equal(app.ReportTypeGit))
+			lookups = append(lookups, hit)
+		})
+
+		_, err := app.CachedReport(cache, "cached-app", app.ReportTypeGit, func() (*app.GitReport, error) {
+			return nil, errors.New("git:report failed")
+		})
+		Expect(err).To(MatchError("git:report failed"))
+		_, err = app.CachedReport(cache, "cached-app", app.ReportTypeGit, func() (*app.GitReport, error) {
+			return &app.GitReport{}, nil
+		})
+		Expect(err).NotTo(HaveOccurred())
+		_, err = app.CachedReport(cache, "cached-app", app.ReportTypeGit, func() (*app.GitReport, error) {
+			return &app.GitReport{}, nil
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(lookups).To(Equal([]bool{false, false, true}))
+	})
+})
@@ -0,0 +1,184 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ReportLoadResult holds the applications hydrated from a combined report,
+// along with a warning for every block that had to be skipped
+type ReportLoadResult struct {
+	Applications []*Application `json:"applications"`
+	Warnings     []string       `json:"warnings,omitempty"`
+}
+
+// reportBlock gathers the report sections printed for one application,
+// keyed by plugin name ("apps", "domains", "ps", "buildpacks", ...)
+type reportBlock struct {
+	appName  string
+	sections map[string]*strings.Builder
+}
+
+// section returns the raw text of a plugin section, if it was reported
+func (b *reportBlock) section(plugin string) (string, bool) {
+	builder, ok := b.sections[plugin]
+	if !ok {
+		return "", false
+	}
+	return builder.String(), true
+}
+
+// LoadApplicationsFromReport hydrates every application found in the output of a
+// combined report, e.g. `dokku report` or concatenated apps, domains, buildpacks and
+// ps reports, in a single pass. Sections may come in any order and any of them may
+// be missing. An application whose sections cannot be parsed is skipped with a
+// warning rather than failing the whole batch. The applications carry no events,
+// they describe observed state.
+func LoadApplicationsFromReport(raw string) *ReportLoadResult {
+	result := &ReportLoadResult{
+		Applications: make([]*Application, 0),
+		Warnings:     make([]string, 0),
+	}
+
+	for _, block := range splitReportBlocks(raw) {
+		application, err := loadApplicationFromBlock(block)
+		if err != nil {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("skipping application %s: %v", block.appName, err))
+			continue
+		}
+		result.Applications = append(result.Applications, application)
+	}
+
+	return result
+}
+
+// splitReportBlocks groups the report sections by application, keeping the order
+// in which the applications first appear
+func splitReportBlocks(raw string) []*reportBlock {
+	var blocks []*reportBlock
+	byApp := make(map[string]*reportBlock)
+
+	var current *strings.Builder
+	for _, line := range strings.Split(raw, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if !strings.HasPrefix(trimmed, "=====>") {
+			if current != nil {
+				current.WriteString(line)
+				current.WriteString("\n")
+			}
+			continue
+		}
+
+		current = nil
+		fields := strings.Fields(strings.TrimPrefix(trimmed, "=====>"))
+		if len(fields) < 2 {
+			continue
+		}
+
+		block, exists := byApp[fields[0]]
+		if !exists {
+			block = &reportBlock{appName: fields[0], sections: make(map[string]*strings.Builder)}
+			byApp[fields[0]] = block
+			blocks = append(blocks, block)
+		}
+
+		// The section keeps its header so the single-report parsers can read it
+		plugin := strings.ToLower(fields[1])
+		current, exists = block.sections[plugin]
+		if !exists {
+			current = &strings.Builder{}
+			block.sections[plugin] = current
+		}
+		current.WriteString(trimmed)
+		current.WriteString("\n")
+	}
+
+	return blocks
+}
+
+func loadApplicationFromBlock(block *reportBlock) (*Application, error) {
+	state := StateExists
+	var processReport *ProcessReport
+	if section, ok := block.section("ps"); ok {
+		report, err := ParseProcessReport(section)
+		if err != nil {
+			return nil, err
+		}
+		processReport = report
+		state = processReport.observedState()
+	}
+
+	application, err := NewApplicationWithState(block.appName, state)
+	if err != nil {
+		return nil, err
+	}
+
+	if section, ok := block.section("domains"); ok {
+		report, err := ParseDomainReport(section)
+		if err != nil {
+			return nil, err
+		}
+		if err := application.SetGlobalDomains(report.GlobalDomains); err != nil {
+			return nil, err
+		}
+		for _, domain := range report.DomainNames() {
+			if err := application.AddDomain(domain); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if section, ok := block.section("buildpacks"); ok {
+		buildpacks, _ := parseReportedBuildpacks(section)
+		for _, buildpack := range buildpacks {
+			if err := application.AddBuildpack(buildpack, 0); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if processReport != nil {
+		if _, err := application.ReconcileProcesses(processReport); err != nil {
+			return nil, err
+		}
+	}
+
+	application.ClearEvents()
+	return application, nil
+}
+
+// observedState derives the application state from what Dokku scheduled
+func (r *ProcessReport) observedState() StateValue {
+	if !r.Deployed {
+		return StateExists
+	}
+	for _, reported := range r.Processes {
+		if reported.Running > 0 {
+			return StateRunning
+		}
+	}
+	return StateStopped
+}
+
+// ParseBuildpacksReport parses the output of buildpacks:report for a single application
+// into its buildpacks, in order
+func ParseBuildpacksReport(raw string) ([]string, error) {
+	buildpacks, found := parseReportedBuildpacks(raw)
+	if !found {
+		return nil, fmt.Errorf("invalid buildpacks report: no buildpacks list")
+	}
+	return buildpacks, nil
+}
+
+// parseReportedBuildpacks reads the comma separated "Buildpacks list" of a buildpacks
+// report, telling whether the list was reported
+func parseReportedBuildpacks(section string) ([]string, bool) {
+	for _, line := range strings.Split(section, "\n") {
+		key, value, ok := strings.Cut(line, ":")
+		if !ok || strings.ToLower(strings.TrimSpace(key)) != "buildpacks list" {
+			continue
+		}
+		return reportValues(strings.ReplaceAll(strings.TrimSpace(value), ",", " ")), true
+	}
+	return []string{}, false
+}
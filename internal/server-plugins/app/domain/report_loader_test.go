@@ -0,0 +1,88 @@
+package app_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	app "github.com/dokku-mcp/dokku-mcp/internal/server-plugins/app/domain"
+)
+
+var _ = Describe("LoadApplicationsFromReport", func() {
+	It("should hydrate every application of a combined report", func() {
+		result := app.LoadApplicationsFromReport(`=====> api app information
+       App deploy source:             git
+=====> api domains information
+       Domains app enabled:           true
+       Domains app vhosts:            api.example.com www.example.com
+=====> api buildpacks information
+       Buildpacks list:               https://github.com/heroku/heroku-buildpack-nodejs.git,https://github.com/heroku/heroku-buildpack-python.git
+=====> api ps information
+       Deployed:                      true
+       Processes:                     3
+       Status web 1:                  running (CID: 1a2b3c)
+       Status web 2:                  running (CID: 4d5e6f)
+       Status worker 1:               exited (CID: 7a8b9c)
+=====> blog app information
+       App deploy source:
+=====> blog ps information
+       Deployed:                      false
+       Processes:                     0`)
+
+		Expect(result.Warnings).To(BeEmpty())
+		Expect(result.Applications).To(HaveLen(2))
+
+		api := result.Applications[0]
+		Expect(api.Name().Value()).To(Equal("api"))
+		Expect(api.State().Value()).To(Equal(app.StateRunning))
+		Expect(api.GetDomains()).To(Equal([]string{"api.example.com", "www.example.com"}))
+		Expect(api.GetBuildpacks()).To(HaveLen(2))
+		Expect(api.GetProcessScale("web")).To(Equal(2))
+		Expect(api.GetProcessScale("worker")).To(Equal(1))
+		Expect(api.GetEvents()).To(BeEmpty())
+
+		blog := result.Applications[1]
+		Expect(blog.Name().Value()).To(Equal("blog"))
+		Expect(blog.State().Value()).To(Equal(app.StateExists))
+		Expect(blog.GetDomains()).To(BeEmpty())
+	})
+
+	It("should load applications with partial data", func() {
+		result := app.LoadApplicationsFromReport(`=====> worker domains information
+       Domains app vhosts:            none`)
+
+		Expect(result.Warnings).To(BeEmpty())
+		Expect(result.Applications).To(HaveLen(1))
+		Expect(result.Applications[0].State().Value()).To(Equal(app.StateExists))
+	})
+
+	It("should mark a deployed application without running containers as stopped", func() {
+		result := app.LoadApplicationsFromReport(`=====> api ps information
+       Deployed:                      true
+       Status web 1:                  exited (CID: 1a2b3c)`)
+
+		Expect(result.Applications).To(HaveLen(1))
+		Expect(result.Applications[0].State().Value()).To(Equal(app.StateStopped))
+	})
+
+	It("should skip malformed blocks with a warning", func() {
+		result := app.LoadApplicationsFromReport(`=====> api domains information
+       Domains app vhosts:            not_a_valid_domain!
+=====> Invalid_Name app information
+       App deploy source:             git
+=====> blog ps information
+       Deployed:                      true
+       Status web 1:                  running (CID: 1a2b3c)`)
+
+		Expect(result.Applications).To(HaveLen(1))
+		Expect(result.Applications[0].Name().Value()).To(Equal("blog"))
+		Expect(result.Warnings).To(HaveLen(2))
+		Expect(result.Warnings[0]).To(ContainSubstring("api"))
+		Expect(result.Warnings[1]).To(ContainSubstring("Invalid_Name"))
+	})
+
+	It("should return nothing for an empty report", func() {
+		result := app.LoadApplicationsFromReport("")
+		Expect(result.Applications).To(BeEmpty())
+		Expect(result.Warnings).To(BeEmpty())
+	})
+})
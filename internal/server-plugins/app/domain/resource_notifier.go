@@ -0,0 +1,227 @@
+package app
+
+import (
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultResourceChangeBuffer is the number of changes a subscriber may lag behind
+// before the oldest ones are dropped
+const DefaultResourceChangeBuffer = 16
+
+// ResourceChange tells that a resource changed and should be read again
+type ResourceChange struct {
+	// URI is the canonical URI of the resource, see ResourceURI.String
+	URI        string
+	AppName    string
+	EventType  string
+	OccurredAt time.Time
+}
+
+// resourceChanges lists, by event type, the resources an event changes besides the
+// application itself. Events missing here only change dokku://apps/{name}. A rename
+// changes every resource of both names and is handled apart.
+var resourceChanges = map[string][]AppResourceKind{
+	"application.created":                        {AppResourceList},
+	"application.destroyed":                      {AppResourceList, AppResourceConfig},
+	"application.deployed":                       {AppResourceList},
+	"application.deployed.image":                 {AppResourceList},
+	"application.rebuilt":                        {AppResourceList},
+	"application.deployment.completed":           {AppResourceList},
+	"application.deployment.failed":              {AppResourceList},
+	"application.undeployed":                     {AppResourceList},
+	"application.state.changed":                  {AppResourceList},
+	"application.label.changed":                  {AppResourceList},
+	"application.scaled":                         {AppResourceConfig},
+	"application.domain.added":                   {AppResourceConfig},
+	"application.domain.removed":                 {AppResourceConfig},
+	"application.buildpack.changed":              {AppResourceConfig},
+	"application.buildpack.added":                {AppResourceConfig},
+	"application.buildpack.removed":              {AppResourceConfig},
+	"application.process.limits.changed":         {AppResourceConfig},
+	"application.process.restart_policy.changed": {AppResourceConfig},
+	"application.healthchecks.changed":           {AppResourceConfig},
+	"application.runasuser.changed":              {AppResourceConfig},
+	"application.git.changed":                    {AppResourceConfig},
+	"application.maintenance.enabled":            {AppResourceConfig},
+	"application.maintenance.disabled":           {AppResourceConfig},
+	"application.ports.changed":                  {AppResourceConfig},
+	"application.scheduled_task.added":           {AppResourceConfig},
+	"application.scheduled_task.removed":         {AppResourceConfig},
+	"application.docker_option.added":            {AppResourceConfig},
+	"application.docker_option.removed":          {AppResourceConfig},
+	"application.configuration.reconciled":       {AppResourceConfig},
+}
+
+type resourceSubscription struct {
+	changes chan ResourceChange
+	closed  bool
+}
+
+// ResourceNotifier turns the dispatched events into changes of the MCP resources, for
+// clients to read again the views they show. A subscriber lagging behind loses its
+// oldest changes rather than blocking the dispatch.
+type ResourceNotifier struct {
+	buffer int
+
+	mu          sync.Mutex
+	subscribers map[string]map[*resourceSubscription]struct{}
+	all         map[*resourceSubscription]struct{}
+	dropped     uint64
+}
+
+// NewResourceNotifier creates a notifier whose subscribers buffer the given number of
+// changes, DefaultResourceChangeBuffer if it is less than one
+func NewResourceNotifier(buffer int) *ResourceNotifier {
+	if buffer < 1 {
+		buffer = DefaultResourceChangeBuffer
+	}
+	return &ResourceNotifier{
+		buffer:      buffer,
+		subscribers: make(map[string]map[*resourceSubscription]struct{}),
+		all:         make(map[*resourceSubscription]struct{}),
+	}
+}
+
+// Subscribe publishes the changes of the events the dispatcher delivers
+func (n *ResourceNotifier) Subscribe(dispatcher *EventDispatcher) {
+	dispatcher.Subscribe(AllEventTypes, n.HandleEvent)
+}
+
+// SubscribeResource returns the changes of a resource, e.g. dokku://apps/my-app/config,
+// and the function ending the subscription, which closes the channel. The query of
+// the URI is ignored and dokku://apps/list is the application collection.
+func (n *ResourceNotifier) SubscribeResource(uri string) (<-chan ResourceChange, func()) {
+	key := canonicalResourceURI(uri)
+	subscription := &resourceSubscription{changes: make(chan ResourceChange, n.buffer)}
+
+	n.mu.Lock()
+	if n.subscribers[key] == nil {
+		n.subscribers[key] = make(map[*resourceSubscription]struct{})
+	}
+	n.subscribers[key][subscription] = struct{}{}
+	n.mu.Unlock()
+
+	return subscription.changes, func() {
+		n.mu.Lock()
+		defer n.mu.Unlock()
+		delete(n.subscribers[key], subscription)
+		if len(n.subscribers[key]) == 0 {
+			delete(n.subscribers, key)
+		}
+		n.close(subscription)
+	}
+}
+
+// SubscribeAllResources returns the changes of every resource, e.g. to forward them
+// to the MCP clients, and the function ending the subscription
+func (n *ResourceNotifier) SubscribeAllResources() (<-chan ResourceChange, func()) {
+	subscription := &resourceSubscription{changes: make(chan ResourceChange, n.buffer)}
+
+	n.mu.Lock()
+	n.all[subscription] = struct{}{}
+	n.mu.Unlock()
+
+	return subscription.changes, func() {
+		n.mu.Lock()
+		defer n.mu.Unlock()
+		delete(n.all, subscription)
+		n.close(subscription)
+	}
+}
+
+// HandleEvent publishes the changes of the resources the event touches
+func (n *ResourceNotifier) HandleEvent(event DomainEvent) {
+	if renamed, ok := event.(*ApplicationRenamedEvent); ok {
+		kinds := []AppResourceKind{AppResourceDetail, AppResourceConfig, AppResourceLogs}
+		n.publish(event, "", AppResourceList)
+		n.publish(event, renamed.OldName(), kinds...)
+		n.publish(event, renamed.NewName(), kinds...)
+		return
+	}
+	n.publish(event, event.AggregateID(), append([]AppResourceKind{AppResourceDetail}, resourceChanges[event.EventType()]...)...)
+}
+
+// Dropped returns the number of changes lost by lagging subscribers
+func (n *ResourceNotifier) Dropped() uint64 {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.dropped
+}
+
+// publish sends a change of each resource kind of the application, the collection
+// being published whatever the application
+func (n *ResourceNotifier) publish(event DomainEvent, appName string, kinds ...AppResourceKind) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	for _, kind := range kinds {
+		uri, ok := resourceURIOf(appName, kind)
+		if !ok {
+			continue
+		}
+		change := ResourceChange{
+			URI:        uri,
+			AppName:    event.AggregateID(),
+			EventType:  event.EventType(),
+			OccurredAt: event.OccurredAt(),
+		}
+		for subscription := range n.subscribers[uri] {
+			n.send(subscription, change)
+		}
+		for subscription := range n.all {
+			n.send(subscription, change)
+		}
+	}
+}
+
+// send delivers the change without blocking, dropping the oldest buffered change of a
+// full subscriber. Only publish sends, under the lock, so the second send never blocks.
+func (n *ResourceNotifier) send(subscription *resourceSubscription, change ResourceChange) {
+	select {
+	case subscription.changes <- change:
+		return
+	default:
+	}
+	select {
+	case <-subscription.changes:
+		n.dropped++
+	default:
+	}
+	subscription.changes <- change
+}
+
+func (n *ResourceNotifier) close(subscription *resourceSubscription) {
+	if !subscription.closed {
+		subscription.closed = true
+		close(subscription.changes)
+	}
+}
+
+// resourceURIOf returns the canonical URI of a resource of the application
+func resourceURIOf(appName string, kind AppResourceKind) (string, bool) {
+	if kind == AppResourceList {
+		return AppsResourceURI().String(), true
+	}
+	name, err := NewApplicationName(appName)
+	if err != nil {
+		return "", false
+	}
+	uri, err := AppResourceURI(name, kind)
+	if err != nil {
+		return "", false
+	}
+	return uri.String(), true
+}
+
+// canonicalResourceURI returns the subscription key of a URI, which is kept as is
+// when it is not an application resource
+func canonicalResourceURI(raw string) string {
+	uri, err := ParseResourceURI(raw)
+	if err != nil {
+		return strings.TrimSpace(raw)
+	}
+	uri.Query = nil
+	return uri.String()
+}
@@ -0,0 +1,124 @@
+package app_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	app "github.com/dokku-mcp/dokku-mcp/internal/server-plugins/app/domain"
+)
+
+var _ = Describe("ResourceNotifier", func() {
+	var (
+		notifier *app.ResourceNotifier
+		now      time.Time
+	)
+
+	BeforeEach(func() {
+		notifier = app.NewResourceNotifier(2)
+		now = time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	})
+
+	It("should notify the config of a scaled application", func() {
+		changes, unsubscribe := notifier.SubscribeResource("dokku://apps/my-app/config")
+		defer unsubscribe()
+
+		notifier.HandleEvent(app.NewApplicationScaledEvent("my-app", "web", 1, 2, now))
+
+		Expect(changes).To(Receive(Equal(app.ResourceChange{
+			URI:        "dokku://apps/my-app/config",
+			AppName:    "my-app",
+			EventType:  "application.scaled",
+			OccurredAt: now,
+		})))
+		Expect(changes).NotTo(Receive())
+	})
+
+	It("should only notify the resources an event touches", func() {
+		config, unsubscribeConfig := notifier.SubscribeResource("dokku://apps/my-app/config")
+		defer unsubscribeConfig()
+		other, unsubscribeOther := notifier.SubscribeResource("dokku://apps/other-app")
+		defer unsubscribeOther()
+		detail, unsubscribeDetail := notifier.SubscribeResource("dokku://apps/my-app")
+		defer unsubscribeDetail()
+
+		notifier.HandleEvent(app.NewApplicationCreatedEvent("my-app", now))
+
+		Expect(detail).To(Receive())
+		Expect(config).NotTo(Receive())
+		Expect(other).NotTo(Receive())
+	})
+
+	It("should treat the list resource and its query as the collection", func() {
+		changes, unsubscribe := notifier.SubscribeResource("dokku://apps/list?limit=10")
+		defer unsubscribe()
+
+		notifier.HandleEvent(app.NewApplicationDestroyedEvent("my-app", now))
+
+		var change app.ResourceChange
+		Expect(changes).To(Receive(&change))
+		Expect(change.URI).To(Equal("dokku://apps"))
+	})
+
+	It("should notify both names of a renamed application", func() {
+		oldName, unsubscribeOld := notifier.SubscribeResource("dokku://apps/old-app/config")
+		defer unsubscribeOld()
+		newName, unsubscribeNew := notifier.SubscribeResource("dokku://apps/new-app")
+		defer unsubscribeNew()
+
+		notifier.HandleEvent(app.NewApplicationRenamedEvent("old-app", "new-app", now))
+
+		Expect(oldName).To(Receive())
+		Expect(newName).To(Receive())
+	})
+
+	It("should drop the oldest changes of a lagging subscriber", func() {
+		changes, unsubscribe := notifier.SubscribeResource("dokku://apps/my-app/config")
+		defer unsubscribe()
+
+		for scale := range 4 {
+			notifier.HandleEvent(app.NewApplicationScaledEvent("my-app", "web", scale, scale+1, now.Add(time.Duration(scale)*time.Second)))
+		}
+
+		var change app.ResourceChange
+		Expect(changes).To(Receive(&change))
+		Expect(change.OccurredAt).To(Equal(now.Add(2 * time.Second)))
+		Expect(changes).To(Receive(&change))
+		Expect(change.OccurredAt).To(Equal(now.Add(3 * time.Second)))
+		Expect(notifier.Dropped()).To(Equal(uint64(2)))
+	})
+
+	It("should close the channel once unsubscribed", func() {
+		changes, unsubscribe := notifier.SubscribeResource("dokku://apps/my-app")
+		unsubscribe()
+		unsubscribe()
+
+		notifier.HandleEvent(app.NewApplicationCreatedEvent("my-app", now))
+		Eventually(changes).Should(BeClosed())
+	})
+
+	It("should publish the changes of every resource to a global subscriber", func() {
+		changes, unsubscribe := notifier.SubscribeAllResources()
+		defer unsubscribe()
+
+		notifier.HandleEvent(app.NewApplicationScaledEvent("my-app", "web", 1, 2, now))
+
+		var first, second app.ResourceChange
+		Expect(changes).To(Receive(&first))
+		Expect(changes).To(Receive(&second))
+		Expect([]string{first.URI, second.URI}).To(ConsistOf("dokku://apps/my-app", "dokku://apps/my-app/config"))
+	})
+
+	It("should publish the events the dispatcher delivers", func() {
+		dispatcher := app.NewEventDispatcher(nil)
+		notifier.Subscribe(dispatcher)
+		changes, unsubscribe := notifier.SubscribeResource("dokku://apps/my-app/config")
+		defer unsubscribe()
+
+		dispatcher.Dispatch([]app.DomainEvent{app.NewApplicationScaledEvent("my-app", "web", 1, 2, now)})
+		dispatcher.Wait()
+
+		Expect(changes).To(Receive())
+	})
+})
@@ -0,0 +1,125 @@
+package app
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// ResourceURIScheme is the scheme of every MCP resource of the server
+const ResourceURIScheme = "dokku"
+
+// appsResourceHost is the authority of the application resources, as in dokku://apps
+const appsResourceHost = "apps"
+
+// legacyListSegment is the path of the paginated list resource, dokku://apps/list,
+// which reads as the application collection rather than an app named "list"
+const legacyListSegment = "list"
+
+// AppResourceKind tells which resource of the applications a URI addresses
+type AppResourceKind string
+
+const (
+	// AppResourceList addresses the application collection, dokku://apps
+	AppResourceList AppResourceKind = "list"
+	// AppResourceDetail addresses one application, dokku://apps/{name}
+	AppResourceDetail AppResourceKind = "detail"
+	// AppResourceConfig addresses the configuration of one application, dokku://apps/{name}/config
+	AppResourceConfig AppResourceKind = "config"
+	// AppResourceLogs addresses the logs of one application, dokku://apps/{name}/logs
+	AppResourceLogs AppResourceKind = "logs"
+)
+
+// ResourceURI is a parsed application resource URI. AppName is nil for the collection.
+type ResourceURI struct {
+	Kind    AppResourceKind
+	AppName *ApplicationName
+	// Query holds the query parameters, such as the filters of the collection
+	Query url.Values
+}
+
+// AppsResourceURI returns the URI of the application collection
+func AppsResourceURI() ResourceURI {
+	return ResourceURI{Kind: AppResourceList, Query: url.Values{}}
+}
+
+// AppResourceURI returns the URI of a resource of one application
+func AppResourceURI(name *ApplicationName, kind AppResourceKind) (ResourceURI, error) {
+	if name == nil {
+		return ResourceURI{}, fmt.Errorf("application name cannot be null")
+	}
+	switch kind {
+	case AppResourceDetail, AppResourceConfig, AppResourceLogs:
+	default:
+		return ResourceURI{}, fmt.Errorf("invalid application resource kind '%s'", kind)
+	}
+	return ResourceURI{Kind: kind, AppName: name, Query: url.Values{}}, nil
+}
+
+// String returns the canonical form of the URI, e.g. dokku://apps/my-app/config
+func (u ResourceURI) String() string {
+	uri := ResourceURIScheme + "://" + appsResourceHost
+	if u.AppName != nil {
+		uri += "/" + u.AppName.Value()
+		if u.Kind == AppResourceConfig || u.Kind == AppResourceLogs {
+			uri += "/" + string(u.Kind)
+		}
+	}
+	if len(u.Query) > 0 {
+		uri += "?" + u.Query.Encode()
+	}
+	return uri
+}
+
+// ParseResourceURI validates an application resource URI and extracts the app name.
+// The name must be in its canonical form, so a URI addresses a single resource and
+// encoded traversals such as %2e%2e are rejected along with any invalid name.
+func ParseResourceURI(raw string) (ResourceURI, error) {
+	parsed, err := url.Parse(raw)
+	if err != nil {
+		return ResourceURI{}, fmt.Errorf("invalid resource URI: %w", err)
+	}
+	if parsed.Scheme != ResourceURIScheme || parsed.Host != appsResourceHost {
+		return ResourceURI{}, fmt.Errorf("invalid resource URI %s: not an application resource", raw)
+	}
+	if parsed.User != nil || parsed.Fragment != "" || parsed.Opaque != "" {
+		return ResourceURI{}, fmt.Errorf("invalid resource URI %s: unexpected user info or fragment", raw)
+	}
+
+	// Valid paths never need escaping, an escaped one hides a separator or a traversal
+	if strings.Contains(parsed.EscapedPath(), "%") {
+		return ResourceURI{}, fmt.Errorf("invalid resource URI %s: escaped path", raw)
+	}
+
+	uri := ResourceURI{Kind: AppResourceList, Query: parsed.Query()}
+	path := strings.TrimSuffix(parsed.Path, "/")
+	if path == "" || path == "/"+legacyListSegment {
+		return uri, nil
+	}
+
+	segments := strings.Split(strings.TrimPrefix(path, "/"), "/")
+	if len(segments) > 2 {
+		return ResourceURI{}, fmt.Errorf("invalid resource URI %s: too many path segments", raw)
+	}
+
+	name, err := NewApplicationName(segments[0])
+	if err != nil {
+		return ResourceURI{}, fmt.Errorf("invalid resource URI %s: %w", raw, err)
+	}
+	if name.Value() != segments[0] {
+		return ResourceURI{}, fmt.Errorf("invalid resource URI %s: application name must be %s", raw, name.Value())
+	}
+	uri.AppName = name
+	uri.Kind = AppResourceDetail
+
+	if len(segments) == 2 {
+		switch kind := AppResourceKind(segments[1]); kind {
+		case AppResourceConfig, AppResourceLogs:
+			uri.Kind = kind
+		default:
+			return ResourceURI{}, fmt.Errorf("invalid resource URI %s: unknown resource %s", raw, segments[1])
+		}
+	}
+
+	return uri, nil
+}
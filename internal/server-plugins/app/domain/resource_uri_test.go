@@ -0,0 +1,71 @@
+package app_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	app "github.com/dokku-mcp/dokku-mcp/internal/server-plugins/app/domain"
+)
+
+var _ = Describe("ResourceURI", func() {
+	It("should build the canonical URIs", func() {
+		name := app.MustNewApplicationName("my-app")
+		Expect(app.AppsResourceURI().String()).To(Equal("dokku://apps"))
+
+		for kind, expected := range map[app.AppResourceKind]string{
+			app.AppResourceDetail: "dokku://apps/my-app",
+			app.AppResourceConfig: "dokku://apps/my-app/config",
+			app.AppResourceLogs:   "dokku://apps/my-app/logs",
+		} {
+			uri, err := app.AppResourceURI(name, kind)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(uri.String()).To(Equal(expected))
+		}
+
+		_, err := app.AppResourceURI(name, app.AppResourceList)
+		Expect(err).To(HaveOccurred())
+	})
+
+	DescribeTable("should parse valid URIs",
+		func(raw string, kind app.AppResourceKind, appName string) {
+			uri, err := app.ParseResourceURI(raw)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(uri.Kind).To(Equal(kind))
+			if appName == "" {
+				Expect(uri.AppName).To(BeNil())
+			} else {
+				Expect(uri.AppName.Value()).To(Equal(appName))
+			}
+		},
+		Entry("collection", "dokku://apps", app.AppResourceList, ""),
+		Entry("legacy list", "dokku://apps/list?state=running", app.AppResourceList, ""),
+		Entry("detail", "dokku://apps/my-app", app.AppResourceDetail, "my-app"),
+		Entry("config", "dokku://apps/my-app/config", app.AppResourceConfig, "my-app"),
+		Entry("logs", "dokku://apps/my-app/logs", app.AppResourceLogs, "my-app"),
+	)
+
+	It("should keep the query of the collection", func() {
+		uri, err := app.ParseResourceURI("dokku://apps?state=running&limit=10")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(uri.Query.Get("state")).To(Equal("running"))
+		Expect(uri.String()).To(Equal("dokku://apps?limit=10&state=running"))
+	})
+
+	DescribeTable("should reject malformed URIs",
+		func(raw string) {
+			_, err := app.ParseResourceURI(raw)
+			Expect(err).To(HaveOccurred())
+		},
+		Entry("another scheme", "https://apps/my-app"),
+		Entry("another resource", "dokku://core/plugins"),
+		Entry("traversal", "dokku://apps/../core"),
+		Entry("encoded traversal", "dokku://apps/%2e%2e/config"),
+		Entry("encoded slash", "dokku://apps/my-app%2Fconfig"),
+		Entry("invalid name", "dokku://apps/my_app"),
+		Entry("uppercase name", "dokku://apps/My-App"),
+		Entry("unknown resource", "dokku://apps/my-app/secrets"),
+		Entry("too many segments", "dokku://apps/my-app/config/extra"),
+		Entry("empty segment", "dokku://apps//config"),
+		Entry("fragment", "dokku://apps/my-app#config"),
+	)
+})
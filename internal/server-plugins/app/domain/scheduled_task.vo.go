@@ -0,0 +1,133 @@
+package app
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// cronMacros are the schedule shorthands Dokku accepts in place of the five fields
+var cronMacros = []string{"@yearly", "@annually", "@monthly", "@weekly", "@daily", "@midnight", "@hourly"}
+
+// cronField bounds a field of a cron expression. Names, when set, are accepted in place
+// of the numbers starting at the lower bound, e.g. jan for 1 or sun for 0.
+type cronField struct {
+	name  string
+	min   int
+	max   int
+	names []string
+}
+
+var cronFields = []cronField{
+	{name: "minute", min: 0, max: 59},
+	{name: "hour", min: 0, max: 23},
+	{name: "day of month", min: 1, max: 31},
+	{name: "month", min: 1, max: 12, names: []string{"jan", "feb", "mar", "apr", "may", "jun", "jul", "aug", "sep", "oct", "nov", "dec"}},
+	// Both 0 and 7 are sunday
+	{name: "day of week", min: 0, max: 7, names: []string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}},
+}
+
+// ScheduledTask is a command run on a schedule, as declared in the cron section of app.json
+type ScheduledTask struct {
+	schedule string
+	command  string
+}
+
+// NewScheduledTask creates a scheduled task from a five field cron expression, or one of
+// the @daily style macros, and the command to run
+func NewScheduledTask(schedule, command string) (*ScheduledTask, error) {
+	schedule = strings.Join(strings.Fields(schedule), " ")
+	command = strings.TrimSpace(command)
+	if command == "" {
+		return nil, fmt.Errorf("scheduled task command cannot be empty")
+	}
+	if err := validateCronSchedule(schedule); err != nil {
+		return nil, err
+	}
+	return &ScheduledTask{schedule: schedule, command: command}, nil
+}
+
+// Schedule returns the cron expression, its fields separated by single spaces
+func (t *ScheduledTask) Schedule() string {
+	return t.schedule
+}
+
+// Command returns the command run on schedule
+func (t *ScheduledTask) Command() string {
+	return t.command
+}
+
+// Equal checks if both tasks run the same command on the same schedule
+func (t *ScheduledTask) Equal(other *ScheduledTask) bool {
+	if t == nil || other == nil {
+		return t == other
+	}
+	return t.schedule == other.schedule && t.command == other.command
+}
+
+// String returns the task in crontab form
+func (t *ScheduledTask) String() string {
+	return t.schedule + " " + t.command
+}
+
+func validateCronSchedule(schedule string) error {
+	if slices.Contains(cronMacros, strings.ToLower(schedule)) {
+		return nil
+	}
+
+	fields := strings.Fields(schedule)
+	if len(fields) != len(cronFields) {
+		return fmt.Errorf("invalid cron schedule '%s': expected 5 fields, got %d", schedule, len(fields))
+	}
+	for i, field := range fields {
+		if err := cronFields[i].validate(field); err != nil {
+			return fmt.Errorf("invalid cron schedule '%s': %w", schedule, err)
+		}
+	}
+	return nil
+}
+
+// validate checks a field made of comma separated items, each a wildcard, a value or
+// a range, optionally followed by a /step
+func (f cronField) validate(value string) error {
+	for _, item := range strings.Split(value, ",") {
+		base, step, hasStep := strings.Cut(item, "/")
+		if hasStep {
+			if n, err := strconv.Atoi(step); err != nil || n < 1 {
+				return fmt.Errorf("invalid step '%s' in %s field", step, f.name)
+			}
+		}
+		if base == "*" {
+			continue
+		}
+
+		low, high, isRange := strings.Cut(base, "-")
+		lowValue, err := f.parse(low)
+		if err != nil {
+			return err
+		}
+		if !isRange {
+			continue
+		}
+		highValue, err := f.parse(high)
+		if err != nil {
+			return err
+		}
+		if lowValue > highValue {
+			return fmt.Errorf("invalid range '%s' in %s field", base, f.name)
+		}
+	}
+	return nil
+}
+
+func (f cronField) parse(value string) (int, error) {
+	if index := slices.Index(f.names, strings.ToLower(value)); index >= 0 {
+		return f.min + index, nil
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n < f.min || n > f.max {
+		return 0, fmt.Errorf("invalid value '%s' in %s field, must be between %d and %d", value, f.name, f.min, f.max)
+	}
+	return n, nil
+}
@@ -0,0 +1,48 @@
+package app_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	app "github.com/dokku-mcp/dokku-mcp/internal/server-plugins/app/domain"
+)
+
+var _ = Describe("ScheduledTask", func() {
+	DescribeTable("NewScheduledTask schedule validation",
+		func(schedule string, valid bool) {
+			task, err := app.NewScheduledTask(schedule, "npm run cleanup")
+			if !valid {
+				Expect(err).To(HaveOccurred())
+				return
+			}
+			Expect(err).NotTo(HaveOccurred())
+			Expect(task.Command()).To(Equal("npm run cleanup"))
+		},
+		Entry("every minute", "* * * * *", true),
+		Entry("nightly", "0 3 * * *", true),
+		Entry("steps, ranges and lists", "*/15 9-17 1,15 * 1-5", true),
+		Entry("month and day names", "0 0 1 jan,jul mon-fri", true),
+		Entry("sunday as 7", "0 0 * * 7", true),
+		Entry("macro", "@daily", true),
+		Entry("four fields", "0 3 * *", false),
+		Entry("six fields", "0 0 3 * * *", false),
+		Entry("minute out of range", "60 * * * *", false),
+		Entry("day of month zero", "0 0 0 * *", false),
+		Entry("reversed range", "0 17-9 * * *", false),
+		Entry("zero step", "*/0 * * * *", false),
+		Entry("unknown macro", "@often", false),
+		Entry("empty schedule", "", false),
+	)
+
+	It("should normalize the spacing of the schedule", func() {
+		task, err := app.NewScheduledTask("  0   3 * *  * ", "npm run cleanup")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(task.Schedule()).To(Equal("0 3 * * *"))
+		Expect(task.String()).To(Equal("0 3 * * * npm run cleanup"))
+	})
+
+	It("should require a command", func() {
+		_, err := app.NewScheduledTask("@hourly", "  ")
+		Expect(err).To(HaveOccurred())
+	})
+})
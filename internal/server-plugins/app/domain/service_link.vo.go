@@ -0,0 +1,129 @@
+package app
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// ServiceTypeApp is the service type of a link where an application consumes another application
+const ServiceTypeApp = "app"
+
+var serviceNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+// ServiceLink represents a service, such as a datastore, linked to an application
+type ServiceLink struct {
+	serviceType string
+	serviceName string
+	appName     string
+}
+
+// NewServiceLink creates a link between a service and the application consuming it
+func NewServiceLink(serviceType, serviceName, appName string) (*ServiceLink, error) {
+	serviceType = strings.TrimSpace(strings.ToLower(serviceType))
+	serviceName = strings.TrimSpace(strings.ToLower(serviceName))
+
+	if !serviceNamePattern.MatchString(serviceType) {
+		return nil, fmt.Errorf("invalid service type: %q", serviceType)
+	}
+	if !serviceNamePattern.MatchString(serviceName) {
+		return nil, fmt.Errorf("invalid service name: %q", serviceName)
+	}
+
+	name, err := NewApplicationName(appName)
+	if err != nil {
+		return nil, fmt.Errorf("invalid service link: %w", err)
+	}
+
+	return &ServiceLink{
+		serviceType: serviceType,
+		serviceName: serviceName,
+		appName:     name.Value(),
+	}, nil
+}
+
+// ServiceType returns the type of the linked service, e.g. postgres
+func (l *ServiceLink) ServiceType() string { return l.serviceType }
+
+// ServiceName returns the name of the linked service
+func (l *ServiceLink) ServiceName() string { return l.serviceName }
+
+// AppName returns the name of the application consuming the service
+func (l *ServiceLink) AppName() string { return l.appName }
+
+// ServiceID identifies the linked service as type:name
+func (l *ServiceLink) ServiceID() string {
+	return l.serviceType + ":" + l.serviceName
+}
+
+// IsAppLink returns true if the linked service is another application
+func (l *ServiceLink) IsAppLink() bool {
+	return l.serviceType == ServiceTypeApp
+}
+
+// Equal checks equality with another link
+func (l *ServiceLink) Equal(other *ServiceLink) bool {
+	if other == nil {
+		return false
+	}
+	return l.serviceType == other.serviceType &&
+		l.serviceName == other.serviceName &&
+		l.appName == other.appName
+}
+
+// serviceContainerPrefix starts the container name a datastore plugin links a service
+// with, e.g. --link dokku.postgres.api-db:dokku-postgres-api-db
+const serviceContainerPrefix = "dokku."
+
+// ParseServiceLinks parses the output of docker-options:report, for one application or
+// all of them, into the services linked to each application. Dokku datastore plugins
+// link a service with a --link docker option naming its container, the other options
+// are ignored.
+func ParseServiceLinks(raw string) ([]*ServiceLink, error) {
+	links := make([]*ServiceLink, 0)
+	for _, block := range splitReportBlocks(raw) {
+		section, ok := block.section("docker")
+		if !ok {
+			continue
+		}
+		for _, line := range strings.Split(section, "\n") {
+			key, value, ok := strings.Cut(line, ":")
+			if !ok || !strings.HasPrefix(strings.ToLower(strings.TrimSpace(key)), "docker options") {
+				continue
+			}
+
+			fields := strings.Fields(value)
+			for i, field := range fields {
+				var target string
+				switch {
+				case field == "--link" && i+1 < len(fields):
+					target = fields[i+1]
+				case strings.HasPrefix(field, "--link="):
+					target = strings.TrimPrefix(field, "--link=")
+				default:
+					continue
+				}
+
+				container, _, _ := strings.Cut(target, ":")
+				if !strings.HasPrefix(container, serviceContainerPrefix) {
+					continue
+				}
+				serviceType, serviceName, ok := strings.Cut(strings.TrimPrefix(container, serviceContainerPrefix), ".")
+				if !ok {
+					continue
+				}
+
+				link, err := NewServiceLink(serviceType, serviceName, block.appName)
+				if err != nil {
+					return nil, fmt.Errorf("application %s: %w", block.appName, err)
+				}
+				// A service is linked in the deploy and run phases alike
+				if !slices.ContainsFunc(links, link.Equal) {
+					links = append(links, link)
+				}
+			}
+		}
+	}
+	return links, nil
+}
@@ -0,0 +1,41 @@
+package app_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	app "github.com/dokku-mcp/dokku-mcp/internal/server-plugins/app/domain"
+)
+
+var _ = Describe("ParseServiceLinks", func() {
+	It("should read the linked services of every application", func() {
+		links, err := app.ParseServiceLinks(`=====> api docker options information
+       Docker options build:
+       Docker options deploy:         --link dokku.postgres.api-db:dokku-postgres-api-db --restart=on-failure:10
+       Docker options run:            --link dokku.postgres.api-db:dokku-postgres-api-db --link=dokku.redis.cache:dokku-redis-cache
+=====> worker docker options information
+       Docker options build:
+       Docker options deploy:         --link dokku.redis.cache:dokku-redis-cache
+       Docker options run:            --link some-container:alias`)
+		Expect(err).NotTo(HaveOccurred())
+
+		serviceIDs := make([]string, 0, len(links))
+		for _, link := range links {
+			serviceIDs = append(serviceIDs, link.AppName()+"->"+link.ServiceID())
+		}
+		Expect(serviceIDs).To(Equal([]string{
+			"api->postgres:api-db",
+			"api->redis:cache",
+			"worker->redis:cache",
+		}))
+	})
+
+	It("should find no link in an application without docker options", func() {
+		links, err := app.ParseServiceLinks(`=====> web docker options information
+       Docker options build:
+       Docker options deploy:
+       Docker options run:`)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(links).To(BeEmpty())
+	})
+})
@@ -9,22 +9,34 @@ import (
 
 	dokkuApi "github.com/dokku-mcp/dokku-mcp/internal/dokku-api"
 	app "github.com/dokku-mcp/dokku-mcp/internal/server-plugins/app/domain"
+	coredomain "github.com/dokku-mcp/dokku-mcp/internal/server-plugins/core/domain"
+	"github.com/dokku-mcp/dokku-mcp/internal/shared"
 	"github.com/dokku-mcp/dokku-mcp/internal/shared/process"
 )
 
 // DokkuApplicationRepository implements the repository for applications via Dokku
 type DokkuApplicationRepository struct {
-	client dokkuApi.DokkuClient
-	dokku  *DokkuApplicationAdapter
-	logger *slog.Logger
+	client     dokkuApi.DokkuClient
+	dokku      *DokkuApplicationAdapter
+	dispatcher *app.EventDispatcher
+	reports    *app.ReportCache
+	logger     *slog.Logger
+	// appOptions configure every application the repository loads
+	appOptions []app.ApplicationOption
 }
 
-// NewDokkuApplicationRepository creates a new application repository
-func NewDokkuApplicationRepository(client dokkuApi.DokkuClient, logger *slog.Logger) app.ApplicationRepository {
+// NewDokkuApplicationRepository creates a new application repository publishing the
+// events of the saved applications to the dispatcher, and reading the parsed reports
+// through the report cache. A nil cache reads every report from Dokku. The loaded
+// applications are created with the given options, e.g. WithMemoryBudget.
+func NewDokkuApplicationRepository(client dokkuApi.DokkuClient, dispatcher *app.EventDispatcher, reports *app.ReportCache, logger *slog.Logger, opts ...app.ApplicationOption) *DokkuApplicationRepository {
 	return &DokkuApplicationRepository{
-		client: client,
-		dokku:  NewDokkuApplicationAdapter(client, logger),
-		logger: logger,
+		client:     client,
+		dokku:      NewDokkuApplicationAdapter(client, logger),
+		dispatcher: dispatcher,
+		reports:    reports,
+		logger:     logger,
+		appOptions: opts,
 	}
 }
 
@@ -99,7 +111,7 @@ func (r *DokkuApplicationRepository) GetByName(ctx context.Context, name *app.Ap
 	state := r.determineStateFromInfo(info)
 
 	// Create application entity with the determined state
-	appInstance, err := app.NewApplicationWithState(name.Value(), state)
+	appInstance, err := app.NewApplicationWithState(name.Value(), state, r.appOptions...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create application entity: %w", err)
 	}
@@ -131,12 +143,41 @@ func (r *DokkuApplicationRepository) Save(ctx context.Context, application *app.
 	r.logger.Debug("Saving application",
 		"app_name", application.Name().Value())
 
+	if err := application.Validate(); err != nil {
+		return fmt.Errorf("refusing to save inconsistent application: %w", err)
+	}
+
 	exists, err := r.Exists(ctx, application.Name())
 	if err != nil {
 		return fmt.Errorf("failed to check application existence: %w", err)
 	}
 
-	if !exists {
+	if application.IsDestroyed() {
+		if exists {
+			if err := r.Delete(ctx, application.Name()); err != nil {
+				return err
+			}
+		}
+		if r.dispatcher != nil {
+			r.dispatcher.Dispatch(application.GetEvents())
+		}
+		application.ClearEvents()
+		return nil
+	}
+
+	// A renamed application exists under its old name: rename it rather than
+	// creating the new name next to it
+	renamed := false
+	for _, event := range application.GetEvents() {
+		if e, ok := event.(*app.ApplicationRenamedEvent); ok {
+			if err := r.dokku.RenameApplication(ctx, e.OldName(), e.NewName()); err != nil {
+				return fmt.Errorf("failed to rename application during save: %w", err)
+			}
+			renamed = true
+		}
+	}
+
+	if !exists && !renamed {
 		_, err := r.dokku.ExecuteCommand(ctx, app.CommandAppsCreate, []string{application.Name().Value()})
 		if err != nil {
 			return fmt.Errorf("failed to create application: %w", err)
@@ -146,13 +187,29 @@ func (r *DokkuApplicationRepository) Save(ctx context.Context, application *app.
 	for _, event := range application.GetEvents() {
 		switch e := event.(type) {
 		case *app.ApplicationScaledEvent:
-			if err := r.dokku.ScaleApplication(ctx, e.AggregateID(), e.ProcessType(), e.NewScale()); err != nil {
+			// Scaled before a rename, the event still carries the old name
+			if err := r.dokku.ScaleApplication(ctx, application.Name().Value(), e.ProcessType(), e.NewScale()); err != nil {
 				r.logger.Error("Failed to apply scaling event", "error", err)
 				return fmt.Errorf("failed to scale application during save: %w", err)
 			}
-			r.logger.Debug("Applied scaling event", "app", e.AggregateID(), "process", e.ProcessType(), "scale", e.NewScale())
+			r.logger.Debug("Applied scaling event", "app", application.Name().Value(), "process", e.ProcessType(), "scale", e.NewScale())
+		case *app.DockerOptionAddedEvent:
+			if err := r.dokku.AddDockerOption(ctx, application.Name().Value(), shared.DockerOptionPhase(e.Phase()), e.Option()); err != nil {
+				return fmt.Errorf("failed to add docker option during save: %w", err)
+			}
+		case *app.DockerOptionRemovedEvent:
+			if err := r.dokku.RemoveDockerOption(ctx, application.Name().Value(), shared.DockerOptionPhase(e.Phase()), e.Option()); err != nil {
+				return fmt.Errorf("failed to remove docker option during save: %w", err)
+			}
+		case *app.RunAsUserChangedEvent:
+			if err := r.applyRunAsUser(ctx, application.Name().Value(), e); err != nil {
+				return fmt.Errorf("failed to change the container user during save: %w", err)
+			}
 		}
 	}
+	if r.dispatcher != nil {
+		r.dispatcher.Dispatch(application.GetEvents())
+	}
 	application.ClearEvents()
 
 	// Update configuration if it exists
@@ -170,6 +227,25 @@ func (r *DokkuApplicationRepository) Save(ctx context.Context, application *app.
 	return nil
 }
 
+// applyRunAsUser swaps the --user docker option of the previous user for the new one
+// on the phases running the application containers
+func (r *DokkuApplicationRepository) applyRunAsUser(ctx context.Context, appName string, event *app.RunAsUserChangedEvent) error {
+	removed, added := event.DockerOptions()
+	for _, phase := range app.ContainerUserPhases {
+		if removed != "" {
+			if err := r.dokku.RemoveDockerOption(ctx, appName, phase, removed); err != nil {
+				return err
+			}
+		}
+		if added != "" {
+			if err := r.dokku.AddDockerOption(ctx, appName, phase, added); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 // Delete deletes an application
 func (r *DokkuApplicationRepository) Delete(ctx context.Context, name *app.ApplicationName) error {
 	r.logger.Debug("Deleting application",
@@ -190,10 +266,15 @@ func (r *DokkuApplicationRepository) Exists(ctx context.Context, name *app.Appli
 	r.logger.Debug("Checking application existence",
 		"app_name", name.Value())
 
+	// apps:exists fails with a not-found error for a missing application, any other
+	// failure leaves the existence unknown
 	_, err := r.dokku.ExecuteCommand(ctx, app.CommandAppsExists, []string{name.Value()})
-	if err != nil {
+	if dokkuApi.IsNotFoundError(err) {
 		return false, nil
 	}
+	if err != nil {
+		return false, err
+	}
 
 	return true, nil
 }
@@ -247,6 +328,178 @@ func (r *DokkuApplicationRepository) GetByState(ctx context.Context, state *app.
 	return filteredApps, nil
 }
 
+// LoadDomains retrieves the domains of one application, or all of them if appName is
+// empty, from a single domains report rather than the full application status
+func (r *DokkuApplicationRepository) LoadDomains(ctx context.Context, appName string) (map[string][]string, error) {
+	r.logger.Debug("Loading application domains",
+		"app_name", appName)
+
+	if appName == "" {
+		return r.dokku.GetDomains(ctx, appName)
+	}
+
+	domains, err := r.domainReport(ctx, appName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get domains report: %w", err)
+	}
+	if !domains.report.VhostsEnabled {
+		return map[string][]string{appName: {}}, nil
+	}
+	return map[string][]string{appName: domains.report.DomainNames()}, nil
+}
+
+// LoadServiceLinks retrieves the services linked to every application from a single
+// docker-options report
+func (r *DokkuApplicationRepository) LoadServiceLinks(ctx context.Context) ([]*app.ServiceLink, error) {
+	r.logger.Debug("Loading service links")
+
+	output, err := r.dokku.ExecuteCommand(ctx, app.CommandDockerOptionsReport, []string{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get docker options report: %w", err)
+	}
+
+	return app.ParseServiceLinks(string(output))
+}
+
+// certifiedDomains is the domains report of an application parsed with its certs
+// report, whose failure only leaves the SSL status unknown
+type certifiedDomains struct {
+	report   *app.DomainReport
+	certsErr error
+}
+
+// domainReport reads the domains and certs reports of the application, through the
+// report cache. The certs section follows the domains one, for the parser to match the
+// SSL hostnames.
+func (r *DokkuApplicationRepository) domainReport(ctx context.Context, appName string) (*certifiedDomains, error) {
+	return app.CachedReport(r.reports, appName, app.ReportTypeDomains, func() (*certifiedDomains, error) {
+		args := []string{appName}
+		output, err := r.dokku.ExecuteCommand(ctx, app.CommandDomainsReport, args)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", app.CommandDomainsReport, err)
+		}
+
+		raw := string(output)
+		certs, certsErr := r.dokku.ExecuteCommand(ctx, app.CommandCertsReport, args)
+		if certsErr != nil {
+			certsErr = fmt.Errorf("%s: %w", app.CommandCertsReport, certsErr)
+		} else {
+			raw += "\n" + string(certs)
+		}
+
+		report, err := app.ParseDomainReport(raw)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", app.CommandDomainsReport, err)
+		}
+		return &certifiedDomains{report: report, certsErr: certsErr}, nil
+	})
+}
+
+// readReport runs the report command of the application and parses its output,
+// through the report cache
+func readReport[T any](ctx context.Context, r *DokkuApplicationRepository, appName string, reportType app.ReportType, command app.ApplicationCommand, parse func(raw string) (T, error)) (T, error) {
+	return app.CachedReport(r.reports, appName, reportType, func() (T, error) {
+		var report T
+		output, err := r.dokku.ExecuteCommand(ctx, command, []string{appName})
+		if err == nil {
+			report, err = parse(string(output))
+		}
+		if err != nil {
+			return report, fmt.Errorf("%s: %w", command, err)
+		}
+		return report, nil
+	})
+}
+
+// DescribeApplication reads the ps, domains, certs, proxy, scheduler, buildpacks and git
+// reports and the config keys of an application into a snapshot. The parsed ps, domains,
+// proxy and git reports come from the report cache; a report that fails to run or parse
+// marks its section unavailable rather than failing the snapshot.
+func (r *DokkuApplicationRepository) DescribeApplication(ctx context.Context, name *app.ApplicationName) (*app.ApplicationSnapshot, error) {
+	r.logger.Debug("Describing application",
+		"app_name", name.Value())
+
+	exists, err := r.Exists(ctx, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check application existence: %w", err)
+	}
+	if !exists {
+		return nil, app.ErrApplicationNotFound
+	}
+
+	appName := name.Value()
+	snapshot := app.NewApplicationSnapshot(appName)
+	readSection := func(section app.SnapshotSection, command app.ApplicationCommand, read func(raw string) error) {
+		output, err := r.dokku.ExecuteCommand(ctx, command, []string{appName})
+		if err == nil {
+			err = read(string(output))
+		}
+		if err != nil {
+			snapshot.MarkUnavailable(section, fmt.Errorf("%s: %w", command, err))
+		}
+	}
+
+	if report, err := readReport(ctx, r, appName, app.ReportTypeProcess, app.CommandPsReport, app.ParseProcessReport); err != nil {
+		snapshot.MarkUnavailable(app.SnapshotSectionProcesses, err)
+	} else {
+		snapshot.SetProcessReport(report)
+	}
+
+	if domains, err := r.domainReport(ctx, appName); err != nil {
+		snapshot.MarkUnavailable(app.SnapshotSectionDomains, err)
+		snapshot.MarkUnavailable(app.SnapshotSectionSSL, fmt.Errorf("the domains report is unavailable"))
+	} else {
+		snapshot.SetDomainReport(domains.report)
+		if domains.certsErr != nil {
+			snapshot.MarkUnavailable(app.SnapshotSectionSSL, domains.certsErr)
+		}
+	}
+
+	if report, err := readReport(ctx, r, appName, app.ReportTypeProxy, app.CommandProxyReport, coredomain.ParseProxyReport); err != nil {
+		snapshot.MarkUnavailable(app.SnapshotSectionProxy, err)
+	} else {
+		snapshot.ProxyType = report.ActiveType().String()
+	}
+
+	readSection(app.SnapshotSectionScheduler, app.CommandSchedulerReport, func(raw string) error {
+		report, err := coredomain.ParseSchedulerReport(raw)
+		if err == nil {
+			snapshot.Scheduler = report.ActiveScheduler().String()
+		}
+		return err
+	})
+
+	readSection(app.SnapshotSectionBuildpacks, app.CommandBuildpacksReport, func(raw string) error {
+		buildpacks, err := app.ParseBuildpacksReport(raw)
+		if err == nil {
+			snapshot.Buildpacks = buildpacks
+		}
+		return err
+	})
+
+	if report, err := readReport(ctx, r, appName, app.ReportTypeGit, app.CommandGitReport, app.ParseGitReport); err != nil {
+		snapshot.MarkUnavailable(app.SnapshotSectionGit, err)
+	} else {
+		snapshot.Git = report
+	}
+
+	readSection(app.SnapshotSectionEnv, app.CommandConfigKeys, func(raw string) error {
+		keys, err := app.ParseConfigKeys(raw)
+		if err == nil {
+			snapshot.SetEnvKeys(keys)
+		}
+		return err
+	})
+
+	if len(snapshot.Unavailable) > 0 {
+		r.logger.Warn("Application snapshot is incomplete",
+			"app_name", appName,
+			"unavailable", snapshot.Unavailable)
+	}
+
+	return snapshot, nil
+}
+
 // GetByDomain retrieves applications by domain
 func (r *DokkuApplicationRepository) GetByDomain(ctx context.Context, domain string) ([]*app.Application, error) {
 	r.logger.Debug("Retrieving applications by domain",
@@ -359,6 +612,10 @@ func (r *DokkuApplicationRepository) GetRecentlyDeployed(ctx context.Context, li
 	r.logger.Debug("Retrieving recently deployed applications",
 		"limit", limit)
 
+	if limit <= 0 {
+		return []*app.Application{}, nil
+	}
+
 	allApps, err := r.GetAll(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to retrieve all applications: %w", err)
@@ -388,7 +645,7 @@ func min(a, b int) int {
 func (r *DokkuApplicationRepository) updateApplicationFromInfo(app *app.Application, info map[string]string, config map[string]string) error {
 	// Apply environment variables
 	for key, value := range config {
-		if err := app.SetEnvironmentVariable(key, value); err != nil {
+		if err := app.LoadEnvironmentVariable(key, value); err != nil {
 			r.logger.Warn("Failed to set environment variable",
 				"key", key,
 				"error", err)
@@ -1,6 +1,10 @@
 package infrastructure
 
 import (
+	"context"
+	"io"
+	"log/slog"
+	"slices"
 	"testing"
 
 	app "github.com/dokku-mcp/dokku-mcp/internal/server-plugins/app/domain"
@@ -37,3 +41,57 @@ func TestDetermineStateFromInfo(t *testing.T) {
 		}
 	})
 }
+
+func TestSaveRenamesARenamedApplication(t *testing.T) {
+	client := &reportClient{outputs: map[string]string{"apps:rename": "", "ps:scale": ""}}
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	repo := NewDokkuApplicationRepository(client, nil, nil, logger)
+
+	application, _ := app.NewApplicationWithState("old-app", app.StateRunning)
+	application.ClearEvents()
+	if err := application.Scale("web", 2); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := application.Rename("new-app"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := repo.Save(context.Background(), application); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []string{"apps:exists new-app", "apps:rename old-app new-app", "ps:scale new-app web=2"}
+	if !slices.Equal(client.calls, expected) {
+		t.Fatalf("expected %v, got %v", expected, client.calls)
+	}
+}
+
+func TestSaveSwapsTheUserDockerOption(t *testing.T) {
+	client := &reportClient{outputs: map[string]string{"apps:exists": "", "docker-options:add": "", "docker-options:remove": ""}}
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	repo := NewDokkuApplicationRepository(client, nil, nil, logger)
+
+	application, _ := app.NewApplicationWithState("my-app", app.StateRunning)
+	if err := application.SetRunAsUser("app"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	application.ClearEvents()
+	if err := application.SetRunAsUser("1000"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := repo.Save(context.Background(), application); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []string{
+		"apps:exists my-app",
+		"docker-options:remove my-app deploy --user app",
+		"docker-options:add my-app deploy --user 1000",
+		"docker-options:remove my-app run --user app",
+		"docker-options:add my-app run --user 1000",
+	}
+	if !slices.Equal(client.calls, expected) {
+		t.Fatalf("expected %v, got %v", expected, client.calls)
+	}
+}
@@ -0,0 +1,181 @@
+package infrastructure
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	dokkuApi "github.com/dokku-mcp/dokku-mcp/internal/dokku-api"
+	app "github.com/dokku-mcp/dokku-mcp/internal/server-plugins/app/domain"
+)
+
+// reportClient answers the commands it knows with a canned output and fails the
+// others, recording every command line it runs. A failed apps:exists reports the
+// application as not found, unless errs gives its error.
+type reportClient struct {
+	dokkuApi.DokkuClient
+	outputs map[string]string
+	errs    map[string]error
+	calls   []string
+}
+
+func (c *reportClient) ExecuteCommand(ctx context.Context, command string, args []string) ([]byte, error) {
+	c.calls = append(c.calls, strings.Join(append([]string{command}, args...), " "))
+	if err, ok := c.errs[command]; ok {
+		return nil, err
+	}
+	output, ok := c.outputs[command]
+	if !ok && command == "apps:exists" {
+		return nil, &dokkuApi.NotFoundError{Command: command, Err: dokkuApi.ErrAppNotFound}
+	}
+	if !ok {
+		return nil, errors.New("command failed")
+	}
+	return []byte(output), nil
+}
+
+func newSnapshotRepository(outputs map[string]string) *DokkuApplicationRepository {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	return NewDokkuApplicationRepository(&reportClient{outputs: outputs}, nil, nil, logger)
+}
+
+var snapshotReports = map[string]string{
+	"apps:exists": "",
+	"ps:report": `=====> my-app ps information
+       Deployed:                      true
+       Ps restart policy:             on-failure:10
+       Status web 1:                  running (CID: d65fa70d1f2)`,
+	"domains:report": `=====> my-app domains information
+       Domains app enabled:           true
+       Domains app vhosts:            my-app.example.com www.example.com`,
+	"certs:report": `=====> my-app ssl information
+       Ssl enabled:                   true
+       Ssl hostnames:                 my-app.example.com`,
+	"proxy:report": `=====> my-app proxy information
+       Proxy enabled:                 true
+       Proxy computed type:           caddy`,
+	"scheduler:report": `=====> my-app scheduler information
+       Scheduler computed selected:   docker-local`,
+	"buildpacks:report": `=====> my-app buildpacks information
+       Buildpacks list:               https://github.com/heroku/heroku-buildpack-go`,
+	"git:report": `=====> my-app git information
+       Git deploy branch:             main`,
+	"config:keys": "DATABASE_URL\nSECRET_KEY",
+}
+
+func TestDescribeApplicationAssemblesEveryReport(t *testing.T) {
+	name, _ := app.NewApplicationName("my-app")
+	snapshot, err := newSnapshotRepository(snapshotReports).DescribeApplication(context.Background(), name)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(snapshot.Unavailable) != 0 {
+		t.Fatalf("expected every section to be available, got %v", snapshot.Unavailable)
+	}
+	if snapshot.State != app.StateRunning || snapshot.Processes.RestartPolicy != "on-failure:10" {
+		t.Fatalf("unexpected processes: state %s, report %+v", snapshot.State, snapshot.Processes)
+	}
+	if !snapshot.SSLEnabled || !snapshot.Domains.AppDomains[0].SSL || snapshot.Domains.AppDomains[1].SSL {
+		t.Fatalf("unexpected SSL status: %+v", snapshot.Domains)
+	}
+	if snapshot.ProxyType != "caddy" || snapshot.Scheduler != "docker-local" {
+		t.Fatalf("unexpected proxy %q or scheduler %q", snapshot.ProxyType, snapshot.Scheduler)
+	}
+	if len(snapshot.Buildpacks) != 1 || snapshot.Git.DeployBranch != "main" {
+		t.Fatalf("unexpected buildpacks %v or git %+v", snapshot.Buildpacks, snapshot.Git)
+	}
+	if len(snapshot.EnvKeys) != 2 || snapshot.EnvKeys[1] != "SECRET_KEY" {
+		t.Fatalf("unexpected env keys: %v", snapshot.EnvKeys)
+	}
+}
+
+func TestDescribeApplicationMarksFailedSectionsUnavailable(t *testing.T) {
+	outputs := make(map[string]string, len(snapshotReports))
+	for command, output := range snapshotReports {
+		outputs[command] = output
+	}
+	delete(outputs, "certs:report")
+	outputs["scheduler:report"] = "=====> my-app proxy information"
+
+	name, _ := app.NewApplicationName("my-app")
+	snapshot, err := newSnapshotRepository(outputs).DescribeApplication(context.Background(), name)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, section := range []app.SnapshotSection{app.SnapshotSectionSSL, app.SnapshotSectionScheduler} {
+		if snapshot.IsAvailable(section) {
+			t.Fatalf("expected %s to be unavailable", section)
+		}
+	}
+	if !strings.HasPrefix(snapshot.Unavailable[app.SnapshotSectionScheduler], "scheduler:report") {
+		t.Fatalf("expected the reason to name the report, got %q", snapshot.Unavailable[app.SnapshotSectionScheduler])
+	}
+	if len(snapshot.Unavailable) != 2 || len(snapshot.Domains.AppDomains) != 2 {
+		t.Fatalf("expected the other sections to stay available, got %v", snapshot.Unavailable)
+	}
+}
+
+func TestDescribeApplicationFailsForAMissingApplication(t *testing.T) {
+	name, _ := app.NewApplicationName("ghost-app")
+	_, err := newSnapshotRepository(map[string]string{}).DescribeApplication(context.Background(), name)
+	if !errors.Is(err, app.ErrApplicationNotFound) {
+		t.Fatalf("expected ErrApplicationNotFound, got %v", err)
+	}
+}
+
+func TestDescribeApplicationReadsReportsThroughTheCache(t *testing.T) {
+	client := &reportClient{outputs: snapshotReports}
+	reports := app.NewReportCache(0, nil)
+	repo := NewDokkuApplicationRepository(client, nil, reports, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	name, _ := app.NewApplicationName("my-app")
+
+	countCalls := func(command string) int {
+		count := 0
+		for _, call := range client.calls {
+			if strings.HasPrefix(call, command+" ") {
+				count++
+			}
+		}
+		return count
+	}
+
+	for range 2 {
+		if _, err := repo.DescribeApplication(context.Background(), name); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if countCalls("ps:report") != 1 || countCalls("domains:report") != 1 || countCalls("scheduler:report") != 2 {
+		t.Fatalf("expected the cached reports to be read once, got %v", client.calls)
+	}
+
+	reports.HandleEvent(app.NewApplicationScaledEvent("my-app", "web", 1, 2, time.Now()))
+	if _, err := repo.DescribeApplication(context.Background(), name); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if countCalls("ps:report") != 2 || countCalls("domains:report") != 1 {
+		t.Fatalf("expected the scale to invalidate only the ps report, got %v", client.calls)
+	}
+}
+
+func TestDescribeApplicationFailsWhenTheExistenceCannotBeChecked(t *testing.T) {
+	client := &reportClient{
+		outputs: snapshotReports,
+		errs:    map[string]error{"apps:exists": errors.New("ssh: connection refused")},
+	}
+	repo := NewDokkuApplicationRepository(client, nil, nil, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	name, _ := app.NewApplicationName("my-app")
+	_, err := repo.DescribeApplication(context.Background(), name)
+	if err == nil || errors.Is(err, app.ErrApplicationNotFound) {
+		t.Fatalf("expected the existence check to fail, got %v", err)
+	}
+	if len(client.calls) != 1 {
+		t.Fatalf("expected no report to be read, got %v", client.calls)
+	}
+}
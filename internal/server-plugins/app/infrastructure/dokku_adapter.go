@@ -8,6 +8,7 @@ import (
 
 	dokkuApi "github.com/dokku-mcp/dokku-mcp/internal/dokku-api"
 	app "github.com/dokku-mcp/dokku-mcp/internal/server-plugins/app/domain"
+	"github.com/dokku-mcp/dokku-mcp/internal/shared"
 )
 
 // DokkuApplicationAdapter provides application-specific operations using the generic DokkuClient
@@ -99,6 +100,16 @@ func (a *DokkuApplicationAdapter) SetApplicationConfig(ctx context.Context, appN
 	return nil
 }
 
+// RenameApplication renames an application, keeping its configuration and deployed image
+func (a *DokkuApplicationAdapter) RenameApplication(ctx context.Context, oldName, newName string) error {
+	_, err := a.ExecuteCommand(ctx, app.CommandAppsRename, []string{oldName, newName})
+	if err != nil {
+		return fmt.Errorf("failed to rename application %s to %s: %w", oldName, newName, err)
+	}
+
+	return nil
+}
+
 // ScaleApplication scales application processes
 func (a *DokkuApplicationAdapter) ScaleApplication(ctx context.Context, appName string, processType string, count int) error {
 	scaleArg := fmt.Sprintf("%s=%d", processType, count)
@@ -110,11 +121,47 @@ func (a *DokkuApplicationAdapter) ScaleApplication(ctx context.Context, appName
 	return nil
 }
 
+// AddDockerOption passes an option to the containers of a phase
+func (a *DokkuApplicationAdapter) AddDockerOption(ctx context.Context, appName string, phase shared.DockerOptionPhase, option string) error {
+	_, err := a.ExecuteCommand(ctx, app.CommandDockerOptionsAdd, []string{appName, string(phase), option})
+	if err != nil {
+		return fmt.Errorf("failed to add %s docker option to application %s: %w", phase, appName, err)
+	}
+
+	return nil
+}
+
+// RemoveDockerOption stops passing an option to the containers of a phase
+func (a *DokkuApplicationAdapter) RemoveDockerOption(ctx context.Context, appName string, phase shared.DockerOptionPhase, option string) error {
+	_, err := a.ExecuteCommand(ctx, app.CommandDockerOptionsRemove, []string{appName, string(phase), option})
+	if err != nil {
+		return fmt.Errorf("failed to remove %s docker option from application %s: %w", phase, appName, err)
+	}
+
+	return nil
+}
+
+// GetDomains runs domains:report, for one application or all of them if appName is
+// empty, and returns the domains of each application
+func (a *DokkuApplicationAdapter) GetDomains(ctx context.Context, appName string) (map[string][]string, error) {
+	args := []string{}
+	if appName != "" {
+		args = append(args, appName)
+	}
+
+	output, err := a.ExecuteCommand(ctx, app.CommandDomainsReport, args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get domains report: %w", err)
+	}
+
+	return app.ParseDomainsByApp(string(output))
+}
+
 // GetApplicationLogs retrieves application logs
 func (a *DokkuApplicationAdapter) GetApplicationLogs(ctx context.Context, appName string, lines int) (string, error) {
 	args := []string{appName}
 	if lines > 0 {
-		args = append(args, "--tail", fmt.Sprintf("%d", lines))
+		args = append(args, "--num", fmt.Sprintf("%d", lines))
 	}
 
 	output, err := a.ExecuteCommand(ctx, app.CommandLogs, args)
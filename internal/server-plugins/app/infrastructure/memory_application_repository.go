@@ -0,0 +1,197 @@
+package infrastructure
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"sync"
+
+	app "github.com/dokku-mcp/dokku-mcp/internal/server-plugins/app/domain"
+)
+
+// InMemoryApplicationRepository is a thread-safe application repository kept in memory,
+// meant for tests and local runs without a Dokku host. Applications are stored by
+// reference, so callers share the instances they save.
+type InMemoryApplicationRepository struct {
+	mu           sync.RWMutex
+	applications map[string]*app.Application
+	dispatcher   *app.EventDispatcher
+}
+
+// NewInMemoryApplicationRepository creates an empty in-memory repository publishing the
+// events of the saved applications to the dispatcher, if any
+func NewInMemoryApplicationRepository(dispatcher *app.EventDispatcher) *InMemoryApplicationRepository {
+	return &InMemoryApplicationRepository{
+		applications: make(map[string]*app.Application),
+		dispatcher:   dispatcher,
+	}
+}
+
+// Save stores the application, or removes it once destroyed, dispatches its pending
+// events, then clears them
+func (r *InMemoryApplicationRepository) Save(ctx context.Context, application *app.Application) error {
+	r.mu.Lock()
+	for _, event := range application.GetEvents() {
+		if renamed, ok := event.(*app.ApplicationRenamedEvent); ok {
+			delete(r.applications, renamed.OldName())
+		}
+	}
+	if application.IsDestroyed() {
+		delete(r.applications, application.Name().Value())
+	} else {
+		r.applications[application.Name().Value()] = application
+	}
+	r.mu.Unlock()
+
+	if r.dispatcher != nil {
+		r.dispatcher.Dispatch(application.GetEvents())
+	}
+	application.ClearEvents()
+
+	return nil
+}
+
+// GetByName retrieves an application by its name
+func (r *InMemoryApplicationRepository) GetByName(ctx context.Context, name *app.ApplicationName) (*app.Application, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	application, exists := r.applications[name.Value()]
+	if !exists {
+		return nil, app.ErrApplicationNotFound
+	}
+	return application, nil
+}
+
+// GetAll retrieves all applications, sorted by name
+func (r *InMemoryApplicationRepository) GetAll(ctx context.Context) ([]*app.Application, error) {
+	return r.filter(func(*app.Application) bool { return true }), nil
+}
+
+// GetByState retrieves applications by state
+func (r *InMemoryApplicationRepository) GetByState(ctx context.Context, state *app.ApplicationState) ([]*app.Application, error) {
+	return r.filter(func(application *app.Application) bool {
+		return application.State().Equal(state)
+	}), nil
+}
+
+// Delete deletes an application
+func (r *InMemoryApplicationRepository) Delete(ctx context.Context, name *app.ApplicationName) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.applications[name.Value()]; !exists {
+		return app.ErrApplicationNotFound
+	}
+	delete(r.applications, name.Value())
+	return nil
+}
+
+// Exists checks if an application exists
+func (r *InMemoryApplicationRepository) Exists(ctx context.Context, name *app.ApplicationName) (bool, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	_, exists := r.applications[name.Value()]
+	return exists, nil
+}
+
+// List retrieves a paginated list of applications, sorted by name
+func (r *InMemoryApplicationRepository) List(ctx context.Context, offset, limit int) ([]*app.Application, int, error) {
+	allApps, _ := r.GetAll(ctx)
+
+	total := len(allApps)
+	start := min(max(offset, 0), total)
+	end := min(start+max(limit, 0), total)
+
+	return allApps[start:end], total, nil
+}
+
+// GetByDomain retrieves applications by domain
+func (r *InMemoryApplicationRepository) GetByDomain(ctx context.Context, domain string) ([]*app.Application, error) {
+	return r.filter(func(application *app.Application) bool {
+		return application.HasDomain(domain)
+	}), nil
+}
+
+// GetRunningApplications retrieves running applications
+func (r *InMemoryApplicationRepository) GetRunningApplications(ctx context.Context) ([]*app.Application, error) {
+	return r.filter(func(application *app.Application) bool {
+		return application.State().IsRunning()
+	}), nil
+}
+
+// GetApplicationsWithBuildpack retrieves applications using a buildpack
+func (r *InMemoryApplicationRepository) GetApplicationsWithBuildpack(ctx context.Context, buildpack string) ([]*app.Application, error) {
+	return r.filter(func(application *app.Application) bool {
+		return slices.Contains(application.GetBuildpacks(), buildpack)
+	}), nil
+}
+
+// GetRecentlyDeployed retrieves the most recently updated applications
+func (r *InMemoryApplicationRepository) GetRecentlyDeployed(ctx context.Context, limit int) ([]*app.Application, error) {
+	if limit <= 0 {
+		return []*app.Application{}, nil
+	}
+
+	allApps, _ := r.GetAll(ctx)
+	sort.SliceStable(allApps, func(i, j int) bool {
+		return allApps[i].UpdatedAt().After(allApps[j].UpdatedAt())
+	})
+
+	if len(allApps) > limit {
+		allApps = allApps[:limit]
+	}
+	return allApps, nil
+}
+
+// CountByState counts applications by state
+func (r *InMemoryApplicationRepository) CountByState(ctx context.Context) (map[app.StateValue]int, error) {
+	allApps, _ := r.GetAll(ctx)
+
+	counts := make(map[app.StateValue]int)
+	for _, application := range allApps {
+		counts[application.State().Value()]++
+	}
+	return counts, nil
+}
+
+// GetApplicationMetrics retrieves application metrics
+func (r *InMemoryApplicationRepository) GetApplicationMetrics(ctx context.Context) (*app.ApplicationMetrics, error) {
+	allApps, _ := r.GetAll(ctx)
+	counts, _ := r.CountByState(ctx)
+
+	buildpacks := make(map[string]int)
+	for _, application := range allApps {
+		for _, buildpack := range application.GetBuildpacks() {
+			buildpacks[buildpack]++
+		}
+	}
+
+	return &app.ApplicationMetrics{
+		TotalApplications:   len(allApps),
+		RunningApplications: counts[app.StateRunning],
+		StoppedApplications: counts[app.StateStopped],
+		ErrorApplications:   counts[app.StateError],
+		ApplicationsByState: counts,
+		MostUsedBuildpacks:  buildpacks,
+	}, nil
+}
+
+func (r *InMemoryApplicationRepository) filter(keep func(*app.Application) bool) []*app.Application {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	applications := make([]*app.Application, 0, len(r.applications))
+	for _, application := range r.applications {
+		if keep(application) {
+			applications = append(applications, application)
+		}
+	}
+	sort.Slice(applications, func(i, j int) bool {
+		return applications[i].Name().Value() < applications[j].Name().Value()
+	})
+	return applications
+}
+
+var _ app.ApplicationRepository = (*InMemoryApplicationRepository)(nil)
@@ -0,0 +1,193 @@
+package infrastructure
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+
+	app "github.com/dokku-mcp/dokku-mcp/internal/server-plugins/app/domain"
+)
+
+func TestInMemoryApplicationRepository(t *testing.T) {
+	ctx := context.Background()
+
+	newApp := func(t *testing.T, name string) *app.Application {
+		t.Helper()
+		application, err := app.NewApplication(name)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return application
+	}
+
+	t.Run("save dispatches then clears events", func(t *testing.T) {
+		dispatcher := app.NewEventDispatcher(nil)
+		repo := NewInMemoryApplicationRepository(dispatcher)
+		var (
+			mu         sync.Mutex
+			dispatched []string
+		)
+		dispatcher.Subscribe(app.AllEventTypes, func(event app.DomainEvent) {
+			mu.Lock()
+			defer mu.Unlock()
+			dispatched = append(dispatched, event.EventType())
+		})
+
+		application := newApp(t, "api")
+		if err := application.AddDomain("api.example.com"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err := repo.Save(ctx, application); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		dispatcher.Wait()
+		if len(dispatched) != 2 || dispatched[0] != "application.created" || dispatched[1] != "application.domain.added" {
+			t.Fatalf("unexpected dispatched events: %v", dispatched)
+		}
+		if len(application.GetEvents()) != 0 {
+			t.Fatalf("expected events to be cleared, got %d", len(application.GetEvents()))
+		}
+
+		stored, err := repo.GetByName(ctx, application.Name())
+		if err != nil || stored != application {
+			t.Fatalf("expected the saved application, got %v (%v)", stored, err)
+		}
+	})
+
+	t.Run("saving a destroyed application removes it", func(t *testing.T) {
+		dispatcher := app.NewEventDispatcher(nil)
+		repo := NewInMemoryApplicationRepository(dispatcher)
+		var (
+			mu         sync.Mutex
+			dispatched []string
+		)
+		dispatcher.Subscribe(app.AllEventTypes, func(event app.DomainEvent) {
+			mu.Lock()
+			defer mu.Unlock()
+			dispatched = append(dispatched, event.EventType())
+		})
+
+		application := newApp(t, "api")
+		if err := repo.Save(ctx, application); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err := application.Destroy("api"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err := repo.Save(ctx, application); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if _, err := repo.GetByName(ctx, application.Name()); !errors.Is(err, app.ErrApplicationNotFound) {
+			t.Fatalf("expected ErrApplicationNotFound, got %v", err)
+		}
+		dispatcher.Wait()
+		if !slices.Contains(dispatched, "application.destroyed") {
+			t.Fatalf("unexpected dispatched events: %v", dispatched)
+		}
+	})
+
+	t.Run("get and delete unknown applications", func(t *testing.T) {
+		repo := NewInMemoryApplicationRepository(nil)
+		name := app.MustNewApplicationName("missing")
+
+		if _, err := repo.GetByName(ctx, name); !errors.Is(err, app.ErrApplicationNotFound) {
+			t.Fatalf("expected ErrApplicationNotFound, got %v", err)
+		}
+		if err := repo.Delete(ctx, name); !errors.Is(err, app.ErrApplicationNotFound) {
+			t.Fatalf("expected ErrApplicationNotFound, got %v", err)
+		}
+	})
+
+	t.Run("list is sorted and paginated", func(t *testing.T) {
+		repo := NewInMemoryApplicationRepository(nil)
+		for _, name := range []string{"web", "api", "worker"} {
+			if err := repo.Save(ctx, newApp(t, name)); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		}
+
+		page, total, err := repo.List(ctx, 1, 5)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if total != 3 || len(page) != 2 || page[0].Name().Value() != "web" || page[1].Name().Value() != "worker" {
+			t.Fatalf("unexpected page: total=%d len=%d", total, len(page))
+		}
+
+		if err := repo.Delete(ctx, app.MustNewApplicationName("web")); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if exists, _ := repo.Exists(ctx, app.MustNewApplicationName("web")); exists {
+			t.Fatal("expected web to be deleted")
+		}
+	})
+
+	t.Run("recently deployed stops at the limit", func(t *testing.T) {
+		repo := NewInMemoryApplicationRepository(nil)
+		for _, name := range []string{"web", "api", "worker"} {
+			if err := repo.Save(ctx, newApp(t, name)); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		}
+
+		for limit, expected := range map[int]int{-1: 0, 0: 0, 2: 2, 5: 3} {
+			recent, err := repo.GetRecentlyDeployed(ctx, limit)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(recent) != expected {
+				t.Fatalf("expected %d applications for limit %d, got %d", expected, limit, len(recent))
+			}
+		}
+	})
+
+	t.Run("save re-keys a renamed application", func(t *testing.T) {
+		repo := NewInMemoryApplicationRepository(nil)
+		application := newApp(t, "api")
+		if err := repo.Save(ctx, application); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if err := application.Rename("backend"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err := repo.Save(ctx, application); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if exists, _ := repo.Exists(ctx, app.MustNewApplicationName("api")); exists {
+			t.Fatal("expected the old name to be gone")
+		}
+		if stored, err := repo.GetByName(ctx, app.MustNewApplicationName("backend")); err != nil || stored != application {
+			t.Fatalf("expected the renamed application, got %v (%v)", stored, err)
+		}
+	})
+
+	t.Run("concurrent saves are safe", func(t *testing.T) {
+		repo := NewInMemoryApplicationRepository(nil)
+		applications := make([]*app.Application, 20)
+		for i := range applications {
+			applications[i] = newApp(t, "app-"+string(rune('a'+i)))
+		}
+
+		var wg sync.WaitGroup
+		for _, application := range applications {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_ = repo.Save(ctx, application)
+				_, _ = repo.GetAll(ctx)
+			}()
+		}
+		wg.Wait()
+
+		all, _ := repo.GetAll(ctx)
+		if len(all) != len(applications) {
+			t.Fatalf("expected %d applications, got %d", len(applications), len(all))
+		}
+	})
+}
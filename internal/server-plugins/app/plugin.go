@@ -6,6 +6,10 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
 
 	dokkuApi "github.com/dokku-mcp/dokku-mcp/internal/dokku-api"
 	"github.com/dokku-mcp/dokku-mcp/internal/server-plugin/domain"
@@ -13,7 +17,10 @@ import (
 	appdomain "github.com/dokku-mcp/dokku-mcp/internal/server-plugins/app/domain"
 	"github.com/dokku-mcp/dokku-mcp/internal/server-plugins/app/infrastructure"
 	"github.com/dokku-mcp/dokku-mcp/internal/shared"
+	"github.com/dokku-mcp/dokku-mcp/internal/shared/process"
+	"github.com/dokku-mcp/dokku-mcp/pkg/config"
 	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
 	"go.uber.org/fx"
 )
 
@@ -21,17 +28,21 @@ import (
 // This replaces the legacy AppsPlugin and demonstrates the new architecture
 type AppsServerPlugin struct {
 	applicationUseCase *appusecases.ApplicationUseCase
+	validationService  *appdomain.ValidationService
 	logger             *slog.Logger
 }
 
 // NewAppsServerPlugin creates a new unified apps server plugin
 func NewAppsServerPlugin(
 	applicationRepo appdomain.ApplicationRepository,
+	describer appdomain.ApplicationDescriber,
+	serviceLinks appdomain.ServiceLinksLoader,
 	deploymentSvc shared.DeploymentService,
 	logger *slog.Logger,
 ) domain.ServerPlugin {
 	return &AppsServerPlugin{
-		applicationUseCase: appusecases.NewApplicationUseCase(applicationRepo, deploymentSvc, logger),
+		applicationUseCase: appusecases.NewApplicationUseCase(applicationRepo, describer, serviceLinks, deploymentSvc, logger),
+		validationService:  appdomain.NewValidationService(),
 		logger:             logger,
 	}
 }
@@ -53,9 +64,9 @@ func (p *AppsServerPlugin) DokkuPluginName() string { return "" }
 func (p *AppsServerPlugin) GetResources(ctx context.Context) ([]domain.Resource, error) {
 	return []domain.Resource{
 		{
-			URI:         "dokku://apps/list",
+			URI:         appsListResourceURI,
 			Name:        "Application List",
-			Description: "Complete list of all Dokku applications with status",
+			Description: "Paginated list of Dokku applications with status (optional state, deployed, name, label=key=value, sort, order, offset and limit query parameters)",
 			MIMEType:    "application/json",
 			Handler:     p.handleApplicationListResource,
 		},
@@ -71,6 +82,12 @@ func (p *AppsServerPlugin) GetTools(ctx context.Context) ([]domain.Tool, error)
 			Builder:     p.buildCreateAppTool,
 			Handler:     p.handleCreateApp,
 		},
+		{
+			Name:        "destroy_app",
+			Description: "Destroy a Dokku application once its name is confirmed",
+			Builder:     p.buildDestroyAppTool,
+			Handler:     p.handleDestroyApp,
+		},
 		{
 			Name:        "deploy_app",
 			Description: "Deploy application from Git with options",
@@ -89,12 +106,30 @@ func (p *AppsServerPlugin) GetTools(ctx context.Context) ([]domain.Tool, error)
 			Builder:     p.buildConfigureAppTool,
 			Handler:     p.handleConfigureApp,
 		},
+		{
+			Name:        "lint_env",
+			Description: "Lint proposed environment variables without applying them",
+			Builder:     p.buildLintEnvTool,
+			Handler:     p.handleLintEnv,
+		},
+		{
+			Name:        "preview_manifest",
+			Description: "Preview the effective configuration after applying a manifest",
+			Builder:     p.buildPreviewManifestTool,
+			Handler:     p.handlePreviewManifest,
+		},
 		{
 			Name:        "get_app_status",
 			Description: "Get comprehensive application status",
 			Builder:     p.buildGetAppStatusTool,
 			Handler:     p.handleGetAppStatus,
 		},
+		{
+			Name:        "describe_app",
+			Description: "Describe everything Dokku reports about an application",
+			Builder:     p.buildDescribeAppTool,
+			Handler:     p.handleDescribeApp,
+		},
 	}, nil
 }
 
@@ -121,17 +156,23 @@ func (p *AppsServerPlugin) handleApplicationListResource(ctx context.Context, re
 	for i, app := range applications {
 		apps[i] = appdomain.ApplicationInfo{
 			Name:       app.Name().Value(),
-			State:      string(app.State().Value()),
+			State:      app.State().Value(),
 			IsRunning:  app.IsRunning(),
 			IsDeployed: app.IsDeployed(),
 			CreatedAt:  app.CreatedAt(),
 			UpdatedAt:  app.UpdatedAt(),
+			Labels:     app.Labels(),
 		}
 	}
 
-	data := appdomain.ApplicationListData{
-		Applications: apps,
-		Count:        len(apps),
+	query, err := parseApplicationListQuery(req.Params.URI)
+	if err != nil {
+		return nil, err
+	}
+	apps = appdomain.FilterAndSort(apps, query.filter, query.sortBy, query.desc)
+	data, err := appdomain.NewApplicationListPage(apps, query.offset, query.limit)
+	if err != nil {
+		return nil, fmt.Errorf("invalid application list page: %w", err)
 	}
 
 	jsonData, err := json.MarshalIndent(data, "", "  ")
@@ -148,6 +189,73 @@ func (p *AppsServerPlugin) handleApplicationListResource(ctx context.Context, re
 	}, nil
 }
 
+// applicationListQuery holds the optional query parameters of the application list resource
+type applicationListQuery struct {
+	filter appdomain.ListFilter
+	sortBy appdomain.ApplicationSortField
+	desc   bool
+	offset int
+	limit  int
+}
+
+// parseApplicationListQuery reads state, deployed, name, label, sort, order, offset and limit
+// from the query of a resource URI. The label parameter, as key=value, may be repeated.
+func parseApplicationListQuery(uri string) (*applicationListQuery, error) {
+	parsed, err := url.Parse(uri)
+	if err != nil {
+		return nil, fmt.Errorf("invalid resource URI: %w", err)
+	}
+	params := parsed.Query()
+
+	query := &applicationListQuery{
+		filter: appdomain.ListFilter{
+			State:        appdomain.StateValue(params.Get("state")),
+			NameContains: params.Get("name"),
+		},
+		sortBy: appdomain.SortByName,
+	}
+
+	if raw := params.Get("deployed"); raw != "" {
+		deployed, err := strconv.ParseBool(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid deployed: %s", raw)
+		}
+		query.filter.Deployed = &deployed
+	}
+	for _, raw := range params["label"] {
+		key, value, found := strings.Cut(raw, "=")
+		if !found || key == "" {
+			return nil, fmt.Errorf("invalid label: %s", raw)
+		}
+		if query.filter.Labels == nil {
+			query.filter.Labels = make(map[string]string)
+		}
+		query.filter.Labels[key] = value
+	}
+	if raw := params.Get("sort"); raw != "" {
+		query.sortBy = appdomain.ApplicationSortField(raw)
+	}
+	switch order := appdomain.SortOrder(params.Get("order")); order {
+	case "", appdomain.SortOrderAsc:
+	case appdomain.SortOrderDesc:
+		query.desc = true
+	default:
+		return nil, fmt.Errorf("invalid order: %s", order)
+	}
+
+	for name, target := range map[string]*int{"offset": &query.offset, "limit": &query.limit} {
+		raw := params.Get(name)
+		if raw == "" {
+			continue
+		}
+		if *target, err = strconv.Atoi(raw); err != nil {
+			return nil, fmt.Errorf("invalid %s: %s", name, raw)
+		}
+	}
+
+	return query, nil
+}
+
 // Tool builders
 func (p *AppsServerPlugin) buildCreateAppTool() mcp.Tool {
 	return mcp.NewTool(
@@ -167,6 +275,21 @@ func (p *AppsServerPlugin) buildCreateAppTool() mcp.Tool {
 	)
 }
 
+func (p *AppsServerPlugin) buildDestroyAppTool() mcp.Tool {
+	return mcp.NewTool(
+		"destroy_app",
+		mcp.WithDescription("Destroy an application with its containers and configuration. This cannot be undone."),
+		mcp.WithString("app_name",
+			mcp.Required(),
+			mcp.Description("Name of the application to destroy"),
+		),
+		mcp.WithString("confirm",
+			mcp.Required(),
+			mcp.Description("The application name again, confirming the destroy like the Dokku prompt"),
+		),
+	)
+}
+
 func (p *AppsServerPlugin) buildDeployAppTool() mcp.Tool {
 	return mcp.NewTool(
 		"deploy_app",
@@ -209,13 +332,15 @@ func (p *AppsServerPlugin) buildScaleAppTool() mcp.Tool {
 func (p *AppsServerPlugin) buildConfigureAppTool() mcp.Tool {
 	return mcp.NewTool(
 		"configure_app",
-		mcp.WithDescription("Set environment variables for an application"),
+		mcp.WithDescription("Set environment variables for an application, from key-value pairs or a dotenv file"),
 		mcp.WithString("app_name",
 			mcp.Required(),
 			mcp.Description("Name of the application to configure"),
 		),
+		mcp.WithString("dotenv",
+			mcp.Description("Content of a .env file to apply, overridden by config for the same keys"),
+		),
 		mcp.WithObject("config",
-			mcp.Required(),
 			mcp.Description("Environment variables as key-value pairs"),
 			mcp.Properties(map[string]interface{}{ // NOTE: This is a valid exception
 				"additionalProperties": map[string]interface{}{ // NOTE: This is a valid exception
@@ -226,10 +351,52 @@ func (p *AppsServerPlugin) buildConfigureAppTool() mcp.Tool {
 	)
 }
 
+func (p *AppsServerPlugin) buildLintEnvTool() mcp.Tool {
+	return mcp.NewTool(
+		"lint_env",
+		mcp.WithDescription("Check proposed environment variables for invalid or reserved keys, oversized values, suspected secrets and undefined references"),
+		mcp.WithObject("config",
+			mcp.Required(),
+			mcp.Description("Proposed environment variables as key-value pairs"),
+			mcp.Properties(map[string]interface{}{ // NOTE: This is a valid exception
+				"additionalProperties": map[string]interface{}{ // NOTE: This is a valid exception
+					"type": "string",
+				},
+			}),
+		),
+	)
+}
+
+func (p *AppsServerPlugin) buildPreviewManifestTool() mcp.Tool {
+	return mcp.NewTool(
+		"preview_manifest",
+		mcp.WithDescription("Compute the configuration resulting from a manifest apply, with masked environment values and the list of changes. Nothing is applied."),
+		mcp.WithString("app_name",
+			mcp.Required(),
+			mcp.Description("Name of the application"),
+		),
+		mcp.WithObject("manifest",
+			mcp.Required(),
+			mcp.Description("Manifest with optional buildpack, domains, env and processes (process type to scale)"),
+		),
+	)
+}
+
 func (p *AppsServerPlugin) buildGetAppStatusTool() mcp.Tool {
 	return mcp.NewTool(
 		"get_app_status",
-		mcp.WithDescription("Get comprehensive status information for an application"),
+		mcp.WithDescription("Get comprehensive status information for an application, with its process scales, last deployment and health"),
+		mcp.WithString("app_name",
+			mcp.Required(),
+			mcp.Description("Name of the application"),
+		),
+	)
+}
+
+func (p *AppsServerPlugin) buildDescribeAppTool() mcp.Tool {
+	return mcp.NewTool(
+		"describe_app",
+		mcp.WithDescription("Describe an application from all its Dokku reports: state, processes with scales and restart policies, domains and SSL, proxy type, scheduler, buildpacks, git settings and masked environment keys. Sections whose report failed are listed as unavailable."),
 		mcp.WithString("app_name",
 			mcp.Required(),
 			mcp.Description("Name of the application"),
@@ -258,6 +425,34 @@ func (p *AppsServerPlugin) handleCreateApp(ctx context.Context, req mcp.CallTool
 	return mcp.NewToolResultText(fmt.Sprintf("Application '%s' created successfully", name)), nil
 }
 
+func (p *AppsServerPlugin) handleDestroyApp(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	appName, err := req.RequireString("app_name")
+	if err != nil {
+		return mcp.NewToolResultError("Application name is required"), nil
+	}
+	confirm, err := req.RequireString("confirm")
+	if err != nil {
+		return mcp.NewToolResultError("Confirmation is required"), nil
+	}
+
+	cmd := appusecases.DestroyApplicationCommand{Name: appName, Confirm: confirm}
+	radius, err := p.applicationUseCase.DestroyApplication(ctx, cmd)
+	if err != nil {
+		if errors.Is(err, appdomain.ErrApplicationNotFound) {
+			return mcp.NewToolResultError(fmt.Sprintf("Application '%s' not found", appName)), nil
+		}
+		if errors.Is(err, appdomain.ErrDestroyNotConfirmed) {
+			return mcp.NewToolResultError(fmt.Sprintf("Destroy of '%s' not confirmed: confirm must be the application name. %s", appName, radius.Summary())), nil
+		}
+		if errors.Is(err, appdomain.ErrDeploymentInProgress) {
+			return mcp.NewToolResultError(fmt.Sprintf("Deployment in progress for '%s'", appName)), nil
+		}
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to destroy application: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(fmt.Sprintf("Application '%s' destroyed. %s", appName, radius.Summary())), nil
+}
+
 func (p *AppsServerPlugin) handleDeployApp(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	appName, err := req.RequireString("app_name")
 	if err != nil {
@@ -349,6 +544,13 @@ func (p *AppsServerPlugin) handleConfigureApp(ctx context.Context, req mcp.CallT
 	}
 
 	configVars := make(map[string]string)
+	if dotenv := req.GetString("dotenv", ""); dotenv != "" {
+		parsed, err := appdomain.ParseDotenv([]byte(dotenv))
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Invalid dotenv: %v", err)), nil
+		}
+		configVars = parsed
+	}
 	if configParam, ok := req.GetArguments()["config"]; ok {
 		if configMap, ok := configParam.(map[string]interface{}); ok { // NOTE: This is a valid exception
 			for key, value := range configMap {
@@ -362,6 +564,9 @@ func (p *AppsServerPlugin) handleConfigureApp(ctx context.Context, req mcp.CallT
 	if len(configVars) == 0 {
 		return mcp.NewToolResultError("At least one configuration variable is required"), nil
 	}
+	if errs := (&appdomain.ApplicationManifest{Env: configVars}).Validate(); len(errs) > 0 {
+		return validationErrorsResult(errs), nil
+	}
 
 	cmd := appusecases.SetConfigCommand{
 		Name:   appName,
@@ -378,31 +583,103 @@ func (p *AppsServerPlugin) handleConfigureApp(ctx context.Context, req mcp.CallT
 	return mcp.NewToolResultText(fmt.Sprintf("Application '%s' configured successfully with %d variables", appName, len(configVars))), nil
 }
 
-func (p *AppsServerPlugin) handleGetAppStatus(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+func (p *AppsServerPlugin) handleLintEnv(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	configVars := make(map[string]string)
+	if configParam, ok := req.GetArguments()["config"]; ok {
+		if configMap, ok := configParam.(map[string]interface{}); ok { // NOTE: This is a valid exception
+			for key, value := range configMap {
+				if valueStr, ok := value.(string); ok {
+					configVars[key] = valueStr
+				}
+			}
+		}
+	}
+
+	if len(configVars) == 0 {
+		return mcp.NewToolResultError("At least one configuration variable is required"), nil
+	}
+
+	result := p.validationService.LintEnv(ctx, configVars)
+
+	resultJSON, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError("Failed to serialize lint findings"), nil
+	}
+
+	return mcp.NewToolResultText(string(resultJSON)), nil
+}
+
+func (p *AppsServerPlugin) handlePreviewManifest(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	appName, err := req.RequireString("app_name")
 	if err != nil {
 		return mcp.NewToolResultError("Application name is required"), nil
 	}
 
+	manifestParam, ok := req.GetArguments()["manifest"]
+	if !ok {
+		return mcp.NewToolResultError("Manifest is required"), nil
+	}
+
+	// Round-trip through JSON to decode the loosely typed arguments into the manifest
+	rawManifest, err := json.Marshal(manifestParam)
+	if err != nil {
+		return mcp.NewToolResultError("Invalid manifest"), nil
+	}
+	var manifest appdomain.ApplicationManifest
+	if err := json.Unmarshal(rawManifest, &manifest); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid manifest: %v", err)), nil
+	}
+
 	app, err := p.applicationUseCase.GetApplicationByName(ctx, appName)
 	if err != nil {
 		if errors.Is(err, appdomain.ErrApplicationNotFound) {
 			return mcp.NewToolResultError(fmt.Sprintf("Application '%s' not found", appName)), nil
 		}
-		return mcp.NewToolResultError(fmt.Sprintf("Failed to get application status: %v", err)), nil
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get application: %v", err)), nil
+	}
+
+	preview, err := app.PreviewManifest(&manifest)
+	if err != nil {
+		var validationErrs appdomain.ValidationErrors
+		if errors.As(err, &validationErrs) {
+			return validationErrorsResult(validationErrs), nil
+		}
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to preview manifest: %v", err)), nil
+	}
+
+	previewJSON, err := json.MarshalIndent(preview, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError("Failed to serialize preview"), nil
+	}
+
+	return mcp.NewToolResultText(string(previewJSON)), nil
+}
+
+// validationErrorsResult reports every invalid field at once, as a JSON list of the
+// field paths with their code and message
+func validationErrorsResult(errs appdomain.ValidationErrors) *mcp.CallToolResult {
+	errorsJSON, err := json.MarshalIndent(map[string]any{"errors": errs}, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError(errs.Error())
 	}
+	return mcp.NewToolResultError(string(errorsJSON))
+}
 
-	status := appdomain.ApplicationStatus{
-		Name:       app.Name().Value(),
-		State:      string(app.State().Value()),
-		CreatedAt:  app.CreatedAt(),
-		UpdatedAt:  app.UpdatedAt(),
-		IsRunning:  app.IsRunning(),
-		IsDeployed: app.IsDeployed(),
-		Domains:    app.GetDomains(),
+func (p *AppsServerPlugin) handleGetAppStatus(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	appName, err := req.RequireString("app_name")
+	if err != nil {
+		return mcp.NewToolResultError("Application name is required"), nil
 	}
 
-	statusJSON, err := json.MarshalIndent(status, "", "  ")
+	app, err := p.applicationUseCase.GetApplicationByName(ctx, appName)
+	if err != nil {
+		if errors.Is(err, appdomain.ErrApplicationNotFound) {
+			return mcp.NewToolResultError(fmt.Sprintf("Application '%s' not found", appName)), nil
+		}
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to get application status: %v", err)), nil
+	}
+
+	statusJSON, err := json.MarshalIndent(app.Detail(), "", "  ")
 	if err != nil {
 		return mcp.NewToolResultError("Failed to serialize status"), nil
 	}
@@ -410,6 +687,28 @@ func (p *AppsServerPlugin) handleGetAppStatus(ctx context.Context, req mcp.CallT
 	return mcp.NewToolResultText(fmt.Sprintf("Application Status for '%s':\n%s", appName, string(statusJSON))), nil
 }
 
+func (p *AppsServerPlugin) handleDescribeApp(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	appName, err := req.RequireString("app_name")
+	if err != nil {
+		return mcp.NewToolResultError("Application name is required"), nil
+	}
+
+	snapshot, err := p.applicationUseCase.DescribeApplication(ctx, appName)
+	if err != nil {
+		if errors.Is(err, appdomain.ErrApplicationNotFound) {
+			return mcp.NewToolResultError(fmt.Sprintf("Application '%s' not found", appName)), nil
+		}
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to describe application: %v", err)), nil
+	}
+
+	snapshotJSON, err := json.MarshalIndent(snapshot, "", "  ")
+	if err != nil {
+		return mcp.NewToolResultError("Failed to serialize snapshot"), nil
+	}
+
+	return mcp.NewToolResultText(string(snapshotJSON)), nil
+}
+
 // Prompt implementations
 func (p *AppsServerPlugin) buildAppDoctorPrompt() mcp.Prompt {
 	return mcp.NewPrompt(
@@ -449,11 +748,14 @@ func (p *AppsServerPlugin) handleAppDoctorPrompt(ctx context.Context, req mcp.Ge
 var Module = fx.Module("app",
 	fx.Provide(
 		// Provide the infrastructure layer dependencies
+		appdomain.NewEventDispatcher,
 		fx.Annotate(
-			func(client dokkuApi.DokkuClient, logger *slog.Logger) appdomain.ApplicationRepository {
-				return infrastructure.NewDokkuApplicationRepository(client, logger)
-			},
+			newApplicationRepository,
+			fx.As(new(appdomain.ApplicationRepository)),
+			fx.As(new(appdomain.ApplicationDescriber)),
+			fx.As(new(appdomain.ServiceLinksLoader)),
 		),
+		newReportCache,
 		// Provide the main plugin - deployment service will be injected from deployment plugin
 		fx.Annotate(
 			NewAppsServerPlugin,
@@ -461,4 +763,137 @@ var Module = fx.Module("app",
 			fx.ResultTags(`group:"server_plugins"`),
 		),
 	),
+	fx.Provide(func() *appdomain.ResourceNotifier {
+		return appdomain.NewResourceNotifier(appdomain.DefaultResourceChangeBuffer)
+	}),
+	fx.Invoke(installDeploymentScheduler),
+	fx.Invoke(forwardResourceChanges),
+	fx.Invoke(forwardEventNotifications),
 )
+
+// newApplicationRepository creates the application repository, the applications it
+// loads being capped to the host memory budget when the configuration sets one
+func newApplicationRepository(client dokkuApi.DokkuClient, dispatcher *appdomain.EventDispatcher, reports *appdomain.ReportCache, cfg *config.ServerConfig, logger *slog.Logger) (*infrastructure.DokkuApplicationRepository, error) {
+	var opts []appdomain.ApplicationOption
+	if cfg.HostMemoryBudget != "" {
+		budget, err := process.ParseSizeLimit(cfg.HostMemoryBudget)
+		if err != nil {
+			return nil, fmt.Errorf("invalid host memory budget: %w", err)
+		}
+		opts = append(opts, appdomain.WithMemoryBudget(budget))
+		logger.Debug("Host memory budget set", "host_memory_budget", cfg.HostMemoryBudget)
+	}
+	return infrastructure.NewDokkuApplicationRepository(client, dispatcher, reports, logger, opts...), nil
+}
+
+// newReportCache creates the cache of the parsed reports, trusted for the cache TTL of
+// the server and invalidated by the events of the saved applications. With the cache
+// disabled it returns nil, and every report is read from Dokku.
+func newReportCache(cfg *config.ServerConfig, dispatcher *appdomain.EventDispatcher) *appdomain.ReportCache {
+	if !cfg.CacheEnabled {
+		return nil
+	}
+	reports := appdomain.NewReportCache(cfg.CacheTTL, appdomain.DefaultClock)
+	reports.Subscribe(dispatcher)
+	return reports
+}
+
+// coalescerFlushInterval is how often the batches of coalesced events are checked for
+// a closed window
+const coalescerFlushInterval = time.Second
+
+// forwardEventNotifications sends the events of the saved applications to the MCP
+// clients as log messages, bursts of deploys and scales coalesced into one summary
+func forwardEventNotifications(lc fx.Lifecycle, dispatcher *appdomain.EventDispatcher, mcpServer *server.MCPServer, logger *slog.Logger) error {
+	coalescer, err := appdomain.NewEventCoalescer(appdomain.DefaultCoalescerConfig(), appdomain.DefaultClock, func(notification appdomain.Notification) {
+		level := mcp.LoggingLevelInfo
+		if notification.Critical {
+			level = mcp.LoggingLevelError
+		}
+		mcpServer.SendNotificationToAllClients("notifications/message", map[string]any{
+			"level":  level,
+			"logger": "apps",
+			"data":   notification.Summary,
+		})
+		logger.Debug("Event notification sent",
+			"event_type", notification.EventType,
+			"count", notification.Count)
+	})
+	if err != nil {
+		return err
+	}
+	coalescer.Subscribe(dispatcher)
+
+	stop := make(chan struct{})
+	lc.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			go func() {
+				ticker := time.NewTicker(coalescerFlushInterval)
+				defer ticker.Stop()
+				for {
+					select {
+					case <-ticker.C:
+						coalescer.FlushDue()
+					case <-stop:
+						return
+					}
+				}
+			}()
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			close(stop)
+			coalescer.Flush()
+			return nil
+		},
+	})
+	return nil
+}
+
+// appsListResourceURI is the URI the application collection is served under
+const appsListResourceURI = "dokku://apps/list"
+
+// forwardResourceChanges notifies the MCP clients of the resources the saved
+// applications change, as notifications/resources/updated
+func forwardResourceChanges(lc fx.Lifecycle, dispatcher *appdomain.EventDispatcher, notifier *appdomain.ResourceNotifier, mcpServer *server.MCPServer, logger *slog.Logger) {
+	notifier.Subscribe(dispatcher)
+
+	var unsubscribe func()
+	lc.Append(fx.Hook{
+		OnStart: func(ctx context.Context) error {
+			var changes <-chan appdomain.ResourceChange
+			changes, unsubscribe = notifier.SubscribeAllResources()
+			go func() {
+				for change := range changes {
+					uri := change.URI
+					if uri == appdomain.AppsResourceURI().String() {
+						uri = appsListResourceURI
+					}
+					mcpServer.SendNotificationToAllClients(mcp.MethodNotificationResourceUpdated, map[string]any{"uri": uri})
+					logger.Debug("Resource change notified", "uri", uri, "event_type", change.EventType)
+				}
+			}()
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			if unsubscribe != nil {
+				unsubscribe()
+			}
+			return nil
+		},
+	})
+}
+
+// installDeploymentScheduler caps concurrent deployments when the configuration asks to
+func installDeploymentScheduler(cfg *config.ServerConfig, logger *slog.Logger) error {
+	if cfg.MaxConcurrentDeploys <= 0 {
+		return nil
+	}
+	scheduler, err := appdomain.NewDeploymentScheduler(cfg.MaxConcurrentDeploys)
+	if err != nil {
+		return err
+	}
+	appdomain.SetDeploymentScheduler(scheduler)
+	logger.Debug("Concurrent deployments capped", "max_concurrent_deploys", cfg.MaxConcurrentDeploys)
+	return nil
+}
@@ -6,6 +6,7 @@ import (
 	"log/slog"
 
 	"github.com/dokku-mcp/dokku-mcp/internal/server-plugins/core/domain"
+	"github.com/dokku-mcp/dokku-mcp/internal/shared"
 )
 
 // CoreService provides application-level orchestration for core Dokku functionality
@@ -15,6 +16,8 @@ type CoreService struct {
 	sshKeyRepo   domain.SSHKeyRepository
 	registryRepo domain.RegistryRepository
 	configRepo   domain.ConfigurationRepository
+	logsRepo     domain.LogsRepository
+	serviceRepo  domain.ServiceDataRepository
 	logger       *slog.Logger
 }
 
@@ -25,6 +28,8 @@ func NewCoreService(
 	sshKeyRepo domain.SSHKeyRepository,
 	registryRepo domain.RegistryRepository,
 	configRepo domain.ConfigurationRepository,
+	logsRepo domain.LogsRepository,
+	serviceRepo domain.ServiceDataRepository,
 	logger *slog.Logger,
 ) *CoreService {
 	return &CoreService{
@@ -33,6 +38,8 @@ func NewCoreService(
 		sshKeyRepo:   sshKeyRepo,
 		registryRepo: registryRepo,
 		configRepo:   configRepo,
+		logsRepo:     logsRepo,
+		serviceRepo:  serviceRepo,
 		logger:       logger,
 	}
 }
@@ -160,7 +167,13 @@ func (s *CoreService) RemoveSSHKey(ctx context.Context, name string) error {
 		return fmt.Errorf("SSH key name cannot be empty")
 	}
 
-	return s.sshKeyRepo.RemoveSSHKey(ctx, name)
+	// Only remove a known key, Dokku removes it by name
+	key, err := s.sshKeyRepo.GetSSHKey(ctx, name)
+	if err != nil {
+		return fmt.Errorf("SSH key not found: %w", err)
+	}
+
+	return s.sshKeyRepo.RemoveSSHKey(ctx, key.Name)
 }
 
 // Registry Management Operations
@@ -196,33 +209,86 @@ func (s *CoreService) GetGlobalConfiguration(ctx context.Context) (*domain.Globa
 func (s *CoreService) SetGlobalProxyType(ctx context.Context, proxyType string) error {
 	s.logger.Info("Setting global proxy type", "proxy_type", proxyType)
 
-	validProxyTypes := []string{"nginx", "caddy", "traefik"}
-	if !s.isValidProxyType(proxyType, validProxyTypes) {
-		return fmt.Errorf("invalid proxy type '%s', must be one of: %v", proxyType, validProxyTypes)
+	validProxyType, err := domain.NewProxyType(proxyType)
+	if err != nil {
+		return err
 	}
 
-	return s.configRepo.SetGlobalProxyType(ctx, proxyType)
+	return s.configRepo.SetGlobalProxyType(ctx, validProxyType.String())
 }
 
 func (s *CoreService) SetGlobalScheduler(ctx context.Context, scheduler string) error {
 	s.logger.Info("Setting global scheduler", "scheduler", scheduler)
 
-	validSchedulers := []string{"docker-local", "k3s", "nomad"}
-	if !s.isValidScheduler(scheduler, validSchedulers) {
-		return fmt.Errorf("invalid scheduler '%s', must be one of: %v", scheduler, validSchedulers)
+	schedulerType, err := domain.NewSchedulerType(scheduler)
+	if err != nil {
+		return err
 	}
 
-	return s.configRepo.SetGlobalScheduler(ctx, scheduler)
+	return s.configRepo.SetGlobalScheduler(ctx, schedulerType.String())
 }
 
 func (s *CoreService) SetGlobalDeployBranch(ctx context.Context, branch string) error {
 	s.logger.Info("Setting global deploy branch", "branch", branch)
 
-	if branch == "" {
-		return fmt.Errorf("deploy branch cannot be empty")
+	branchRef, err := shared.NewBranchRef(branch)
+	if err != nil {
+		return fmt.Errorf("invalid deploy branch: %w", err)
+	}
+
+	return s.configRepo.SetGlobalDeployBranch(ctx, branchRef.Value())
+}
+
+// Logs Operations
+func (s *CoreService) GetAppLogs(ctx context.Context, appName, processType string, lines int) (string, error) {
+	s.logger.Debug("Getting application logs", "app_name", appName, "process_type", processType, "lines", lines)
+
+	query, err := domain.NewLogsQuery(appName, processType, lines)
+	if err != nil {
+		return "", err
 	}
 
-	return s.configRepo.SetGlobalDeployBranch(ctx, branch)
+	return s.logsRepo.GetLogs(ctx, query)
+}
+
+// Service Data Operations
+
+// ExportServiceData dumps the data of a service, e.g. a postgres database, to a file of the
+// service export directory
+func (s *CoreService) ExportServiceData(ctx context.Context, plugin, service, output string) error {
+	s.logger.Info("Exporting service data", "plugin", plugin, "service", service, "output", output)
+
+	command, err := domain.NewServiceCommand(plugin, domain.ServiceVerbExport)
+	if err != nil {
+		return err
+	}
+
+	return s.serviceRepo.ExportServiceData(ctx, command, service, output)
+}
+
+// ImportServiceData loads the data of a service from a file of the service export directory,
+// replacing its current data
+func (s *CoreService) ImportServiceData(ctx context.Context, plugin, service, input string) error {
+	s.logger.Info("Importing service data", "plugin", plugin, "service", service, "input", input)
+
+	command, err := domain.NewServiceCommand(plugin, domain.ServiceVerbImport)
+	if err != nil {
+		return err
+	}
+
+	return s.serviceRepo.ImportServiceData(ctx, command, service, input)
+}
+
+// BackupServiceData uploads a dump of the data of a service to an S3 bucket
+func (s *CoreService) BackupServiceData(ctx context.Context, plugin, service, bucket string) error {
+	s.logger.Info("Backing up service data", "plugin", plugin, "service", service, "bucket", bucket)
+
+	command, err := domain.NewServiceCommand(plugin, domain.ServiceVerbBackup)
+	if err != nil {
+		return err
+	}
+
+	return s.serviceRepo.BackupServiceData(ctx, command, service, bucket)
 }
 
 // Validation helpers
@@ -249,24 +315,6 @@ func (s *CoreService) validateSSHKeyContent(keyContent string) error {
 	return nil
 }
 
-func (s *CoreService) isValidProxyType(proxyType string, validTypes []string) bool {
-	for _, valid := range validTypes {
-		if proxyType == valid {
-			return true
-		}
-	}
-	return false
-}
-
-func (s *CoreService) isValidScheduler(scheduler string, validSchedulers []string) bool {
-	for _, valid := range validSchedulers {
-		if scheduler == valid {
-			return true
-		}
-	}
-	return false
-}
-
 // Utility functions
 func containsAny(s string, substrings []string) bool {
 	for _, substr := range substrings {
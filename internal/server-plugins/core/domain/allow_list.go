@@ -0,0 +1,56 @@
+package domain
+
+import (
+	"fmt"
+	"slices"
+)
+
+// AllowList is the effective policy on core commands: the allowed commands minus the
+// ones an operator denied. IsValid stays the syntax-level check, a denied command is
+// still valid but must not run.
+type AllowList struct {
+	denied map[CoreCommand]struct{}
+}
+
+// NewAllowList creates an allow-list allowing every command of GetAllowedCoreCommands
+func NewAllowList() *AllowList {
+	return &AllowList{denied: make(map[CoreCommand]struct{})}
+}
+
+// LoadAllowList creates an allow-list denying the named commands, as read from the
+// configuration. An unknown name is refused, a typo must not leave a command allowed.
+func LoadAllowList(denied []string) (*AllowList, error) {
+	list := NewAllowList()
+	for _, name := range denied {
+		command := CoreCommand(name)
+		if !command.IsValid() {
+			return nil, fmt.Errorf("cannot deny unknown core command: %s", name)
+		}
+		list.Deny(command)
+	}
+	return list, nil
+}
+
+// Deny removes the commands from the allow-list
+func (l *AllowList) Deny(commands ...CoreCommand) *AllowList {
+	for _, command := range commands {
+		l.denied[command] = struct{}{}
+	}
+	return l
+}
+
+// IsAllowed returns true if the command is valid and not denied
+func (l *AllowList) IsAllowed(command CoreCommand) bool {
+	if !command.IsValid() {
+		return false
+	}
+	_, denied := l.denied[command]
+	return !denied
+}
+
+// Commands returns the allowed commands, in the order of GetAllowedCoreCommands
+func (l *AllowList) Commands() []CoreCommand {
+	return slices.DeleteFunc(GetAllowedCoreCommands(), func(c CoreCommand) bool {
+		return !l.IsAllowed(c)
+	})
+}
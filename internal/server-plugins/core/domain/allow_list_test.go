@@ -0,0 +1,35 @@
+package domain_test
+
+import (
+	"github.com/dokku-mcp/dokku-mcp/internal/server-plugins/core/domain"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("AllowList", func() {
+	It("should allow every allowed core command by default", func() {
+		list := domain.NewAllowList()
+
+		Expect(list.Commands()).To(Equal(domain.GetAllowedCoreCommands()))
+		Expect(list.IsAllowed(domain.CoreCommand("apps:destroy"))).To(BeFalse())
+	})
+
+	It("should reject a denied command that is still valid", func() {
+		list := domain.NewAllowList().Deny(domain.CommandPluginInstall, domain.CommandRegistryLogin)
+
+		Expect(list.IsAllowed(domain.CommandPluginInstall)).To(BeFalse())
+		Expect(domain.CommandPluginInstall.IsValid()).To(BeTrue())
+		Expect(list.IsAllowed(domain.CommandPluginList)).To(BeTrue())
+		Expect(list.Commands()).NotTo(ContainElements(domain.CommandPluginInstall, domain.CommandRegistryLogin))
+		Expect(list.Commands()).To(HaveLen(len(domain.GetAllowedCoreCommands()) - 2))
+	})
+
+	It("should load the denied commands from the configuration", func() {
+		list, err := domain.LoadAllowList([]string{"registry:login"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(list.IsAllowed(domain.CommandRegistryLogin)).To(BeFalse())
+
+		_, err = domain.LoadAllowList([]string{"registry:logn"})
+		Expect(err).To(MatchError(ContainSubstring("unknown core command: registry:logn")))
+	})
+})
@@ -0,0 +1,78 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+)
+
+// shellMetacharacters are refused in any argument: Dokku runs through SSH, whose remote
+// side hands the command line to a shell
+const shellMetacharacters = ";&|`$(){}<>\n\r\x00"
+
+// BuildArgv renders a core command and its arguments into an argv, the command first,
+// then the app if any, then the arguments. The command must be allowed and the arguments
+// must match its spec and be free of shell metacharacters. The result is meant to be
+// executed as separate arguments, never joined into a shell string by the caller.
+func BuildArgv(cmd CoreCommand, app string, args []string) ([]string, error) {
+	if !cmd.IsValid() {
+		return nil, fmt.Errorf("invalid core command: %s", cmd)
+	}
+
+	argv := make([]string, 0, len(args)+2)
+	argv = append(argv, cmd.String())
+	if app != "" {
+		argv = append(argv, app)
+	}
+	argv = append(argv, args...)
+
+	for i, arg := range argv[1:] {
+		if index := strings.IndexAny(arg, shellMetacharacters); index >= 0 {
+			return nil, fmt.Errorf("%s: argument %d contains the forbidden character %q", cmd, i, arg[index])
+		}
+	}
+
+	if err := cmd.Validate(argv[1:]); err != nil {
+		return nil, err
+	}
+
+	return argv, nil
+}
+
+// PlannedCommand is an argv built by BuildArgv, telling whether it was executed.
+// A dry run returns it unexecuted, for the client to review before anything runs.
+type PlannedCommand struct {
+	Argv     []string `json:"argv"`
+	Executed bool     `json:"executed"`
+}
+
+// String returns the command line, for display only
+func (c PlannedCommand) String() string {
+	return strings.Join(c.Argv, " ")
+}
+
+// CommandRequest is a core command to build and run. A dry run builds and validates
+// the argv like a real run, but never executes it.
+type CommandRequest struct {
+	Command CoreCommand
+	App     string
+	Args    []string
+	DryRun  bool
+}
+
+// RunCommand builds the argv of the request and hands it to run, unless the request
+// is a dry run. The planned command is returned either way, with its output if run.
+func RunCommand(req CommandRequest, run func(argv []string) ([]byte, error)) (PlannedCommand, []byte, error) {
+	argv, err := BuildArgv(req.Command, req.App, req.Args)
+	if err != nil {
+		return PlannedCommand{}, nil, err
+	}
+
+	planned := PlannedCommand{Argv: argv}
+	if req.DryRun {
+		return planned, nil, nil
+	}
+
+	output, err := run(argv)
+	planned.Executed = true
+	return planned, output, err
+}
@@ -0,0 +1,95 @@
+package domain_test
+
+import (
+	"github.com/dokku-mcp/dokku-mcp/internal/server-plugins/core/domain"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("BuildArgv", func() {
+	It("should order the command, the app and the arguments", func() {
+		argv, err := domain.BuildArgv(domain.CommandProxySet, "my-app", []string{"nginx"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(argv).To(Equal([]string{"proxy:set", "my-app", "nginx"}))
+	})
+
+	It("should omit an empty app", func() {
+		argv, err := domain.BuildArgv(domain.CommandPluginList, "", nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(argv).To(Equal([]string{"plugin:list"}))
+	})
+
+	It("should refuse a command outside the allowed list", func() {
+		_, err := domain.BuildArgv(domain.CoreCommand("apps:destroy"), "my-app", nil)
+		Expect(err).To(MatchError(ContainSubstring("invalid core command")))
+	})
+
+	It("should run the command spec", func() {
+		_, err := domain.BuildArgv(domain.CommandPluginUninstall, "", nil)
+		Expect(err).To(HaveOccurred())
+	})
+
+	DescribeTable("should reject shell metacharacters in any argument",
+		func(cmd domain.CoreCommand, app string, args []string) {
+			_, err := domain.BuildArgv(cmd, app, args)
+			Expect(err).To(MatchError(ContainSubstring("forbidden character")))
+		},
+		Entry("semicolon", domain.CommandGitSet, "my-app", []string{"deploy-branch", "main; rm -rf /"}),
+		Entry("pipe", domain.CommandGitSet, "my-app", []string{"deploy-branch", "main|cat"}),
+		Entry("command substitution", domain.CommandGitSet, "my-app", []string{"deploy-branch", "$(whoami)"}),
+		Entry("backtick", domain.CommandGitSet, "my-app", []string{"deploy-branch", "`whoami`"}),
+		Entry("newline", domain.CommandGitSet, "my-app", []string{"deploy-branch", "main\nrm"}),
+		Entry("NUL byte", domain.CommandGitSet, "my-app", []string{"deploy-branch", "main\x00"}),
+		Entry("in the app", domain.CommandGitSet, "my-app&&ls", []string{"deploy-branch", "main"}),
+		Entry("in a plugin name", domain.CommandPluginEnable, "", []string{"postgres;ls"}),
+		Entry("in a DSN query string", domain.CommandLogsSet, "", []string{"--global", "vector-sink", "http://logs.example.com/?uri=http://x&format=json"}),
+		Entry("in a property value", domain.CommandRegistrySet, "", []string{"--global", "password", "pa$$;word"}),
+		Entry("in a flag value", domain.CommandPluginInstall, "", []string{"https://github.com/dokku/dokku-postgres.git", "--committish", "v1.0&beta"}),
+		Entry("in the arguments of an unchecked command", domain.CommandProxyReport, "my-app|ls", nil),
+	)
+})
+
+var _ = Describe("RunCommand", func() {
+	It("should return the argv unexecuted on a dry run", func() {
+		ran := false
+		planned, output, err := domain.RunCommand(domain.CommandRequest{
+			Command: domain.CommandProxySet,
+			App:     "my-app",
+			Args:    []string{"traefik"},
+			DryRun:  true,
+		}, func(argv []string) ([]byte, error) {
+			ran = true
+			return nil, nil
+		})
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ran).To(BeFalse())
+		Expect(output).To(BeNil())
+		Expect(planned.Executed).To(BeFalse())
+		Expect(planned.String()).To(Equal("proxy:set my-app traefik"))
+	})
+
+	It("should run the built argv otherwise", func() {
+		var received []string
+		planned, output, err := domain.RunCommand(domain.CommandRequest{Command: domain.CommandPluginList},
+			func(argv []string) ([]byte, error) {
+				received = argv
+				return []byte("ok"), nil
+			})
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(received).To(Equal([]string{"plugin:list"}))
+		Expect(string(output)).To(Equal("ok"))
+		Expect(planned.Executed).To(BeTrue())
+	})
+
+	It("should validate a dry run like a real one", func() {
+		_, _, err := domain.RunCommand(domain.CommandRequest{
+			Command: domain.CommandProxySet,
+			App:     "my-app",
+			Args:    []string{"nginx;reboot"},
+			DryRun:  true,
+		}, nil)
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -1,5 +1,11 @@
 package domain
 
+import (
+	"slices"
+
+	dokkuApi "github.com/dokku-mcp/dokku-mcp/internal/dokku-api"
+)
+
 // CoreCommand represents allowed Dokku commands for the core plugin
 type CoreCommand string
 
@@ -33,10 +39,37 @@ const (
 	CommandSSHKeysRemove CoreCommand = "ssh-keys:remove"
 
 	// Registry commands
+	CommandRegistryLogin  CoreCommand = "registry:login"
 	CommandRegistryLogout CoreCommand = "registry:logout"
+	CommandRegistrySet    CoreCommand = "registry:set"
+	CommandRegistryReport CoreCommand = "registry:report"
+	CommandRegistryPull   CoreCommand = "registry:pull"
+	CommandRegistryPush   CoreCommand = "registry:push"
 
 	// Logs commands
-	CommandLogsSet CoreCommand = "logs:set"
+	CommandLogs       CoreCommand = "logs"
+	CommandLogsFailed CoreCommand = "logs:failed"
+	CommandLogsSet    CoreCommand = "logs:set"
+
+	// Maintenance commands
+	CommandMaintenanceEnable  CoreCommand = "maintenance:enable"
+	CommandMaintenanceDisable CoreCommand = "maintenance:disable"
+	CommandMaintenanceReport  CoreCommand = "maintenance:report"
+
+	// Storage commands
+	CommandStorageReport CoreCommand = "storage:report"
+	CommandStorageList   CoreCommand = "storage:list"
+
+	// Let's Encrypt commands, from the letsencrypt plugin
+	CommandLetsEncryptEnable  CoreCommand = "letsencrypt:enable"
+	CommandLetsEncryptDisable CoreCommand = "letsencrypt:disable"
+	CommandLetsEncryptList    CoreCommand = "letsencrypt:list"
+	CommandLetsEncryptCron    CoreCommand = "letsencrypt:cron-job"
+
+	// Docker options commands
+	CommandDockerOptionsReport CoreCommand = "docker-options:report"
+	CommandDockerOptionsAdd    CoreCommand = "docker-options:add"
+	CommandDockerOptionsRemove CoreCommand = "docker-options:remove"
 )
 
 // IsValid checks if the command is a valid core command
@@ -49,14 +82,56 @@ func (c CoreCommand) IsValid() bool {
 		CommandPluginList, CommandPluginInstall, CommandPluginUninstall,
 		CommandPluginEnable, CommandPluginDisable, CommandPluginUpdate,
 		CommandSSHKeysList, CommandSSHKeysRemove,
-		CommandRegistryLogout,
-		CommandLogsSet:
+		CommandRegistryLogin, CommandRegistryLogout, CommandRegistrySet,
+		CommandRegistryReport, CommandRegistryPull, CommandRegistryPush,
+		CommandLogs, CommandLogsFailed, CommandLogsSet,
+		CommandMaintenanceEnable, CommandMaintenanceDisable, CommandMaintenanceReport,
+		CommandStorageReport, CommandStorageList,
+		CommandLetsEncryptEnable, CommandLetsEncryptDisable, CommandLetsEncryptList, CommandLetsEncryptCron,
+		CommandDockerOptionsReport, CommandDockerOptionsAdd, CommandDockerOptionsRemove:
 		return true
 	default:
 		return false
 	}
 }
 
+// commandMinimumVersions are the releases introducing the commands added after Dokku 0.20.
+// The other commands are available in every supported release.
+var commandMinimumVersions = map[CoreCommand]DokkuVersion{
+	CommandRegistryReport:  {Major: 0, Minor: 25, Patch: 0},
+	CommandRegistrySet:     {Major: 0, Minor: 25, Patch: 0},
+	CommandLogsSet:         {Major: 0, Minor: 25, Patch: 0},
+	CommandStorageReport:   {Major: 0, Minor: 25, Patch: 0},
+	CommandSchedulerReport: {Major: 0, Minor: 26, Patch: 0},
+	CommandSchedulerSet:    {Major: 0, Minor: 26, Patch: 0},
+	CommandRegistryLogout:  {Major: 0, Minor: 35, Patch: 0},
+	CommandRegistryPull:    {Major: 0, Minor: 35, Patch: 0},
+	CommandRegistryPush:    {Major: 0, Minor: 35, Patch: 0},
+}
+
+// AvailableIn returns true if the command is valid and exists in the given Dokku release
+func (c CoreCommand) AvailableIn(v DokkuVersion) bool {
+	if !c.IsValid() {
+		return false
+	}
+	minimum, gated := commandMinimumVersions[c]
+	return !gated || v.AtLeast(minimum)
+}
+
+// HasSensitiveArgs returns true if the arguments carry credentials that must never be logged
+func (c CoreCommand) HasSensitiveArgs() bool {
+	return dokkuApi.IsSensitiveCommand(string(c))
+}
+
+// IsStreaming returns true if the invocation follows its output until interrupted,
+// like logs --tail, and so cannot go through a buffered execution
+func (c CoreCommand) IsStreaming(args []string) bool {
+	if c != CommandLogs {
+		return false
+	}
+	return slices.Contains(args, "--tail") || slices.Contains(args, "-t")
+}
+
 // String returns the string representation of the command
 func (c CoreCommand) String() string {
 	return string(c)
@@ -81,7 +156,33 @@ func GetAllowedCoreCommands() []CoreCommand {
 		CommandPluginUpdate,
 		CommandSSHKeysList,
 		CommandSSHKeysRemove,
+		CommandRegistryLogin,
 		CommandRegistryLogout,
+		CommandRegistrySet,
+		CommandRegistryReport,
+		CommandRegistryPull,
+		CommandRegistryPush,
+		CommandLogs,
+		CommandLogsFailed,
 		CommandLogsSet,
+		CommandMaintenanceEnable,
+		CommandMaintenanceDisable,
+		CommandMaintenanceReport,
+		CommandStorageReport,
+		CommandStorageList,
+		CommandLetsEncryptEnable,
+		CommandLetsEncryptDisable,
+		CommandLetsEncryptList,
+		CommandLetsEncryptCron,
+		CommandDockerOptionsReport,
+		CommandDockerOptionsAdd,
+		CommandDockerOptionsRemove,
 	}
 }
+
+// GetAvailableCoreCommands returns the allowed core commands that exist in the given Dokku release
+func GetAvailableCoreCommands(v DokkuVersion) []CoreCommand {
+	return slices.DeleteFunc(GetAllowedCoreCommands(), func(c CoreCommand) bool {
+		return !c.AvailableIn(v)
+	})
+}
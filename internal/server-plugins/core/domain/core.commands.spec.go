@@ -0,0 +1,174 @@
+package domain
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/dokku-mcp/dokku-mcp/internal/shared"
+)
+
+// CommandArgSpec describes the arguments a core command accepts
+type CommandArgSpec struct {
+	// Scoped commands take either --global or an app name as their first argument
+	Scoped bool
+	// Required names the required positional arguments, in order
+	Required []string
+	// Optional names the positional arguments that may follow the required ones
+	Optional []string
+	// Flags lists the allowed boolean flags
+	Flags []string
+	// ValueFlags lists the allowed flags taking a value
+	ValueFlags []string
+	// Unchecked commands accept any argument
+	Unchecked bool
+	// Values validates the positional arguments, by name, that only accept known values
+	Values map[string]func(string) error
+	// Properties validates the value argument of the property/value commands, by property
+	Properties map[string]func(string) error
+	// Rest names the required argument made of everything after the required positional
+	// arguments, taken verbatim since it may itself hold flags
+	Rest string
+	// RestValue validates the rest argument, its parts joined by spaces
+	RestValue func(string) error
+}
+
+// commandArgSpecs declares the arguments of commands that change the server
+var commandArgSpecs = map[CoreCommand]CommandArgSpec{
+	CommandVersion:             {},
+	CommandPluginList:          {},
+	CommandSSHKeysList:         {},
+	CommandProxySet:            {Scoped: true, Required: []string{"proxy-type"}, Values: map[string]func(string) error{"proxy-type": validateProxyType}},
+	CommandSchedulerSet:        {Scoped: true, Required: []string{"property"}, Optional: []string{"value"}, Properties: map[string]func(string) error{"selected": validateSchedulerType}},
+	CommandGitSet:              {Scoped: true, Required: []string{"property"}, Optional: []string{"value"}},
+	CommandLogsSet:             {Scoped: true, Required: []string{"property"}, Optional: []string{"value"}},
+	CommandPluginInstall:       {Required: []string{"source"}, Flags: []string{"--core"}, ValueFlags: []string{"--committish", "--name"}},
+	CommandPluginUninstall:     {Required: []string{"name"}},
+	CommandPluginEnable:        {Required: []string{"name"}},
+	CommandPluginDisable:       {Required: []string{"name"}},
+	CommandPluginUpdate:        {Required: []string{"name"}, Optional: []string{"committish"}},
+	CommandSSHKeysRemove:       {Required: []string{"name"}, Values: map[string]func(string) error{"name": validateSSHKeyIdentifier}},
+	CommandLogs:                {Required: []string{"app"}, Flags: []string{"--tail", "--quiet"}, ValueFlags: []string{"--num", "--ps"}},
+	CommandLogsFailed:          {Optional: []string{"app"}, Flags: []string{"--all"}},
+	CommandRegistryLogin:       {Required: []string{"server", "username"}, Optional: []string{"password"}, Flags: []string{"--global", "--password-stdin"}},
+	CommandRegistrySet:         {Scoped: true, Required: []string{"property"}, Optional: []string{"value"}},
+	CommandMaintenanceEnable:   {Required: []string{"app"}},
+	CommandMaintenanceDisable:  {Required: []string{"app"}},
+	CommandStorageList:         {Required: []string{"app"}, ValueFlags: []string{"--format"}},
+	CommandLetsEncryptEnable:   {Required: []string{"app"}},
+	CommandLetsEncryptDisable:  {Required: []string{"app"}},
+	CommandLetsEncryptList:     {},
+	CommandLetsEncryptCron:     {Flags: []string{"--add", "--remove"}},
+	CommandDockerOptionsAdd:    {Required: []string{"app", "phase"}, Rest: "option", Values: map[string]func(string) error{"phase": validateDockerOptionPhases}, RestValue: shared.ValidateDockerOption},
+	CommandDockerOptionsRemove: {Required: []string{"app", "phase"}, Rest: "option", Values: map[string]func(string) error{"phase": validateDockerOptionPhases}, RestValue: shared.ValidateDockerOption},
+}
+
+// ArgSpec returns the argument spec of the command. Commands without a declared
+// spec, such as reports, are unchecked.
+func (c CoreCommand) ArgSpec() CommandArgSpec {
+	if spec, exists := commandArgSpecs[c]; exists {
+		return spec
+	}
+	return CommandArgSpec{Unchecked: true}
+}
+
+// Validate checks the arguments against the command spec before execution
+func (c CoreCommand) Validate(args []string) error {
+	if !c.IsValid() {
+		return fmt.Errorf("invalid core command: %s", c)
+	}
+
+	return c.ArgSpec().validate(c.String(), args)
+}
+
+// validate checks the arguments of the named command against the spec
+func (spec CommandArgSpec) validate(c string, args []string) error {
+	if spec.Unchecked {
+		return nil
+	}
+
+	// The rest argument holds everything after the required ones, flags included
+	var rest []string
+	if spec.Rest != "" && len(args) > len(spec.Required) {
+		args, rest = args[:len(spec.Required)], args[len(spec.Required):]
+	}
+
+	global := false
+	positional := make([]string, 0, len(args))
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if !strings.HasPrefix(arg, "--") {
+			positional = append(positional, arg)
+			continue
+		}
+
+		switch {
+		case arg == "--global" && spec.Scoped:
+			global = true
+		case slices.Contains(spec.Flags, arg):
+		case slices.Contains(spec.ValueFlags, arg):
+			if i+1 >= len(args) || strings.HasPrefix(args[i+1], "--") {
+				return fmt.Errorf("%s: flag %s requires a value", c, arg)
+			}
+			i++
+		default:
+			return fmt.Errorf("%s: unknown flag %s", c, arg)
+		}
+	}
+
+	required := spec.Required
+	if spec.Scoped && !global {
+		required = append([]string{"app"}, required...)
+	}
+
+	if len(positional) < len(required) {
+		return fmt.Errorf("%s: missing required argument %s", c, required[len(positional)])
+	}
+	if maxArgs := len(required) + len(spec.Optional); len(positional) > maxArgs {
+		return fmt.Errorf("%s: too many arguments, expected at most %d", c, maxArgs)
+	}
+	for i, name := range required {
+		if strings.TrimSpace(positional[i]) == "" {
+			return fmt.Errorf("%s: argument %s cannot be empty", c, name)
+		}
+	}
+	if spec.Rest != "" {
+		if len(rest) == 0 {
+			return fmt.Errorf("%s: missing required argument %s", c, spec.Rest)
+		}
+		if spec.RestValue != nil {
+			if err := spec.RestValue(strings.Join(rest, " ")); err != nil {
+				return fmt.Errorf("%s: %w", c, err)
+			}
+		}
+	}
+	named := make(map[string]string, len(positional))
+	for i, name := range slices.Concat(required, spec.Optional)[:len(positional)] {
+		named[name] = positional[i]
+		if validate, exists := spec.Values[name]; exists {
+			if err := validate(positional[i]); err != nil {
+				return fmt.Errorf("%s: %w", c, err)
+			}
+		}
+	}
+	// A property without value is unset, which is always allowed
+	if validate, exists := spec.Properties[named["property"]]; exists {
+		if value, set := named["value"]; set {
+			if err := validate(value); err != nil {
+				return fmt.Errorf("%s: %w", c, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func validateDockerOptionPhases(value string) error {
+	_, err := shared.ParseDockerOptionPhases(value)
+	return err
+}
+
+func validateProxyType(value string) error {
+	_, err := NewProxyType(value)
+	return err
+}
@@ -0,0 +1,149 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/dokku-mcp/dokku-mcp/internal/server-plugins/core/domain"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestCoreCommands(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Core Commands Suite")
+}
+
+var _ = Describe("CoreCommand", func() {
+	DescribeTable("Validate accepts well-formed invocations",
+		func(command domain.CoreCommand, args []string) {
+			Expect(command.Validate(args)).To(Succeed())
+		},
+		Entry("global proxy:set", domain.CommandProxySet, []string{"--global", "traefik"}),
+		Entry("app proxy:set", domain.CommandProxySet, []string{"my-app", "caddy"}),
+		Entry("haproxy proxy:set", domain.CommandProxySet, []string{"my-app", "haproxy"}),
+		Entry("global scheduler:set", domain.CommandSchedulerSet, []string{"--global", "selected", "k3s"}),
+		Entry("scheduler:set of another property", domain.CommandSchedulerSet, []string{"my-app", "shm-size", "256m"}),
+		Entry("scheduler:set unsetting a property", domain.CommandSchedulerSet, []string{"my-app", "selected"}),
+		Entry("git:set", domain.CommandGitSet, []string{"--global", "deploy-branch", "main"}),
+		Entry("logs:set", domain.CommandLogsSet, []string{"--global", "vector-sink", "console://?encoding[codec]=json"}),
+		Entry("plugin:install with options", domain.CommandPluginInstall,
+			[]string{"https://github.com/dokku/dokku-postgres.git", "--committish", "1.0.0", "--name", "postgres"}),
+		Entry("core plugin:install", domain.CommandPluginInstall, []string{"letsencrypt", "--core"}),
+		Entry("ssh-keys:remove", domain.CommandSSHKeysRemove, []string{"admin"}),
+		Entry("unchecked report", domain.CommandProxyReport, []string{"--global", "--proxy-type"}),
+		Entry("bounded logs", domain.CommandLogs, []string{"my-app", "--num", "50", "--ps", "web"}),
+		Entry("followed logs", domain.CommandLogs, []string{"my-app", "--tail"}),
+		Entry("failed logs of an app", domain.CommandLogsFailed, []string{"my-app"}),
+		Entry("failed logs of every app", domain.CommandLogsFailed, []string{"--all"}),
+		Entry("maintenance:enable", domain.CommandMaintenanceEnable, []string{"my-app"}),
+		Entry("storage:list", domain.CommandStorageList, []string{"my-app", "--format", "json"}),
+		Entry("letsencrypt:enable", domain.CommandLetsEncryptEnable, []string{"my-app"}),
+		Entry("letsencrypt:cron-job", domain.CommandLetsEncryptCron, []string{"--add"}),
+		Entry("docker-options:add with a flag and its value", domain.CommandDockerOptionsAdd,
+			[]string{"my-app", "deploy,run", "--add-host", "db:10.0.0.2"}),
+		Entry("docker-options:remove", domain.CommandDockerOptionsRemove, []string{"my-app", "build", "--no-cache"}),
+	)
+
+	DescribeTable("Validate rejects malformed invocations",
+		func(command domain.CoreCommand, args []string, message string) {
+			err := command.Validate(args)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring(message))
+		},
+		Entry("proxy:set without a type", domain.CommandProxySet, []string{"--global"}, "missing required argument proxy-type"),
+		Entry("proxy:set without a scope", domain.CommandProxySet, []string{}, "missing required argument app"),
+		Entry("scheduler:set with too many arguments", domain.CommandSchedulerSet,
+			[]string{"--global", "selected", "k3s", "extra"}, "too many arguments"),
+		Entry("git:set with an unknown flag", domain.CommandGitSet, []string{"--all", "deploy-branch", "main"}, "unknown flag --all"),
+		Entry("logs:set without a property", domain.CommandLogsSet, []string{"--global"}, "missing required argument property"),
+		Entry("plugin:install without a source", domain.CommandPluginInstall, []string{"--core"}, "missing required argument source"),
+		Entry("plugin:install with a dangling flag", domain.CommandPluginInstall,
+			[]string{"postgres", "--committish"}, "flag --committish requires a value"),
+		Entry("ssh-keys:remove without a name", domain.CommandSSHKeysRemove, []string{}, "missing required argument name"),
+		Entry("ssh-keys:remove with an empty name", domain.CommandSSHKeysRemove, []string{" "}, "argument name cannot be empty"),
+		Entry("logs without an app", domain.CommandLogs, []string{"--num", "50"}, "missing required argument app"),
+		Entry("logs with a dangling process filter", domain.CommandLogs, []string{"my-app", "--ps"}, "flag --ps requires a value"),
+		Entry("logs with an unknown flag", domain.CommandLogs, []string{"my-app", "--follow"}, "unknown flag --follow"),
+		Entry("scheduler:set with an unknown scheduler", domain.CommandSchedulerSet,
+			[]string{"my-app", "selected", "kubernetes"}, "invalid scheduler 'kubernetes'"),
+		Entry("proxy:set with an unknown proxy", domain.CommandProxySet, []string{"my-app", "bogus-proxy"}, "invalid proxy type 'bogus-proxy'"),
+		Entry("maintenance:disable without an app", domain.CommandMaintenanceDisable, []string{}, "missing required argument app"),
+		Entry("letsencrypt:disable without an app", domain.CommandLetsEncryptDisable, []string{}, "missing required argument app"),
+		Entry("letsencrypt:list with arguments", domain.CommandLetsEncryptList, []string{"my-app"}, "too many arguments"),
+		Entry("docker-options:add with an unknown phase", domain.CommandDockerOptionsAdd,
+			[]string{"my-app", "release", "--add-host", "db:10.0.0.2"}, "invalid docker-options phase 'release'"),
+		Entry("docker-options:add without an option", domain.CommandDockerOptionsAdd, []string{"my-app", "deploy"}, "missing required argument option"),
+		Entry("docker-options:add with a value only", domain.CommandDockerOptionsAdd,
+			[]string{"my-app", "deploy", "db:10.0.0.2"}, "must be a flag"),
+		Entry("version with arguments", domain.CommandVersion, []string{"extra"}, "too many arguments"),
+		Entry("unknown command", domain.CoreCommand("apps:destroy"), []string{"my-app"}, "invalid core command"),
+	)
+
+	DescribeTable("registry, logs and maintenance commands are allowed",
+		func(command domain.CoreCommand) {
+			Expect(command.IsValid()).To(BeTrue())
+			Expect(domain.GetAllowedCoreCommands()).To(ContainElement(command))
+		},
+		Entry("registry:login", domain.CommandRegistryLogin),
+		Entry("registry:logout", domain.CommandRegistryLogout),
+		Entry("registry:set", domain.CommandRegistrySet),
+		Entry("registry:report", domain.CommandRegistryReport),
+		Entry("registry:pull", domain.CommandRegistryPull),
+		Entry("registry:push", domain.CommandRegistryPush),
+		Entry("logs", domain.CommandLogs),
+		Entry("logs:failed", domain.CommandLogsFailed),
+		Entry("logs:set", domain.CommandLogsSet),
+		Entry("maintenance:enable", domain.CommandMaintenanceEnable),
+		Entry("maintenance:disable", domain.CommandMaintenanceDisable),
+		Entry("maintenance:report", domain.CommandMaintenanceReport),
+	)
+
+	It("should list every allowed command once", func() {
+		allowed := domain.GetAllowedCoreCommands()
+		Expect(allowed).To(HaveLen(37))
+		seen := make(map[domain.CoreCommand]bool)
+		for _, command := range allowed {
+			Expect(command.IsValid()).To(BeTrue())
+			Expect(seen[command]).To(BeFalse(), "duplicate command %s", command)
+			seen[command] = true
+		}
+	})
+
+	DescribeTable("AvailableIn",
+		func(command domain.CoreCommand, version domain.DokkuVersion, available bool) {
+			Expect(command.AvailableIn(version)).To(Equal(available))
+		},
+		Entry("registry:push in a recent release", domain.CommandRegistryPush, domain.DokkuVersion{Minor: 36, Patch: 7}, true),
+		Entry("registry:push in an old release", domain.CommandRegistryPush, domain.DokkuVersion{Minor: 30, Patch: 2}, false),
+		Entry("registry:push in its first release", domain.CommandRegistryPush, domain.DokkuVersion{Minor: 35}, true),
+		Entry("version in any release", domain.CommandVersion, domain.DokkuVersion{Minor: 20}, true),
+		Entry("unknown command", domain.CoreCommand("apps:destroy"), domain.DokkuVersion{Minor: 36}, false),
+	)
+
+	It("should only list the commands available in a release", func() {
+		old := domain.GetAvailableCoreCommands(domain.DokkuVersion{Minor: 24})
+		Expect(old).To(ContainElement(domain.CommandPluginList))
+		Expect(old).NotTo(ContainElement(domain.CommandSchedulerSet))
+		Expect(old).NotTo(ContainElement(domain.CommandRegistryPull))
+
+		Expect(domain.GetAvailableCoreCommands(domain.DokkuVersion{Minor: 36, Patch: 7})).To(Equal(domain.GetAllowedCoreCommands()))
+	})
+
+	It("should tell a followed logs invocation from a bounded one", func() {
+		Expect(domain.CommandLogs.IsStreaming([]string{"my-app", "--tail"})).To(BeTrue())
+		Expect(domain.CommandLogs.IsStreaming([]string{"my-app", "--num", "100"})).To(BeFalse())
+		Expect(domain.CommandLogsFailed.IsStreaming([]string{"my-app"})).To(BeFalse())
+	})
+
+	It("should flag registry:login arguments as sensitive", func() {
+		Expect(domain.CommandRegistryLogin.HasSensitiveArgs()).To(BeTrue())
+		Expect(domain.CommandRegistryLogout.HasSensitiveArgs()).To(BeFalse())
+	})
+
+	It("should expose the spec of a command", func() {
+		spec := domain.CommandPluginInstall.ArgSpec()
+		Expect(spec.Required).To(Equal([]string{"source"}))
+		Expect(spec.ValueFlags).To(ContainElements("--committish", "--name"))
+		Expect(domain.CommandGitReport.ArgSpec().Unchecked).To(BeTrue())
+	})
+})
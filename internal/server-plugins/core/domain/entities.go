@@ -33,6 +33,7 @@ type SSHKey struct {
 	Fingerprint string    `json:"fingerprint"`
 	KeyType     string    `json:"key_type"`
 	Comment     string    `json:"comment"`
+	PublicKey   string    `json:"public_key,omitempty"`
 	AddedAt     time.Time `json:"added_at"`
 }
 
@@ -0,0 +1,115 @@
+package domain
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// syslogTimestampLayout is the timestamp of the classic syslog format, without a year
+const syslogTimestampLayout = "Jan _2 15:04:05"
+
+var (
+	// syslogEventRegex matches an events line in the classic syslog format:
+	// Jul  3 16:09:48 dokku dokku[127630]: INVOKED: post-deploy( my-app 5000 )
+	syslogEventRegex = regexp.MustCompile(`^([A-Z][a-z]{2}\s+\d{1,2} \d{2}:\d{2}:\d{2})\s+\S+\s+(.*)$`)
+	// isoEventRegex matches an events line with an RFC 3339 timestamp, as written by rsyslog
+	// with high precision timestamps
+	isoEventRegex = regexp.MustCompile(`^(\d{4}-\d{2}-\d{2}T\S+)\s+\S+\s+(.*)$`)
+	// eventMessageRegex matches the message of an event: dokku[pid]: STATUS: command( args )
+	eventMessageRegex = regexp.MustCompile(`^[\w.-]+(?:\[\d+\])?:\s+([A-Z_]+):\s+([\w:.-]+)\s*(?:\((.*)\))?`)
+)
+
+// DokkuEvent is an entry of the Dokku events log, a plugin trigger Dokku ran
+type DokkuEvent struct {
+	Timestamp time.Time `json:"timestamp"`
+	// Command is the trigger, e.g. post-deploy
+	Command string `json:"command"`
+	// App is the application the trigger ran for, empty for host-level triggers
+	App string `json:"app,omitempty"`
+	// Status is the event kind Dokku logged, e.g. INVOKED
+	Status string `json:"status"`
+	// Args are the trigger arguments after the application
+	Args []string `json:"args,omitempty"`
+}
+
+// ParseEvents parses the output of events. Lines that are not trigger events, such as
+// the events:on notice or truncated lines, are skipped. Syslog timestamps carry no
+// year: an event is dated in the current year, unless it would then be in the future.
+func ParseEvents(raw string) ([]DokkuEvent, error) {
+	return parseEvents(raw, time.Now())
+}
+
+func parseEvents(raw string, now time.Time) ([]DokkuEvent, error) {
+	events := make([]DokkuEvent, 0)
+	for _, line := range strings.Split(raw, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		var timestamp time.Time
+		var message string
+		if match := isoEventRegex.FindStringSubmatch(line); match != nil {
+			parsed, err := time.Parse(time.RFC3339Nano, match[1])
+			if err != nil {
+				return nil, fmt.Errorf("invalid timestamp in events line: %s", line)
+			}
+			timestamp, message = parsed.UTC(), match[2]
+		} else if match := syslogEventRegex.FindStringSubmatch(line); match != nil {
+			parsed, err := time.ParseInLocation(syslogTimestampLayout, match[1], now.Location())
+			if err != nil {
+				return nil, fmt.Errorf("invalid timestamp in events line: %s", line)
+			}
+			timestamp = time.Date(now.Year(), parsed.Month(), parsed.Day(),
+				parsed.Hour(), parsed.Minute(), parsed.Second(), 0, now.Location())
+			if timestamp.After(now) {
+				timestamp = timestamp.AddDate(-1, 0, 0)
+			}
+			message = match[2]
+		} else {
+			continue
+		}
+
+		match := eventMessageRegex.FindStringSubmatch(message)
+		if match == nil {
+			continue
+		}
+		event := DokkuEvent{Timestamp: timestamp, Status: match[1], Command: match[2]}
+		if args := strings.Fields(match[3]); len(args) > 0 {
+			event.App = args[0]
+			event.Args = args[1:]
+		}
+		events = append(events, event)
+	}
+
+	return events, nil
+}
+
+// FilterEventsByApp returns the events of an application, in their original order
+func FilterEventsByApp(events []DokkuEvent, app string) []DokkuEvent {
+	filtered := make([]DokkuEvent, 0)
+	for _, event := range events {
+		if event.App == app {
+			filtered = append(filtered, event)
+		}
+	}
+	return filtered
+}
+
+// FilterEventsBetween returns the events from since, included, to until, excluded.
+// A zero bound leaves that side open.
+func FilterEventsBetween(events []DokkuEvent, since, until time.Time) []DokkuEvent {
+	filtered := make([]DokkuEvent, 0)
+	for _, event := range events {
+		if !since.IsZero() && event.Timestamp.Before(since) {
+			continue
+		}
+		if !until.IsZero() && !event.Timestamp.Before(until) {
+			continue
+		}
+		filtered = append(filtered, event)
+	}
+	return filtered
+}
@@ -0,0 +1,60 @@
+package domain_test
+
+import (
+	"time"
+
+	"github.com/dokku-mcp/dokku-mcp/internal/server-plugins/core/domain"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ParseEvents", func() {
+	const raw = `2024-07-03T16:09:48.123456+00:00 dokku dokku[127630]: INVOKED: pre-release-buildpack( api )
+2024-07-03T16:10:52+00:00 dokku dokku[128114]: INVOKED: post-deploy( api 5000 dokku/api latest )
+2024-07-03T16:11:00+00:00 dokku dokku[128200]: INVOKED: post-domains-update( web add web.example.com )
+-----> Events are enabled
+2024-07-03T16:12:00+00:00 dokku dokku[128300]: INVOKED: update-vhosts
+2024-07-03T16:13:00+00:00 dokku garbled
+`
+
+	It("should parse the trigger events and skip the other lines", func() {
+		events, err := domain.ParseEvents(raw)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(events).To(HaveLen(4))
+
+		Expect(events[1]).To(Equal(domain.DokkuEvent{
+			Timestamp: time.Date(2024, 7, 3, 16, 10, 52, 0, time.UTC),
+			Command:   "post-deploy",
+			App:       "api",
+			Status:    "INVOKED",
+			Args:      []string{"5000", "dokku/api", "latest"},
+		}))
+		Expect(events[3].Command).To(Equal("update-vhosts"))
+		Expect(events[3].App).To(BeEmpty())
+	})
+
+	It("should date syslog timestamps in the past year", func() {
+		events, err := domain.ParseEvents("Jul  3 16:09:48 dokku dokku[127630]: INVOKED: post-deploy( api 5000 )")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(events).To(HaveLen(1))
+		Expect(events[0].Timestamp.Month()).To(Equal(time.July))
+		Expect(events[0].Timestamp.Day()).To(Equal(3))
+		Expect(events[0].Timestamp.After(time.Now())).To(BeFalse())
+		Expect(events[0].App).To(Equal("api"))
+	})
+
+	It("should filter the events by app and time window", func() {
+		events, err := domain.ParseEvents(raw)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(domain.FilterEventsByApp(events, "api")).To(HaveLen(2))
+		Expect(domain.FilterEventsByApp(events, "unknown")).To(BeEmpty())
+
+		since := time.Date(2024, 7, 3, 16, 10, 52, 0, time.UTC)
+		until := time.Date(2024, 7, 3, 16, 12, 0, 0, time.UTC)
+		window := domain.FilterEventsBetween(events, since, until)
+		Expect(window).To(HaveLen(2))
+		Expect(window[0].Command).To(Equal("post-deploy"))
+		Expect(domain.FilterEventsBetween(events, since, time.Time{})).To(HaveLen(3))
+	})
+})
@@ -0,0 +1,72 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// letsEncryptExpiryLayout is the layout of the expiry column of letsencrypt:list, in UTC
+const letsEncryptExpiryLayout = "2006-01-02 15:04:05"
+
+// SSLConfiguration is the SSL state of an application as the letsencrypt plugin reports it
+type SSLConfiguration struct {
+	AppName     string `json:"app_name"`
+	LetsEncrypt bool   `json:"letsencrypt"`
+	// certExpiresAt is nil when the expiry of the certificate is unknown
+	certExpiresAt *time.Time
+}
+
+// NewSSLConfiguration describes an application certificate, certExpiresAt being nil
+// when its expiry is unknown
+func NewSSLConfiguration(appName string, letsEncrypt bool, certExpiresAt *time.Time) *SSLConfiguration {
+	config := &SSLConfiguration{AppName: appName, LetsEncrypt: letsEncrypt}
+	if certExpiresAt != nil {
+		expiresAt := *certExpiresAt
+		config.certExpiresAt = &expiresAt
+	}
+	return config
+}
+
+// CertExpiresAt returns when the certificate expires, or nil if unknown
+func (c *SSLConfiguration) CertExpiresAt() *time.Time {
+	if c.certExpiresAt == nil {
+		return nil
+	}
+	expiresAt := *c.certExpiresAt
+	return &expiresAt
+}
+
+// CertificateExpiringWithin returns true if the certificate expires in less than d,
+// already expired certificates included. An unknown expiry is not reported.
+func (c *SSLConfiguration) CertificateExpiringWithin(d time.Duration) bool {
+	if c.certExpiresAt == nil {
+		return false
+	}
+	return time.Until(*c.certExpiresAt) < d
+}
+
+// ParseLetsEncryptList parses the output of letsencrypt:list, one application per line
+// after the header: <app> <expiry date> <expiry time> <time before expiry> <time before renewal>
+func ParseLetsEncryptList(raw string) ([]*SSLConfiguration, error) {
+	configs := make([]*SSLConfiguration, 0)
+	for _, line := range strings.Split(raw, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "----->") || strings.HasPrefix(line, "=====>") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) < 3 {
+			return nil, fmt.Errorf("invalid letsencrypt list line: %s", line)
+		}
+		expiresAt, err := time.ParseInLocation(letsEncryptExpiryLayout, fields[1]+" "+fields[2], time.UTC)
+		if err != nil {
+			return nil, fmt.Errorf("invalid certificate expiry in letsencrypt list line: %s", line)
+		}
+
+		configs = append(configs, NewSSLConfiguration(fields[0], true, &expiresAt))
+	}
+
+	return configs, nil
+}
@@ -0,0 +1,60 @@
+package domain_test
+
+import (
+	"time"
+
+	"github.com/dokku-mcp/dokku-mcp/internal/server-plugins/core/domain"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ParseLetsEncryptList", func() {
+	It("should parse the certificate expiry of each application", func() {
+		raw := `-----> App name           Certificate Expiry        Time before expiry        Time before renewal
+api                       2024-07-31 13:23:00       89d, 23h, 51m, 32s        59d, 23h, 51m, 32s
+web                       2024-05-02 08:00:00       0d, 10h, 0m, 0s           -29d, 14h, 0m, 0s
+`
+		configs, err := domain.ParseLetsEncryptList(raw)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(configs).To(HaveLen(2))
+		Expect(configs[0].AppName).To(Equal("api"))
+		Expect(configs[0].LetsEncrypt).To(BeTrue())
+		Expect(*configs[0].CertExpiresAt()).To(Equal(time.Date(2024, 7, 31, 13, 23, 0, 0, time.UTC)))
+		Expect(configs[1].AppName).To(Equal("web"))
+	})
+
+	It("should return no configuration for an empty list", func() {
+		configs, err := domain.ParseLetsEncryptList("-----> App name           Certificate Expiry        Time before expiry        Time before renewal\n")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(configs).To(BeEmpty())
+	})
+
+	It("should reject a line without an expiry", func() {
+		_, err := domain.ParseLetsEncryptList("api  never\n")
+		Expect(err).To(MatchError(ContainSubstring("invalid letsencrypt list line")))
+
+		_, err = domain.ParseLetsEncryptList("api  soon  later  89d\n")
+		Expect(err).To(MatchError(ContainSubstring("invalid certificate expiry")))
+	})
+})
+
+var _ = Describe("SSLConfiguration", func() {
+	It("should tell a certificate expiring soon", func() {
+		expiresAt := time.Now().Add(48 * time.Hour)
+		config := domain.NewSSLConfiguration("api", true, &expiresAt)
+
+		Expect(config.CertificateExpiringWithin(72 * time.Hour)).To(BeTrue())
+		Expect(config.CertificateExpiringWithin(24 * time.Hour)).To(BeFalse())
+	})
+
+	It("should report an expired certificate", func() {
+		expiresAt := time.Now().Add(-time.Hour)
+		Expect(domain.NewSSLConfiguration("api", true, &expiresAt).CertificateExpiringWithin(0)).To(BeTrue())
+	})
+
+	It("should not report an unknown expiry", func() {
+		config := domain.NewSSLConfiguration("api", false, nil)
+		Expect(config.CertExpiresAt()).To(BeNil())
+		Expect(config.CertificateExpiringWithin(24 * time.Hour)).To(BeFalse())
+	})
+})
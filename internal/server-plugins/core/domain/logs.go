@@ -0,0 +1,51 @@
+package domain
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	// DefaultLogLines is the number of lines fetched when none is given
+	DefaultLogLines = 100
+	// MaxLogLines bounds a single fetch
+	MaxLogLines = 5000
+)
+
+// LogsQuery is a bounded fetch of the last lines of an application logs.
+// Following the logs (logs --tail) streams until interrupted and is not a LogsQuery.
+type LogsQuery struct {
+	AppName     string `json:"app_name"`
+	ProcessType string `json:"process_type,omitempty"`
+	Lines       int    `json:"lines"`
+}
+
+// NewLogsQuery creates a logs query. A lines value of 0 uses DefaultLogLines.
+func NewLogsQuery(appName, processType string, lines int) (*LogsQuery, error) {
+	appName = strings.TrimSpace(appName)
+	if appName == "" {
+		return nil, fmt.Errorf("application name is required")
+	}
+	if lines < 0 || lines > MaxLogLines {
+		return nil, fmt.Errorf("lines must be between 1 and %d, got %d", MaxLogLines, lines)
+	}
+	if lines == 0 {
+		lines = DefaultLogLines
+	}
+
+	return &LogsQuery{
+		AppName:     appName,
+		ProcessType: strings.TrimSpace(processType),
+		Lines:       lines,
+	}, nil
+}
+
+// Args returns the logs command arguments, never following the output
+func (q *LogsQuery) Args() []string {
+	args := []string{q.AppName, "--num", strconv.Itoa(q.Lines)}
+	if q.ProcessType != "" {
+		args = append(args, "--ps", q.ProcessType)
+	}
+	return args
+}
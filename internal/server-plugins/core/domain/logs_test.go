@@ -0,0 +1,37 @@
+package domain_test
+
+import (
+	"github.com/dokku-mcp/dokku-mcp/internal/server-plugins/core/domain"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("LogsQuery", func() {
+	It("should build a bounded fetch filtered by process type", func() {
+		query, err := domain.NewLogsQuery("my-app", "worker", 50)
+		Expect(err).NotTo(HaveOccurred())
+
+		args := query.Args()
+		Expect(args).To(Equal([]string{"my-app", "--num", "50", "--ps", "worker"}))
+		Expect(domain.CommandLogs.Validate(args)).To(Succeed())
+		Expect(domain.CommandLogs.IsStreaming(args)).To(BeFalse())
+	})
+
+	It("should default the number of lines", func() {
+		query, err := domain.NewLogsQuery("my-app", "", 0)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(query.Lines).To(Equal(domain.DefaultLogLines))
+		Expect(query.Args()).NotTo(ContainElement("--ps"))
+	})
+
+	It("should reject an invalid query", func() {
+		_, err := domain.NewLogsQuery(" ", "web", 10)
+		Expect(err).To(HaveOccurred())
+
+		_, err = domain.NewLogsQuery("my-app", "web", -1)
+		Expect(err).To(HaveOccurred())
+
+		_, err = domain.NewLogsQuery("my-app", "web", domain.MaxLogLines+1)
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -0,0 +1,84 @@
+package domain
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Plugin statuses listed by plugin:list
+const (
+	PluginStatusEnabled  = "enabled"
+	PluginStatusDisabled = "disabled"
+)
+
+// corePluginPrefixRegex matches the ordering prefix of core plugin names, e.g. 20_ in 20_events
+var corePluginPrefixRegex = regexp.MustCompile(`^[0-9]+_`)
+
+// PluginInfo is a plugin listed by `plugin:list`
+type PluginInfo struct {
+	Name        string `json:"name"`
+	Version     string `json:"version"`
+	Enabled     bool   `json:"enabled"`
+	Core        bool   `json:"core"`
+	Description string `json:"description,omitempty"`
+}
+
+// ShortName returns the plugin name without the ordering prefix of core plugins
+func (p PluginInfo) ShortName() string {
+	return corePluginPrefixRegex.ReplaceAllString(p.Name, "")
+}
+
+// ParsePluginList parses the output of plugin:list, whose columns are separated by
+// any mix of tabs and spaces: name, version, status and a free description.
+// Core plugins are told apart by their "dokku core" description.
+func ParsePluginList(raw string) ([]PluginInfo, error) {
+	plugins := make([]PluginInfo, 0)
+	for _, line := range strings.Split(raw, "\n") {
+		line = strings.TrimSpace(line)
+		// Skip the headers and the plugn version line of older Dokku releases
+		if line == "" || strings.HasPrefix(line, "----->") || strings.HasPrefix(line, "=====>") ||
+			strings.HasPrefix(line, "plugn:") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) < 3 {
+			return nil, fmt.Errorf("invalid plugin list line: %s", line)
+		}
+
+		plugin := PluginInfo{
+			Name:        fields[0],
+			Version:     fields[1],
+			Description: strings.Join(fields[3:], " "),
+		}
+		switch fields[2] {
+		case PluginStatusEnabled:
+			plugin.Enabled = true
+		case PluginStatusDisabled:
+		default:
+			return nil, fmt.Errorf("invalid status '%s' of plugin %s", fields[2], plugin.Name)
+		}
+		plugin.Core = strings.HasPrefix(strings.ToLower(plugin.Description), "dokku core")
+
+		plugins = append(plugins, plugin)
+	}
+
+	return plugins, nil
+}
+
+// FindPlugin looks a plugin up by name, core plugins matching with or without their prefix
+func FindPlugin(plugins []PluginInfo, name string) (PluginInfo, bool) {
+	for _, plugin := range plugins {
+		if plugin.Name == name || plugin.ShortName() == name {
+			return plugin, true
+		}
+	}
+	return PluginInfo{}, false
+}
+
+// IsPluginEnabled returns true if the named plugin is installed and enabled
+func IsPluginEnabled(plugins []PluginInfo, name string) bool {
+	plugin, found := FindPlugin(plugins, name)
+	return found && plugin.Enabled
+}
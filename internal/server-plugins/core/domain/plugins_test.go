@@ -0,0 +1,56 @@
+package domain_test
+
+import (
+	"github.com/dokku-mcp/dokku-mcp/internal/server-plugins/core/domain"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ParsePluginList", func() {
+	It("should parse core and community plugins whatever the spacing", func() {
+		plugins, err := domain.ParsePluginList("plugn: 0.13.0\n" +
+			"  00_dokku-standard    0.35.12 enabled    dokku core standard plugin\n" +
+			"  20_events\t0.35.12\tdisabled\tdokku core events logging plugin\n" +
+			"  postgres             1.41.0  enabled    dokku postgres service plugin\n")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(plugins).To(HaveLen(3))
+
+		Expect(plugins[0]).To(Equal(domain.PluginInfo{
+			Name:        "00_dokku-standard",
+			Version:     "0.35.12",
+			Enabled:     true,
+			Core:        true,
+			Description: "dokku core standard plugin",
+		}))
+		Expect(plugins[1].Enabled).To(BeFalse())
+		Expect(plugins[1].ShortName()).To(Equal("events"))
+		Expect(plugins[2].Core).To(BeFalse())
+		Expect(plugins[2].Version).To(Equal("1.41.0"))
+	})
+
+	It("should return no plugin for an empty output", func() {
+		plugins, err := domain.ParsePluginList("")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(plugins).To(BeEmpty())
+	})
+
+	It("should reject malformed lines", func() {
+		_, err := domain.ParsePluginList("postgres 1.41.0")
+		Expect(err).To(HaveOccurred())
+		_, err = domain.ParsePluginList("postgres 1.41.0 broken dokku postgres service plugin")
+		Expect(err).To(MatchError(ContainSubstring("invalid status 'broken'")))
+	})
+
+	It("should tell whether a plugin is installed and enabled", func() {
+		plugins, err := domain.ParsePluginList(`postgres 1.41.0 enabled dokku postgres service plugin
+redis 1.38.0 disabled dokku redis service plugin
+20_events 0.35.12 enabled dokku core events logging plugin`)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(domain.IsPluginEnabled(plugins, "postgres")).To(BeTrue())
+		Expect(domain.IsPluginEnabled(plugins, "redis")).To(BeFalse())
+		Expect(domain.IsPluginEnabled(plugins, "mysql")).To(BeFalse())
+		Expect(domain.IsPluginEnabled(plugins, "events")).To(BeTrue())
+		Expect(domain.IsPluginEnabled(plugins, "20_events")).To(BeTrue())
+	})
+})
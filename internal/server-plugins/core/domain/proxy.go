@@ -0,0 +1,161 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dokku-mcp/dokku-mcp/internal/shared"
+)
+
+// ProxyType is a proxy implementation Dokku can route traffic through
+type ProxyType string
+
+const (
+	ProxyTypeNginx   ProxyType = "nginx"
+	ProxyTypeTraefik ProxyType = "traefik"
+	ProxyTypeCaddy   ProxyType = "caddy"
+	ProxyTypeHAProxy ProxyType = "haproxy"
+)
+
+// DefaultProxyType is the proxy Dokku uses when none is set
+const DefaultProxyType = ProxyTypeNginx
+
+// NewProxyType creates a proxy type, rejecting unknown implementations
+func NewProxyType(value string) (ProxyType, error) {
+	proxyType := ProxyType(strings.ToLower(strings.TrimSpace(value)))
+	if !proxyType.IsValid() {
+		return "", fmt.Errorf("invalid proxy type '%s', must be one of: %v", value, GetProxyTypes())
+	}
+	return proxyType, nil
+}
+
+// IsValid checks if the proxy type is a known implementation
+func (p ProxyType) IsValid() bool {
+	switch p {
+	case ProxyTypeNginx, ProxyTypeTraefik, ProxyTypeCaddy, ProxyTypeHAProxy:
+		return true
+	default:
+		return false
+	}
+}
+
+// String returns the string representation of the proxy type
+func (p ProxyType) String() string {
+	return string(p)
+}
+
+// GetProxyTypes returns all known proxy types
+func GetProxyTypes() []ProxyType {
+	return []ProxyType{
+		ProxyTypeNginx,
+		ProxyTypeTraefik,
+		ProxyTypeCaddy,
+		ProxyTypeHAProxy,
+	}
+}
+
+// ProxyReport is the typed form of `proxy:report <app>`, optionally followed by
+// the `ports:report <app>` and `domains:report <app>` sections
+type ProxyReport struct {
+	AppName      string    `json:"app_name"`
+	Enabled      bool      `json:"enabled"`
+	Type         ProxyType `json:"type,omitempty"`
+	ComputedType ProxyType `json:"computed_type,omitempty"`
+	GlobalType   ProxyType `json:"global_type,omitempty"`
+	// PortMap is the mapping set for the app, overriding the detected one
+	PortMap []shared.PortMapping `json:"port_map"`
+	// DetectedPortMap is the mapping Dokku derives from the image and the host settings
+	DetectedPortMap []shared.PortMapping `json:"detected_port_map"`
+	VhostsEnabled   bool                 `json:"vhosts_enabled"`
+	Vhosts          []string             `json:"vhosts"`
+}
+
+// ActiveType returns the proxy the app actually uses: the computed type, falling back
+// on the app then global settings, and on the Dokku default
+func (r *ProxyReport) ActiveType() ProxyType {
+	for _, proxyType := range []ProxyType{r.ComputedType, r.Type, r.GlobalType} {
+		if proxyType != "" {
+			return proxyType
+		}
+	}
+	return DefaultProxyType
+}
+
+// EffectivePortMap returns the mapping the proxy serves: the app mapping if set,
+// the detected one otherwise
+func (r *ProxyReport) EffectivePortMap() []shared.PortMapping {
+	if len(r.PortMap) > 0 {
+		return r.PortMap
+	}
+	return r.DetectedPortMap
+}
+
+// ParseProxyReport parses the output of proxy:report for a single application
+func ParseProxyReport(raw string) (*ProxyReport, error) {
+	report := &ProxyReport{
+		PortMap:         make([]shared.PortMapping, 0),
+		DetectedPortMap: make([]shared.PortMapping, 0),
+		Vhosts:          make([]string, 0),
+	}
+
+	found := false
+	for _, line := range strings.Split(raw, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		if strings.HasPrefix(line, "=====>") {
+			fields := strings.Fields(strings.TrimPrefix(line, "=====>"))
+			if len(fields) > 0 && report.AppName == "" {
+				report.AppName = fields[0]
+			}
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		value = strings.TrimSpace(value)
+
+		var err error
+		switch strings.ToLower(strings.TrimSpace(key)) {
+		case "proxy enabled":
+			report.Enabled = value == "true"
+			found = true
+		case "proxy type":
+			report.Type, err = parseReportedProxyType(value)
+			found = true
+		case "proxy computed type":
+			report.ComputedType, err = parseReportedProxyType(value)
+		case "proxy global type":
+			report.GlobalType, err = parseReportedProxyType(value)
+		case "proxy port map", "ports map":
+			report.PortMap, err = shared.ParsePortMappings(value)
+		case "ports map detected":
+			report.DetectedPortMap, err = shared.ParsePortMappings(value)
+		case "domains app enabled":
+			report.VhostsEnabled = value == "true"
+		case "domains app vhosts":
+			report.Vhosts = strings.Fields(value)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy report: %w", err)
+		}
+	}
+
+	if !found {
+		return nil, fmt.Errorf("invalid proxy report: no proxy section")
+	}
+
+	return report, nil
+}
+
+// parseReportedProxyType parses a proxy type from a report, where an empty value means unset
+func parseReportedProxyType(value string) (ProxyType, error) {
+	if value == "" {
+		return "", nil
+	}
+	return NewProxyType(value)
+}
@@ -0,0 +1,96 @@
+package domain_test
+
+import (
+	"github.com/dokku-mcp/dokku-mcp/internal/server-plugins/core/domain"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ProxyType", func() {
+	DescribeTable("NewProxyType",
+		func(value string, expected domain.ProxyType, valid bool) {
+			proxyType, err := domain.NewProxyType(value)
+			if !valid {
+				Expect(err).To(HaveOccurred())
+				return
+			}
+			Expect(err).NotTo(HaveOccurred())
+			Expect(proxyType).To(Equal(expected))
+		},
+		Entry("nginx", "nginx", domain.ProxyTypeNginx, true),
+		Entry("traefik", "traefik", domain.ProxyTypeTraefik, true),
+		Entry("caddy with surrounding spaces", " caddy ", domain.ProxyTypeCaddy, true),
+		Entry("haproxy in uppercase", "HAProxy", domain.ProxyTypeHAProxy, true),
+		Entry("unknown proxy", "bogus-proxy", domain.ProxyType(""), false),
+		Entry("empty value", "", domain.ProxyType(""), false),
+	)
+})
+
+var _ = Describe("ParseProxyReport", func() {
+	It("should parse the proxy and vhost settings of an app", func() {
+		report, err := domain.ParseProxyReport(`=====> my-app proxy information
+       Proxy enabled:                 true
+       Proxy type:                    traefik
+       Proxy computed type:           traefik
+       Proxy global type:             nginx
+       Proxy port map:                http:80:5000 https:443:5000
+=====> my-app domains information
+       Domains app enabled:           true
+       Domains app vhosts:            my-app.example.com`)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(report.AppName).To(Equal("my-app"))
+		Expect(report.Enabled).To(BeTrue())
+		Expect(report.Type).To(Equal(domain.ProxyTypeTraefik))
+		Expect(report.GlobalType).To(Equal(domain.ProxyTypeNginx))
+		Expect(report.ActiveType()).To(Equal(domain.ProxyTypeTraefik))
+		Expect(report.PortMap).To(HaveLen(2))
+		Expect(report.PortMap[0].String()).To(Equal("http:80:5000"))
+		Expect(report.PortMap[1].String()).To(Equal("https:443:5000"))
+		Expect(report.VhostsEnabled).To(BeTrue())
+		Expect(report.Vhosts).To(Equal([]string{"my-app.example.com"}))
+	})
+
+	It("should fall back on the global then default proxy", func() {
+		report, err := domain.ParseProxyReport(`=====> my-app proxy information
+       Proxy enabled:                 false
+       Proxy type:
+       Proxy global type:             caddy`)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(report.Enabled).To(BeFalse())
+		Expect(report.ActiveType()).To(Equal(domain.ProxyTypeCaddy))
+
+		Expect((&domain.ProxyReport{}).ActiveType()).To(Equal(domain.DefaultProxyType))
+	})
+
+	It("should fall back on the detected port map without an app override", func() {
+		report, err := domain.ParseProxyReport(`=====> my-app proxy information
+       Proxy enabled:                 true
+=====> my-app ports information
+       Ports map:
+       Ports map detected:            http:80:5000`)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(report.PortMap).To(BeEmpty())
+		Expect(report.EffectivePortMap()).To(HaveLen(1))
+		Expect(report.EffectivePortMap()[0].String()).To(Equal("http:80:5000"))
+	})
+
+	It("should reject an invalid port mapping", func() {
+		_, err := domain.ParseProxyReport(`=====> my-app proxy information
+       Proxy enabled:                 true
+       Proxy port map:                http:80`)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should reject an unknown proxy type", func() {
+		_, err := domain.ParseProxyReport(`=====> my-app proxy information
+       Proxy enabled:                 true
+       Proxy type:                    bogus-proxy`)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should reject output that is not a proxy report", func() {
+		_, err := domain.ParseProxyReport("=====> my-app ps information")
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -47,3 +47,16 @@ type ConfigurationRepository interface {
 	SetVectorSink(ctx context.Context, sink string) error
 	GetConfigurationKeys(ctx context.Context, scope string) ([]ConfigurationKey, error)
 }
+
+// ServiceDataRepository defines methods for transferring the data of service plugins
+type ServiceDataRepository interface {
+	ExportServiceData(ctx context.Context, command ServiceCommand, service, output string) error
+	ImportServiceData(ctx context.Context, command ServiceCommand, service, input string) error
+	BackupServiceData(ctx context.Context, command ServiceCommand, service, bucket string) error
+}
+
+// LogsRepository defines methods for fetching application logs
+type LogsRepository interface {
+	GetLogs(ctx context.Context, query *LogsQuery) (string, error)
+	GetFailedLogs(ctx context.Context, appName string) (string, error)
+}
@@ -0,0 +1,132 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SchedulerType is a scheduler Dokku can run app containers with
+type SchedulerType string
+
+const (
+	SchedulerTypeDockerLocal SchedulerType = "docker-local"
+	SchedulerTypeK3s         SchedulerType = "k3s"
+	SchedulerTypeNomad       SchedulerType = "nomad"
+)
+
+// DefaultSchedulerType is the scheduler Dokku uses when none is selected
+const DefaultSchedulerType = SchedulerTypeDockerLocal
+
+// NewSchedulerType creates a scheduler type, rejecting unknown schedulers
+func NewSchedulerType(value string) (SchedulerType, error) {
+	schedulerType := SchedulerType(strings.ToLower(strings.TrimSpace(value)))
+	if !schedulerType.IsValid() {
+		return "", fmt.Errorf("invalid scheduler '%s', must be one of: %v", value, GetSchedulerTypes())
+	}
+	return schedulerType, nil
+}
+
+// IsValid checks if the scheduler type is a known scheduler
+func (s SchedulerType) IsValid() bool {
+	switch s {
+	case SchedulerTypeDockerLocal, SchedulerTypeK3s, SchedulerTypeNomad:
+		return true
+	default:
+		return false
+	}
+}
+
+// String returns the string representation of the scheduler type
+func (s SchedulerType) String() string {
+	return string(s)
+}
+
+// GetSchedulerTypes returns all known scheduler types
+func GetSchedulerTypes() []SchedulerType {
+	return []SchedulerType{
+		SchedulerTypeDockerLocal,
+		SchedulerTypeK3s,
+		SchedulerTypeNomad,
+	}
+}
+
+// SchedulerReport is the typed form of `scheduler:report <app>`. The selected types are
+// kept as reported, so a scheduler unknown to this version stays readable: check IsValid.
+type SchedulerReport struct {
+	AppName          string        `json:"app_name"`
+	Selected         SchedulerType `json:"selected,omitempty"`
+	ComputedSelected SchedulerType `json:"computed_selected,omitempty"`
+	GlobalSelected   SchedulerType `json:"global_selected,omitempty"`
+	// Options holds every other scheduler property, keyed like scheduler:set properties
+	Options map[string]string `json:"options"`
+}
+
+// ActiveScheduler returns the scheduler the app actually uses: the computed selection,
+// falling back on the app then global selection, and on the Dokku default
+func (r *SchedulerReport) ActiveScheduler() SchedulerType {
+	for _, schedulerType := range []SchedulerType{r.ComputedSelected, r.Selected, r.GlobalSelected} {
+		if schedulerType != "" {
+			return schedulerType
+		}
+	}
+	return DefaultSchedulerType
+}
+
+// ParseSchedulerReport parses the output of scheduler:report for a single application.
+// Lines it does not know about, such as the ones added by newer Dokku versions, end up
+// in the options rather than failing the parse.
+func ParseSchedulerReport(raw string) (*SchedulerReport, error) {
+	report := &SchedulerReport{
+		Options: make(map[string]string),
+	}
+
+	found := false
+	for _, line := range strings.Split(raw, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		if strings.HasPrefix(line, "=====>") {
+			fields := strings.Fields(strings.TrimPrefix(line, "=====>"))
+			if len(fields) > 0 && report.AppName == "" {
+				report.AppName = fields[0]
+			}
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		key = strings.ToLower(strings.TrimSpace(key))
+		value = strings.TrimSpace(value)
+
+		switch key {
+		case "scheduler selected":
+			report.Selected = SchedulerType(value)
+			found = true
+		case "scheduler computed selected":
+			report.ComputedSelected = SchedulerType(value)
+			found = true
+		case "scheduler global selected":
+			report.GlobalSelected = SchedulerType(value)
+			found = true
+		default:
+			if property, isScheduler := strings.CutPrefix(key, "scheduler "); isScheduler {
+				report.Options[strings.ReplaceAll(property, " ", "-")] = value
+			}
+		}
+	}
+
+	if !found {
+		return nil, fmt.Errorf("invalid scheduler report: no scheduler section")
+	}
+
+	return report, nil
+}
+
+func validateSchedulerType(value string) error {
+	_, err := NewSchedulerType(value)
+	return err
+}
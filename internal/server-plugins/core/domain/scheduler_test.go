@@ -0,0 +1,70 @@
+package domain_test
+
+import (
+	"github.com/dokku-mcp/dokku-mcp/internal/server-plugins/core/domain"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SchedulerType", func() {
+	DescribeTable("NewSchedulerType",
+		func(value string, expected domain.SchedulerType, valid bool) {
+			schedulerType, err := domain.NewSchedulerType(value)
+			if !valid {
+				Expect(err).To(HaveOccurred())
+				return
+			}
+			Expect(err).NotTo(HaveOccurred())
+			Expect(schedulerType).To(Equal(expected))
+		},
+		Entry("docker-local", "docker-local", domain.SchedulerTypeDockerLocal, true),
+		Entry("k3s", "k3s", domain.SchedulerTypeK3s, true),
+		Entry("nomad in uppercase", "Nomad", domain.SchedulerTypeNomad, true),
+		Entry("unknown scheduler", "kubernetes", domain.SchedulerType(""), false),
+		Entry("empty value", "", domain.SchedulerType(""), false),
+	)
+})
+
+var _ = Describe("ParseSchedulerReport", func() {
+	It("should parse the selected scheduler and its options", func() {
+		report, err := domain.ParseSchedulerReport(`=====> my-app scheduler information
+       Scheduler computed selected:   k3s
+       Scheduler global selected:     docker-local
+       Scheduler selected:            k3s
+       Scheduler k3s deploy timeout:  300s`)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(report.AppName).To(Equal("my-app"))
+		Expect(report.Selected).To(Equal(domain.SchedulerTypeK3s))
+		Expect(report.GlobalSelected).To(Equal(domain.SchedulerTypeDockerLocal))
+		Expect(report.ActiveScheduler()).To(Equal(domain.SchedulerTypeK3s))
+		Expect(report.Options).To(Equal(map[string]string{"k3s-deploy-timeout": "300s"}))
+	})
+
+	It("should tolerate lines and schedulers it does not know about", func() {
+		report, err := domain.ParseSchedulerReport(`=====> my-app scheduler information
+       Scheduler computed selected:   kubernetes
+       Scheduler global selected:     docker-local
+       Scheduler selected:
+       Scheduler shiny new option:    true
+       Something else entirely:       42`)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(report.ActiveScheduler()).To(Equal(domain.SchedulerType("kubernetes")))
+		Expect(report.ActiveScheduler().IsValid()).To(BeFalse())
+		Expect(report.Options).To(HaveKeyWithValue("shiny-new-option", "true"))
+		Expect(report.Options).NotTo(HaveKey("something-else-entirely"))
+	})
+
+	It("should default to docker-local when nothing is selected", func() {
+		report, err := domain.ParseSchedulerReport(`=====> my-app scheduler information
+       Scheduler selected:`)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(report.ActiveScheduler()).To(Equal(domain.DefaultSchedulerType))
+	})
+
+	It("should reject output that is not a scheduler report", func() {
+		_, err := domain.ParseSchedulerReport("=====> my-app proxy information")
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -0,0 +1,151 @@
+package domain
+
+import (
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// ServiceVerb is a data transfer verb a service plugin may expose, as <plugin>:<verb>
+type ServiceVerb string
+
+const (
+	// ServiceVerbExport dumps the service data to stdout, written to the output target
+	ServiceVerbExport ServiceVerb = "export"
+	// ServiceVerbImport loads the service data from stdin, read from the input target
+	ServiceVerbImport ServiceVerb = "import"
+	// ServiceVerbBackup uploads a dump of the service data to an S3 bucket
+	ServiceVerbBackup ServiceVerb = "backup"
+)
+
+// serviceVerbSpecs declares the arguments of each verb. Every verb requires the
+// target the data is transferred from or to.
+var serviceVerbSpecs = map[ServiceVerb]CommandArgSpec{
+	ServiceVerbExport: {Required: []string{"service", "output"}, Values: map[string]func(string) error{"output": validateTransferTarget}},
+	ServiceVerbImport: {Required: []string{"service", "input"}, Values: map[string]func(string) error{"input": validateTransferTarget}},
+	ServiceVerbBackup: {Required: []string{"service", "bucket-name"}, Flags: []string{"--use-iam"}},
+}
+
+var servicePluginNameRegex = regexp.MustCompile(`^[a-z][a-z0-9-]*$`)
+
+var (
+	servicePluginsMu sync.RWMutex
+	// servicePlugins maps the service plugins to the verbs they declared
+	servicePlugins = map[string][]ServiceVerb{
+		"postgres": {ServiceVerbExport, ServiceVerbImport, ServiceVerbBackup},
+		"mysql":    {ServiceVerbExport, ServiceVerbImport, ServiceVerbBackup},
+		"mariadb":  {ServiceVerbExport, ServiceVerbImport, ServiceVerbBackup},
+		"mongo":    {ServiceVerbExport, ServiceVerbImport, ServiceVerbBackup},
+		"redis":    {ServiceVerbExport, ServiceVerbImport, ServiceVerbBackup},
+	}
+)
+
+// RegisterServicePlugin declares the data transfer verbs of a service plugin, replacing
+// any previous declaration. The official datastore plugins are declared by default.
+func RegisterServicePlugin(plugin string, verbs ...ServiceVerb) error {
+	if !servicePluginNameRegex.MatchString(plugin) {
+		return fmt.Errorf("invalid service plugin name '%s'", plugin)
+	}
+	if len(verbs) == 0 {
+		return fmt.Errorf("service plugin %s must declare at least one verb", plugin)
+	}
+	for _, verb := range verbs {
+		if _, exists := serviceVerbSpecs[verb]; !exists {
+			return fmt.Errorf("invalid service verb '%s' for plugin %s", verb, plugin)
+		}
+	}
+
+	servicePluginsMu.Lock()
+	defer servicePluginsMu.Unlock()
+
+	servicePlugins[plugin] = slices.Clone(verbs)
+	return nil
+}
+
+// GetServicePlugins returns the names of the declared service plugins, sorted
+func GetServicePlugins() []string {
+	servicePluginsMu.RLock()
+	defer servicePluginsMu.RUnlock()
+
+	return slices.Sorted(maps.Keys(servicePlugins))
+}
+
+// ServiceCommand is a data transfer command of a service plugin, e.g. postgres:export
+type ServiceCommand struct {
+	plugin string
+	verb   ServiceVerb
+}
+
+// NewServiceCommand creates the command of a verb the plugin declared
+func NewServiceCommand(plugin string, verb ServiceVerb) (ServiceCommand, error) {
+	servicePluginsMu.RLock()
+	verbs, exists := servicePlugins[plugin]
+	servicePluginsMu.RUnlock()
+
+	if !exists {
+		return ServiceCommand{}, fmt.Errorf("unknown service plugin '%s'. Valid plugins: %s",
+			plugin, strings.Join(GetServicePlugins(), ", "))
+	}
+	if !slices.Contains(verbs, verb) {
+		return ServiceCommand{}, fmt.Errorf("service plugin %s does not support %s", plugin, verb)
+	}
+	return ServiceCommand{plugin: plugin, verb: verb}, nil
+}
+
+// Plugin returns the service plugin name
+func (c ServiceCommand) Plugin() string {
+	return c.plugin
+}
+
+// Verb returns the data transfer verb
+func (c ServiceCommand) Verb() ServiceVerb {
+	return c.verb
+}
+
+// String returns the Dokku command, e.g. postgres:export
+func (c ServiceCommand) String() string {
+	return c.plugin + ":" + string(c.verb)
+}
+
+// ArgSpec returns the argument spec of the command
+func (c ServiceCommand) ArgSpec() CommandArgSpec {
+	return serviceVerbSpecs[c.verb]
+}
+
+// Validate checks the arguments against the command spec before execution
+func (c ServiceCommand) Validate(args []string) error {
+	if c.plugin == "" {
+		return fmt.Errorf("invalid service command: %s", c)
+	}
+	return c.ArgSpec().validate(c.String(), args)
+}
+
+// DokkuArgs returns the arguments passed to Dokku. The export output and import input
+// are local targets handled by the caller, through stdout and stdin.
+func (c ServiceCommand) DokkuArgs(args []string) []string {
+	if c.verb == ServiceVerbBackup || len(args) == 0 {
+		return args
+	}
+	return args[:1]
+}
+
+// Target returns the target of the transfer: the output, input or bucket name
+func (c ServiceCommand) Target(args []string) string {
+	positional := slices.DeleteFunc(slices.Clone(args), func(arg string) bool {
+		return strings.HasPrefix(arg, "--")
+	})
+	if len(positional) < 2 {
+		return ""
+	}
+	return positional[1]
+}
+
+func validateTransferTarget(value string) error {
+	if strings.ContainsAny(value, "\x00\n\r") {
+		return fmt.Errorf("invalid transfer target '%s'", value)
+	}
+	return nil
+}
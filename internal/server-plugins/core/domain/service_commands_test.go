@@ -0,0 +1,72 @@
+package domain_test
+
+import (
+	"github.com/dokku-mcp/dokku-mcp/internal/server-plugins/core/domain"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ServiceCommand", func() {
+	It("should build the commands of the official datastore plugins", func() {
+		command, err := domain.NewServiceCommand("postgres", domain.ServiceVerbExport)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(command.String()).To(Equal("postgres:export"))
+		Expect(domain.GetServicePlugins()).To(ContainElements("postgres", "mysql", "redis"))
+	})
+
+	It("should reject unknown plugins and undeclared verbs", func() {
+		_, err := domain.NewServiceCommand("postgress", domain.ServiceVerbExport)
+		Expect(err).To(MatchError(ContainSubstring("unknown service plugin 'postgress'")))
+
+		Expect(domain.RegisterServicePlugin("elasticsearch", domain.ServiceVerbExport)).To(Succeed())
+		_, err = domain.NewServiceCommand("elasticsearch", domain.ServiceVerbImport)
+		Expect(err).To(MatchError("service plugin elasticsearch does not support import"))
+	})
+
+	It("should let a service plugin declare its verbs", func() {
+		Expect(domain.RegisterServicePlugin("clickhouse", domain.ServiceVerbExport, domain.ServiceVerbImport)).To(Succeed())
+
+		command, err := domain.NewServiceCommand("clickhouse", domain.ServiceVerbImport)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(command.Validate([]string{"analytics", "/backups/analytics.dump"})).To(Succeed())
+
+		Expect(domain.RegisterServicePlugin("Bad Name", domain.ServiceVerbExport)).NotTo(Succeed())
+		Expect(domain.RegisterServicePlugin("clickhouse", "restore")).NotTo(Succeed())
+		Expect(domain.RegisterServicePlugin("clickhouse")).NotTo(Succeed())
+	})
+
+	DescribeTable("Validate requires a target",
+		func(verb domain.ServiceVerb, args []string, message string) {
+			command, err := domain.NewServiceCommand("postgres", verb)
+			Expect(err).NotTo(HaveOccurred())
+
+			err = command.Validate(args)
+			if message == "" {
+				Expect(err).NotTo(HaveOccurred())
+				return
+			}
+			Expect(err).To(MatchError(ContainSubstring(message)))
+		},
+		Entry("export to a file", domain.ServiceVerbExport, []string{"db", "/tmp/db.dump"}, ""),
+		Entry("export without output", domain.ServiceVerbExport, []string{"db"}, "postgres:export: missing required argument output"),
+		Entry("import without input", domain.ServiceVerbImport, []string{"db"}, "missing required argument input"),
+		Entry("export to a malformed output", domain.ServiceVerbExport, []string{"db", "/tmp/db\n.dump"}, "invalid transfer target"),
+		Entry("backup to a bucket with IAM", domain.ServiceVerbBackup, []string{"db", "my-bucket", "--use-iam"}, ""),
+		Entry("backup without bucket", domain.ServiceVerbBackup, []string{"db", "--use-iam"}, "missing required argument bucket-name"),
+	)
+
+	It("should pass the local target through stdout, not to Dokku", func() {
+		export, err := domain.NewServiceCommand("postgres", domain.ServiceVerbExport)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(export.DokkuArgs([]string{"db", "/tmp/db.dump"})).To(Equal([]string{"db"}))
+		Expect(export.Target([]string{"db", "/tmp/db.dump"})).To(Equal("/tmp/db.dump"))
+
+		backup, err := domain.NewServiceCommand("postgres", domain.ServiceVerbBackup)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(backup.DokkuArgs([]string{"db", "my-bucket"})).To(Equal([]string{"db", "my-bucket"}))
+	})
+
+	It("should reject a zero command", func() {
+		Expect(domain.ServiceCommand{}.Validate([]string{"db", "out"})).NotTo(Succeed())
+	})
+})
@@ -0,0 +1,64 @@
+package domain
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var (
+	// md5FingerprintRegex matches the legacy colon separated hex form, e.g. 43:51:43:a1:...
+	md5FingerprintRegex = regexp.MustCompile(`^(?:MD5:)?(?:[0-9a-f]{2}:){15}[0-9a-f]{2}$`)
+	// sha256FingerprintRegex matches the OpenSSH form, e.g. SHA256:nThbg6kXUpJWGl7E1IGOCspRomTxdCARLviKw6E5SY8
+	sha256FingerprintRegex = regexp.MustCompile(`^SHA256:[A-Za-z0-9+/]{43}=?$`)
+	sshKeyNameRegex        = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_.@-]*$`)
+	sshKeyListNameRegex    = regexp.MustCompile(`NAME="?([^"\s]*)"?`)
+)
+
+// IsValidSSHKeyFingerprint checks if the value is an MD5 or SHA256 key fingerprint
+func IsValidSSHKeyFingerprint(value string) bool {
+	return md5FingerprintRegex.MatchString(value) || sha256FingerprintRegex.MatchString(value)
+}
+
+// ParseSSHKeyList parses the output of ssh-keys:list, one key per line:
+// <fingerprint> NAME="<name>" SSHCOMMAND_ALLOWED_KEYS="<options>"
+func ParseSSHKeyList(raw string) ([]SSHKey, error) {
+	keys := make([]SSHKey, 0)
+	for _, line := range strings.Split(raw, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "----->") || strings.HasPrefix(line, "=====>") {
+			continue
+		}
+
+		fingerprint, _, _ := strings.Cut(line, " ")
+		if !IsValidSSHKeyFingerprint(fingerprint) {
+			return nil, fmt.Errorf("invalid fingerprint in SSH key list line: %s", line)
+		}
+		match := sshKeyListNameRegex.FindStringSubmatch(line)
+		if match == nil || match[1] == "" {
+			return nil, fmt.Errorf("missing name in SSH key list line: %s", line)
+		}
+
+		keys = append(keys, SSHKey{Name: match[1], Fingerprint: fingerprint})
+	}
+
+	return keys, nil
+}
+
+// FindSSHKey looks a key up by name or fingerprint
+func FindSSHKey(keys []SSHKey, identifier string) (SSHKey, bool) {
+	for _, key := range keys {
+		if key.Name == identifier || key.Fingerprint == identifier {
+			return key, true
+		}
+	}
+	return SSHKey{}, false
+}
+
+// validateSSHKeyIdentifier accepts a key name or fingerprint
+func validateSSHKeyIdentifier(value string) error {
+	if sshKeyNameRegex.MatchString(value) || IsValidSSHKeyFingerprint(value) {
+		return nil
+	}
+	return fmt.Errorf("invalid SSH key identifier '%s', must be a key name or an MD5 or SHA256 fingerprint", value)
+}
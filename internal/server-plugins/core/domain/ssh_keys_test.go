@@ -0,0 +1,54 @@
+package domain_test
+
+import (
+	"github.com/dokku-mcp/dokku-mcp/internal/server-plugins/core/domain"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+const sha256Fingerprint = "SHA256:nThbg6kXUpJWGl7E1IGOCspRomTxdCARLviKw6E5SY8"
+
+var _ = Describe("SSH keys", func() {
+	DescribeTable("IsValidSSHKeyFingerprint",
+		func(value string, valid bool) {
+			Expect(domain.IsValidSSHKeyFingerprint(value)).To(Equal(valid))
+		},
+		Entry("SHA256", sha256Fingerprint, true),
+		Entry("MD5", "43:51:43:a1:b5:fc:8b:b7:0a:3a:a9:b1:0f:66:73:a8", true),
+		Entry("prefixed MD5", "MD5:43:51:43:a1:b5:fc:8b:b7:0a:3a:a9:b1:0f:66:73:a8", true),
+		Entry("truncated MD5", "43:51:43:a1", false),
+		Entry("truncated SHA256", "SHA256:nThbg6kX", false),
+		Entry("a name", "admin", false),
+	)
+
+	It("should parse the ssh-keys:list output", func() {
+		keys, err := domain.ParseSSHKeyList(sha256Fingerprint + ` NAME="admin" SSHCOMMAND_ALLOWED_KEYS="no-agent-forwarding,no-user-rc"
+43:51:43:a1:b5:fc:8b:b7:0a:3a:a9:b1:0f:66:73:a8 NAME=ci SSHCOMMAND_ALLOWED_KEYS="no-agent-forwarding"
+`)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(keys).To(HaveLen(2))
+		Expect(keys[0].Name).To(Equal("admin"))
+		Expect(keys[0].Fingerprint).To(Equal(sha256Fingerprint))
+		Expect(keys[1].Name).To(Equal("ci"))
+
+		key, found := domain.FindSSHKey(keys, sha256Fingerprint)
+		Expect(found).To(BeTrue())
+		Expect(key.Name).To(Equal("admin"))
+		_, found = domain.FindSSHKey(keys, "deploy")
+		Expect(found).To(BeFalse())
+	})
+
+	It("should reject malformed lines", func() {
+		_, err := domain.ParseSSHKeyList(`not-a-fingerprint NAME="admin"`)
+		Expect(err).To(HaveOccurred())
+		_, err = domain.ParseSSHKeyList(sha256Fingerprint + ` SSHCOMMAND_ALLOWED_KEYS="none"`)
+		Expect(err).To(MatchError(ContainSubstring("missing name")))
+	})
+
+	It("should only remove a key by name or fingerprint", func() {
+		Expect(domain.CommandSSHKeysRemove.Validate([]string{"admin"})).To(Succeed())
+		Expect(domain.CommandSSHKeysRemove.Validate([]string{sha256Fingerprint})).To(Succeed())
+		Expect(domain.CommandSSHKeysRemove.Validate([]string{"admin;rm"})).To(MatchError(ContainSubstring("invalid SSH key identifier")))
+		Expect(domain.CommandSSHKeysRemove.Validate([]string{"SHA256:short"})).NotTo(Succeed())
+	})
+})
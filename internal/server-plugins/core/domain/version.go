@@ -0,0 +1,61 @@
+package domain
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// versionRegex matches the output of `dokku version`, e.g. "dokku version 0.36.7",
+// tolerating a leading v and a pre-release or build suffix
+var versionRegex = regexp.MustCompile(`^(?:dokku version\s+)?v?(\d+)\.(\d+)\.(\d+)(?:[-+]\S*)?$`)
+
+// DokkuVersion is a released Dokku version
+type DokkuVersion struct {
+	Major int `json:"major"`
+	Minor int `json:"minor"`
+	Patch int `json:"patch"`
+}
+
+// ParseVersion parses the output of the version command
+func ParseVersion(raw string) (DokkuVersion, error) {
+	match := versionRegex.FindStringSubmatch(strings.TrimSpace(raw))
+	if match == nil {
+		return DokkuVersion{}, fmt.Errorf("invalid Dokku version '%s'", strings.TrimSpace(raw))
+	}
+
+	parts := make([]int, 3)
+	for i, part := range match[1:] {
+		value, err := strconv.Atoi(part)
+		if err != nil {
+			return DokkuVersion{}, fmt.Errorf("invalid Dokku version '%s': %w", strings.TrimSpace(raw), err)
+		}
+		parts[i] = value
+	}
+
+	return DokkuVersion{Major: parts[0], Minor: parts[1], Patch: parts[2]}, nil
+}
+
+// Compare returns -1, 0 or 1 as the version is older than, the same as or newer than other
+func (v DokkuVersion) Compare(other DokkuVersion) int {
+	for _, diff := range []int{v.Major - other.Major, v.Minor - other.Minor, v.Patch - other.Patch} {
+		switch {
+		case diff < 0:
+			return -1
+		case diff > 0:
+			return 1
+		}
+	}
+	return 0
+}
+
+// AtLeast returns true if the version is the same as or newer than other
+func (v DokkuVersion) AtLeast(other DokkuVersion) bool {
+	return v.Compare(other) >= 0
+}
+
+// String returns the version as major.minor.patch
+func (v DokkuVersion) String() string {
+	return fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+}
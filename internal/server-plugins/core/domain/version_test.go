@@ -0,0 +1,40 @@
+package domain_test
+
+import (
+	"github.com/dokku-mcp/dokku-mcp/internal/server-plugins/core/domain"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("DokkuVersion", func() {
+	DescribeTable("ParseVersion",
+		func(raw string, expected domain.DokkuVersion) {
+			version, err := domain.ParseVersion(raw)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(version).To(Equal(expected))
+		},
+		Entry("version command output", "dokku version 0.36.7\n", domain.DokkuVersion{Major: 0, Minor: 36, Patch: 7}),
+		Entry("bare version", "0.35.12", domain.DokkuVersion{Major: 0, Minor: 35, Patch: 12}),
+		Entry("leading v", "v1.2.3", domain.DokkuVersion{Major: 1, Minor: 2, Patch: 3}),
+		Entry("pre-release suffix", "dokku version 0.37.0-rc1", domain.DokkuVersion{Major: 0, Minor: 37, Patch: 0}),
+	)
+
+	DescribeTable("should reject malformed versions",
+		func(raw string) {
+			_, err := domain.ParseVersion(raw)
+			Expect(err).To(HaveOccurred())
+		},
+		Entry("empty", ""),
+		Entry("missing patch", "dokku version 0.36"),
+		Entry("not a version", "unknown"),
+	)
+
+	It("should compare versions component by component", func() {
+		v := domain.DokkuVersion{Major: 0, Minor: 36, Patch: 7}
+		Expect(v.AtLeast(domain.DokkuVersion{Major: 0, Minor: 36, Patch: 7})).To(BeTrue())
+		Expect(v.AtLeast(domain.DokkuVersion{Major: 0, Minor: 9, Patch: 20})).To(BeTrue())
+		Expect(v.AtLeast(domain.DokkuVersion{Major: 0, Minor: 36, Patch: 8})).To(BeFalse())
+		Expect(v.AtLeast(domain.DokkuVersion{Major: 1, Minor: 0, Patch: 0})).To(BeFalse())
+		Expect(v.String()).To(Equal("0.36.7"))
+	})
+})
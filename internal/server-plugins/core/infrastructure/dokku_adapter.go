@@ -3,7 +3,9 @@ package infrastructure
 import (
 	"context"
 	"fmt"
+	"io"
 	"log/slog"
+	"os"
 	"strings"
 	"time"
 
@@ -13,26 +15,47 @@ import (
 
 // DokkuCoreAdapter implements core domain repositories using Dokku CLI
 type DokkuCoreAdapter struct {
-	client dokkuApi.DokkuClient
-	logger *slog.Logger
+	client    dokkuApi.DokkuClient
+	allowList *domain.AllowList
+	exportDir string
+	logger    *slog.Logger
 }
 
-// NewDokkuCoreAdapter creates a new core adapter
-func NewDokkuCoreAdapter(client dokkuApi.DokkuClient, logger *slog.Logger) *DokkuCoreAdapter {
+// NewDokkuCoreAdapter creates a new core adapter running only the commands of the allow-list.
+// Service data is exported to and imported from exportDir only, empty to disable transfers.
+func NewDokkuCoreAdapter(client dokkuApi.DokkuClient, allowList *domain.AllowList, exportDir string, logger *slog.Logger) *DokkuCoreAdapter {
 	return &DokkuCoreAdapter{
-		client: client,
-		logger: logger,
+		client:    client,
+		allowList: allowList,
+		exportDir: exportDir,
+		logger:    logger,
 	}
 }
 
 // executeCommand wraps the client's ExecuteCommand with core-specific context and validation
 func (a *DokkuCoreAdapter) executeCommand(ctx context.Context, command domain.CoreCommand, args []string) ([]byte, error) {
-	// Validate command is allowed
-	if !command.IsValid() {
-		return nil, fmt.Errorf("invalid core command: %s", command)
+	if command.IsValid() && !a.allowList.IsAllowed(command) {
+		return nil, fmt.Errorf("core command %s is denied on this server", command)
 	}
 
-	return a.client.ExecuteCommand(ctx, command.String(), args)
+	// Validate command is allowed and its arguments match its spec and are shell safe
+	_, output, err := domain.RunCommand(domain.CommandRequest{Command: command, Args: args}, func(argv []string) ([]byte, error) {
+		// Buffered execution would wait forever on a streaming invocation
+		if command.IsStreaming(args) {
+			return nil, fmt.Errorf("command %s %s streams its output and cannot be executed here", command, strings.Join(args, " "))
+		}
+		return a.client.ExecuteCommand(ctx, argv[0], argv[1:])
+	})
+	return output, err
+}
+
+// executeServiceCommand runs a service plugin command once its arguments match the verb spec
+func (a *DokkuCoreAdapter) executeServiceCommand(ctx context.Context, command domain.ServiceCommand, args []string) ([]byte, error) {
+	if err := command.Validate(args); err != nil {
+		return nil, err
+	}
+
+	return a.client.ExecuteCommand(ctx, command.String(), command.DokkuArgs(args))
 }
 
 // SystemRepository implementation
@@ -48,6 +71,9 @@ func (a *DokkuCoreAdapter) GetSystemStatus(ctx context.Context) (*domain.SystemS
 		status.Version = "unknown"
 	} else {
 		status.Version = strings.TrimSpace(string(versionOutput))
+		if version, err := domain.ParseVersion(status.Version); err == nil {
+			status.Version = version.String()
+		}
 	}
 
 	// Get proxy type
@@ -138,7 +164,25 @@ func (a *DokkuCoreAdapter) ListPlugins(ctx context.Context) ([]domain.DokkuPlugi
 		return nil, fmt.Errorf("failed to list plugins: %w", err)
 	}
 
-	return a.parsePluginList(string(output)), nil
+	infos, err := domain.ParsePluginList(string(output))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse plugin list: %w", err)
+	}
+
+	plugins := make([]domain.DokkuPlugin, len(infos))
+	for i, info := range infos {
+		plugins[i] = domain.DokkuPlugin{
+			Name:        info.Name,
+			Version:     info.Version,
+			Status:      domain.PluginStatusDisabled,
+			Description: info.Description,
+			CorePlugin:  info.Core,
+		}
+		if info.Enabled {
+			plugins[i].Status = domain.PluginStatusEnabled
+		}
+	}
+	return plugins, nil
 }
 
 func (a *DokkuCoreAdapter) GetPlugin(ctx context.Context, name string) (*domain.DokkuPlugin, error) {
@@ -225,7 +269,11 @@ func (a *DokkuCoreAdapter) ListSSHKeys(ctx context.Context) ([]domain.SSHKey, er
 		return nil, fmt.Errorf("failed to list SSH keys: %w", err)
 	}
 
-	return a.parseSSHKeys(string(output)), nil
+	keys, err := domain.ParseSSHKeyList(string(output))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse SSH keys: %w", err)
+	}
+	return keys, nil
 }
 
 func (a *DokkuCoreAdapter) AddSSHKey(ctx context.Context, name string, keyContent string) error {
@@ -249,13 +297,11 @@ func (a *DokkuCoreAdapter) GetSSHKey(ctx context.Context, name string) (*domain.
 		return nil, err
 	}
 
-	for _, key := range keys {
-		if key.Name == name {
-			return &key, nil
-		}
+	key, found := domain.FindSSHKey(keys, name)
+	if !found {
+		return nil, fmt.Errorf("SSH key %s not found", name)
 	}
-
-	return nil, fmt.Errorf("SSH key %s not found", name)
+	return &key, nil
 }
 
 // RegistryRepository implementation
@@ -290,6 +336,23 @@ func (a *DokkuCoreAdapter) GetRegistryStatus(ctx context.Context, registry strin
 	}, nil
 }
 
+// LogsRepository implementation
+func (a *DokkuCoreAdapter) GetLogs(ctx context.Context, query *domain.LogsQuery) (string, error) {
+	output, err := a.executeCommand(ctx, domain.CommandLogs, query.Args())
+	if err != nil {
+		return "", fmt.Errorf("failed to get logs for %s: %w", query.AppName, err)
+	}
+	return string(output), nil
+}
+
+func (a *DokkuCoreAdapter) GetFailedLogs(ctx context.Context, appName string) (string, error) {
+	output, err := a.executeCommand(ctx, domain.CommandLogsFailed, []string{appName})
+	if err != nil {
+		return "", fmt.Errorf("failed to get failed build logs for %s: %w", appName, err)
+	}
+	return string(output), nil
+}
+
 // ConfigurationRepository implementation
 func (a *DokkuCoreAdapter) GetGlobalConfiguration(ctx context.Context) (*domain.GlobalConfiguration, error) {
 	config := &domain.GlobalConfiguration{
@@ -351,57 +414,90 @@ func (a *DokkuCoreAdapter) GetConfigurationKeys(ctx context.Context, scope strin
 	return []domain.ConfigurationKey{}, nil
 }
 
-// Helper parsing methods
-
-func (a *DokkuCoreAdapter) parsePluginList(output string) []domain.DokkuPlugin {
-	var plugins []domain.DokkuPlugin
-	fieldsOutput := dokkuApi.ParseFieldsOutput(output, true)
-
-	for _, fields := range fieldsOutput {
-		if len(fields) >= 3 {
-			plugin := domain.DokkuPlugin{
-				Name:    fields[0],
-				Version: fields[1],
-				Status:  fields[2],
-			}
+// ServiceDataRepository implementation
+func (a *DokkuCoreAdapter) ExportServiceData(ctx context.Context, command domain.ServiceCommand, service, output string) error {
+	if command.Verb() != domain.ServiceVerbExport {
+		return fmt.Errorf("%s is not an export command", command)
+	}
+	args := []string{service, output}
+	if err := command.Validate(args); err != nil {
+		return err
+	}
 
-			if len(fields) > 3 {
-				plugin.Description = strings.Join(fields[3:], " ")
-			}
+	root, err := a.openExportDir()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = root.Close() }()
 
-			// Determine if this is a core plugin
-			plugin.CorePlugin = strings.Contains(strings.ToLower(plugin.Description), "dokku core") ||
-				strings.Contains(strings.ToLower(plugin.Description), "core plugin")
+	// Dumps hold the service data, keep them private to the server user and never
+	// replace an earlier one
+	file, err := root.OpenFile(output, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to create the export of service %s: %w", service, err)
+	}
 
-			plugins = append(plugins, plugin)
+	err = a.client.StreamCommand(ctx, command.String(), command.DokkuArgs(args), nil, file)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		// A partial dump must not pass for a complete one
+		if removeErr := root.Remove(output); removeErr != nil {
+			a.logger.Warn("Failed to remove a partial export", "output", output, "error", removeErr)
 		}
+		return fmt.Errorf("failed to export service %s: %w", service, err)
 	}
-
-	return plugins
+	return nil
 }
 
-func (a *DokkuCoreAdapter) parseSSHKeys(output string) []domain.SSHKey {
-	var keys []domain.SSHKey
-	lines := dokkuApi.ParseTrimmedLines(output, true)
+func (a *DokkuCoreAdapter) ImportServiceData(ctx context.Context, command domain.ServiceCommand, service, input string) error {
+	if command.Verb() != domain.ServiceVerbImport {
+		return fmt.Errorf("%s is not an import command", command)
+	}
+	args := []string{service, input}
+	if err := command.Validate(args); err != nil {
+		return err
+	}
 
-	for _, line := range lines {
-		fields := strings.Fields(line)
-		if len(fields) >= 2 {
-			key := domain.SSHKey{
-				Name:        fields[0],
-				Fingerprint: "",
-				KeyType:     "",
-				Comment:     "",
-				AddedAt:     time.Now(),
-			}
+	root, err := a.openExportDir()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = root.Close() }()
 
-			if len(fields) > 1 {
-				key.Fingerprint = fields[1]
-			}
+	file, err := root.Open(input)
+	if err != nil {
+		return fmt.Errorf("failed to open the import of service %s: %w", service, err)
+	}
+	defer func() { _ = file.Close() }()
 
-			keys = append(keys, key)
-		}
+	if err := a.client.StreamCommand(ctx, command.String(), command.DokkuArgs(args), file, io.Discard); err != nil {
+		return fmt.Errorf("failed to import service %s: %w", service, err)
+	}
+	return nil
+}
+
+// openExportDir opens the directory service data is transferred through. Targets are
+// resolved within it, they cannot reach any other path of the server.
+func (a *DokkuCoreAdapter) openExportDir() (*os.Root, error) {
+	if a.exportDir == "" {
+		return nil, fmt.Errorf("service data transfers are disabled, set service_export_dir to enable them")
 	}
+	root, err := os.OpenRoot(a.exportDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open the service export directory: %w", err)
+	}
+	return root, nil
+}
 
-	return keys
+func (a *DokkuCoreAdapter) BackupServiceData(ctx context.Context, command domain.ServiceCommand, service, bucket string) error {
+	if command.Verb() != domain.ServiceVerbBackup {
+		return fmt.Errorf("%s is not a backup command", command)
+	}
+
+	if _, err := a.executeServiceCommand(ctx, command, []string{service, bucket}); err != nil {
+		return fmt.Errorf("failed to back up service %s: %w", service, err)
+	}
+	return nil
 }
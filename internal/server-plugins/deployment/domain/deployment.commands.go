@@ -8,11 +8,15 @@ const (
 	CommandBuildpacksSet DeploymentCommand = "buildpacks:set"
 
 	// Git commands
-	CommandGitSync DeploymentCommand = "git:sync"
+	CommandGitSync      DeploymentCommand = "git:sync"
+	CommandGitFromImage DeploymentCommand = "git:from-image"
 
 	// Process commands
 	CommandPsRebuild DeploymentCommand = "ps:rebuild"
 
+	// Repository commands
+	CommandRepoPurgeCache DeploymentCommand = "repo:purge-cache"
+
 	// Event commands
 	CommandEvents DeploymentCommand = "events"
 )
@@ -21,7 +25,7 @@ const (
 func (c DeploymentCommand) IsValid() bool {
 	switch c {
 	case CommandBuildpacksSet,
-		CommandGitSync, CommandPsRebuild, CommandEvents:
+		CommandGitSync, CommandGitFromImage, CommandPsRebuild, CommandRepoPurgeCache, CommandEvents:
 		return true
 	default:
 		return false
@@ -38,7 +42,9 @@ func GetAllowedDeploymentCommands() []DeploymentCommand {
 	return []DeploymentCommand{
 		CommandBuildpacksSet,
 		CommandGitSync,
+		CommandGitFromImage,
 		CommandPsRebuild,
+		CommandRepoPurgeCache,
 		CommandEvents,
 	}
 }
@@ -16,6 +16,7 @@ type Deployment struct {
 	completedAt *time.Time
 	errorMsg    string
 	buildLogs   string
+	imageDigest string
 }
 
 // DeploymentStatus état d'un déploiement
@@ -110,6 +111,16 @@ func (d *Deployment) BuildLogs() string {
 	return d.buildLogs
 }
 
+// ImageDigest returns the digest of the image built for this deployment, if known
+func (d *Deployment) ImageDigest() string {
+	return d.imageDigest
+}
+
+// SetImageDigest records the digest of the image built for this deployment
+func (d *Deployment) SetImageDigest(digest string) {
+	d.imageDigest = digest
+}
+
 // Start démarre le déploiement
 func (d *Deployment) Start() {
 	d.status = DeploymentStatusRunning
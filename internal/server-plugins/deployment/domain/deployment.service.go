@@ -22,6 +22,7 @@ type DeploymentService interface {
 type DeploymentInfrastructure interface {
 	SetBuildpack(ctx context.Context, appName string, buildpack string) error
 	PerformGitDeploy(ctx context.Context, deploymentID, appName, repoURL, gitRef string) error
+	PerformImageDeploy(ctx context.Context, deploymentID, appName, image string) error
 	ParseDeploymentHistory(ctx context.Context, appName string) ([]*Deployment, error)
 }
 
@@ -30,6 +31,19 @@ type DeployOptions struct {
 	RepoURL   string
 	GitRef    *shared.GitRef
 	BuildPack *shared.BuildpackName
+	// Image deploys this docker image instead of the git repository
+	Image *shared.DockerImage
+}
+
+// ref returns what the deployment is built from: the image, or else the git reference
+func (o DeployOptions) ref() string {
+	if o.Image != nil {
+		return o.Image.Value()
+	}
+	if o.GitRef != nil {
+		return o.GitRef.Value()
+	}
+	return ""
 }
 
 // ApplicationDeploymentService implémentation du service de déploiement
@@ -57,23 +71,31 @@ func NewApplicationDeploymentService(
 
 // Deploy lance un déploiement d'application
 func (s *ApplicationDeploymentService) Deploy(ctx context.Context, appName string, options DeployOptions) (*Deployment, error) {
+	ref := options.ref()
 	s.logger.Info("Démarrage du déploiement d'application",
 		"nom_app", appName,
-		"git_ref", options.GitRef.Value())
+		"git_ref", ref)
 
-	deployment, err := NewDeployment(appName, options.GitRef.Value())
+	deployment, err := NewDeployment(appName, ref)
 	if err != nil {
 		return nil, fmt.Errorf("échec de création du déploiement: %w", err)
 	}
 
+	if options.Image != nil {
+		// Only an image pinned by digest tells which image gets deployed
+		deployment.SetImageDigest(options.Image.Digest())
+	}
 	deployment.Start()
 
-	// Track the deployment
+	// Track the deployment, and keep it in the history read by the exports
 	if s.tracker != nil {
 		if err := s.tracker.Track(deployment); err != nil {
 			s.logger.Warn("Failed to track deployment", "error", err)
 		}
 	}
+	if err := s.deploymentRepo.Save(ctx, deployment); err != nil {
+		s.logger.Warn("Failed to save deployment", "error", err)
+	}
 
 	if options.BuildPack != nil {
 		if err := s.infrastructure.SetBuildpack(ctx, appName, options.BuildPack.Value()); err != nil {
@@ -82,20 +104,27 @@ func (s *ApplicationDeploymentService) Deploy(ctx context.Context, appName strin
 	}
 
 	// Start async deployment - infrastructure will handle tracking via poller
-	if err := s.infrastructure.PerformGitDeploy(ctx, deployment.ID(), appName, options.RepoURL, options.GitRef.Value()); err != nil {
-		deployment.Fail(fmt.Sprintf("Échec du déploiement depuis git: %v", err))
-		s.logger.Error("Git deployment failed", "app_name", appName, "error", err)
+	source := "git"
+	if options.Image != nil {
+		source = "image"
+		err = s.infrastructure.PerformImageDeploy(ctx, deployment.ID(), appName, options.Image.Value())
+	} else {
+		err = s.infrastructure.PerformGitDeploy(ctx, deployment.ID(), appName, options.RepoURL, ref)
+	}
+	if err != nil {
+		deployment.Fail(fmt.Sprintf("Échec du déploiement depuis %s: %v", source, err))
+		s.logger.Error("Deployment failed", "app_name", appName, "source", source, "error", err)
 
 		if s.tracker != nil {
 			_ = s.tracker.UpdateStatus(deployment.ID(), DeploymentStatusFailed, err.Error())
 		}
 
-		return deployment, fmt.Errorf("échec du déploiement depuis git: %w", err)
+		return deployment, fmt.Errorf("échec du déploiement depuis %s: %w", source, err)
 	}
 
 	s.logger.Info("Déploiement initié avec succès (async)",
 		"nom_app", appName,
-		"git_ref", options.GitRef.Value(),
+		"git_ref", ref,
 		"deployment_id", deployment.ID())
 
 	// Return immediately - deployment is tracked async
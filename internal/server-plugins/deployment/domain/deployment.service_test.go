@@ -0,0 +1,69 @@
+package domain_test
+
+import (
+	"context"
+	"io"
+	"log/slog"
+
+	"github.com/dokku-mcp/dokku-mcp/internal/server-plugins/deployment/domain"
+	"github.com/dokku-mcp/dokku-mcp/internal/shared"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type stubDeploymentInfrastructure struct {
+	images []string
+}
+
+func (i *stubDeploymentInfrastructure) SetBuildpack(ctx context.Context, appName string, buildpack string) error {
+	return nil
+}
+
+func (i *stubDeploymentInfrastructure) PerformGitDeploy(ctx context.Context, deploymentID, appName, repoURL, gitRef string) error {
+	return nil
+}
+
+func (i *stubDeploymentInfrastructure) PerformImageDeploy(ctx context.Context, deploymentID, appName, image string) error {
+	i.images = append(i.images, image)
+	return nil
+}
+
+func (i *stubDeploymentInfrastructure) ParseDeploymentHistory(ctx context.Context, appName string) ([]*domain.Deployment, error) {
+	return nil, nil
+}
+
+var _ = Describe("ApplicationDeploymentService", func() {
+	var (
+		repo    *stubDeploymentRepository
+		infra   *stubDeploymentInfrastructure
+		service *domain.ApplicationDeploymentService
+	)
+
+	BeforeEach(func() {
+		repo = &stubDeploymentRepository{}
+		infra = &stubDeploymentInfrastructure{}
+		service = domain.NewApplicationDeploymentService(repo, infra, nil, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	})
+
+	It("should record the digest of an image pinned by digest", func() {
+		digest := "sha256:" + "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+		image, err := shared.NewDockerImage("registry.example.com/my-app@" + digest)
+		Expect(err).NotTo(HaveOccurred())
+
+		deployment, err := service.Deploy(context.Background(), "my-app", domain.DeployOptions{Image: image})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(infra.images).To(Equal([]string{image.Value()}))
+		Expect(deployment.ImageDigest()).To(Equal(digest))
+
+		records, err := domain.NewDeploymentHistoryExporter(repo).Records(context.Background(), domain.ExportFilter{AppName: "my-app"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(records).To(HaveLen(1))
+		Expect(records[0].ImageDigest).To(Equal(digest))
+	})
+
+	It("should leave the digest unknown for a git deploy", func() {
+		deployment, err := service.Deploy(context.Background(), "my-app", domain.DeployOptions{GitRef: shared.MustNewGitRef("main")})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(deployment.ImageDigest()).To(BeEmpty())
+	})
+})
@@ -0,0 +1,198 @@
+package domain
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/dokku-mcp/dokku-mcp/internal/shared"
+)
+
+// ExportFormat is the output format of a deployment history export
+type ExportFormat string
+
+const (
+	ExportFormatCSV  ExportFormat = "csv"
+	ExportFormatJSON ExportFormat = "json"
+)
+
+// IsValid checks that the export format is supported
+func (f ExportFormat) IsValid() bool {
+	return f == ExportFormatCSV || f == ExportFormatJSON
+}
+
+// ExportFilter narrows the deployments included in an export.
+// An empty AppName exports the history of the whole fleet.
+type ExportFilter struct {
+	AppName string
+	From    *time.Time
+	To      *time.Time
+}
+
+// Matches reports whether a deployment falls within the filter
+func (f ExportFilter) Matches(deployment *Deployment) bool {
+	if f.AppName != "" && deployment.AppName() != f.AppName {
+		return false
+	}
+	createdAt := deployment.CreatedAt()
+	if f.From != nil && createdAt.Before(*f.From) {
+		return false
+	}
+	if f.To != nil && createdAt.After(*f.To) {
+		return false
+	}
+	return true
+}
+
+// DeploymentRecord is the exported view of a deployment.
+// Build logs and error messages are deliberately left out as they may carry
+// secrets or build arguments.
+type DeploymentRecord struct {
+	ID          string    `json:"id"`
+	AppName     string    `json:"app_name"`
+	Ref         string    `json:"ref"`
+	Tag         string    `json:"tag,omitempty"`
+	Timestamp   time.Time `json:"timestamp"`
+	Result      string    `json:"result"`
+	ImageDigest string    `json:"image_digest,omitempty"`
+	DurationSec float64   `json:"duration_seconds"`
+}
+
+var deploymentRecordCSVHeader = []string{
+	"id", "app_name", "ref", "tag", "timestamp", "result", "image_digest", "duration_seconds",
+}
+
+// NewDeploymentRecord builds the export record of a deployment
+func NewDeploymentRecord(deployment *Deployment) DeploymentRecord {
+	record := DeploymentRecord{
+		ID:          deployment.ID(),
+		AppName:     deployment.AppName(),
+		Ref:         deployment.GitRef(),
+		Timestamp:   deployment.CreatedAt(),
+		Result:      string(deployment.Status()),
+		ImageDigest: deployment.ImageDigest(),
+	}
+
+	if gitRef, err := shared.NewGitRef(deployment.GitRef()); err == nil && gitRef.IsTag() {
+		record.Tag = gitRef.Value()
+	}
+	if deployment.IsCompleted() {
+		record.DurationSec = deployment.Duration().Seconds()
+	}
+
+	return record
+}
+
+// DeploymentHistoryExporter exports deployment history for reporting
+type DeploymentHistoryExporter struct {
+	deploymentRepo DeploymentRepository
+}
+
+// NewDeploymentHistoryExporter creates a new deployment history exporter
+func NewDeploymentHistoryExporter(deploymentRepo DeploymentRepository) *DeploymentHistoryExporter {
+	return &DeploymentHistoryExporter{
+		deploymentRepo: deploymentRepo,
+	}
+}
+
+// Records returns the deployments matching the filter, oldest first
+func (e *DeploymentHistoryExporter) Records(ctx context.Context, filter ExportFilter) ([]DeploymentRecord, error) {
+	var (
+		deployments []*Deployment
+		err         error
+	)
+	if filter.AppName != "" {
+		deployments, err = e.deploymentRepo.FindByAppName(ctx, filter.AppName)
+	} else {
+		deployments, err = e.deploymentRepo.FindAll(ctx)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load deployment history: %w", err)
+	}
+
+	records := make([]DeploymentRecord, 0, len(deployments))
+	for _, deployment := range deployments {
+		if filter.Matches(deployment) {
+			records = append(records, NewDeploymentRecord(deployment))
+		}
+	}
+
+	sort.SliceStable(records, func(i, j int) bool {
+		return records[i].Timestamp.Before(records[j].Timestamp)
+	})
+
+	return records, nil
+}
+
+// Export renders the deployments matching the filter in the requested format
+func (e *DeploymentHistoryExporter) Export(ctx context.Context, filter ExportFilter, format ExportFormat) ([]byte, error) {
+	if !format.IsValid() {
+		return nil, fmt.Errorf("unsupported export format: %s", format)
+	}
+	if filter.From != nil && filter.To != nil && filter.From.After(*filter.To) {
+		return nil, fmt.Errorf("invalid time range: from %s is after to %s",
+			filter.From.Format(time.RFC3339), filter.To.Format(time.RFC3339))
+	}
+
+	records, err := e.Records(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	switch format {
+	case ExportFormatCSV:
+		err = WriteDeploymentRecordsCSV(&buf, records)
+	case ExportFormatJSON:
+		err = WriteDeploymentRecordsJSON(&buf, records)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// WriteDeploymentRecordsCSV writes records as CSV with a header row.
+// Fields containing commas, quotes or newlines are quoted per RFC 4180.
+func WriteDeploymentRecordsCSV(w io.Writer, records []DeploymentRecord) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(deploymentRecordCSVHeader); err != nil {
+		return fmt.Errorf("failed to write CSV header: %w", err)
+	}
+
+	for _, record := range records {
+		row := []string{
+			record.ID,
+			record.AppName,
+			record.Ref,
+			record.Tag,
+			record.Timestamp.UTC().Format(time.RFC3339),
+			record.Result,
+			record.ImageDigest,
+			strconv.FormatFloat(record.DurationSec, 'f', -1, 64),
+		}
+		if err := writer.Write(row); err != nil {
+			return fmt.Errorf("failed to write CSV row: %w", err)
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// WriteDeploymentRecordsJSON writes records as an indented JSON array
+func WriteDeploymentRecordsJSON(w io.Writer, records []DeploymentRecord) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(records); err != nil {
+		return fmt.Errorf("failed to encode deployment records: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,139 @@
+package domain_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"time"
+
+	"github.com/dokku-mcp/dokku-mcp/internal/server-plugins/deployment/domain"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type stubDeploymentRepository struct {
+	deployments []*domain.Deployment
+}
+
+func (r *stubDeploymentRepository) Save(ctx context.Context, d *domain.Deployment) error {
+	r.deployments = append(r.deployments, d)
+	return nil
+}
+
+func (r *stubDeploymentRepository) FindByID(ctx context.Context, id string) (*domain.Deployment, error) {
+	for _, d := range r.deployments {
+		if d.ID() == id {
+			return d, nil
+		}
+	}
+	return nil, domain.ErrDeploymentNotFound
+}
+
+func (r *stubDeploymentRepository) FindByAppName(ctx context.Context, appName string) ([]*domain.Deployment, error) {
+	var result []*domain.Deployment
+	for _, d := range r.deployments {
+		if d.AppName() == appName {
+			result = append(result, d)
+		}
+	}
+	return result, nil
+}
+
+func (r *stubDeploymentRepository) FindAll(ctx context.Context) ([]*domain.Deployment, error) {
+	return r.deployments, nil
+}
+
+func (r *stubDeploymentRepository) Delete(ctx context.Context, id string) error { return nil }
+
+func (r *stubDeploymentRepository) Update(ctx context.Context, d *domain.Deployment) error {
+	return nil
+}
+
+var _ = Describe("DeploymentHistoryExporter", func() {
+	var (
+		repo     *stubDeploymentRepository
+		exporter *domain.DeploymentHistoryExporter
+		ctx      context.Context
+		base     time.Time
+	)
+
+	newDeployment := func(appName, gitRef string, at time.Time) *domain.Deployment {
+		d, err := domain.NewDeploymentWithTimestamp(appName, gitRef, at)
+		Expect(err).NotTo(HaveOccurred())
+		d.Start()
+		d.AddBuildLogs("SECRET_TOKEN=hunter2")
+		d.Complete()
+		return d
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		base = time.Date(2025, 1, 10, 12, 0, 0, 0, time.UTC)
+		repo = &stubDeploymentRepository{}
+		exporter = domain.NewDeploymentHistoryExporter(repo)
+
+		tagged := newDeployment("api", "v1.2.0", base)
+		tagged.SetImageDigest("sha256:abc123")
+		_ = repo.Save(ctx, tagged)
+		_ = repo.Save(ctx, newDeployment("api", "main", base.Add(24*time.Hour)))
+		_ = repo.Save(ctx, newDeployment("web", "main", base.Add(48*time.Hour)))
+	})
+
+	It("should export the fleet history as JSON without build logs", func() {
+		out, err := exporter.Export(ctx, domain.ExportFilter{}, domain.ExportFormatJSON)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(out)).NotTo(ContainSubstring("hunter2"))
+
+		var records []domain.DeploymentRecord
+		Expect(json.Unmarshal(out, &records)).To(Succeed())
+		Expect(records).To(HaveLen(3))
+		Expect(records[0].Tag).To(Equal("v1.2.0"))
+		Expect(records[0].ImageDigest).To(Equal("sha256:abc123"))
+		Expect(records[0].Result).To(Equal("succeeded"))
+		Expect(records[1].Tag).To(BeEmpty())
+	})
+
+	It("should filter by application and time range", func() {
+		from := base.Add(time.Hour)
+		records, err := exporter.Records(ctx, domain.ExportFilter{AppName: "api", From: &from})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(records).To(HaveLen(1))
+		Expect(records[0].Ref).To(Equal("main"))
+	})
+
+	It("should reject an inverted time range", func() {
+		from := base.Add(time.Hour)
+		to := base
+		_, err := exporter.Export(ctx, domain.ExportFilter{From: &from, To: &to}, domain.ExportFormatCSV)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should reject an unknown format", func() {
+		_, err := exporter.Export(ctx, domain.ExportFilter{}, "xml")
+		Expect(err).To(HaveOccurred())
+	})
+
+	Describe("CSV output", func() {
+		It("should quote fields containing commas", func() {
+			records := []domain.DeploymentRecord{{
+				ID:        "deploy_1",
+				AppName:   "api",
+				Ref:       "feature/a,b",
+				Timestamp: base,
+				Result:    "failed",
+			}}
+
+			var buf bytes.Buffer
+			Expect(domain.WriteDeploymentRecordsCSV(&buf, records)).To(Succeed())
+			Expect(buf.String()).To(ContainSubstring(`"feature/a,b"`))
+
+			rows, err := csv.NewReader(&buf).ReadAll()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(rows).To(HaveLen(2))
+			Expect(rows[0][2]).To(Equal("ref"))
+			Expect(rows[1][2]).To(Equal("feature/a,b"))
+			Expect(rows[1][4]).To(Equal("2025-01-10T12:00:00Z"))
+		})
+	})
+})
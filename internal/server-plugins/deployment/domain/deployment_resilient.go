@@ -0,0 +1,171 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+)
+
+// cacheFailureSignatures are build output fragments caused by a corrupt build cache.
+// They are matched case-insensitively.
+var cacheFailureSignatures = []string{
+	"failed to restore cache",
+	"error restoring cache",
+	"cache is corrupt",
+	"corrupted cache",
+	"error extracting cache",
+	"failed to compute cache key",
+	"layer does not exist",
+	"unexpected eof while reading cache",
+}
+
+// IsCacheFailure reports whether a build output matches a known cache failure signature
+func IsCacheFailure(output string) bool {
+	lowerOutput := strings.ToLower(output)
+	for _, signature := range cacheFailureSignatures {
+		if strings.Contains(lowerOutput, signature) {
+			return true
+		}
+	}
+	return false
+}
+
+// BuildExecutor runs builds and manages the build cache of an application
+type BuildExecutor interface {
+	// Rebuild builds and releases the application, returning the build output
+	Rebuild(ctx context.Context, appName string) (string, error)
+	// DeployImage deploys the application from a docker image, returning the build output
+	DeployImage(ctx context.Context, appName, image string) (string, error)
+	// PurgeBuildCache removes the build cache of the application
+	PurgeBuildCache(ctx context.Context, appName string) error
+}
+
+// DeploymentEventType identifies what happened during a resilient deployment
+type DeploymentEventType string
+
+const (
+	DeploymentEventAttemptSucceeded DeploymentEventType = "deployment.attempt.succeeded"
+	DeploymentEventAttemptFailed    DeploymentEventType = "deployment.attempt.failed"
+	DeploymentEventCachePurged      DeploymentEventType = "deployment.cache.purged"
+)
+
+// DeploymentEvent is emitted for each step of a resilient deployment
+type DeploymentEvent struct {
+	Type       DeploymentEventType
+	AppName    string
+	Attempt    int
+	Reason     string
+	OccurredAt time.Time
+}
+
+// ResilientDeployResult summarizes a resilient deployment
+type ResilientDeployResult struct {
+	Attempts    int
+	CachePurged bool
+	Output      string
+	Events      []DeploymentEvent
+}
+
+// ResilientDeployer retries a deployment once after purging the build cache,
+// when the first attempt failed with a known cache failure signature.
+// It covers both the rebuild of a git deploy and the deploy of an image.
+type ResilientDeployer struct {
+	executor BuildExecutor
+	logger   *slog.Logger
+
+	mu        sync.RWMutex
+	listeners []func(DeploymentEvent)
+}
+
+// NewResilientDeployer creates a new resilient deployer
+func NewResilientDeployer(executor BuildExecutor, logger *slog.Logger) *ResilientDeployer {
+	return &ResilientDeployer{
+		executor: executor,
+		logger:   logger,
+	}
+}
+
+// OnEvent registers a listener called synchronously for every deployment event
+func (d *ResilientDeployer) OnEvent(listener func(DeploymentEvent)) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.listeners = append(d.listeners, listener)
+}
+
+// Deploy rebuilds the application, purging the cache and retrying once on cache failures
+func (d *ResilientDeployer) Deploy(ctx context.Context, appName string) (*ResilientDeployResult, error) {
+	return d.run(ctx, appName, func(ctx context.Context) (string, error) {
+		return d.executor.Rebuild(ctx, appName)
+	})
+}
+
+// DeployImage deploys the application from an image, purging the cache and retrying once on cache failures
+func (d *ResilientDeployer) DeployImage(ctx context.Context, appName, image string) (*ResilientDeployResult, error) {
+	return d.run(ctx, appName, func(ctx context.Context) (string, error) {
+		return d.executor.DeployImage(ctx, appName, image)
+	})
+}
+
+func (d *ResilientDeployer) run(ctx context.Context, appName string, build func(context.Context) (string, error)) (*ResilientDeployResult, error) {
+	result := &ResilientDeployResult{}
+
+	output, err := d.attempt(ctx, appName, build, result)
+	if err == nil {
+		return result, nil
+	}
+
+	if !IsCacheFailure(output) && !IsCacheFailure(err.Error()) {
+		return result, fmt.Errorf("deployment failed: %w", err)
+	}
+
+	d.logger.Warn("Deployment failed on a corrupt build cache, purging cache and retrying",
+		"app_name", appName,
+		"error", err)
+
+	if purgeErr := d.executor.PurgeBuildCache(ctx, appName); purgeErr != nil {
+		return result, fmt.Errorf("failed to purge build cache after cache failure (%v): %w", err, purgeErr)
+	}
+	result.CachePurged = true
+	d.emit(result, DeploymentEvent{Type: DeploymentEventCachePurged, AppName: appName, Attempt: result.Attempts})
+
+	if _, err := d.attempt(ctx, appName, build, result); err != nil {
+		return result, fmt.Errorf("deployment failed after build cache purge: %w", err)
+	}
+
+	return result, nil
+}
+
+func (d *ResilientDeployer) attempt(ctx context.Context, appName string, build func(context.Context) (string, error), result *ResilientDeployResult) (string, error) {
+	result.Attempts++
+	output, err := build(ctx)
+	result.Output = output
+
+	if err != nil {
+		d.emit(result, DeploymentEvent{
+			Type:    DeploymentEventAttemptFailed,
+			AppName: appName,
+			Attempt: result.Attempts,
+			Reason:  err.Error(),
+		})
+		return output, err
+	}
+
+	d.emit(result, DeploymentEvent{Type: DeploymentEventAttemptSucceeded, AppName: appName, Attempt: result.Attempts})
+	return output, nil
+}
+
+func (d *ResilientDeployer) emit(result *ResilientDeployResult, event DeploymentEvent) {
+	event.OccurredAt = time.Now()
+	result.Events = append(result.Events, event)
+
+	d.mu.RLock()
+	listeners := append([]func(DeploymentEvent){}, d.listeners...)
+	d.mu.RUnlock()
+
+	for _, listener := range listeners {
+		listener(event)
+	}
+}
@@ -0,0 +1,139 @@
+package domain_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+
+	"github.com/dokku-mcp/dokku-mcp/internal/server-plugins/deployment/domain"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type buildResult struct {
+	output string
+	err    error
+}
+
+type mockBuildExecutor struct {
+	results  []buildResult
+	builds   int
+	purges   int
+	purgeErr error
+	images   []string
+}
+
+func (m *mockBuildExecutor) Rebuild(ctx context.Context, appName string) (string, error) {
+	result := m.results[m.builds]
+	m.builds++
+	return result.output, result.err
+}
+
+func (m *mockBuildExecutor) DeployImage(ctx context.Context, appName, image string) (string, error) {
+	m.images = append(m.images, image)
+	return m.Rebuild(ctx, appName)
+}
+
+func (m *mockBuildExecutor) PurgeBuildCache(ctx context.Context, appName string) error {
+	m.purges++
+	return m.purgeErr
+}
+
+var _ = Describe("ResilientDeployer", func() {
+	var (
+		executor *mockBuildExecutor
+		deployer *domain.ResilientDeployer
+		ctx      context.Context
+		received []domain.DeploymentEvent
+	)
+
+	cacheFailure := buildResult{
+		output: "-----> Restoring cache\n       ERROR: failed to restore cache: unexpected EOF",
+		err:    errors.New("exit status 1"),
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		executor = &mockBuildExecutor{}
+		deployer = domain.NewResilientDeployer(executor, slog.New(slog.NewTextHandler(io.Discard, nil)))
+		received = nil
+		deployer.OnEvent(func(event domain.DeploymentEvent) {
+			received = append(received, event)
+		})
+	})
+
+	It("should purge the cache and retry once after a cache failure", func() {
+		executor.results = []buildResult{cacheFailure, {output: "=====> Application deployed"}}
+
+		result, err := deployer.Deploy(ctx, "my-app")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Attempts).To(Equal(2))
+		Expect(result.CachePurged).To(BeTrue())
+		Expect(executor.purges).To(Equal(1))
+
+		types := make([]domain.DeploymentEventType, len(received))
+		for i, event := range received {
+			types[i] = event.Type
+		}
+		Expect(types).To(Equal([]domain.DeploymentEventType{
+			domain.DeploymentEventAttemptFailed,
+			domain.DeploymentEventCachePurged,
+			domain.DeploymentEventAttemptSucceeded,
+		}))
+		Expect(received[0].Attempt).To(Equal(1))
+		Expect(received[2].Attempt).To(Equal(2))
+		Expect(result.Events).To(Equal(received))
+	})
+
+	It("should not retry on unrelated failures", func() {
+		executor.results = []buildResult{{output: "npm ERR! missing script: build", err: errors.New("exit status 1")}}
+
+		result, err := deployer.Deploy(ctx, "my-app")
+		Expect(err).To(HaveOccurred())
+		Expect(result.Attempts).To(Equal(1))
+		Expect(result.CachePurged).To(BeFalse())
+		Expect(executor.purges).To(BeZero())
+	})
+
+	It("should retry only once", func() {
+		executor.results = []buildResult{cacheFailure, cacheFailure, {output: "ok"}}
+
+		result, err := deployer.Deploy(ctx, "my-app")
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("after build cache purge"))
+		Expect(result.Attempts).To(Equal(2))
+		Expect(executor.builds).To(Equal(2))
+	})
+
+	It("should not retry when the cache purge fails", func() {
+		executor.results = []buildResult{cacheFailure, {output: "ok"}}
+		executor.purgeErr = errors.New("permission denied")
+
+		result, err := deployer.Deploy(ctx, "my-app")
+		Expect(err).To(HaveOccurred())
+		Expect(result.Attempts).To(Equal(1))
+	})
+
+	It("should succeed without retry when the first attempt passes", func() {
+		executor.results = []buildResult{{output: "ok"}}
+
+		result, err := deployer.Deploy(ctx, "my-app")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Attempts).To(Equal(1))
+		Expect(executor.purges).To(BeZero())
+	})
+
+	It("should purge the cache and retry an image deploy the same way", func() {
+		executor.results = []buildResult{cacheFailure, {output: "=====> Application deployed"}}
+
+		result, err := deployer.DeployImage(ctx, "my-app", "registry.example.com/my-app:1.2.0")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Attempts).To(Equal(2))
+		Expect(result.CachePurged).To(BeTrue())
+		Expect(executor.images).To(Equal([]string{
+			"registry.example.com/my-app:1.2.0",
+			"registry.example.com/my-app:1.2.0",
+		}))
+	})
+})
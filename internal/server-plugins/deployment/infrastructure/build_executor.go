@@ -0,0 +1,46 @@
+package dokku
+
+import (
+	"context"
+	"fmt"
+
+	dokku_client "github.com/dokku-mcp/dokku-mcp/internal/dokku-api"
+	"github.com/dokku-mcp/dokku-mcp/internal/server-plugins/deployment/domain"
+)
+
+// buildExecutor runs synchronous rebuilds and cache purges through Dokku
+type buildExecutor struct {
+	client dokku_client.DokkuClient
+}
+
+// NewBuildExecutor creates a new Dokku build executor
+func NewBuildExecutor(client dokku_client.DokkuClient) domain.BuildExecutor {
+	return &buildExecutor{client: client}
+}
+
+// Rebuild runs ps:rebuild and returns the build output.
+// On failure the client only surfaces the error, which is what the cache detection inspects.
+func (e *buildExecutor) Rebuild(ctx context.Context, appName string) (string, error) {
+	output, err := e.client.ExecuteCommand(ctx, domain.CommandPsRebuild.String(), []string{appName})
+	if err != nil {
+		return string(output), fmt.Errorf("rebuild failed: %w", err)
+	}
+	return string(output), nil
+}
+
+// DeployImage runs git:from-image and returns the build output
+func (e *buildExecutor) DeployImage(ctx context.Context, appName, image string) (string, error) {
+	output, err := e.client.ExecuteCommand(ctx, domain.CommandGitFromImage.String(), []string{appName, image})
+	if err != nil {
+		return string(output), fmt.Errorf("image deploy failed: %w", err)
+	}
+	return string(output), nil
+}
+
+// PurgeBuildCache runs repo:purge-cache
+func (e *buildExecutor) PurgeBuildCache(ctx context.Context, appName string) error {
+	if _, err := e.client.ExecuteCommand(ctx, domain.CommandRepoPurgeCache.String(), []string{appName}); err != nil {
+		return fmt.Errorf("failed to purge build cache: %w", err)
+	}
+	return nil
+}
@@ -23,6 +23,9 @@ type deploymentInfrastructure struct {
 	tracker *domain.DeploymentTracker
 	poller  *domain.DeploymentPoller
 
+	// Builds, retried once after a build cache purge on cache failures
+	deployer *domain.ResilientDeployer
+
 	// Deployment locking to prevent concurrent deployments of the same app
 	deploymentMutex   sync.Mutex
 	activeDeployments map[string]bool
@@ -34,12 +37,14 @@ func NewDeploymentInfrastructure(
 	logger *slog.Logger,
 	tracker *domain.DeploymentTracker,
 	poller *domain.DeploymentPoller,
+	deployer *domain.ResilientDeployer,
 ) domain.DeploymentInfrastructure {
 	return &deploymentInfrastructure{
 		client:            client,
 		logger:            logger,
 		tracker:           tracker,
 		poller:            poller,
+		deployer:          deployer,
 		activeDeployments: make(map[string]bool),
 	}
 }
@@ -70,33 +75,14 @@ func (s *deploymentInfrastructure) PerformGitDeploy(ctx context.Context, deploym
 		"repo_url", repoURL,
 		"git_ref", gitRef)
 
-	// Check for concurrent deployment
-	s.deploymentMutex.Lock()
-	if s.activeDeployments[appName] {
-		s.deploymentMutex.Unlock()
-		return fmt.Errorf("deployment already in progress for application %s", appName)
+	release, err := s.acquireDeployment(appName, deploymentID)
+	if err != nil {
+		return err
 	}
-	s.activeDeployments[appName] = true
-	s.deploymentMutex.Unlock()
+	defer release()
 
-	// Ensure cleanup on exit
-	defer func() {
-		s.deploymentMutex.Lock()
-		delete(s.activeDeployments, appName)
-		s.deploymentMutex.Unlock()
-		s.logger.Debug("Deployment lock released", "app_name", appName, "deployment_id", deploymentID)
-	}()
-
-	// Perform git sync. Some environments may need a slightly longer timeout
-	// than the default client timeout due to network and repository size.
-	gitSyncCtx := ctx
-	if _, ok := ctx.Deadline(); !ok {
-		// Provide a modest 2 minute timeout for git:sync when caller didn't set one
-		var cancel context.CancelFunc
-		gitSyncCtx, cancel = context.WithTimeout(ctx, 2*time.Minute)
-		defer cancel()
-	}
-	_, err := s.executeCommand(gitSyncCtx, domain.CommandGitSync, []string{appName, repoURL, gitRef})
+	// Perform git sync, the client gives it the deploy timeout of its policy
+	_, err = s.executeCommand(ctx, domain.CommandGitSync, []string{appName, repoURL, gitRef})
 	if err != nil {
 		return fmt.Errorf("git sync failed: %w", err)
 	}
@@ -106,36 +92,79 @@ func (s *deploymentInfrastructure) PerformGitDeploy(ctx context.Context, deploym
 		"deployment_id", deploymentID)
 
 	// Trigger async rebuild with tracking
-	s.performAsyncRebuild(deploymentID, appName, gitRef)
+	s.performAsyncBuild(deploymentID, appName, gitRef, func(ctx context.Context) error {
+		_, err := s.deployer.Deploy(ctx, appName)
+		return err
+	})
 
 	return nil
 }
 
-// performAsyncRebuild performs the rebuild operation with proper tracking
-func (s *deploymentInfrastructure) performAsyncRebuild(deploymentID, appName, gitRef string) {
-	s.logger.Info("Starting tracked async rebuild",
+// PerformImageDeploy deploys an application from a docker image in Dokku - INFRASTRUCTURE ONLY
+func (s *deploymentInfrastructure) PerformImageDeploy(ctx context.Context, deploymentID, appName, image string) error {
+	s.logger.Debug("Performing image deployment",
 		"deployment_id", deploymentID,
 		"app_name", appName,
-		"git_ref", gitRef)
+		"image", image)
+
+	release, err := s.acquireDeployment(appName, deploymentID)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	s.performAsyncBuild(deploymentID, appName, image, func(ctx context.Context) error {
+		_, err := s.deployer.DeployImage(ctx, appName, image)
+		return err
+	})
+
+	return nil
+}
+
+// acquireDeployment takes the deployment lock of an application, failing if a deployment
+// is already in progress. The returned function releases it.
+func (s *deploymentInfrastructure) acquireDeployment(appName, deploymentID string) (func(), error) {
+	s.deploymentMutex.Lock()
+	if s.activeDeployments[appName] {
+		s.deploymentMutex.Unlock()
+		return nil, fmt.Errorf("deployment already in progress for application %s", appName)
+	}
+	s.activeDeployments[appName] = true
+	s.deploymentMutex.Unlock()
+
+	return func() {
+		s.deploymentMutex.Lock()
+		delete(s.activeDeployments, appName)
+		s.deploymentMutex.Unlock()
+		s.logger.Debug("Deployment lock released", "app_name", appName, "deployment_id", deploymentID)
+	}, nil
+}
+
+// performAsyncBuild runs the build through the resilient deployer with proper tracking
+func (s *deploymentInfrastructure) performAsyncBuild(deploymentID, appName, ref string, build func(context.Context) error) {
+	s.logger.Info("Starting tracked async build",
+		"deployment_id", deploymentID,
+		"app_name", appName,
+		"ref", ref)
 
 	// Start polling for status in background
 	if s.poller != nil {
 		s.poller.StartPolling(context.Background(), deploymentID, appName)
 	}
 
-	// Trigger the rebuild command (may timeout but build continues on Dokku)
+	// Trigger the build (may timeout but build continues on Dokku)
 	go func() {
 		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
 		defer cancel()
 
-		s.logger.Debug("Executing ps:rebuild command", "deployment_id", deploymentID, "app_name", appName)
+		s.logger.Debug("Executing build", "deployment_id", deploymentID, "app_name", appName)
 
-		_, err := s.executeCommand(ctx, domain.CommandPsRebuild, []string{appName})
+		err := build(ctx)
 
 		// SSH timeout is expected - the poller will track actual status
 		if err != nil {
 			if dokku_client.IsNotFoundError(err) {
-				s.logger.Warn("Rebuild command skipped (app missing)",
+				s.logger.Warn("Build skipped (app missing)",
 					"deployment_id", deploymentID,
 					"app_name", appName)
 				// Update tracker with failed status but without surfacing an error
@@ -146,21 +175,21 @@ func (s *deploymentInfrastructure) performAsyncRebuild(deploymentID, appName, gi
 				strings.Contains(err.Error(), "context deadline exceeded") ||
 				strings.Contains(err.Error(), "connection closed") ||
 				strings.Contains(err.Error(), "timeout") {
-				s.logger.Info("Rebuild command sent, SSH connection closed (expected for long builds)",
+				s.logger.Info("Build command sent, SSH connection closed (expected for long builds)",
 					"deployment_id", deploymentID,
 					"app_name", appName,
 					"note", "Poller will track actual completion status")
 			} else {
 				// Demote expected not-found races using sentinel classification only
 				if dokku_client.IsNotFoundError(err) {
-					s.logger.Warn("Rebuild aborted (app removed during deploy)",
+					s.logger.Warn("Build aborted (app removed during deploy)",
 						"deployment_id", deploymentID,
 						"app_name", appName)
 					if s.tracker != nil {
 						_ = s.tracker.UpdateStatus(deploymentID, domain.DeploymentStatusFailed, "application no longer exists")
 					}
 				} else {
-					s.logger.Error("Rebuild command failed",
+					s.logger.Error("Build failed",
 						"deployment_id", deploymentID,
 						"app_name", appName,
 						"error", err)
@@ -0,0 +1,99 @@
+package dokku
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	dokku_client "github.com/dokku-mcp/dokku-mcp/internal/dokku-api"
+	"github.com/dokku-mcp/dokku-mcp/internal/server-plugins/deployment/domain"
+)
+
+// buildClient fails the first build with a corrupt cache and records every command
+// line it runs, closing done once the retried build ran
+type buildClient struct {
+	dokku_client.DokkuClient
+
+	mu     sync.Mutex
+	calls  []string
+	builds int
+	done   chan struct{}
+}
+
+func (c *buildClient) ExecuteCommand(ctx context.Context, command string, args []string) ([]byte, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.calls = append(c.calls, strings.Join(append([]string{command}, args...), " "))
+
+	switch command {
+	case domain.CommandPsRebuild.String(), domain.CommandGitFromImage.String():
+		c.builds++
+		if c.builds == 1 {
+			return []byte("ERROR: failed to restore cache"), errors.New("exit status 1")
+		}
+		close(c.done)
+	}
+	return nil, nil
+}
+
+func (c *buildClient) waitForRetry(t *testing.T) []string {
+	t.Helper()
+	select {
+	case <-c.done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected the build to be retried")
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]string{}, c.calls...)
+}
+
+func newBuildInfrastructure(client *buildClient) domain.DeploymentInfrastructure {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	deployer := domain.NewResilientDeployer(NewBuildExecutor(client), logger)
+	return NewDeploymentInfrastructure(client, logger, nil, nil, deployer)
+}
+
+func TestGitDeployRetriesTheRebuildAfterACachePurge(t *testing.T) {
+	client := &buildClient{done: make(chan struct{})}
+	infra := newBuildInfrastructure(client)
+
+	if err := infra.PerformGitDeploy(context.Background(), "deploy-1", "my-app", "https://github.com/acme/my-app.git", "main"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	calls := client.waitForRetry(t)
+	expected := []string{
+		"git:sync my-app https://github.com/acme/my-app.git main",
+		"ps:rebuild my-app",
+		"repo:purge-cache my-app",
+		"ps:rebuild my-app",
+	}
+	if strings.Join(calls, "\n") != strings.Join(expected, "\n") {
+		t.Fatalf("unexpected commands: %v", calls)
+	}
+}
+
+func TestImageDeployRetriesAfterACachePurge(t *testing.T) {
+	client := &buildClient{done: make(chan struct{})}
+	infra := newBuildInfrastructure(client)
+
+	if err := infra.PerformImageDeploy(context.Background(), "deploy-1", "my-app", "registry.example.com/my-app:1.2.0"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	calls := client.waitForRetry(t)
+	expected := []string{
+		"git:from-image my-app registry.example.com/my-app:1.2.0",
+		"repo:purge-cache my-app",
+		"git:from-image my-app registry.example.com/my-app:1.2.0",
+	}
+	if strings.Join(calls, "\n") != strings.Join(expected, "\n") {
+		t.Fatalf("unexpected commands: %v", calls)
+	}
+}
@@ -3,6 +3,7 @@ package dokku
 import (
 	"context"
 	"errors"
+	"io"
 	"testing"
 
 	dokku_client "github.com/dokku-mcp/dokku-mcp/internal/dokku-api"
@@ -16,6 +17,9 @@ func (f *fakeClient) ExecuteCommand(ctx context.Context, command string, args []
 }
 
 // satisfy interfaces used by status checker but not needed for this test
+func (f *fakeClient) StreamCommand(ctx context.Context, command string, args []string, stdin io.Reader, stdout io.Writer) error {
+	return nil
+}
 func (f *fakeClient) GetKeyValueOutput(ctx context.Context, command string, args []string, separator string) (map[string]string, error) {
 	return nil, nil
 }
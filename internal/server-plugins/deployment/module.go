@@ -46,6 +46,13 @@ var Module = fx.Module("deployment",
 				)
 			},
 		),
+		// Build executor and resilient deployer, running the builds of the deployment infrastructure
+		fx.Annotate(
+			deployment_infrastructure.NewBuildExecutor,
+		),
+		fx.Annotate(
+			domain.NewResilientDeployer,
+		),
 		// Deployment infrastructure
 		fx.Annotate(
 			deployment_infrastructure.NewDeploymentInfrastructure,
@@ -55,6 +62,10 @@ var Module = fx.Module("deployment",
 			domain.NewApplicationDeploymentService,
 			fx.As(new(domain.DeploymentService)),
 		),
+		// Deployment history exporter
+		fx.Annotate(
+			domain.NewDeploymentHistoryExporter,
+		),
 		// Deployment adapter
 		fx.Annotate(
 			adapter.NewDeploymentServiceAdapter,
@@ -266,3 +266,75 @@ func generateDescription(name, language string, isOfficial bool) string {
 
 	return fmt.Sprintf("Buildpack personnalisé: %s", name)
 }
+
+// BuildpackKind identifie la pile d'un buildpack connu
+type BuildpackKind string
+
+const (
+	BuildpackKindNodeJS     BuildpackKind = "nodejs"
+	BuildpackKindPython     BuildpackKind = "python"
+	BuildpackKindGo         BuildpackKind = "go"
+	BuildpackKindRuby       BuildpackKind = "ruby"
+	BuildpackKindPHP        BuildpackKind = "php"
+	BuildpackKindStatic     BuildpackKind = "static"
+	BuildpackKindDockerfile BuildpackKind = "dockerfile"
+	BuildpackKindUnknown    BuildpackKind = "unknown"
+)
+
+// buildpackKindAliases associe le dernier segment d'un buildpack, sans préfixe, à sa pile
+var buildpackKindAliases = map[string]BuildpackKind{
+	"nodejs":     BuildpackKindNodeJS,
+	"node":       BuildpackKindNodeJS,
+	"python":     BuildpackKindPython,
+	"go":         BuildpackKindGo,
+	"golang":     BuildpackKindGo,
+	"ruby":       BuildpackKindRuby,
+	"php":        BuildpackKindPHP,
+	"static":     BuildpackKindStatic,
+	"nginx":      BuildpackKindStatic,
+	"dockerfile": BuildpackKindDockerfile,
+}
+
+// buildpackRepositoryPrefixes sont les préfixes usuels des dépôts de buildpacks
+var buildpackRepositoryPrefixes = []string{"heroku-buildpack-", "dokku-buildpack-", "buildpack-"}
+
+// Label retourne un libellé lisible de la pile, vide si elle est inconnue
+func (k BuildpackKind) Label() string {
+	switch k {
+	case BuildpackKindNodeJS:
+		return "Node.js"
+	case BuildpackKindPython:
+		return "Python"
+	case BuildpackKindGo:
+		return "Go"
+	case BuildpackKindRuby:
+		return "Ruby"
+	case BuildpackKindPHP:
+		return "PHP"
+	case BuildpackKindStatic:
+		return "Static site"
+	case BuildpackKindDockerfile:
+		return "Dockerfile"
+	default:
+		return ""
+	}
+}
+
+// Kind identifie la pile du buildpack à partir de son nom court (node), de son nom
+// complet (heroku/nodejs) ou de son URL (.../heroku-buildpack-nodejs.git). Seul le
+// dernier segment compte, comparé exactement, sinon la pile est inconnue.
+func (b *BuildpackName) Kind() BuildpackKind {
+	value := strings.ToLower(b.value)
+	value = strings.TrimSuffix(strings.TrimSuffix(value, "/"), ".git")
+	if index := strings.LastIndex(value, "/"); index >= 0 {
+		value = value[index+1:]
+	}
+	for _, prefix := range buildpackRepositoryPrefixes {
+		value = strings.TrimPrefix(value, prefix)
+	}
+
+	if kind, exists := buildpackKindAliases[value]; exists {
+		return kind
+	}
+	return BuildpackKindUnknown
+}
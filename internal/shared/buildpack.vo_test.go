@@ -0,0 +1,29 @@
+package shared_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/dokku-mcp/dokku-mcp/internal/shared"
+)
+
+var _ = Describe("BuildpackName", func() {
+	DescribeTable("Kind",
+		func(name string, expected shared.BuildpackKind) {
+			Expect(shared.MustNewBuildpackName(name).Kind()).To(Equal(expected))
+		},
+		Entry("git URL", "https://github.com/heroku/heroku-buildpack-nodejs.git", shared.BuildpackKindNodeJS),
+		Entry("URL of a dokku buildpack", "https://github.com/dokku/buildpack-nginx", shared.BuildpackKindStatic),
+		Entry("shorthand", "heroku/nodejs", shared.BuildpackKindNodeJS),
+		Entry("short name", "python", shared.BuildpackKindPython),
+		Entry("go buildpack", "heroku/go", shared.BuildpackKindGo),
+		Entry("dockerfile", "dockerfile", shared.BuildpackKindDockerfile),
+		Entry("name merely containing a language", "acme/mongo-tools", shared.BuildpackKindUnknown),
+		Entry("gibberish", "zzqx-foo", shared.BuildpackKindUnknown),
+	)
+
+	It("should label known kinds only", func() {
+		Expect(shared.BuildpackKindNodeJS.Label()).To(Equal("Node.js"))
+		Expect(shared.BuildpackKindUnknown.Label()).To(BeEmpty())
+	})
+})
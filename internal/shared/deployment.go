@@ -34,15 +34,17 @@ type DeploymentResult struct {
 	CreatedAt   time.Time
 	CompletedAt *time.Time
 	ErrorMsg    string
+	ImageDigest string
 }
 
 // DeploymentSummary provides a lightweight view of deployment history
 type DeploymentSummary struct {
-	ID        string
-	GitRef    string
-	Status    DeploymentStatus
-	CreatedAt time.Time
-	Duration  time.Duration
+	ID          string
+	GitRef      string
+	Status      DeploymentStatus
+	CreatedAt   time.Time
+	Duration    time.Duration
+	ImageDigest string
 }
 
 // DeploymentStatus represents deployment state
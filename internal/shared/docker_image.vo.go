@@ -3,6 +3,7 @@ package shared
 import (
 	"fmt"
 	"regexp"
+	"strings"
 )
 
 var (
@@ -11,20 +12,43 @@ var (
 	// but it's a good starting point for validation.
 	// It allows for optional host, user/org, repository and tag.
 	DockerImageRegex = regexp.MustCompile(`^(?:[a-z0-9]+(?:[._-][a-z0-9]+)*\/)*[a-z0-9_.-]+(?::[a-zA-Z0-9_.-]+)?$`)
+
+	dockerRegistryRegex      = regexp.MustCompile(`^(?:[a-zA-Z0-9](?:[a-zA-Z0-9-]*[a-zA-Z0-9])?)(?:\.[a-zA-Z0-9](?:[a-zA-Z0-9-]*[a-zA-Z0-9])?)*(?::[0-9]+)?$`)
+	dockerPathComponentRegex = regexp.MustCompile(`^[a-z0-9]+(?:(?:[._]|__|-+)[a-z0-9]+)*$`)
+	dockerTagRegex           = regexp.MustCompile(`^[a-zA-Z0-9_][a-zA-Z0-9_.-]{0,127}$`)
+	dockerDigestRegex        = regexp.MustCompile(`^[a-z0-9]+(?:[.+_-][a-z0-9]+)*:[a-fA-F0-9]{32,}$`)
 )
 
-// DockerImage represents a Docker image name as a value object.
+// dockerDefaultTag is the tag an untagged reference resolves to
+const dockerDefaultTag = "latest"
+
+// DockerImage represents a Docker image reference as a value object,
+// of the form [registry[:port]/]repository[:tag][@digest].
 type DockerImage struct {
-	value string
+	value      string
+	registry   string
+	repository string
+	tag        string
+	digest     string
 }
 
 // NewDockerImage creates a new DockerImage value object.
-// It returns an error if the image name is invalid.
+// It returns an error if the image reference is invalid.
 func NewDockerImage(value string) (*DockerImage, error) {
-	if !DockerImageRegex.MatchString(value) {
+	image, ok := parseDockerImage(value)
+	if !ok {
 		return nil, fmt.Errorf("invalid docker image format: %s", value)
 	}
-	return &DockerImage{value: value}, nil
+	return image, nil
+}
+
+// MustNewDockerImage creates a DockerImage, panicking on error.
+func MustNewDockerImage(value string) *DockerImage {
+	image, err := NewDockerImage(value)
+	if err != nil {
+		panic(fmt.Sprintf("cannot create docker image %s: %v", value, err))
+	}
+	return image
 }
 
 // Value returns the string representation of the Docker image.
@@ -32,6 +56,55 @@ func (d *DockerImage) Value() string {
 	return d.value
 }
 
+// Registry returns the registry host, with its port if any, or an empty string for the default registry.
+func (d *DockerImage) Registry() string {
+	return d.registry
+}
+
+// Repository returns the repository path, without registry, tag or digest.
+func (d *DockerImage) Repository() string {
+	return d.repository
+}
+
+// Tag returns the explicit tag, or an empty string if none was given.
+func (d *DockerImage) Tag() string {
+	return d.tag
+}
+
+// Digest returns the content digest, e.g. sha256:..., or an empty string if none was given.
+func (d *DockerImage) Digest() string {
+	return d.digest
+}
+
+// DisplayName returns a short human-friendly name: the repository with its tag,
+// or with the start of its digest when untagged.
+func (d *DockerImage) DisplayName() string {
+	switch {
+	case d.tag != "":
+		return d.repository + ":" + d.tag
+	case d.digest != "":
+		_, hex, _ := strings.Cut(d.digest, ":")
+		return d.repository + "@" + hex[:12]
+	default:
+		return d.repository + ":" + dockerDefaultTag
+	}
+}
+
+// SameImage returns true if both references point to the same image. Digests are
+// compared when both are pinned, otherwise the tags are, an untagged reference meaning latest.
+func (d *DockerImage) SameImage(other *DockerImage) bool {
+	if other == nil {
+		return false
+	}
+	if d.registry != other.registry || d.repository != other.repository {
+		return false
+	}
+	if d.digest != "" && other.digest != "" {
+		return d.digest == other.digest
+	}
+	return d.effectiveTag() == other.effectiveTag()
+}
+
 // Equal checks if two DockerImage objects are equal.
 func (d *DockerImage) Equal(other *DockerImage) bool {
 	if other == nil {
@@ -39,3 +112,59 @@ func (d *DockerImage) Equal(other *DockerImage) bool {
 	}
 	return d.value == other.value
 }
+
+func (d *DockerImage) effectiveTag() string {
+	if d.tag == "" && d.digest == "" {
+		return dockerDefaultTag
+	}
+	return d.tag
+}
+
+// parseDockerImage splits an image reference into its components, validating each of them
+func parseDockerImage(value string) (*DockerImage, bool) {
+	if value == "" || strings.TrimSpace(value) != value {
+		return nil, false
+	}
+
+	image := &DockerImage{value: value}
+
+	name := value
+	if at := strings.Index(value, "@"); at >= 0 {
+		name, image.digest = value[:at], value[at+1:]
+		if !dockerDigestRegex.MatchString(image.digest) {
+			return nil, false
+		}
+	}
+
+	// The first component is a registry if it looks like a host
+	if first, rest, found := strings.Cut(name, "/"); found &&
+		(strings.ContainsAny(first, ".:") || first == "localhost") {
+		if !dockerRegistryRegex.MatchString(first) {
+			return nil, false
+		}
+		image.registry = first
+		name = rest
+	}
+
+	lastComponent := name[strings.LastIndex(name, "/")+1:]
+	switch strings.Count(lastComponent, ":") {
+	case 0:
+	case 1:
+		colon := strings.LastIndex(name, ":")
+		name, image.tag = name[:colon], name[colon+1:]
+		if !dockerTagRegex.MatchString(image.tag) {
+			return nil, false
+		}
+	default:
+		return nil, false
+	}
+
+	for _, component := range strings.Split(name, "/") {
+		if !dockerPathComponentRegex.MatchString(component) {
+			return nil, false
+		}
+	}
+	image.repository = name
+
+	return image, true
+}
@@ -7,6 +7,8 @@ import (
 	"github.com/dokku-mcp/dokku-mcp/internal/shared"
 )
 
+const digestHex = "4f53cda18c2baa0c0354bb5f9a3ecbe5ed12ab4d8e11ba873c2f11161202b945"
+
 var _ = Describe("DockerImage", func() {
 	Describe("NewDockerImage", func() {
 		DescribeTable("creating a new Docker image",
@@ -36,9 +38,58 @@ var _ = Describe("DockerImage", func() {
 			Entry("invalid image name with spaces", "ubuntu image", true, "invalid docker image format: ubuntu image"),
 			Entry("invalid image name with uppercase", "Ubuntu:latest", true, "invalid docker image format: Ubuntu:latest"),
 			Entry("invalid image name with trailing slash", "user/app/:latest", true, "invalid docker image format: user/app/:latest"),
+			Entry("valid image with registry port and digest",
+				"registry.example.com:5000/team/app:v1.2.3@sha256:"+digestHex, false, ""),
+			Entry("valid localhost registry", "localhost/app", false, ""),
+			Entry("invalid empty image", "", true, "invalid docker image format: "),
+			Entry("invalid image with a double tag", "app:v1:v2", true, "invalid docker image format: app:v1:v2"),
+			Entry("invalid image with a short digest", "app@sha256:abc", true, "invalid docker image format: app@sha256:abc"),
+			Entry("invalid image with an empty tag", "app:", true, "invalid docker image format: app:"),
+			Entry("invalid registry port", "registry.example.com:port/app", true, "invalid docker image format: registry.example.com:port/app"),
 		)
 	})
 
+	Describe("components", func() {
+		DescribeTable("parsing an image reference",
+			func(image, registry, repository, tag, digest string) {
+				di, err := shared.NewDockerImage(image)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(di.Registry()).To(Equal(registry))
+				Expect(di.Repository()).To(Equal(repository))
+				Expect(di.Tag()).To(Equal(tag))
+				Expect(di.Digest()).To(Equal(digest))
+			},
+			Entry("bare repository", "ubuntu", "", "ubuntu", "", ""),
+			Entry("user repository with tag", "user/app:1.0", "", "user/app", "1.0", ""),
+			Entry("registry with port", "registry.example.com:5000/team/app:v1.2.3",
+				"registry.example.com:5000", "team/app", "v1.2.3", ""),
+			Entry("registry, tag and digest", "gcr.io/team/app:v1@sha256:"+digestHex,
+				"gcr.io", "team/app", "v1", "sha256:"+digestHex),
+			Entry("digest only", "team/app@sha256:"+digestHex, "", "team/app", "", "sha256:"+digestHex),
+		)
+
+		It("should display a human-friendly name", func() {
+			Expect(shared.MustNewDockerImage("gcr.io/team/app:v1").DisplayName()).To(Equal("team/app:v1"))
+			Expect(shared.MustNewDockerImage("team/app").DisplayName()).To(Equal("team/app:latest"))
+			Expect(shared.MustNewDockerImage("team/app@sha256:" + digestHex).DisplayName()).To(Equal("team/app@" + digestHex[:12]))
+		})
+	})
+
+	Describe("SameImage", func() {
+		It("should compare references by their components", func() {
+			Expect(shared.MustNewDockerImage("app").SameImage(shared.MustNewDockerImage("app:latest"))).To(BeTrue())
+			Expect(shared.MustNewDockerImage("app:v1").SameImage(shared.MustNewDockerImage("app:v2"))).To(BeFalse())
+			Expect(shared.MustNewDockerImage("gcr.io/app:v1").SameImage(shared.MustNewDockerImage("app:v1"))).To(BeFalse())
+			Expect(shared.MustNewDockerImage("app:v1").SameImage(nil)).To(BeFalse())
+		})
+
+		It("should prefer digests when both references are pinned", func() {
+			pinned := shared.MustNewDockerImage("app:v1@sha256:" + digestHex)
+			retagged := shared.MustNewDockerImage("app:stable@sha256:" + digestHex)
+			Expect(pinned.SameImage(retagged)).To(BeTrue())
+		})
+	})
+
 	Describe("Equal", func() {
 		It("should correctly compare two DockerImage objects", func() {
 			image1, _ := shared.NewDockerImage("ubuntu:latest")
@@ -0,0 +1,64 @@
+package shared
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DockerOptionPhase is a phase of the docker-options plugin, the container commands
+// an option is passed to
+type DockerOptionPhase string
+
+const (
+	DockerOptionPhaseBuild  DockerOptionPhase = "build"
+	DockerOptionPhaseDeploy DockerOptionPhase = "deploy"
+	DockerOptionPhaseRun    DockerOptionPhase = "run"
+)
+
+// IsValid checks if the phase is one the docker-options plugin knows
+func (p DockerOptionPhase) IsValid() bool {
+	switch p {
+	case DockerOptionPhaseBuild, DockerOptionPhaseDeploy, DockerOptionPhaseRun:
+		return true
+	default:
+		return false
+	}
+}
+
+// NewDockerOptionPhase validates a phase name
+func NewDockerOptionPhase(value string) (DockerOptionPhase, error) {
+	phase := DockerOptionPhase(strings.ToLower(strings.TrimSpace(value)))
+	if !phase.IsValid() {
+		return "", fmt.Errorf("invalid docker-options phase '%s', must be one of: build, deploy, run", value)
+	}
+	return phase, nil
+}
+
+// ParseDockerOptionPhases parses a comma separated list of phases, as docker-options:add
+// accepts them, e.g. deploy,run
+func ParseDockerOptionPhases(value string) ([]DockerOptionPhase, error) {
+	phases := make([]DockerOptionPhase, 0)
+	for _, name := range strings.Split(value, ",") {
+		phase, err := NewDockerOptionPhase(name)
+		if err != nil {
+			return nil, err
+		}
+		phases = append(phases, phase)
+	}
+	return phases, nil
+}
+
+// ValidateDockerOption checks an option is a single-line docker flag, e.g. --add-host db:10.0.0.2
+func ValidateDockerOption(option string) error {
+	option = strings.TrimSpace(option)
+	if option == "" {
+		return fmt.Errorf("docker option cannot be empty")
+	}
+	if !strings.HasPrefix(option, "-") {
+		return fmt.Errorf("docker option '%s' must be a flag starting with '-'", option)
+	}
+	if strings.ContainsAny(option, "\n\r") {
+		return fmt.Errorf("docker option cannot span several lines")
+	}
+	return nil
+}
@@ -0,0 +1,37 @@
+package shared_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/dokku-mcp/dokku-mcp/internal/shared"
+)
+
+var _ = Describe("DockerOptionPhase", func() {
+	It("should parse a comma separated list of phases", func() {
+		phases, err := shared.ParseDockerOptionPhases("deploy,Run")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(phases).To(Equal([]shared.DockerOptionPhase{shared.DockerOptionPhaseDeploy, shared.DockerOptionPhaseRun}))
+
+		_, err = shared.ParseDockerOptionPhases("deploy,release")
+		Expect(err).To(MatchError(ContainSubstring("invalid docker-options phase 'release'")))
+		_, err = shared.ParseDockerOptionPhases("")
+		Expect(err).To(HaveOccurred())
+	})
+
+	DescribeTable("ValidateDockerOption",
+		func(option string, valid bool) {
+			err := shared.ValidateDockerOption(option)
+			if valid {
+				Expect(err).NotTo(HaveOccurred())
+			} else {
+				Expect(err).To(HaveOccurred())
+			}
+		},
+		Entry("flag with a value", "--add-host db:10.0.0.2", true),
+		Entry("short flag", "-v /var/data:/data", true),
+		Entry("empty", "  ", false),
+		Entry("value without a flag", "db:10.0.0.2", false),
+		Entry("several lines", "--add-host db:10.0.0.2\n--privileged", false),
+	)
+})
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"net/url"
 	"regexp"
+	"slices"
 	"strings"
 )
 
@@ -149,3 +150,21 @@ func validateDomain(domain string) error {
 
 	return nil
 }
+
+// GroupDomainsByBase regroupe les domaines par domaine racine, pour décider lesquels
+// peuvent partager un certificat wildcard ou multi-SAN. Chaque groupe garde l'ordre d'entrée,
+// sans doublon. Un domaine racine seul forme son propre groupe.
+func GroupDomainsByBase(domains []*DomainName) map[string][]*DomainName {
+	groups := make(map[string][]*DomainName)
+	for _, domain := range domains {
+		if domain == nil {
+			continue
+		}
+		base := domain.RootDomain()
+		if slices.ContainsFunc(groups[base], domain.Equal) {
+			continue
+		}
+		groups[base] = append(groups[base], domain)
+	}
+	return groups
+}
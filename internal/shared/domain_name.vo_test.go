@@ -0,0 +1,32 @@
+package shared_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/dokku-mcp/dokku-mcp/internal/shared"
+)
+
+var _ = Describe("GroupDomainsByBase", func() {
+	It("should group subdomains under their base domain", func() {
+		groups := shared.GroupDomainsByBase([]*shared.DomainName{
+			shared.MustNewDomainName("api.example.com"),
+			shared.MustNewDomainName("other.org"),
+			shared.MustNewDomainName("www.eu.example.com"),
+			shared.MustNewDomainName("example.com"),
+			shared.MustNewDomainName("api.example.com"),
+		})
+
+		Expect(groups).To(HaveLen(2))
+		Expect(groups["example.com"]).To(Equal([]*shared.DomainName{
+			shared.MustNewDomainName("api.example.com"),
+			shared.MustNewDomainName("www.eu.example.com"),
+			shared.MustNewDomainName("example.com"),
+		}))
+		Expect(groups["other.org"]).To(Equal([]*shared.DomainName{shared.MustNewDomainName("other.org")}))
+	})
+
+	It("should return no group without domains", func() {
+		Expect(shared.GroupDomainsByBase(nil)).To(BeEmpty())
+	})
+})
@@ -1,14 +1,45 @@
 package shared
 
 import (
+	"encoding/json"
 	"fmt"
+	"math"
 	"regexp"
+	"slices"
+	"sort"
+	"strings"
 )
 
 var (
 	// EnvVarKeyRegex defines the validation for an environment variable key.
 	// It must be a valid C identifier (letters, numbers, and underscore).
 	EnvVarKeyRegex = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+	// secretEnvKeyRegex matches the keys conventionally holding credentials
+	secretEnvKeyRegex = regexp.MustCompile(`(?i)(SECRET|PASSWORD|PASSWD|TOKEN|API_?KEY|PRIVATE_?KEY|CREDENTIALS?|_KEY$|DATABASE_URL$)`)
+
+	// suspiciousSecretPatterns match well-known credential formats, by kind
+	suspiciousSecretPatterns = map[string]*regexp.Regexp{
+		"AWS access key":    regexp.MustCompile(`AKIA[0-9A-Z]{16}`),
+		"private key":       regexp.MustCompile(`-----BEGIN [A-Z ]*PRIVATE KEY-----`),
+		"GitHub token":      regexp.MustCompile(`gh[pousr]_[A-Za-z0-9]{36}`),
+		"Slack token":       regexp.MustCompile(`xox[abprs]-[A-Za-z0-9-]{10,}`),
+		"Stripe live key":   regexp.MustCompile(`sk_live_[A-Za-z0-9]{16,}`),
+		"URL with password": regexp.MustCompile(`[a-z][a-z0-9+.-]*://[^/\s:@]+:[^/\s@]+@`),
+	}
+
+	// hexKeyRegex matches hex keys of 128 bits or more
+	hexKeyRegex = regexp.MustCompile(`^[0-9a-fA-F]{32,}$`)
+	// base64KeyRegex matches the alphabet of base64 and base64url keys
+	base64KeyRegex = regexp.MustCompile(`^[A-Za-z0-9+/=_-]+$`)
+)
+
+const (
+	// minBase64KeyLength is the shortest value considered a generated base64 key
+	minBase64KeyLength = 24
+	// minBase64KeyBits is the Shannon entropy per character from which a base64 value
+	// looks random: generated keys reach 5, identifiers made of words stay near 4
+	minBase64KeyBits = 4.5
 )
 
 // EnvVarKey represents an environment variable key as a value object.
@@ -30,6 +61,31 @@ func (k *EnvVarKey) Value() string {
 	return k.value
 }
 
+// InternalEnvVarPrefixes are the prefixes of the variables Dokku manages itself.
+var InternalEnvVarPrefixes = []string{"DOKKU_"}
+
+// InternalEnvVarKeys are the variables Dokku manages itself outside the prefixes.
+var InternalEnvVarKeys = []string{"GIT_REV", "NO_VHOST"}
+
+// IsInternal returns true if Dokku manages the variable, e.g. DOKKU_PROXY_PORT or GIT_REV.
+func (k *EnvVarKey) IsInternal() bool {
+	if slices.Contains(InternalEnvVarKeys, k.value) {
+		return true
+	}
+	for _, prefix := range InternalEnvVarPrefixes {
+		if strings.HasPrefix(k.value, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// LooksLikeSecret returns true if the key conventionally holds a credential, such as
+// API_TOKEN, AWS_SECRET_ACCESS_KEY, DB_PASSWORD or DATABASE_URL.
+func (k *EnvVarKey) LooksLikeSecret() bool {
+	return secretEnvKeyRegex.MatchString(k.value)
+}
+
 // Equal checks if two EnvVarKey objects are equal.
 func (k *EnvVarKey) Equal(other *EnvVarKey) bool {
 	if other == nil {
@@ -38,7 +94,14 @@ func (k *EnvVarKey) Equal(other *EnvVarKey) bool {
 	return k.value == other.value
 }
 
+// maskedEnvVarValue replaces the middle of a value, or all of it when too short to hint at
+const maskedEnvVarValue = "****"
+
+// minRevealableEnvVarLength is the shortest value whose first and last characters are shown when masked
+const minRevealableEnvVarLength = 8
+
 // EnvVarValue represents an environment variable value as a value object.
+// It is masked when serialized to JSON, see RevealEnvVarValue to expose the plaintext.
 type EnvVarValue struct {
 	value string
 }
@@ -60,3 +123,99 @@ func (v *EnvVarValue) Equal(other *EnvVarValue) bool {
 	}
 	return v.value == other.value
 }
+
+// LooksLikeSecret returns true if the value has a known credential format, such as a
+// URL with a password, or looks like a randomly generated key.
+func (v *EnvVarValue) LooksLikeSecret() bool {
+	if _, found := SuspectedSecretKind(v.value); found {
+		return true
+	}
+	return isHighEntropy(v.value)
+}
+
+// SuspectedSecretKind returns the first kind of credential, in name order, the value looks like
+func SuspectedSecretKind(value string) (string, bool) {
+	names := make([]string, 0, len(suspiciousSecretPatterns))
+	for name := range suspiciousSecretPatterns {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if suspiciousSecretPatterns[name].MatchString(value) {
+			return name, true
+		}
+	}
+	return "", false
+}
+
+// isHighEntropy returns true if the value looks like a generated key: a long hex run,
+// or a long base64 run of letters and digits mixed spread like random characters
+func isHighEntropy(value string) bool {
+	if hexKeyRegex.MatchString(value) {
+		return true
+	}
+	if len(value) < minBase64KeyLength || !base64KeyRegex.MatchString(value) {
+		return false
+	}
+	if !strings.ContainsAny(value, "0123456789") || strings.IndexFunc(value, isASCIILetter) < 0 {
+		return false
+	}
+
+	counts := make(map[rune]int)
+	for _, r := range value {
+		counts[r]++
+	}
+	entropy := 0.0
+	for _, count := range counts {
+		p := float64(count) / float64(len(value))
+		entropy -= p * math.Log2(p)
+	}
+	return entropy >= minBase64KeyBits
+}
+
+func isASCIILetter(r rune) bool {
+	return (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z')
+}
+
+// Masked returns the value with its middle redacted, keeping only the first and last
+// characters of long enough values. An empty value stays empty.
+func (v *EnvVarValue) Masked() string {
+	runes := []rune(v.value)
+	switch {
+	case len(runes) == 0:
+		return ""
+	case len(runes) < minRevealableEnvVarLength:
+		return maskedEnvVarValue
+	default:
+		return string(runes[0]) + maskedEnvVarValue + string(runes[len(runes)-1])
+	}
+}
+
+// String returns the masked value, so a value never leaks through formatting.
+func (v *EnvVarValue) String() string {
+	return v.Masked()
+}
+
+// MarshalJSON serializes the masked value.
+func (v *EnvVarValue) MarshalJSON() ([]byte, error) {
+	return json.Marshal(v.Masked())
+}
+
+// revealedEnvVarValue serializes the plaintext of an environment variable value
+type revealedEnvVarValue struct {
+	value *EnvVarValue
+}
+
+// RevealEnvVarValue wraps a value so it serializes to its plaintext. Only use it when
+// the caller explicitly needs the secret, never for data returned to clients by default.
+func RevealEnvVarValue(v *EnvVarValue) json.Marshaler {
+	return revealedEnvVarValue{value: v}
+}
+
+func (r revealedEnvVarValue) MarshalJSON() ([]byte, error) {
+	if r.value == nil {
+		return []byte("null"), nil
+	}
+	return json.Marshal(r.value.value)
+}
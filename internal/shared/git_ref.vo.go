@@ -173,6 +173,31 @@ func validateGitRef(ref string) error {
 		return fmt.Errorf("la référence Git ne peut pas contenir des doubles slashes")
 	}
 
+	// Séquences réservées par la syntaxe des révisions Git (a..b, ref@{1})
+	for _, sequence := range []string{"..", "@{"} {
+		if strings.Contains(ref, sequence) {
+			return fmt.Errorf("la référence Git ne peut pas contenir %s", sequence)
+		}
+	}
+
+	// Aucun caractère de contrôle
+	for _, r := range ref {
+		if r < 0x20 || r == 0x7f {
+			return fmt.Errorf("la référence Git contient un caractère de contrôle")
+		}
+	}
+
+	// Chaque composant ne peut pas commencer par un point ni finir par .lock
+	for _, component := range strings.Split(ref, "/") {
+		if strings.HasPrefix(component, ".") || strings.HasSuffix(component, ".lock") {
+			return fmt.Errorf("le composant %q de la référence Git est invalide", component)
+		}
+	}
+
+	if ref == "@" {
+		return fmt.Errorf("la référence Git ne peut pas être @")
+	}
+
 	return nil
 }
 
@@ -0,0 +1,45 @@
+package shared_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/dokku-mcp/dokku-mcp/internal/shared"
+)
+
+var _ = Describe("GitRef", func() {
+	DescribeTable("valid references",
+		func(ref string, expected shared.GitRefType) {
+			gitRef, err := shared.NewGitRef(ref)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(gitRef.Type()).To(Equal(expected))
+		},
+		Entry("branch", "main", shared.GitRefTypeBranch),
+		Entry("nested branch", "feature/login-form", shared.GitRefTypeBranch),
+		Entry("tag", "v1.2.3", shared.GitRefTypeTag),
+		Entry("commit", "a1b2c3d", shared.GitRefTypeCommit),
+	)
+
+	DescribeTable("invalid references",
+		func(ref string) {
+			_, err := shared.NewGitRef(ref)
+			Expect(err).To(HaveOccurred())
+		},
+		Entry("empty", ""),
+		Entry("space", "my branch"),
+		Entry("range", "main..dev"),
+		Entry("leading slash", "/main"),
+		Entry("trailing slash", "main/"),
+		Entry("reflog syntax", "main@{1}"),
+		Entry("hidden component", "feature/.hidden"),
+		Entry("lock file", "main.lock"),
+		Entry("control character", "main\x01"),
+		Entry("at sign alone", "@"),
+	)
+
+	It("should type a branch reference as a branch whatever it looks like", func() {
+		branch, err := shared.NewBranchRef("v1.0")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(branch.IsBranch()).To(BeTrue())
+	})
+})
@@ -0,0 +1,122 @@
+package shared
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// PortScheme is the protocol the proxy serves a port mapping with
+type PortScheme string
+
+const (
+	PortSchemeHTTP  PortScheme = "http"
+	PortSchemeHTTPS PortScheme = "https"
+	PortSchemeTCP   PortScheme = "tcp"
+	PortSchemeUDP   PortScheme = "udp"
+)
+
+// IsValid checks if the scheme is one Dokku proxies support
+func (s PortScheme) IsValid() bool {
+	switch s {
+	case PortSchemeHTTP, PortSchemeHTTPS, PortSchemeTCP, PortSchemeUDP:
+		return true
+	default:
+		return false
+	}
+}
+
+// PortMapping routes a host port to a container port, as in Dokku's
+// <scheme>:<host port>:<container port> notation, e.g. https:443:5000
+type PortMapping struct {
+	scheme        PortScheme
+	hostPort      int
+	containerPort int
+}
+
+// NewPortMapping creates a port mapping, both ports being in 1-65535
+func NewPortMapping(scheme string, hostPort, containerPort int) (PortMapping, error) {
+	portScheme := PortScheme(strings.ToLower(strings.TrimSpace(scheme)))
+	if !portScheme.IsValid() {
+		return PortMapping{}, fmt.Errorf("invalid port mapping scheme '%s', must be one of: http, https, tcp, udp", scheme)
+	}
+	if err := validatePort("host", hostPort); err != nil {
+		return PortMapping{}, err
+	}
+	if err := validatePort("container", containerPort); err != nil {
+		return PortMapping{}, err
+	}
+	return PortMapping{scheme: portScheme, hostPort: hostPort, containerPort: containerPort}, nil
+}
+
+// ParsePortMapping parses the <scheme>:<host port>:<container port> notation
+func ParsePortMapping(value string) (PortMapping, error) {
+	parts := strings.Split(strings.TrimSpace(value), ":")
+	if len(parts) != 3 {
+		return PortMapping{}, fmt.Errorf("invalid port mapping '%s', expected <scheme>:<host port>:<container port>", value)
+	}
+	hostPort, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return PortMapping{}, fmt.Errorf("invalid host port in port mapping '%s'", value)
+	}
+	containerPort, err := strconv.Atoi(parts[2])
+	if err != nil {
+		return PortMapping{}, fmt.Errorf("invalid container port in port mapping '%s'", value)
+	}
+	return NewPortMapping(parts[0], hostPort, containerPort)
+}
+
+// ParsePortMappings parses a space separated list of port mappings, as reported by Dokku
+func ParsePortMappings(value string) ([]PortMapping, error) {
+	mappings := make([]PortMapping, 0)
+	for _, field := range strings.Fields(value) {
+		mapping, err := ParsePortMapping(field)
+		if err != nil {
+			return nil, err
+		}
+		mappings = append(mappings, mapping)
+	}
+	return mappings, nil
+}
+
+func validatePort(name string, port int) error {
+	if port < 1 || port > 65535 {
+		return fmt.Errorf("invalid %s port %d, must be between 1 and 65535", name, port)
+	}
+	return nil
+}
+
+// Scheme returns the protocol of the mapping
+func (m PortMapping) Scheme() PortScheme {
+	return m.scheme
+}
+
+// HostPort returns the port the proxy listens on
+func (m PortMapping) HostPort() int {
+	return m.hostPort
+}
+
+// ContainerPort returns the port the container listens on
+func (m PortMapping) ContainerPort() int {
+	return m.containerPort
+}
+
+// String returns the Dokku notation of the mapping
+func (m PortMapping) String() string {
+	return fmt.Sprintf("%s:%d:%d", m.scheme, m.hostPort, m.containerPort)
+}
+
+// MarshalText serializes the mapping in the Dokku notation
+func (m PortMapping) MarshalText() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+// UnmarshalText parses the mapping from the Dokku notation
+func (m *PortMapping) UnmarshalText(data []byte) error {
+	mapping, err := ParsePortMapping(string(data))
+	if err != nil {
+		return err
+	}
+	*m = mapping
+	return nil
+}
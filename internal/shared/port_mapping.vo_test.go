@@ -0,0 +1,53 @@
+package shared_test
+
+import (
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/dokku-mcp/dokku-mcp/internal/shared"
+)
+
+var _ = Describe("PortMapping", func() {
+	DescribeTable("ParsePortMapping",
+		func(value string, valid bool) {
+			mapping, err := shared.ParsePortMapping(value)
+			if !valid {
+				Expect(err).To(HaveOccurred())
+				return
+			}
+			Expect(err).NotTo(HaveOccurred())
+			Expect(mapping.String()).To(Equal(value))
+		},
+		Entry("http", "http:80:5000", true),
+		Entry("https", "https:443:5000", true),
+		Entry("tcp", "tcp:2222:22", true),
+		Entry("unknown scheme", "ftp:21:21", false),
+		Entry("missing container port", "http:80", false),
+		Entry("zero container port", "http:80:0", false),
+		Entry("host port out of range", "http:70000:5000", false),
+		Entry("non numeric port", "http:web:5000", false),
+	)
+
+	It("should tell the scheme apart for the same container port", func() {
+		mappings, err := shared.ParsePortMappings("http:80:5000 https:443:5000")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(mappings).To(HaveLen(2))
+		Expect(mappings[0].Scheme()).To(Equal(shared.PortSchemeHTTP))
+		Expect(mappings[1].Scheme()).To(Equal(shared.PortSchemeHTTPS))
+		Expect(mappings[1].HostPort()).To(Equal(443))
+		Expect(mappings[1].ContainerPort()).To(Equal(5000))
+	})
+
+	It("should serialize to the Dokku notation", func() {
+		mapping, _ := shared.NewPortMapping("HTTPS", 443, 5000)
+		data, err := json.Marshal([]shared.PortMapping{mapping})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).To(Equal(`["https:443:5000"]`))
+
+		var decoded []shared.PortMapping
+		Expect(json.Unmarshal(data, &decoded)).To(Succeed())
+		Expect(decoded).To(Equal([]shared.PortMapping{mapping}))
+	})
+})
@@ -7,10 +7,19 @@ type Process struct {
 	processType ProcessType
 	command     *ProcessCommand
 	scale       *ProcessScale
+	limits      *ResourceLimits
+	// restartPolicy is nil until set, Dokku then applies the app-wide policy
+	restartPolicy *RestartPolicy
 }
 
 // NewProcess creates a new Process.
+// The process type is normalized to lowercase.
 func NewProcess(processType ProcessType, command string, scale int) (*Process, error) {
+	processType, err := NormalizeProcessType(processType)
+	if err != nil {
+		return nil, fmt.Errorf("invalid process type: %w", err)
+	}
+
 	cmd, err := NewProcessCommand(command)
 	if err != nil {
 		return nil, fmt.Errorf("invalid process command: %w", err)
@@ -31,6 +40,11 @@ func NewProcess(processType ProcessType, command string, scale int) (*Process, e
 // This is used when scaling a process type that doesn't exist yet - the command will be
 // determined from the Procfile during deployment.
 func NewProcessForScaling(processType ProcessType, scale int) (*Process, error) {
+	processType, err := NormalizeProcessType(processType)
+	if err != nil {
+		return nil, fmt.Errorf("invalid process type: %w", err)
+	}
+
 	ps, err := NewProcessScale(scale)
 	if err != nil {
 		return nil, fmt.Errorf("invalid process scale: %w", err)
@@ -81,3 +95,33 @@ func (p *Process) SetCommand(command string) error {
 	p.command = cmd
 	return nil
 }
+
+// ResourceLimits returns the process resource limits, or nil if none are set.
+func (p *Process) ResourceLimits() *ResourceLimits {
+	return p.limits
+}
+
+// SetResourceLimits updates the process memory, CPU and ephemeral storage limits.
+// Passing empty values for all three clears the limits.
+func (p *Process) SetResourceLimits(memory, cpu, storage string) error {
+	limits, err := NewResourceLimits(memory, cpu, storage)
+	if err != nil {
+		return err
+	}
+	if limits.IsEmpty() {
+		p.limits = nil
+		return nil
+	}
+	p.limits = limits
+	return nil
+}
+
+// RestartPolicy returns the process restart policy, or nil if none is set.
+func (p *Process) RestartPolicy() *RestartPolicy {
+	return p.restartPolicy
+}
+
+// SetRestartPolicy updates the process restart policy. A nil policy clears it.
+func (p *Process) SetRestartPolicy(policy *RestartPolicy) {
+	p.restartPolicy = policy
+}
@@ -1,7 +1,17 @@
 package process
 
-import "fmt"
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// processTypeNameRegex matches the process names Dokku accepts in a Procfile and ps:scale
+var processTypeNameRegex = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
 
+// ProcessType is the name of a Procfile process. Any valid name is accepted, the
+// constants below are the well-known ones, web and release having a meaning in Dokku.
 type ProcessType string
 
 const (
@@ -20,6 +30,25 @@ func NewProcessType(processType string) (ProcessType, error) {
 	return pt, nil
 }
 
+// NormalizeProcessType validates a process name and lowercases it.
+// Empty names, names containing whitespace and names with characters other than
+// letters, digits, dashes and underscores are rejected.
+func NormalizeProcessType(processType ProcessType) (ProcessType, error) {
+	name := string(processType)
+	if name == "" {
+		return "", fmt.Errorf("process type cannot be empty")
+	}
+	if strings.IndexFunc(name, unicode.IsSpace) >= 0 {
+		return "", fmt.Errorf("process type %q cannot contain whitespace", name)
+	}
+
+	normalized := strings.ToLower(name)
+	if !processTypeNameRegex.MatchString(normalized) {
+		return "", fmt.Errorf("process type %q must contain only letters, digits, dashes and underscores", name)
+	}
+	return ProcessType(normalized), nil
+}
+
 func (pt ProcessType) IsValid() bool {
 	validTypes := []ProcessType{
 		ProcessTypeWeb, ProcessTypeWorker, ProcessTypeCron,
@@ -34,10 +63,30 @@ func (pt ProcessType) IsValid() bool {
 	return false
 }
 
-func (pt ProcessType) IsWebProcess() bool {
+// IsWeb reports whether the process is the one Dokku proxies HTTP traffic to
+func (pt ProcessType) IsWeb() bool {
 	return pt == ProcessTypeWeb
 }
 
+// IsOneOff reports whether the process runs once per deploy instead of being kept
+// running, like the release process
+func (pt ProcessType) IsOneOff() bool {
+	return pt == ProcessTypeRelease
+}
+
+// ValidateScale checks an instance count makes sense for the process: a one-off process
+// runs at most once per deploy, so it cannot be scaled above 1
+func (pt ProcessType) ValidateScale(instances int) error {
+	if pt.IsOneOff() && instances > 1 {
+		return fmt.Errorf("the %s process runs once per deploy and cannot be scaled to %d", pt, instances)
+	}
+	return nil
+}
+
+func (pt ProcessType) IsWebProcess() bool {
+	return pt.IsWeb()
+}
+
 func (pt ProcessType) RequiresHTTPAccess() bool {
 	return pt == ProcessTypeWeb
 }
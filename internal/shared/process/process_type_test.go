@@ -0,0 +1,62 @@
+package process_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/dokku-mcp/dokku-mcp/internal/shared/process"
+)
+
+var _ = Describe("NormalizeProcessType", func() {
+	DescribeTable("should accept valid process types",
+		func(input, expected string) {
+			processType, err := process.NormalizeProcessType(process.ProcessType(input))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(processType).To(Equal(process.ProcessType(expected)))
+		},
+		Entry("web", "web", "web"),
+		Entry("worker with a suffix", "worker-1", "worker-1"),
+		Entry("underscores", "release_task", "release_task"),
+		Entry("uppercase is lowercased", "Web", "web"),
+	)
+
+	DescribeTable("should reject invalid process types",
+		func(input, message string) {
+			_, err := process.NormalizeProcessType(process.ProcessType(input))
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring(message))
+		},
+		Entry("empty", "", "cannot be empty"),
+		Entry("whitespace", "my proc", "cannot contain whitespace"),
+		Entry("surrounding whitespace", " web", "cannot contain whitespace"),
+		Entry("special characters", "web!", "must contain only"),
+		Entry("leading dash", "-web", "must contain only"),
+	)
+
+	It("should be enforced when creating a process", func() {
+		proc, err := process.NewProcessForScaling("", 1)
+		Expect(err).To(HaveOccurred())
+		Expect(proc).To(BeNil())
+		Expect(err.Error()).To(Equal("invalid process type: process type cannot be empty"))
+
+		proc, err = process.NewProcess("Worker", "bundle exec sidekiq", 1)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(proc.Type()).To(Equal(process.ProcessTypeWorker))
+	})
+})
+
+var _ = Describe("ProcessType", func() {
+	It("should identify the web and one-off processes", func() {
+		Expect(process.ProcessTypeWeb.IsWeb()).To(BeTrue())
+		Expect(process.ProcessTypeWorker.IsWeb()).To(BeFalse())
+		Expect(process.ProcessTypeRelease.IsOneOff()).To(BeTrue())
+		Expect(process.ProcessTypeWeb.IsOneOff()).To(BeFalse())
+	})
+
+	It("should not scale a one-off process above 1", func() {
+		Expect(process.ProcessTypeRelease.ValidateScale(0)).To(Succeed())
+		Expect(process.ProcessTypeRelease.ValidateScale(1)).To(Succeed())
+		Expect(process.ProcessTypeRelease.ValidateScale(2)).To(MatchError(ContainSubstring("runs once per deploy")))
+		Expect(process.ProcessTypeWorker.ValidateScale(5)).To(Succeed())
+	})
+})
@@ -0,0 +1,111 @@
+package process
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	// sizeLimitRegex matches Dokku size limits such as 512m, 1g or a plain byte count.
+	sizeLimitRegex = regexp.MustCompile(`^[0-9]+(\.[0-9]+)?[bkmg]?$`)
+	// cpuLimitRegex matches Dokku CPU limits such as 1, 0.5 or 1024 (CPU shares).
+	cpuLimitRegex = regexp.MustCompile(`^[0-9]+(\.[0-9]+)?$`)
+)
+
+// ResourceLimits represents the container limits of a process as a value object.
+// Empty values mean that the limit is not set.
+type ResourceLimits struct {
+	memory  string
+	cpu     string
+	storage string
+}
+
+// NewResourceLimits creates a new ResourceLimits value object.
+// Memory and storage accept a number with an optional b, k, m or g unit;
+// CPU accepts a plain number.
+func NewResourceLimits(memory, cpu, storage string) (*ResourceLimits, error) {
+	memory = strings.ToLower(strings.TrimSpace(memory))
+	cpu = strings.TrimSpace(cpu)
+	storage = strings.ToLower(strings.TrimSpace(storage))
+
+	if memory != "" && !sizeLimitRegex.MatchString(memory) {
+		return nil, fmt.Errorf("invalid memory limit: %s", memory)
+	}
+	if cpu != "" && !cpuLimitRegex.MatchString(cpu) {
+		return nil, fmt.Errorf("invalid cpu limit: %s", cpu)
+	}
+	if storage != "" && !sizeLimitRegex.MatchString(storage) {
+		return nil, fmt.Errorf("invalid storage limit: %s", storage)
+	}
+
+	return &ResourceLimits{memory: memory, cpu: cpu, storage: storage}, nil
+}
+
+// ParseSizeLimit converts a Dokku size limit such as 512m or 1.5g to bytes, units
+// being powers of 1024 as Docker reads them. A plain number is a byte count and an
+// empty limit is 0.
+func ParseSizeLimit(limit string) (int64, error) {
+	limit = strings.ToLower(strings.TrimSpace(limit))
+	if limit == "" {
+		return 0, nil
+	}
+	if !sizeLimitRegex.MatchString(limit) {
+		return 0, fmt.Errorf("invalid size limit: %s", limit)
+	}
+
+	multiplier := int64(1)
+	switch limit[len(limit)-1] {
+	case 'k':
+		multiplier = 1 << 10
+	case 'm':
+		multiplier = 1 << 20
+	case 'g':
+		multiplier = 1 << 30
+	}
+	number := strings.TrimRight(limit, "bkmg")
+	value, err := strconv.ParseFloat(number, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid size limit: %s", limit)
+	}
+	bytes := value * float64(multiplier)
+	if bytes >= math.MaxInt64 {
+		return 0, fmt.Errorf("size limit %s is too large", limit)
+	}
+	return int64(bytes), nil
+}
+
+// MemoryBytes returns the memory limit in bytes, 0 if it is not set.
+func (r *ResourceLimits) MemoryBytes() (int64, error) {
+	return ParseSizeLimit(r.memory)
+}
+
+// Memory returns the memory limit.
+func (r *ResourceLimits) Memory() string {
+	return r.memory
+}
+
+// CPU returns the CPU limit.
+func (r *ResourceLimits) CPU() string {
+	return r.cpu
+}
+
+// Storage returns the ephemeral storage limit.
+func (r *ResourceLimits) Storage() string {
+	return r.storage
+}
+
+// IsEmpty returns true if no limit is set.
+func (r *ResourceLimits) IsEmpty() bool {
+	return r.memory == "" && r.cpu == "" && r.storage == ""
+}
+
+// Equal checks if two ResourceLimits objects are equal.
+func (r *ResourceLimits) Equal(other *ResourceLimits) bool {
+	if other == nil {
+		return false
+	}
+	return r.memory == other.memory && r.cpu == other.cpu && r.storage == other.storage
+}
@@ -0,0 +1,94 @@
+package process_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/dokku-mcp/dokku-mcp/internal/shared/process"
+)
+
+var _ = Describe("ResourceLimits", func() {
+	DescribeTable("creating new ResourceLimits",
+		func(memory, cpu, storage string, shouldFail bool) {
+			limits, err := process.NewResourceLimits(memory, cpu, storage)
+			if shouldFail {
+				Expect(err).To(HaveOccurred())
+				Expect(limits).To(BeNil())
+			} else {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(limits).ToNot(BeNil())
+			}
+		},
+		Entry("memory in megabytes", "512m", "", "", false),
+		Entry("memory in gigabytes", "1g", "", "", false),
+		Entry("uppercase unit", "1G", "", "", false),
+		Entry("cpu shares", "", "1024", "", false),
+		Entry("fractional cpu", "", "0.5", "", false),
+		Entry("all limits", "512m", "1", "1g", false),
+		Entry("no limits", "", "", "", false),
+		Entry("unknown memory unit", "512x", "", "", true),
+		Entry("cpu with unit", "", "2g", "", true),
+		Entry("negative storage", "", "", "-1g", true),
+	)
+})
+
+var _ = Describe("Process resource limits", func() {
+	var proc *process.Process
+
+	BeforeEach(func() {
+		proc, _ = process.NewProcessForScaling(process.ProcessTypeWeb, 1)
+	})
+
+	It("should have no limits by default", func() {
+		Expect(proc.ResourceLimits()).To(BeNil())
+	})
+
+	It("should set valid limits", func() {
+		Expect(proc.SetResourceLimits("512M", "1", "2g")).To(Succeed())
+		Expect(proc.ResourceLimits().Memory()).To(Equal("512m"))
+		Expect(proc.ResourceLimits().CPU()).To(Equal("1"))
+		Expect(proc.ResourceLimits().Storage()).To(Equal("2g"))
+	})
+
+	It("should keep the previous limits on invalid input", func() {
+		Expect(proc.SetResourceLimits("512m", "", "")).To(Succeed())
+		Expect(proc.SetResourceLimits("lots", "", "")).ToNot(Succeed())
+		Expect(proc.ResourceLimits().Memory()).To(Equal("512m"))
+	})
+
+	It("should clear limits when all values are empty", func() {
+		Expect(proc.SetResourceLimits("512m", "", "")).To(Succeed())
+		Expect(proc.SetResourceLimits("", "", "")).To(Succeed())
+		Expect(proc.ResourceLimits()).To(BeNil())
+	})
+
+	DescribeTable("parsing size limits",
+		func(limit string, expected int64) {
+			size, err := process.ParseSizeLimit(limit)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(size).To(Equal(expected))
+		},
+		Entry("empty", "", int64(0)),
+		Entry("bytes", "2048", int64(2048)),
+		Entry("bytes with unit", "2048b", int64(2048)),
+		Entry("kilobytes", "4k", int64(4096)),
+		Entry("megabytes", "512m", int64(512<<20)),
+		Entry("fractional gigabytes", "1.5G", int64(3<<29)),
+	)
+
+	It("should refuse an invalid size limit", func() {
+		_, err := process.ParseSizeLimit("512x")
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should refuse a size limit past the byte count range", func() {
+		_, err := process.ParseSizeLimit("9999999999g")
+		Expect(err).To(MatchError("size limit 9999999999g is too large"))
+	})
+
+	It("should return the memory limit in bytes", func() {
+		limits, err := process.NewResourceLimits("256m", "", "")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(limits.MemoryBytes()).To(Equal(int64(256 << 20)))
+	})
+})
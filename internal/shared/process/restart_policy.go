@@ -8,8 +8,10 @@ import (
 )
 
 type RestartPolicy struct {
-	policy         RestartPolicyType
-	maxRestarts    int
+	policy      RestartPolicyType
+	maxRestarts int
+	// hasMaxRestarts is true when an on-failure policy carries an explicit retry count
+	hasMaxRestarts bool
 	restartDelay   time.Duration
 	backoffFactor  float64
 	maxRestartTime time.Duration
@@ -81,6 +83,7 @@ func (rp *RestartPolicy) WithMaxRestarts(max int) *RestartPolicy {
 		max = 0
 	}
 	rp.maxRestarts = max
+	rp.hasMaxRestarts = rp.policy == RestartPolicyOnFailure
 	return rp
 }
 
@@ -104,6 +107,32 @@ func (rp *RestartPolicy) RestartDelay() time.Duration {
 	return rp.restartDelay
 }
 
+// HasMaxRestarts returns true if an on-failure policy carries an explicit retry count
+func (rp *RestartPolicy) HasMaxRestarts() bool {
+	return rp.hasMaxRestarts
+}
+
+// String returns the policy in the form accepted by ps:set restart-policy,
+// e.g. "no", "always" or "on-failure:5"
+func (rp *RestartPolicy) String() string {
+	switch {
+	case rp.policy == RestartPolicyNever:
+		return "no"
+	case rp.hasMaxRestarts:
+		return fmt.Sprintf("%s:%d", rp.policy, rp.maxRestarts)
+	default:
+		return string(rp.policy)
+	}
+}
+
+// Equal compares the Dokku-visible part of two policies
+func (rp *RestartPolicy) Equal(other *RestartPolicy) bool {
+	if rp == nil || other == nil {
+		return rp == other
+	}
+	return rp.String() == other.String()
+}
+
 func isValidRestartPolicyType(policyType RestartPolicyType) bool {
 	validTypes := []RestartPolicyType{
 		RestartPolicyAlways, RestartPolicyOnFailure,
@@ -40,4 +40,29 @@ var _ = Describe("RestartPolicy", func() {
 			Entry("invalid policy with max restarts", "always:5", true, "max restarts count is only applicable for 'on-failure' policy: always:5", process.RestartPolicyType(""), 0),
 		)
 	})
+
+	DescribeTable("String renders the ps:set form",
+		func(policyStr string, expected string) {
+			policy, err := process.NewRestartPolicyFromString(policyStr)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(policy.String()).To(Equal(expected))
+		},
+		Entry("never as no", "never", "no"),
+		Entry("no", "no", "no"),
+		Entry("on-failure without a retry count", "on-failure", "on-failure"),
+		Entry("on-failure with a retry count", "on-failure:5", "on-failure:5"),
+		Entry("unless-stopped", "unless-stopped", "unless-stopped"),
+	)
+
+	It("should only carry a retry count for on-failure", func() {
+		onFailure, _ := process.NewRestartPolicyFromString("on-failure")
+		Expect(onFailure.HasMaxRestarts()).To(BeFalse())
+		onFailure.WithMaxRestarts(3)
+		Expect(onFailure.HasMaxRestarts()).To(BeTrue())
+
+		always, _ := process.NewRestartPolicy(process.RestartPolicyAlways)
+		always.WithMaxRestarts(3)
+		Expect(always.HasMaxRestarts()).To(BeFalse())
+		Expect(always.String()).To(Equal("always"))
+	})
 })
@@ -27,26 +27,45 @@ type PluginDiscoveryConfig struct {
 	Enabled      bool          `mapstructure:"enabled"`
 }
 
+// CommandTimeoutsConfig sets the timeout of each kind of Dokku command, a zero
+// timeout falling back to the global one
+type CommandTimeoutsConfig struct {
+	Report   time.Duration `mapstructure:"report"`
+	Mutation time.Duration `mapstructure:"mutation"`
+	Deploy   time.Duration `mapstructure:"deploy"`
+	Plugin   time.Duration `mapstructure:"plugin"`
+}
+
 type SecurityConfig struct {
 	Blacklist []string `mapstructure:"blacklist"`
+	// DeniedCommands names the core commands disabled on this deployment, e.g. plugin:install
+	DeniedCommands []string `mapstructure:"denied_commands"`
 }
 
 type ServerConfig struct {
-	Transport          TransportConfig       `mapstructure:"transport"`
-	Host               string                `mapstructure:"host"`
-	Port               int                   `mapstructure:"port"`
-	LogLevel           string                `mapstructure:"log_level"`
-	LogFormat          string                `mapstructure:"log_format"`
-	ExposeServerLogs   bool                  `mapstructure:"expose_server_logs"`
-	LogBufferCapacity  int                   `mapstructure:"log_buffer_capacity"`
-	DeploymentLogLines int                   `mapstructure:"deployment_log_lines"`
-	Timeout            time.Duration         `mapstructure:"timeout"`
-	DokkuPath          string                `mapstructure:"dokku_path"`
-	CacheEnabled       bool                  `mapstructure:"cache_enabled"`
-	CacheTTL           time.Duration         `mapstructure:"cache_ttl"`
-	SSH                SSHConfig             `mapstructure:"ssh"`
-	PluginDiscovery    PluginDiscoveryConfig `mapstructure:"plugin_discovery"`
-	Security           SecurityConfig        `mapstructure:"security"`
+	Transport          TransportConfig `mapstructure:"transport"`
+	Host               string          `mapstructure:"host"`
+	Port               int             `mapstructure:"port"`
+	LogLevel           string          `mapstructure:"log_level"`
+	LogFormat          string          `mapstructure:"log_format"`
+	ExposeServerLogs   bool            `mapstructure:"expose_server_logs"`
+	LogBufferCapacity  int             `mapstructure:"log_buffer_capacity"`
+	DeploymentLogLines int             `mapstructure:"deployment_log_lines"`
+	// MaxConcurrentDeploys caps the deployments running at once across applications, 0 for no cap
+	MaxConcurrentDeploys int `mapstructure:"max_concurrent_deploys"`
+	// HostMemoryBudget caps the memory the formation of an application may request, e.g. 8g, empty for no cap
+	HostMemoryBudget string `mapstructure:"host_memory_budget"`
+	// ServiceExportDir is the server directory service data is exported to and imported
+	// from, empty to disable exports and imports
+	ServiceExportDir string                `mapstructure:"service_export_dir"`
+	Timeout          time.Duration         `mapstructure:"timeout"`
+	CommandTimeouts  CommandTimeoutsConfig `mapstructure:"command_timeouts"`
+	DokkuPath        string                `mapstructure:"dokku_path"`
+	CacheEnabled     bool                  `mapstructure:"cache_enabled"`
+	CacheTTL         time.Duration         `mapstructure:"cache_ttl"`
+	SSH              SSHConfig             `mapstructure:"ssh"`
+	PluginDiscovery  PluginDiscoveryConfig `mapstructure:"plugin_discovery"`
+	Security         SecurityConfig        `mapstructure:"security"`
 }
 
 func DefaultConfig() *ServerConfig {
@@ -64,9 +83,14 @@ func DefaultConfig() *ServerConfig {
 		LogBufferCapacity:  2000,
 		DeploymentLogLines: 200,
 		Timeout:            30 * time.Second,
-		DokkuPath:          "/usr/bin/dokku",
-		CacheEnabled:       true,
-		CacheTTL:           5 * time.Minute,
+		CommandTimeouts: CommandTimeoutsConfig{
+			Report: 10 * time.Second,
+			Deploy: 15 * time.Minute,
+			Plugin: 10 * time.Minute,
+		},
+		DokkuPath:    "/usr/bin/dokku",
+		CacheEnabled: true,
+		CacheTTL:     5 * time.Second,
 		SSH: SSHConfig{
 			Host:    "localhost",
 			Port:    3022,
@@ -78,7 +102,8 @@ func DefaultConfig() *ServerConfig {
 			Enabled:      true,
 		},
 		Security: SecurityConfig{
-			Blacklist: []string{},
+			Blacklist:      []string{},
+			DeniedCommands: []string{},
 		},
 	}
 }
@@ -106,7 +131,14 @@ func LoadConfig() (*ServerConfig, error) {
 	viper.SetDefault("expose_server_logs", config.ExposeServerLogs)
 	viper.SetDefault("log_buffer_capacity", config.LogBufferCapacity)
 	viper.SetDefault("deployment_log_lines", config.DeploymentLogLines)
+	viper.SetDefault("max_concurrent_deploys", config.MaxConcurrentDeploys)
+	viper.SetDefault("host_memory_budget", config.HostMemoryBudget)
+	viper.SetDefault("service_export_dir", config.ServiceExportDir)
 	viper.SetDefault("timeout", config.Timeout)
+	viper.SetDefault("command_timeouts.report", config.CommandTimeouts.Report)
+	viper.SetDefault("command_timeouts.mutation", config.CommandTimeouts.Mutation)
+	viper.SetDefault("command_timeouts.deploy", config.CommandTimeouts.Deploy)
+	viper.SetDefault("command_timeouts.plugin", config.CommandTimeouts.Plugin)
 	viper.SetDefault("dokku_path", config.DokkuPath)
 	viper.SetDefault("cache_enabled", config.CacheEnabled)
 	viper.SetDefault("cache_ttl", config.CacheTTL)
@@ -123,6 +155,7 @@ func LoadConfig() (*ServerConfig, error) {
 
 	// Security configuration defaults
 	viper.SetDefault("security.blacklist", config.Security.Blacklist)
+	viper.SetDefault("security.denied_commands", config.Security.DeniedCommands)
 
 	if err := viper.ReadInConfig(); err != nil {
 		if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
@@ -95,7 +95,7 @@ dokku_path: "$DOKKU_MCP_DOKKU_PATH"
 
 # Configuration du cache
 cache_enabled: true
-cache_ttl: "5m"
+cache_ttl: "5s"
 
 # Configuration de sécurité
 security: